@@ -0,0 +1,129 @@
+package admin
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
+)
+
+const commonUsage = `
+Commands of both hubs:
+  migrate up                 apply pending migrations
+  migrate down [-limit n]    roll back the last n migrations, 1 by default
+  migrate status             list applied and pending migrations
+  rotate-key                 replace the hub RSA key, the old key is kept with a timestamp suffix
+  drain-deletes              remove the blobs waiting in blob_pending_deletes
+  check-blobs                compare the blobs the database refers to with the blob storage
+
+Flags:
+`
+
+var ErrUsage = merry.New("invalid arguments")
+
+type Command func(ctx context.Context, h *Hub, args []string) error
+
+// Hub holds what the commands need from the hub config.
+type Hub struct {
+	DB              common.DatabaseConfig
+	S3              common.S3Config
+	Blob            common.FSStorageConfig
+	ExternalAddress string
+	PrivateKeyPath  string
+	Log             logging.Config
+
+	Migrations migrate.MigrationSource
+	Migrate    func(db *sqlx.DB, dialect string) (n int, err error)
+	// OnBlobRemoved (if set) is called for each blob drain-deletes removes.
+	OnBlobRemoved func(ctx context.Context, db *sqlx.DB, blobID string) error
+	// ReferencedBlobs maps the blobs the database refers to to their recorded sizes, -1 if the size isn't recorded.
+	ReferencedBlobs func(ctx context.Context, db *sqlx.DB) (map[string]int64, error)
+	Commands        map[string]Command
+}
+
+// Main runs the command of the hub loaded from the -config flag.
+// usage describes the hub commands, the commands of both hubs are appended to it.
+func Main(usage string, load func(configPath string) (*Hub, error)) {
+	var configPath string
+	flag.StringVar(&configPath, "config", "", "config path")
+	flag.Usage = func() {
+		_, _ = fmt.Fprint(flag.CommandLine.Output(), usage+commonUsage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	h, err := load(configPath)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	err = h.Log.Setup()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	err = h.run(common.ShutdownContext(), flag.Args())
+	if err != nil {
+		if merry.Is(err, ErrUsage) {
+			flag.Usage()
+			os.Exit(2)
+		}
+		logrus.Fatal(err)
+	}
+}
+
+func (h *Hub) run(ctx context.Context, args []string) error {
+	commands := map[string]Command{
+		"migrate":       migrateCommand,
+		"rotate-key":    rotateKeyCommand,
+		"drain-deletes": drainDeletesCommand,
+		"check-blobs":   checkBlobsCommand,
+	}
+	for name, cmd := range h.Commands {
+		commands[name] = cmd
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return ErrUsage.Here()
+	}
+	return cmd(ctx, h, args[1:])
+}
+
+// OpenDB doesn't migrate the database, "migrate up" does.
+func (h *Hub) OpenDB() (*sqlx.DB, error) {
+	db, _, err := common.OpenDatabase(h.DB)
+	return db, err
+}
+
+func (h *Hub) OpenBlobStorage() (common.BlobStorage, error) {
+	privateKey, _, _, err := common.RSAKeysFromPrivateKeyFile(h.PrivateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	return common.CreateBlobStorage(h.S3, h.Blob, h.ExternalAddress, privateKey)
+}
+
+// ParseFlags parses the flags of a command, errors are reported as ErrUsage.
+func ParseFlags(fs *flag.FlagSet, args []string) error {
+	fs.SetOutput(os.Stderr)
+	fs.Usage = func() {}
+	err := fs.Parse(args)
+	if err != nil {
+		return ErrUsage.Here()
+	}
+	return nil
+}
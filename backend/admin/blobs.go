@@ -0,0 +1,113 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ansel1/merry"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+// rotateKeyCommand can't reach running hubs: they, and the hubs that cached their public key, have to be restarted.
+func rotateKeyCommand(_ context.Context, h *Hub, args []string) error {
+	if len(args) != 0 {
+		return ErrUsage.Here()
+	}
+
+	backupPath, err := common.RotateRSAKey(h.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+	logrus.Infof("generated a new key at %s, restart the hub to use it", h.PrivateKeyPath)
+	if backupPath != "" {
+		logrus.Infof("moved the old key to %s", backupPath)
+		logrus.Warn("tokens and blob links signed with the old key are no longer valid, the user hub and message hubs cache each other's keys until restarted")
+	}
+	return nil
+}
+
+func drainDeletesCommand(ctx context.Context, h *Hub, args []string) error {
+	if len(args) != 0 {
+		return ErrUsage.Here()
+	}
+
+	db, err := h.OpenDB()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	blobStorage, err := h.OpenBlobStorage()
+	if err != nil {
+		return err
+	}
+
+	var onRemoved func(ctx context.Context, blobID string) error
+	if h.OnBlobRemoved != nil {
+		onRemoved = func(ctx context.Context, blobID string) error {
+			return h.OnBlobRemoved(ctx, db, blobID)
+		}
+	}
+	cleaner := common.NewS3Cleaner(db, blobStorage, onRemoved)
+	pending, err := cleaner.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	remaining, err := cleaner.Drain(ctx)
+	if err != nil {
+		return err
+	}
+	logrus.Infof("removed %d of %d pending blobs", len(pending)-remaining, len(pending))
+	if remaining > 0 {
+		return merry.Errorf("%d blobs couldn't be removed", remaining)
+	}
+	return nil
+}
+
+func checkBlobsCommand(ctx context.Context, h *Hub, args []string) error {
+	if len(args) != 0 {
+		return ErrUsage.Here()
+	}
+
+	db, err := h.OpenDB()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	blobStorage, err := h.OpenBlobStorage()
+	if err != nil {
+		return err
+	}
+
+	referenced, err := h.ReferencedBlobs(ctx, db)
+	if err != nil {
+		return err
+	}
+	pending, err := common.NewS3Cleaner(db, blobStorage, nil).Pending(ctx)
+	if err != nil {
+		return err
+	}
+	report, err := common.CheckBlobs(ctx, blobStorage, referenced, pending)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("referenced blobs:  %d\n", report.Referenced)
+	fmt.Printf("stored blobs:      %d (%d bytes)\n", report.Stored, report.StoredSize)
+	fmt.Printf("pending deletes:   %d\n", report.PendingDeletes)
+	printBlobs("missing in storage", report.Missing)
+	printBlobs(fmt.Sprintf("orphaned in storage, %d bytes", report.OrphanedSize), report.Orphaned)
+	printBlobs("size differs from the recorded one", report.SizeMismatches)
+	if !report.Consistent() {
+		return merry.New("database and blob storage are inconsistent")
+	}
+	return nil
+}
+
+func printBlobs(title string, blobIDs []string) {
+	fmt.Printf("%s: %d\n", title, len(blobIDs))
+	for _, blobID := range blobIDs {
+		fmt.Printf("  %s\n", blobID)
+	}
+}
@@ -0,0 +1,129 @@
+package admin
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ansel1/merry"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+func migrateCommand(ctx context.Context, h *Hub, args []string) error {
+	if len(args) == 0 {
+		return ErrUsage.Here()
+	}
+
+	switch args[0] {
+	case "up":
+		return migrateUp(h)
+	case "down":
+		fs := flag.NewFlagSet("migrate down", flag.ContinueOnError)
+		limit := fs.Int("limit", 1, "number of migrations to roll back")
+		err := ParseFlags(fs, args[1:])
+		if err != nil {
+			return err
+		}
+		if *limit < 1 {
+			return ErrUsage.Here()
+		}
+		return migrateDown(h, *limit)
+	case "status":
+		return migrateStatus(h)
+	default:
+		return ErrUsage.Here()
+	}
+}
+
+// migrateUp does what the hub does on start.
+func migrateUp(h *Hub) error {
+	err := common.CreateDatabaseIfNotExist(h.DB)
+	if err != nil {
+		return err
+	}
+	db, n, err := common.OpenDatabase(h.DB, h.Migrate)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	logrus.Infof("applied %d migrations to %s", n, h.DB.DBName)
+	return nil
+}
+
+// migrateDown refuses to roll back migrations without down statements,
+// removing only their records would leave the schema as it is.
+func migrateDown(h *Hub, limit int) error {
+	db, err := h.OpenDB()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	planned, _, err := migrate.PlanMigration(db.DB, db.DriverName(), h.Migrations, migrate.Down, limit)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	var irreversible []string
+	for _, m := range planned {
+		if len(m.Down) == 0 {
+			irreversible = append(irreversible, m.Id)
+		}
+	}
+	if len(irreversible) > 0 {
+		return merry.Errorf("migrations %s have no down statements and can't be rolled back", strings.Join(irreversible, ", "))
+	}
+
+	n, err := migrate.ExecMax(db.DB, db.DriverName(), h.Migrations, migrate.Down, limit)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	logrus.Infof("rolled back %d migrations of %s", n, h.DB.DBName)
+	return nil
+}
+
+func migrateStatus(h *Hub) error {
+	db, err := h.OpenDB()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	records, err := migrate.GetMigrationRecords(db.DB, db.DriverName())
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	migrations, err := h.Migrations.FindMigrations()
+	if err != nil {
+		return merry.Wrap(err)
+	}
+
+	appliedAt := make(map[string]string, len(records))
+	for _, record := range records {
+		appliedAt[record.Id] = record.AppliedAt.Format("2006-01-02 15:04:05 MST")
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "MIGRATION\tAPPLIED AT")
+	known := make(map[string]bool, len(migrations))
+	for _, m := range migrations {
+		known[m.Id] = true
+		status, ok := appliedAt[m.Id]
+		if !ok {
+			status = "pending"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", m.Id, status)
+	}
+	for _, record := range records {
+		if !known[record.Id] {
+			_, _ = fmt.Fprintf(w, "%s\t%s (unknown to this version)\n", record.Id, appliedAt[record.Id])
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,68 @@
+package common
+
+import (
+	"context"
+	"sort"
+)
+
+// BlobReport compares the blobs the database refers to with the stored ones.
+type BlobReport struct {
+	Referenced     int
+	Stored         int
+	StoredSize     int64
+	PendingDeletes int
+	// Missing blobs are referenced, but not stored.
+	Missing []string
+	// Orphaned blobs are stored, but neither referenced nor waiting for removal.
+	Orphaned     []string
+	OrphanedSize int64
+	// SizeMismatches are stored blobs whose size differs from the recorded one.
+	SizeMismatches []string
+}
+
+// CheckBlobs walks the storage once. referenced maps blob IDs to the recorded sizes, negative sizes aren't compared.
+// Blobs waiting for removal are neither missing nor orphaned.
+func CheckBlobs(ctx context.Context, blobStorage BlobStorage, referenced map[string]int64, pendingDeletes []string) (BlobReport, error) {
+	pending := make(map[string]bool, len(pendingDeletes))
+	for _, blobID := range pendingDeletes {
+		pending[blobID] = true
+	}
+
+	report := BlobReport{
+		Referenced:     len(referenced),
+		PendingDeletes: len(pending),
+	}
+	stored := make(map[string]bool)
+	err := blobStorage.Walk(ctx, func(blobID string, size int64) error {
+		stored[blobID] = true
+		report.Stored++
+		report.StoredSize += size
+
+		recordedSize, ok := referenced[blobID]
+		switch {
+		case !ok && !pending[blobID]:
+			report.Orphaned = append(report.Orphaned, blobID)
+			report.OrphanedSize += size
+		case ok && recordedSize >= 0 && recordedSize != size:
+			report.SizeMismatches = append(report.SizeMismatches, blobID)
+		}
+		return nil
+	})
+	if err != nil {
+		return BlobReport{}, err
+	}
+
+	for blobID := range referenced {
+		if !stored[blobID] && !pending[blobID] {
+			report.Missing = append(report.Missing, blobID)
+		}
+	}
+	sort.Strings(report.Missing)
+	sort.Strings(report.Orphaned)
+	sort.Strings(report.SizeMismatches)
+	return report, nil
+}
+
+func (r BlobReport) Consistent() bool {
+	return len(r.Missing) == 0 && len(r.Orphaned) == 0 && len(r.SizeMismatches) == 0
+}
@@ -0,0 +1,51 @@
+package common
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckBlobs(t *testing.T) {
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "koto-blobs")
+	require.Nil(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	storage, err := NewFSStorage(dir, "http://localhost/blob", []byte("secret"))
+	require.Nil(t, err)
+
+	for blobID, content := range map[string]string{
+		"ok.jpg":       "1234",
+		"resized.jpg":  "12",
+		"orphan 1.mp4": "123",
+		"deleted.jpg":  "1",
+	} {
+		require.Nil(t, storage.PutObject(ctx, blobID, []byte(content), "image/jpeg"))
+	}
+
+	report, err := CheckBlobs(ctx, storage, map[string]int64{
+		"ok.jpg":      4,
+		"resized.jpg": 5,
+		"lost.jpg":    -1,
+		"removed.jpg": 1,
+	}, []string{"deleted.jpg", "removed.jpg"})
+	require.Nil(t, err)
+
+	expected := BlobReport{
+		Referenced:     4,
+		Stored:         4,
+		StoredSize:     10,
+		PendingDeletes: 2,
+		Missing:        []string{"lost.jpg"},
+		Orphaned:       []string{"orphan 1.mp4"},
+		OrphanedSize:   3,
+		SizeMismatches: []string{"resized.jpg"},
+	}
+	assert.Equal(t, expected, report)
+	assert.False(t, report.Consistent())
+}
@@ -0,0 +1,46 @@
+package common
+
+import (
+	"context"
+	"crypto/rsa"
+	"io"
+	"time"
+
+	"github.com/ansel1/merry"
+)
+
+type BlobStorage interface {
+	Exists(ctx context.Context, blobID string) (bool, error)
+	Size(ctx context.Context, blobID string) (int64, error)
+	Read(ctx context.Context, blobID string, w io.Writer) error
+	ReadN(ctx context.Context, blobID string, n int) ([]byte, error)
+	CreateLink(ctx context.Context, blobID string, expiration time.Duration) (string, error)
+	PutObject(ctx context.Context, blobID string, content []byte, contentType string) error
+	// CreateUploadLink limits the uploaded file to maxSize bytes unless maxSize is 0.
+	CreateUploadLink(ctx context.Context, blobID, contentType string, maxSize int64, metadata map[string]string) (uploadLink string, formData map[string]string, err error)
+	RemoveObject(ctx context.Context, blobID string) error
+	// Ping checks that the storage is reachable.
+	Ping(ctx context.Context) error
+	// Walk calls fn for each stored blob and stops at the first error.
+	Walk(ctx context.Context, fn func(blobID string, size int64) error) error
+}
+
+// CreateBlobStorage prefers S3 and falls back to the local filesystem served from externalAddress + "/blob".
+func CreateBlobStorage(s3Cfg S3Config, fsCfg FSStorageConfig, externalAddress string, privateKey *rsa.PrivateKey) (BlobStorage, error) {
+	s3Storage, err := s3Cfg.CreateStorage()
+	if err != nil {
+		return nil, err
+	}
+	if s3Storage != nil {
+		return s3Storage, nil
+	}
+
+	fsStorage, err := fsCfg.CreateStorage(externalAddress+"/blob", privateKey)
+	if err != nil {
+		return nil, err
+	}
+	if fsStorage != nil {
+		return fsStorage, nil
+	}
+	return nil, merry.New("blob storage isn't configured: set S3 endpoint or blob dir")
+}
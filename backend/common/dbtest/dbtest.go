@@ -0,0 +1,131 @@
+// Package dbtest creates Postgres databases for the tests which need one.
+package dbtest
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+var (
+	templatesMu       sync.Mutex
+	templates         = make(map[string]bool)
+	defaultDBSettings = common.DatabaseConfig{
+		Host:     "localhost",
+		Port:     5433,
+		User:     "postgres",
+		Password: "docker",
+		SSLMode:  "disable",
+	}
+)
+
+type Migration func(db *sqlx.DB, dialect string) (int, error)
+
+type TestEnvironment struct {
+	DB      *sqlx.DB
+	Ctx     context.Context
+	TempDir string
+	dbName  string
+}
+
+// NewTestEnvironment clones a new database from the template database with the given name.
+// The template database is created and migrated once per test binary.
+func NewTestEnvironment(templateName string, migration Migration) *TestEnvironment {
+	tempDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		panic(err)
+	}
+
+	te := &TestEnvironment{
+		TempDir: tempDir,
+		Ctx:     context.Background(),
+	}
+
+	templateDBName := fmt.Sprintf("test_template_%s", templateName)
+	ensureTemplateDB(templateDBName, migration)
+
+	te.DB, te.dbName = cloneTemplateDB(templateDBName)
+	return te
+}
+
+func (te *TestEnvironment) Cleanup() {
+	_ = os.RemoveAll(te.TempDir)
+	_ = te.DB.Close()
+
+	db := openMasterDB()
+	defer func() { _ = db.Close() }()
+
+	_, err := db.Exec(fmt.Sprintf("drop database %s;", te.dbName))
+	if err != nil {
+		panic(err)
+	}
+}
+
+func openMasterDB() *sqlx.DB {
+	dbSettings := defaultDBSettings
+	dbSettings.DBName = common.MasterDBName
+
+	db, _, err := common.OpenDatabase(dbSettings)
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
+
+func cloneTemplateDB(templateDBName string) (*sqlx.DB, string) {
+	db := openMasterDB()
+	defer func() { _ = db.Close() }()
+
+	dbName := fmt.Sprintf("test_%d", time.Now().UnixNano())
+
+	_, err := db.Exec(fmt.Sprintf(`create database "%s" template %s;`, dbName, templateDBName))
+	if err != nil {
+		panic(err)
+	}
+
+	dbSettings := defaultDBSettings
+	dbSettings.DBName = dbName
+	testDB, _, err := common.OpenDatabase(dbSettings)
+	if err != nil {
+		panic(err)
+	}
+
+	return testDB, dbName
+}
+
+func ensureTemplateDB(templateDBName string, migration Migration) {
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+
+	if !templates[templateDBName] {
+		createTemplateDB(templateDBName, migration)
+		templates[templateDBName] = true
+	}
+}
+
+func createTemplateDB(templateDBName string, migration Migration) {
+	db := openMasterDB()
+	defer func() { _ = db.Close() }()
+
+	_, _ = db.Exec(fmt.Sprintf("drop database %s;", templateDBName))
+
+	_, err := db.Exec(fmt.Sprintf(`create database "%s";`, templateDBName))
+	if err != nil {
+		panic(err)
+	}
+
+	dbSettings := defaultDBSettings
+	dbSettings.DBName = templateDBName
+	templateDB, _, err := common.OpenDatabase(dbSettings, migration)
+	if err != nil {
+		panic(err)
+	}
+	_ = templateDB.Close()
+}
@@ -0,0 +1,438 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/go-chi/chi"
+
+	"github.com/mreider/koto/backend/common/logging"
+)
+
+const (
+	fsMaxUploadSize = 1 << 30
+)
+
+var (
+	ErrInvalidBlobID = merry.New("invalid blob id")
+	ErrBlobTooLarge  = merry.New("blob is too large")
+)
+
+// fsInlineContentTypes are served as they are. Other blobs, e.g. encrypted attachments, are served as downloads,
+// so a blob can't be rendered as a page on the hub origin.
+var fsInlineContentTypes = map[string]bool{
+	"image/bmp":       true,
+	"image/gif":       true,
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/webp":      true,
+	"video/avi":       true,
+	"video/mp4":       true,
+	"video/quicktime": true,
+	"video/webm":      true,
+}
+
+type FSStorageConfig struct {
+	Dir string `yaml:"dir" env:"KOTO_BLOB_DIR"`
+}
+
+// CreateStorage returns nil when no dir is set.
+// Links are signed with a secret derived from the hub's private key, so they survive restarts.
+func (cfg FSStorageConfig) CreateStorage(baseURL string, privateKey *rsa.PrivateKey) (*FSStorage, error) {
+	if cfg.Dir == "" {
+		return nil, nil
+	}
+	secret := sha256.Sum256(x509.MarshalPKCS1PrivateKey(privateKey))
+	return NewFSStorage(cfg.Dir, baseURL, secret[:])
+}
+
+// FSStorage keeps blobs on the local disk and serves them through Handler.
+// Download and upload links are HMAC-signed and expire like S3 presigned links.
+type FSStorage struct {
+	blobDir string
+	typeDir string
+	baseURL string
+	secret  []byte
+}
+
+func NewFSStorage(dir, baseURL string, secret []byte) (*FSStorage, error) {
+	s := &FSStorage{
+		blobDir: filepath.Join(dir, "blobs"),
+		typeDir: filepath.Join(dir, "types"),
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		secret:  secret,
+	}
+	for _, d := range []string{s.blobDir, s.typeDir} {
+		err := os.MkdirAll(d, 0700)
+		if err != nil {
+			return nil, merry.Wrap(err)
+		}
+	}
+	return s, nil
+}
+
+func (s *FSStorage) Exists(_ context.Context, blobID string) (bool, error) {
+	path, err := s.blobPath(blobID)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, merry.Wrap(err)
+	}
+	return true, nil
+}
+
+func (s *FSStorage) Size(_ context.Context, blobID string) (int64, error) {
+	path, err := s.blobPath(blobID)
+	if err != nil {
+		return 0, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, merry.Wrap(err)
+	}
+	return info.Size(), nil
+}
+
+func (s *FSStorage) Read(_ context.Context, blobID string, w io.Writer) error {
+	f, err := s.open(blobID)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	_, err = io.Copy(w, f)
+	if err != nil {
+		return merry.Prepend(err, "can't read blob")
+	}
+	return nil
+}
+
+func (s *FSStorage) ReadN(_ context.Context, blobID string, n int) ([]byte, error) {
+	f, err := s.open(blobID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	buf := make([]byte, n)
+	count, err := io.ReadFull(f, buf)
+	if err != nil && !merry.Is(err, io.EOF) && !merry.Is(err, io.ErrUnexpectedEOF) {
+		return nil, merry.Prepend(err, "can't read blob")
+	}
+	return buf[:count], nil
+}
+
+// CreateLink rounds the expiration time so repeated calls return the same link for a while
+// and browsers can cache the blob.
+func (s *FSStorage) CreateLink(_ context.Context, blobID string, expiration time.Duration) (string, error) {
+	if _, err := s.blobPath(blobID); err != nil {
+		return "", err
+	}
+	if expiration <= 0 {
+		expiration = defaultLinkExpiration
+	}
+
+	expires := strconv.FormatInt(time.Now().UTC().Truncate(expiration/2).Add(expiration).Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(http.MethodGet, blobID, "", expires, ""))
+	return s.baseURL + "/" + url.PathEscape(blobID) + "?" + query.Encode(), nil
+}
+
+func (s *FSStorage) PutObject(_ context.Context, blobID string, content []byte, contentType string) error {
+	return s.write(blobID, contentType, bytes.NewReader(content), 0)
+}
+
+func (s *FSStorage) CreateUploadLink(_ context.Context, blobID, contentType string, maxSize int64, _ map[string]string) (uploadLink string, formData map[string]string, err error) {
+	if _, err := s.blobPath(blobID); err != nil {
+		return "", nil, err
+	}
+
+	expires := strconv.FormatInt(time.Now().UTC().Add(defaultLinkExpiration).Unix(), 10)
+	formData = map[string]string{
+		"key":          blobID,
+		"Content-Type": contentType,
+		"expires":      expires,
+	}
+	if maxSize > 0 {
+		formData["max-size"] = strconv.FormatInt(maxSize, 10)
+	}
+	formData["signature"] = s.sign(http.MethodPost, blobID, contentType, expires, formData["max-size"])
+	return s.baseURL + "/", formData, nil
+}
+
+func (s *FSStorage) RemoveObject(_ context.Context, blobID string) error {
+	path, err := s.blobPath(blobID)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return merry.Prepend(err, "can't remove blob")
+	}
+	err = os.Remove(filepath.Join(s.typeDir, filepath.Base(path)))
+	if err != nil && !os.IsNotExist(err) {
+		return merry.Prepend(err, "can't remove blob")
+	}
+	return nil
+}
+
+func (s *FSStorage) Ping(_ context.Context) error {
+	for _, dir := range []string{s.blobDir, s.typeDir} {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return merry.Prepend(err, "can't stat blob dir")
+		}
+		if !info.IsDir() {
+			return merry.Errorf("%s isn't a directory", dir)
+		}
+	}
+	return nil
+}
+
+// Walk skips unfinished uploads.
+func (s *FSStorage) Walk(_ context.Context, fn func(blobID string, size int64) error) error {
+	files, err := ioutil.ReadDir(s.blobDir)
+	if err != nil {
+		return merry.Prepend(err, "can't read blob dir")
+	}
+	for _, file := range files {
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".upload-") {
+			continue
+		}
+		blobID, err := url.PathUnescape(file.Name())
+		if err != nil {
+			continue
+		}
+		err = fn(blobID, file.Size())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler serves signed download links (GET /{blobID}) and upload forms (POST /).
+// The upload form mirrors S3 POST policy uploads: the signed fields come first, the "file" part last.
+func (s *FSStorage) Handler() http.Handler {
+	r := chi.NewRouter()
+	r.Post("/", s.upload)
+	r.Get("/*", s.download)
+	return r
+}
+
+func (s *FSStorage) download(w http.ResponseWriter, r *http.Request) {
+	blobID, err := url.PathUnescape(chi.URLParam(r, "*"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	expires := r.URL.Query().Get("expires")
+	if !s.verify(http.MethodGet, blobID, "", expires, "", r.URL.Query().Get("signature")) {
+		http.Error(w, "", http.StatusForbidden)
+		return
+	}
+
+	f, err := s.open(blobID)
+	if err != nil {
+		if os.IsNotExist(merry.Cause(err)) {
+			http.NotFound(w, r)
+			return
+		}
+		logging.FromContext(r.Context()).WithError(err).Error("can't open blob")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	stat, err := f.Stat()
+	if err != nil {
+		logging.FromContext(r.Context()).WithError(err).Error("can't stat blob")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	contentType, err := ioutil.ReadFile(filepath.Join(s.typeDir, filepath.Base(f.Name())))
+	if err != nil || !fsInlineContentTypes[string(contentType)] {
+		contentType = []byte("application/octet-stream")
+		w.Header().Set("Content-Disposition", "attachment")
+	}
+	w.Header().Set("Content-Type", string(contentType))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	http.ServeContent(w, r, blobID, stat.ModTime(), f)
+}
+
+func (s *FSStorage) upload(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, fsMaxUploadSize)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fields := make(map[string]string)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			http.Error(w, "file is missing", http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if part.FormName() != "file" {
+			value, err := ioutil.ReadAll(io.LimitReader(part, 4096))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			fields[part.FormName()] = string(value)
+			continue
+		}
+
+		blobID, contentType := fields["key"], fields["Content-Type"]
+		if !s.verify(http.MethodPost, blobID, contentType, fields["expires"], fields["max-size"], fields["signature"]) {
+			http.Error(w, "", http.StatusForbidden)
+			return
+		}
+		var maxSize int64
+		if fields["max-size"] != "" {
+			maxSize, _ = strconv.ParseInt(fields["max-size"], 10, 64)
+		}
+		if partType := part.Header.Get("Content-Type"); contentType == "" && partType != "" {
+			contentType, _, _ = mime.ParseMediaType(partType)
+		}
+		content := bufio.NewReaderSize(part, 512)
+		head, _ := content.Peek(512)
+		contentType, ok := uploadedContentType(head, contentType)
+		if !ok {
+			http.Error(w, "content type isn't supported", http.StatusUnsupportedMediaType)
+			return
+		}
+		err = s.write(blobID, contentType, content, maxSize)
+		if merry.Is(err, ErrBlobTooLarge) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			logging.FromContext(r.Context()).WithError(err).Error("can't write blob")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+}
+
+func (s *FSStorage) write(blobID, contentType string, content io.Reader, maxSize int64) error {
+	path, err := s.blobPath(blobID)
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(s.blobDir, ".upload-")
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	if maxSize > 0 {
+		content = io.LimitReader(content, maxSize+1)
+	}
+	n, err := io.Copy(f, content)
+	if err != nil {
+		_ = f.Close()
+		return merry.Prepend(err, "can't write blob")
+	}
+	if maxSize > 0 && n > maxSize {
+		_ = f.Close()
+		return ErrBlobTooLarge.Here()
+	}
+	err = f.Close()
+	if err != nil {
+		return merry.Wrap(err)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(s.typeDir, filepath.Base(path)), []byte(contentType), 0600)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return merry.Wrap(os.Rename(f.Name(), path))
+}
+
+// uploadedContentType detects the type of the uploaded content instead of trusting the declared one.
+// Binary content that isn't recognized keeps the declared type if it's an image or video type.
+func uploadedContentType(head []byte, declaredType string) (string, bool) {
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if fsInlineContentTypes[contentType] {
+		return contentType, true
+	}
+	if contentType != "application/octet-stream" {
+		return "", false
+	}
+	if fsInlineContentTypes[declaredType] {
+		return declaredType, true
+	}
+	return contentType, true
+}
+
+func (s *FSStorage) open(blobID string) (*os.File, error) {
+	path, err := s.blobPath(blobID)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return f, nil
+}
+
+func (s *FSStorage) blobPath(blobID string) (string, error) {
+	name := url.PathEscape(blobID)
+	if blobID == "" || name == "." || name == ".." || strings.HasPrefix(name, ".upload-") {
+		return "", ErrInvalidBlobID.Here()
+	}
+	return filepath.Join(s.blobDir, name), nil
+}
+
+func (s *FSStorage) sign(method, blobID, contentType, expires, maxSize string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	_, _ = io.WriteString(mac, method+"\n"+blobID+"\n"+contentType+"\n"+expires+"\n"+maxSize)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *FSStorage) verify(method, blobID, contentType, expires, maxSize, signature string) bool {
+	if blobID == "" || expires == "" || signature == "" {
+		return false
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().UTC().Unix() > expiresAt {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(s.sign(method, blobID, contentType, expires, maxSize)))
+}
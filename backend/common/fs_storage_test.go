@@ -0,0 +1,140 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFSStorage(t *testing.T) {
+	ctx := context.Background()
+
+	var handler http.Handler
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.StripPrefix("/blob", handler).ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "koto-blobs")
+	require.Nil(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	storage, err := NewFSStorage(dir, server.URL+"/blob", []byte("secret"))
+	require.Nil(t, err)
+	handler = storage.Handler()
+	require.Nil(t, storage.Ping(ctx))
+
+	const blobID = "photo 1-abc.jpg"
+	const jpegContent = "\xff\xd8\xff\xe0jpeg content"
+	link, formData, err := storage.CreateUploadLink(ctx, blobID, "image/jpeg", 100, nil)
+	require.Nil(t, err)
+
+	upload := func(formData map[string]string, content string) int {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		for key, value := range formData {
+			_ = mw.WriteField(key, value)
+		}
+		fw, _ := mw.CreateFormFile("file", "photo.jpg")
+		_, _ = fw.Write([]byte(content))
+		_ = mw.Close()
+
+		resp, err := http.Post(link, mw.FormDataContentType(), &body)
+		require.Nil(t, err)
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+	download := func(blobID string) *http.Response {
+		downloadLink, err := storage.CreateLink(ctx, blobID, 0)
+		require.Nil(t, err)
+		resp, err := http.Get(downloadLink)
+		require.Nil(t, err)
+		return resp
+	}
+
+	tampered := make(map[string]string)
+	for key, value := range formData {
+		tampered[key] = value
+	}
+	tampered["key"] = "other.jpg"
+	assert.Equal(t, http.StatusForbidden, upload(tampered, jpegContent), "tampered key")
+
+	_, smallFormData, err := storage.CreateUploadLink(ctx, blobID, "image/jpeg", 5, nil)
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, upload(smallFormData, jpegContent))
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, upload(formData, "<html><script>alert(1)</script></html>"),
+		"the declared type isn't trusted")
+	assert.Equal(t, http.StatusNoContent, upload(formData, jpegContent))
+
+	exists, err := storage.Exists(ctx, blobID)
+	require.Nil(t, err)
+	assert.True(t, exists)
+	size, err := storage.Size(ctx, blobID)
+	require.Nil(t, err)
+	assert.Equal(t, int64(len(jpegContent)), size)
+	content, err := storage.ReadN(ctx, blobID, 4)
+	require.Nil(t, err)
+	assert.Equal(t, jpegContent[:4], string(content))
+
+	resp := download(blobID)
+	body, _ := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, jpegContent, string(body))
+	assert.Equal(t, "image/jpeg", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
+	assert.Empty(t, resp.Header.Get("Content-Disposition"))
+
+	downloadLink, err := storage.CreateLink(ctx, blobID, 0)
+	require.Nil(t, err)
+	resp, err = http.Get(strings.Replace(downloadLink, "signature=", "signature=0", 1))
+	require.Nil(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, "bad signature")
+
+	const encryptedID = "encrypted"
+	_, encryptedFormData, err := storage.CreateUploadLink(ctx, encryptedID, "text/html", 0, nil)
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, upload(encryptedFormData, "\x00\x01\x02\x03 encrypted"))
+	resp = download(encryptedID)
+	_ = resp.Body.Close()
+	assert.Equal(t, "application/octet-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "attachment", resp.Header.Get("Content-Disposition"))
+
+	require.Nil(t, storage.RemoveObject(ctx, blobID))
+	exists, err = storage.Exists(ctx, blobID)
+	require.Nil(t, err)
+	assert.False(t, exists)
+
+	_, err = storage.Exists(ctx, "..")
+	assert.NotNil(t, err, "invalid blob id")
+}
+
+func TestUploadedContentType(t *testing.T) {
+	tests := []struct {
+		head         string
+		declaredType string
+		expected     string
+		ok           bool
+	}{
+		{"\x89PNG\x0d\x0a\x1a\x0a", "image/jpeg", "image/png", true},
+		{"\x00\x01\x02", "video/quicktime", "video/quicktime", true},
+		{"\x00\x01\x02", "text/html", "application/octet-stream", true},
+		{"<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>", "image/svg+xml", "", false},
+		{"hello", "image/jpeg", "", false},
+	}
+	for _, test := range tests {
+		contentType, ok := uploadedContentType([]byte(test.head), test.declaredType)
+		assert.Equal(t, test.ok, ok, test.head)
+		assert.Equal(t, test.expected, contentType, test.head)
+	}
+}
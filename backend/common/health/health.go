@@ -0,0 +1,88 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mreider/koto/backend/common/logging"
+)
+
+const checkTimeout = time.Second * 5
+
+type Check func(ctx context.Context) error
+
+// Checker serves the liveness and readiness endpoints.
+type Checker struct {
+	names        []string
+	checks       []Check
+	shuttingDown int32
+}
+
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+func (c *Checker) Add(name string, check Check) {
+	c.names = append(c.names, name)
+	c.checks = append(c.checks, check)
+}
+
+// SetShuttingDown makes the readiness check fail, so load balancers stop sending new requests.
+func (c *Checker) SetShuttingDown() {
+	atomic.StoreInt32(&c.shuttingDown, 1)
+}
+
+// LiveHandler reports that the process is able to serve requests.
+func (c *Checker) LiveHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+}
+
+// ReadyHandler runs the checks in parallel. The response names the failed checks, the errors are logged.
+func (c *Checker) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&c.shuttingDown) == 1 {
+			writeStatus(w, http.StatusServiceUnavailable, map[string]string{"status": "shutting down"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+		defer cancel()
+
+		errs := make([]error, len(c.checks))
+		var wg sync.WaitGroup
+		for i, check := range c.checks {
+			wg.Add(1)
+			go func(i int, check Check) {
+				defer wg.Done()
+				errs[i] = check(ctx)
+			}(i, check)
+		}
+		wg.Wait()
+
+		status := http.StatusOK
+		result := map[string]string{"status": "ok"}
+		for i, err := range errs {
+			if err != nil {
+				logging.FromContext(r.Context()).WithError(err).Errorf("%s check failed", c.names[i])
+				status = http.StatusServiceUnavailable
+				result["status"] = "fail"
+				result[c.names[i]] = "fail"
+			} else {
+				result[c.names[i]] = "ok"
+			}
+		}
+		writeStatus(w, status, result)
+	})
+}
+
+func writeStatus(w http.ResponseWriter, status int, result map[string]string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(result)
+}
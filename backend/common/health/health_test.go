@@ -0,0 +1,47 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ansel1/merry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common/health"
+)
+
+func getStatus(t *testing.T, handler http.Handler) (int, map[string]string) {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var result map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	return w.Code, result
+}
+
+func TestChecker(t *testing.T) {
+	storageErr := merry.New("connection refused")
+	checker := health.NewChecker()
+	checker.Add("postgres", func(context.Context) error { return nil })
+	checker.Add("storage", func(context.Context) error { return storageErr })
+
+	status, result := getStatus(t, checker.LiveHandler())
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok", result["status"])
+
+	status, result = getStatus(t, checker.ReadyHandler())
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, map[string]string{"status": "fail", "postgres": "ok", "storage": "fail"}, result)
+
+	storageErr = nil
+	status, result = getStatus(t, checker.ReadyHandler())
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok", result["status"])
+
+	checker.SetShuttingDown()
+	status, _ = getStatus(t, checker.ReadyHandler())
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+}
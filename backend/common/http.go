@@ -1,7 +1,27 @@
 package common
 
 import (
+	"net"
+	"net/http"
 	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ansel1/merry"
+)
+
+var (
+	ErrAddressNotPublic = merry.New("address isn't public")
+
+	nonPublicNetworks = parseCIDRs(
+		"0.0.0.0/8",
+		"10.0.0.0/8",
+		"100.64.0.0/10",
+		"172.16.0.0/12",
+		"192.168.0.0/16",
+		"198.18.0.0/15",
+		"fc00::/7",
+	)
 )
 
 func CleanPublicURL(url string) string {
@@ -15,3 +35,70 @@ func CleanPublicURL(url string) string {
 	}
 	return url
 }
+
+// IsPublicIP reports whether the IP address is reachable from the internet,
+// loopback, private, link-local and multicast addresses aren't.
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidatePublicHost checks that the host isn't a name or an IP address of the local network.
+// The addresses the host resolves to are checked by the client of NewPublicHTTPClient.
+func ValidatePublicHost(host string) error {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "" || host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".local") {
+		return ErrAddressNotPublic.Here()
+	}
+	if ip := net.ParseIP(strings.Trim(host, "[]")); ip != nil && !IsPublicIP(ip) {
+		return ErrAddressNotPublic.Here()
+	}
+	return nil
+}
+
+// NewPublicHTTPClient returns a client which connects only to public IP addresses,
+// so user provided URLs can't be used to reach the services of the hub network.
+func NewPublicHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: time.Second * 30,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return merry.Wrap(err)
+			}
+			if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+				return ErrAddressNotPublic.Here()
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: time.Second * 10,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     time.Second * 90,
+		},
+	}
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
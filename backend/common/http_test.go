@@ -0,0 +1,39 @@
+package common
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	for _, ip := range []string{"8.8.8.8", "2001:4860:4860::8888"} {
+		assert.True(t, IsPublicIP(net.ParseIP(ip)), ip)
+	}
+	for _, ip := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "::1", "fd00::1", "fe80::1", "::ffff:127.0.0.1"} {
+		assert.False(t, IsPublicIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestValidatePublicHost(t *testing.T) {
+	assert.Nil(t, ValidatePublicHost("push.example.com"))
+	assert.Nil(t, ValidatePublicHost("8.8.8.8"))
+	for _, host := range []string{"", "localhost", "api.localhost", "printer.local", "127.0.0.1", "[::1]", "169.254.169.254"} {
+		assert.True(t, merry.Is(ValidatePublicHost(host), ErrAddressNotPublic), host)
+	}
+}
+
+func TestNewPublicHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	_, err := NewPublicHTTPClient(time.Second).Get(server.URL)
+	require.NotNil(t, err)
+	assert.True(t, merry.Is(err, ErrAddressNotPublic), "a loopback address is refused")
+}
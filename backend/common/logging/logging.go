@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/ansel1/merry"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+type Config struct {
+	Level  string `yaml:"level" default:"info" env:"KOTO_LOG_LEVEL"`
+	Format string `yaml:"format" default:"text" env:"KOTO_LOG_FORMAT"`
+}
+
+// Setup configures the standard logger.
+// Output of the "log" package goes through it too, so messages of third-party packages are redacted as well.
+func (cfg Config) Setup() error {
+	return cfg.configure(logrus.StandardLogger())
+}
+
+func (cfg Config) configure(logger *logrus.Logger) error {
+	level, err := logrus.ParseLevel(cfg.Level)
+	if err != nil {
+		return merry.Prepend(err, "invalid log level")
+	}
+
+	switch cfg.Format {
+	case FormatText, "":
+		logger.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	case FormatJSON:
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	default:
+		return merry.Errorf("invalid log format '%s'", cfg.Format)
+	}
+	logger.SetLevel(level)
+	logger.ReplaceHooks(make(logrus.LevelHooks))
+	logger.AddHook(redactHook{})
+
+	log.SetFlags(0)
+	log.SetOutput(logger.WriterLevel(logrus.InfoLevel))
+	return nil
+}
+
+type contextKey struct{}
+
+type requestFields struct {
+	mu     sync.Mutex
+	fields logrus.Fields
+}
+
+// NewContext returns a context whose logger adds fields to every line.
+// AddField adds more fields later, e.g. once the user is authenticated.
+func NewContext(ctx context.Context, fields logrus.Fields) context.Context {
+	holder := &requestFields{
+		fields: make(logrus.Fields, len(fields)),
+	}
+	for key, value := range fields {
+		holder.fields[key] = value
+	}
+	return context.WithValue(ctx, contextKey{}, holder)
+}
+
+func AddField(ctx context.Context, key string, value interface{}) {
+	holder, ok := ctx.Value(contextKey{}).(*requestFields)
+	if !ok {
+		return
+	}
+	holder.mu.Lock()
+	holder.fields[key] = value
+	holder.mu.Unlock()
+}
+
+// FromContext returns the logger with the request fields, or the standard logger outside of a request.
+func FromContext(ctx context.Context) *logrus.Entry {
+	holder, ok := ctx.Value(contextKey{}).(*requestFields)
+	if !ok {
+		return logrus.NewEntry(logrus.StandardLogger())
+	}
+	holder.mu.Lock()
+	defer holder.mu.Unlock()
+	return logrus.WithFields(holder.fields)
+}
@@ -0,0 +1,84 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common/logging"
+)
+
+func setupLogger(t *testing.T) *bytes.Buffer {
+	require.NoError(t, logging.Config{Level: "debug", Format: logging.FormatJSON}.Setup())
+	var buf bytes.Buffer
+	logrus.SetOutput(&buf)
+	return &buf
+}
+
+func readLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &fields))
+		lines = append(lines, fields)
+	}
+	return lines
+}
+
+func TestConfig_Setup(t *testing.T) {
+	assert.Error(t, logging.Config{Level: "loud", Format: logging.FormatText}.Setup())
+	assert.Error(t, logging.Config{Level: "info", Format: "xml"}.Setup())
+}
+
+func TestRedact(t *testing.T) {
+	assert.Equal(t, "Authorization: Bearer [REDACTED]", logging.Redact("Authorization: Bearer abc.def"))
+	assert.Equal(t, `{"password":"[REDACTED]","name":"ann"}`, logging.Redact(`{"password":"secret1","name":"ann"}`))
+	assert.Equal(t, "GET /metrics?token=[REDACTED]&x=1", logging.Redact("GET /metrics?token=abc&x=1"))
+	assert.Equal(t, "link [REDACTED] sent", logging.Redact("link eyJhbGciOiJSUzI1NiJ9.eyJpZCI6IjEifQ.c2ln sent"))
+	assert.Equal(t, "nothing to hide", logging.Redact("nothing to hide"))
+}
+
+func TestRedactHook(t *testing.T) {
+	buf := setupLogger(t)
+
+	logrus.WithFields(logrus.Fields{
+		"session_cookie": "abc",
+		"user_id":        "1",
+	}).Info("login with password=qwerty")
+
+	lines := readLines(t, buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "login with password=[REDACTED]", lines[0]["msg"])
+	assert.Equal(t, "[REDACTED]", lines[0]["session_cookie"])
+	assert.Equal(t, "1", lines[0]["user_id"])
+}
+
+func TestMiddleware(t *testing.T) {
+	buf := setupLogger(t)
+
+	handler := middleware.RequestID(logging.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logging.AddField(r.Context(), "user_id", "user-1")
+		logging.FromContext(r.Context()).Debug("handling")
+		w.WriteHeader(http.StatusTeapot)
+	})))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/rpc.UserService/Me?token=abc", nil))
+
+	lines := readLines(t, buf)
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.NotEmpty(t, line["request_id"])
+		assert.Equal(t, "user-1", line["user_id"])
+	}
+	assert.Equal(t, "handling", lines[0]["msg"])
+	assert.Equal(t, "/rpc.UserService/Me", lines[1]["path"])
+	assert.Equal(t, float64(http.StatusTeapot), lines[1]["status"])
+}
@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	sensitiveKeys = []string{"token", "password", "cookie", "authorization", "secret"}
+
+	sensitivePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)(bearer\s+)[^\s"',;]+`),
+		regexp.MustCompile(`(?i)((?:token|password|secret|cookie|authorization)[\w-]*["']?\s*[:=]\s*["']?(?:bearer\s+)?)[^\s"',;&]+`),
+		regexp.MustCompile(`eyJ[\w-]+\.[\w-]+\.[\w-]*`),
+	}
+)
+
+// Redact masks tokens, passwords and cookies in s.
+func Redact(s string) string {
+	for _, pattern := range sensitivePatterns {
+		if pattern.NumSubexp() > 0 {
+			s = pattern.ReplaceAllString(s, "${1}"+redacted)
+		} else {
+			s = pattern.ReplaceAllString(s, redacted)
+		}
+	}
+	return s
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitiveKey := range sensitiveKeys {
+		if strings.Contains(key, sensitiveKey) {
+			return true
+		}
+	}
+	return false
+}
+
+type redactHook struct{}
+
+func (redactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire gets a copy of the entry, but its data is shared with the caller, so the redacted values go to a new map.
+func (redactHook) Fire(entry *logrus.Entry) error {
+	data := make(logrus.Fields, len(entry.Data))
+	for key, value := range entry.Data {
+		switch v := value.(type) {
+		case string:
+			if isSensitiveKey(key) {
+				value = redacted
+			} else {
+				value = Redact(v)
+			}
+		case error:
+			value = Redact(v.Error())
+		default:
+			if isSensitiveKey(key) {
+				value = redacted
+			}
+		}
+		data[key] = value
+	}
+	entry.Data = data
+	entry.Message = Redact(entry.Message)
+	return nil
+}
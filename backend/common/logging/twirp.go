@@ -0,0 +1,37 @@
+package logging
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ansel1/merry"
+	"github.com/twitchtv/twirp"
+)
+
+// TwirpHooks adds the Twirp method to the request logger and logs errors.
+// Internal errors are logged with the stack trace, others only if they carry a source line.
+func TwirpHooks() *twirp.ServerHooks {
+	return &twirp.ServerHooks{
+		RequestRouted: func(ctx context.Context) (context.Context, error) {
+			service, _ := twirp.ServiceName(ctx)
+			method, _ := twirp.MethodName(ctx)
+			AddField(ctx, "rpc", service+"/"+method)
+			return ctx, nil
+		},
+		Error: func(ctx context.Context, err twirp.Error) context.Context {
+			logger := FromContext(ctx).WithField("code", err.Code())
+			cause := errors.Unwrap(err)
+			switch {
+			case cause == nil:
+				logger.Info(err.Msg())
+			case err.Code() == twirp.Internal:
+				logger.WithField("stack", merry.Stacktrace(cause)).Error(cause)
+			default:
+				if sourceLine := merry.SourceLine(cause); sourceLine != "" {
+					logger.WithField("source", sourceLine).Warn(cause)
+				}
+			}
+			return ctx
+		},
+	}
+}
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"database/sql"
+)
+
+// RegisterDBStats exposes the connection pool stats of db.
+func RegisterDBStats(registry *Registry, db *sql.DB) {
+	stat := func(f func(stats sql.DBStats) float64) func() (float64, error) {
+		return func() (float64, error) {
+			return f(db.Stats()), nil
+		}
+	}
+	registry.GaugeFunc("koto_db_open_connections", "Established database connections, both in use and idle.",
+		stat(func(stats sql.DBStats) float64 { return float64(stats.OpenConnections) }))
+	registry.GaugeFunc("koto_db_in_use_connections", "Database connections currently in use.",
+		stat(func(stats sql.DBStats) float64 { return float64(stats.InUse) }))
+	registry.GaugeFunc("koto_db_idle_connections", "Idle database connections.",
+		stat(func(stats sql.DBStats) float64 { return float64(stats.Idle) }))
+	registry.GaugeFunc("koto_db_max_open_connections", "Maximum number of open database connections.",
+		stat(func(stats sql.DBStats) float64 { return float64(stats.MaxOpenConnections) }))
+	registry.GaugeFunc("koto_db_wait_count", "Total number of connections waited for.",
+		stat(func(stats sql.DBStats) float64 { return float64(stats.WaitCount) }))
+	registry.GaugeFunc("koto_db_wait_duration_seconds", "Total time blocked waiting for a new connection.",
+		stat(func(stats sql.DBStats) float64 { return stats.WaitDuration.Seconds() }))
+}
@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Handler serves the registry to requests that pass the token as a bearer token or the "token" query parameter.
+func Handler(registry *Registry, token string) http.Handler {
+	metricsHandler := promhttp.HandlerFor(registry.registry, promhttp.HandlerOpts{})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestToken := r.URL.Query().Get("token")
+		if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+			requestToken = strings.TrimPrefix(auth, "Bearer ")
+		}
+		if token == "" || subtle.ConstantTimeCompare([]byte(requestToken), []byte(token)) != 1 {
+			http.Error(w, "", http.StatusUnauthorized)
+			return
+		}
+		metricsHandler.ServeHTTP(w, r)
+	})
+}
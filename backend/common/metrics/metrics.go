@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultBuckets are latency buckets in seconds.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Default is the registry served by the hubs' /metrics endpoints.
+var Default = NewRegistry()
+
+// Registry wraps a Prometheus registry. Registering a metric name again replaces the metric,
+// so components that are created more than once (e.g. in tests) don't fail to register.
+type Registry struct {
+	mu         sync.Mutex
+	registry   *prometheus.Registry
+	collectors map[string]prometheus.Collector
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		registry:   prometheus.NewRegistry(),
+		collectors: make(map[string]prometheus.Collector),
+	}
+}
+
+func (r *Registry) register(name string, collector prometheus.Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.collectors[name]; ok {
+		r.registry.Unregister(existing)
+	}
+	r.registry.MustRegister(collector)
+	r.collectors[name] = collector
+}
+
+// Counter is a monotonically increasing value partitioned by labels.
+type Counter struct {
+	vec *prometheus.CounterVec
+}
+
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	c := &Counter{
+		vec: prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labels),
+	}
+	r.register(name, c.vec)
+	return c
+}
+
+func NewCounter(name, help string, labels ...string) *Counter {
+	return Default.NewCounter(name, help, labels...)
+}
+
+func (c *Counter) Inc(labelValues ...string) {
+	c.vec.WithLabelValues(labelValues...).Inc()
+}
+
+func (c *Counter) Add(value float64, labelValues ...string) {
+	c.vec.WithLabelValues(labelValues...).Add(value)
+}
+
+// Gauge is a value that can go up and down, partitioned by labels.
+type Gauge struct {
+	vec *prometheus.GaugeVec
+}
+
+func (r *Registry) NewGauge(name, help string, labels ...string) *Gauge {
+	g := &Gauge{
+		vec: prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, labels),
+	}
+	r.register(name, g.vec)
+	return g
+}
+
+func NewGauge(name, help string, labels ...string) *Gauge {
+	return Default.NewGauge(name, help, labels...)
+}
+
+func (g *Gauge) Set(value float64, labelValues ...string) {
+	g.vec.WithLabelValues(labelValues...).Set(value)
+}
+
+type gaugeFunc struct {
+	desc *prometheus.Desc
+	name string
+	f    func() (float64, error)
+}
+
+// GaugeFunc registers a gauge evaluated on every scrape. The gauge is skipped if f fails.
+// Registering the same name again replaces the function.
+func (r *Registry) GaugeFunc(name, help string, f func() (float64, error)) {
+	r.register(name, &gaugeFunc{
+		desc: prometheus.NewDesc(name, help, nil, nil),
+		name: name,
+		f:    f,
+	})
+}
+
+func GaugeFunc(name, help string, f func() (float64, error)) {
+	Default.GaugeFunc(name, help, f)
+}
+
+func (g *gaugeFunc) Describe(ch chan<- *prometheus.Desc) {
+	ch <- g.desc
+}
+
+func (g *gaugeFunc) Collect(ch chan<- prometheus.Metric) {
+	value, err := g.f()
+	if err != nil {
+		logrus.WithError(err).Warnf("can't collect %s", g.name)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, value)
+}
+
+// Histogram counts observations in cumulative buckets, partitioned by labels.
+type Histogram struct {
+	vec *prometheus.HistogramVec
+}
+
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	h := &Histogram{
+		vec: prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets}, labels),
+	}
+	r.register(name, h.vec)
+	return h
+}
+
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	return Default.NewHistogram(name, help, buckets, labels...)
+}
+
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	h.vec.WithLabelValues(labelValues...).Observe(value)
+}
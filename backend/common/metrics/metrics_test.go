@@ -0,0 +1,72 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ansel1/merry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common/metrics"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := metrics.NewRegistry()
+	counter := registry.NewCounter("test_requests_total", "Requests.", "method")
+	counter.Inc("b")
+	counter.Add(2, `a"`)
+	registry.NewGauge("test_backlog", "Backlog.").Set(3)
+	registry.GaugeFunc("test_depth", "Depth.", func() (float64, error) { return 5, nil })
+	registry.GaugeFunc("test_depth", "Depth.", func() (float64, error) { return 7, nil })
+	registry.GaugeFunc("test_broken", "Broken.", func() (float64, error) { return 0, merry.New("failed") })
+	histogram := registry.NewHistogram("test_duration_seconds", "Duration.", []float64{0.1, 1}, "method")
+	histogram.Observe(0.5, "a")
+	histogram.Observe(2, "a")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	r.Header.Set("Authorization", "Bearer secret")
+	metrics.Handler(registry, "secret").ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	for _, line := range []string{
+		"# TYPE test_backlog gauge\ntest_backlog 3\n",
+		"test_depth 7\n",
+		`test_duration_seconds_bucket{method="a",le="0.1"} 0` + "\n",
+		`test_duration_seconds_bucket{method="a",le="1"} 1` + "\n",
+		`test_duration_seconds_bucket{method="a",le="+Inf"} 2` + "\n",
+		`test_duration_seconds_sum{method="a"} 2.5` + "\n",
+		`test_requests_total{method="a\""} 2` + "\n",
+		`test_requests_total{method="b"} 1` + "\n",
+	} {
+		assert.Contains(t, body, line)
+	}
+	assert.NotContains(t, body, "test_broken", "a failed gauge is skipped")
+}
+
+func TestHandler(t *testing.T) {
+	registry := metrics.NewRegistry()
+	registry.NewGauge("test_backlog", "Backlog.").Set(1)
+	handler := metrics.Handler(registry, "secret")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	r.Header.Set("Authorization", "Bearer secret")
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "test_backlog 1\n")
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics?token=secret", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	metrics.Handler(registry, "").ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics?token=", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "metrics are never served without a token")
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/twitchtv/twirp"
+)
+
+type requestStartKey struct{}
+
+var (
+	rpcRequests = NewCounter("koto_rpc_requests_total", "Twirp requests by HTTP status.",
+		"service", "method", "status")
+	rpcErrors = NewCounter("koto_rpc_errors_total", "Twirp errors by error code.",
+		"service", "method", "code")
+	rpcDuration = NewHistogram("koto_rpc_request_duration_seconds", "Twirp request latencies.",
+		DefaultBuckets, "service", "method")
+)
+
+// TwirpHooks counts requests, errors and latencies per Twirp method.
+func TwirpHooks() *twirp.ServerHooks {
+	return &twirp.ServerHooks{
+		RequestReceived: func(ctx context.Context) (context.Context, error) {
+			return context.WithValue(ctx, requestStartKey{}, time.Now()), nil
+		},
+		Error: func(ctx context.Context, err twirp.Error) context.Context {
+			service, method := twirpMethod(ctx)
+			rpcErrors.Inc(service, method, string(err.Code()))
+			return ctx
+		},
+		ResponseSent: func(ctx context.Context) {
+			service, method := twirpMethod(ctx)
+			status, _ := twirp.StatusCode(ctx)
+			rpcRequests.Inc(service, method, status)
+			if start, ok := ctx.Value(requestStartKey{}).(time.Time); ok {
+				rpcDuration.Observe(time.Since(start).Seconds(), service, method)
+			}
+		},
+	}
+}
+
+func twirpMethod(ctx context.Context) (service, method string) {
+	service, _ = twirp.ServiceName(ctx)
+	method, ok := twirp.MethodName(ctx)
+	if !ok {
+		method = "unknown"
+	}
+	return service, method
+}
@@ -0,0 +1,54 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/mreider/koto/backend/common/logging"
+)
+
+const (
+	notificationCleanInterval = time.Hour
+)
+
+type NotificationCleaner struct {
+	repo      NotificationRepo
+	retention time.Duration
+}
+
+func NewNotificationCleaner(repo NotificationRepo, retention time.Duration) *NotificationCleaner {
+	return &NotificationCleaner{
+		repo:      repo,
+		retention: retention,
+	}
+}
+
+func (c *NotificationCleaner) Clean(ctx context.Context) {
+	if c.retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(notificationCleanInterval)
+	defer ticker.Stop()
+
+	c.clean(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.clean(ctx)
+		}
+	}
+}
+
+func (c *NotificationCleaner) clean(ctx context.Context) {
+	deleted, err := c.repo.DeleteExpired(ctx, CurrentTimestamp().Add(-c.retention))
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("can't delete expired notifications")
+		return
+	}
+	if deleted > 0 {
+		logging.FromContext(ctx).Infof("deleted %d expired notifications", deleted)
+	}
+}
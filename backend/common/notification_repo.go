@@ -1,8 +1,10 @@
 package common
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"strconv"
 	"time"
 
 	"github.com/ansel1/merry"
@@ -11,6 +13,8 @@ import (
 	"github.com/jmoiron/sqlx/types"
 )
 
+var ErrNotificationNotFound = ErrNotFound.WithMessage("notification not found")
+
 type Notification struct {
 	ID        string         `db:"id"`
 	UserID    string         `db:"user_id"`
@@ -18,15 +22,34 @@ type Notification struct {
 	Type      string         `db:"type"`
 	Data      types.JSONText `db:"data"`
 	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
 	ReadAt    sql.NullTime   `db:"read_at"`
 }
 
 type NotificationRepo interface {
-	AddNotifications(userIDs []string, text, notificationType string, data map[string]interface{}) error
-	Counts(userID string) (total int, unread int, err error)
-	Notifications(userID string) ([]Notification, error)
-	Clean(userID string, lastKnownID string) error
-	MarkRead(userID string, lastKnownID string) error
+	AddNotifications(ctx context.Context, userIDs []string, text, notificationType string, data map[string]interface{}) error
+	Counts(ctx context.Context, userID string) (total int, unread int, err error)
+	Notifications(ctx context.Context, userID string, beforeID string, count int) ([]Notification, error)
+	Clean(ctx context.Context, userID string, lastKnownID string) error
+	MarkRead(ctx context.Context, userID string, lastKnownID string) error
+	DeleteNotifications(ctx context.Context, userID string, notificationIDs []string) error
+	MarkNotificationsRead(ctx context.Context, userID string, notificationIDs []string) error
+	DeleteExpired(ctx context.Context, updatedBefore time.Time) (int64, error)
+}
+
+type notificationGroup struct {
+	targetKey string
+	action    string
+}
+
+// notificationGroups lists the notification types that are merged into one unread notification per target.
+var notificationGroups = map[string]notificationGroup{
+	"message/like":     {targetKey: "message_id", action: "liked your post"},
+	"message/reaction": {targetKey: "message_id", action: "reacted to your post"},
+	"comment/like":     {targetKey: "comment_id", action: "liked your comment"},
+	"comment/reaction": {targetKey: "comment_id", action: "reacted to your comment"},
+	"comment/post":     {targetKey: "message_id", action: "commented on your post"},
+	"event/rsvp":       {targetKey: "message_id", action: "replied to your event"},
 }
 
 type notificationRepo struct {
@@ -39,41 +62,82 @@ func NewNotifications(db *sqlx.DB) NotificationRepo {
 	}
 }
 
-func (r *notificationRepo) AddNotifications(userIDs []string, text, notificationType string, data map[string]interface{}) error {
-	var jsonData []byte
-	var err error
-	if data != nil {
-		jsonData, err = json.Marshal(data)
-		if err != nil {
-			return merry.Wrap(err)
+func (r *notificationRepo) AddNotifications(ctx context.Context, userIDs []string, text, notificationType string, data map[string]interface{}) error {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+
+	var groupKey sql.NullString
+	group, ok := notificationGroups[notificationType]
+	if ok {
+		if targetID, _ := data[group.targetKey].(string); targetID != "" {
+			groupKey = sql.NullString{String: notificationType + "/" + targetID, Valid: true}
 		}
-	} else {
-		jsonData = []byte("{}")
 	}
 
 	now := CurrentTimestamp()
 	for _, userID := range userIDs {
-		notificationID, err := uuid.NewV4()
-		if err != nil {
+		err := RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+			if groupKey.Valid {
+				var existing Notification
+				err := tx.GetContext(ctx, &existing, `
+					select id, data
+					from notifications
+					where user_id = $1 and group_key = $2 and read_at is null
+					order by updated_at desc
+					limit 1
+					for update`,
+					userID, groupKey.String)
+				if err != nil && !merry.Is(err, sql.ErrNoRows) {
+					return merry.Wrap(err)
+				}
+				if err == nil {
+					var existingData map[string]interface{}
+					err = json.Unmarshal(existing.Data, &existingData)
+					if err != nil {
+						return merry.Wrap(err)
+					}
+					groupData := mergeNotificationData(existingData, data)
+					jsonData, err := json.Marshal(groupData)
+					if err != nil {
+						return merry.Wrap(err)
+					}
+					_, err = tx.ExecContext(ctx, `
+						update notifications
+						set text = $1, data = $2, updated_at = $3
+						where id = $4`,
+						groupNotificationText(groupData, group.action, text), types.JSONText(jsonData), now, existing.ID)
+					return merry.Wrap(err)
+				}
+			}
+
+			jsonData, err := json.Marshal(data)
+			if err != nil {
+				return merry.Wrap(err)
+			}
+			notificationID, err := uuid.NewV4()
+			if err != nil {
+				return merry.Wrap(err)
+			}
+			_, err = tx.ExecContext(ctx, `
+				insert into notifications(id, user_id, text, type, data, created_at, updated_at, group_key)
+				values ($1, $2, $3, $4, $5, $6, $6, $7)`,
+				notificationID, userID, text, notificationType, types.JSONText(jsonData), now, groupKey)
 			return merry.Wrap(err)
-		}
-		_, err = r.db.Exec(`
-		insert into notifications(id, user_id, text, type, data, created_at) 
-		values ($1, $2, $3, $4, $5, $6)`,
-			notificationID, userID, text, notificationType, types.JSONText(jsonData), now)
+		})
 		if err != nil {
-			return merry.Wrap(err)
+			return err
 		}
 	}
 	return nil
 }
 
-func (r *notificationRepo) Counts(userID string) (total int, unread int, err error) {
+func (r *notificationRepo) Counts(ctx context.Context, userID string) (total int, unread int, err error) {
 	var counters struct {
 		Total  int `db:"total"`
 		Unread int `db:"unread"`
 	}
-	err = r.db.Get(&counters, `
+	err = r.db.GetContext(ctx, &counters, `
 		select count(*) total,
 		       count(*) filter (where read_at is null) unread
 		from notifications
@@ -85,33 +149,204 @@ func (r *notificationRepo) Counts(userID string) (total int, unread int, err err
 	return counters.Total, counters.Unread, nil
 }
 
-func (r *notificationRepo) Notifications(userID string) ([]Notification, error) {
+// Notifications returns up to count latest updated notifications updated before beforeID (if set), ordered ascending.
+// A group is updated when a notification is merged into it.
+// ErrNotificationNotFound is returned if beforeID doesn't exist (e.g. it's deleted or merged into a group).
+func (r *notificationRepo) Notifications(ctx context.Context, userID string, beforeID string, count int) ([]Notification, error) {
 	var notifications []Notification
-	err := r.db.Select(&notifications, `
-		select id, user_id, text, type, data, created_at, read_at
-		from notifications
-		where user_id = $1
-		order by created_at`,
-		userID)
-	if err != nil {
-		return nil, merry.Wrap(err)
+	if beforeID == "" {
+		err := r.db.SelectContext(ctx, &notifications, `
+			select id, user_id, text, type, data, created_at, updated_at, read_at
+			from notifications
+			where user_id = $1
+			order by updated_at desc, id desc
+			limit $2`,
+			userID, count)
+		if err != nil {
+			return nil, merry.Wrap(err)
+		}
+	} else {
+		var before Notification
+		err := r.db.GetContext(ctx, &before, `
+			select id, updated_at
+			from notifications
+			where user_id = $1 and id = $2`,
+			userID, beforeID)
+		if err != nil {
+			if merry.Is(err, sql.ErrNoRows) {
+				return nil, ErrNotificationNotFound.Here()
+			}
+			return nil, merry.Wrap(err)
+		}
+		err = r.db.SelectContext(ctx, &notifications, `
+			select id, user_id, text, type, data, created_at, updated_at, read_at
+			from notifications
+			where user_id = $1 and (updated_at, id) < ($2, $3)
+			order by updated_at desc, id desc
+			limit $4`,
+			userID, before.UpdatedAt, before.ID, count)
+		if err != nil {
+			return nil, merry.Wrap(err)
+		}
+	}
+	for i, j := 0, len(notifications)-1; i < j; i, j = i+1, j-1 {
+		notifications[i], notifications[j] = notifications[j], notifications[i]
 	}
 	return notifications, nil
 }
 
-func (r *notificationRepo) Clean(userID string, lastKnownID string) error {
-	_, err := r.db.Exec(`
+func (r *notificationRepo) Clean(ctx context.Context, userID string, lastKnownID string) error {
+	_, err := r.db.ExecContext(ctx, `
 		delete from notifications
-		where user_id = $1 and created_at <= (select created_at from notifications where user_id = $1 and id = $2)`,
+		where user_id = $1 and updated_at <= (select updated_at from notifications where user_id = $1 and id = $2)`,
 		userID, lastKnownID)
 	return merry.Wrap(err)
 }
 
-func (r *notificationRepo) MarkRead(userID string, lastKnownID string) error {
-	_, err := r.db.Exec(`
+func (r *notificationRepo) MarkRead(ctx context.Context, userID string, lastKnownID string) error {
+	_, err := r.db.ExecContext(ctx, `
 		update notifications
 		set read_at = $1
-		where user_id = $2 and read_at is null and created_at <= (select created_at from notifications where user_id = $2 and id = $3)`,
+		where user_id = $2 and read_at is null and updated_at <= (select updated_at from notifications where user_id = $2 and id = $3)`,
 		CurrentTimestamp(), userID, lastKnownID)
 	return merry.Wrap(err)
 }
+
+func (r *notificationRepo) DeleteNotifications(ctx context.Context, userID string, notificationIDs []string) error {
+	if len(notificationIDs) == 0 {
+		return nil
+	}
+	query, args, err := sqlx.In(`
+		delete from notifications
+		where user_id = ? and id in (?)`,
+		userID, notificationIDs)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	_, err = r.db.ExecContext(ctx, r.db.Rebind(query), args...)
+	return merry.Wrap(err)
+}
+
+func (r *notificationRepo) MarkNotificationsRead(ctx context.Context, userID string, notificationIDs []string) error {
+	if len(notificationIDs) == 0 {
+		return nil
+	}
+	query, args, err := sqlx.In(`
+		update notifications
+		set read_at = ?
+		where user_id = ? and read_at is null and id in (?)`,
+		CurrentTimestamp(), userID, notificationIDs)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	_, err = r.db.ExecContext(ctx, r.db.Rebind(query), args...)
+	return merry.Wrap(err)
+}
+
+func (r *notificationRepo) DeleteExpired(ctx context.Context, updatedBefore time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `
+		delete from notifications
+		where updated_at < $1`,
+		updatedBefore)
+	if err != nil {
+		return 0, merry.Wrap(err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, merry.Wrap(err)
+	}
+	return rowsAffected, nil
+}
+
+// mergeNotificationData adds the actors of the new notification to the existing group, the latest actor first.
+func mergeNotificationData(existing, data map[string]interface{}) map[string]interface{} {
+	userIDs, userNames := notificationActors(data)
+	existingUserIDs, existingUserNames := notificationActors(existing)
+	for i, userID := range existingUserIDs {
+		if !containsString(userIDs, userID) {
+			userIDs = append(userIDs, userID)
+			userNames = append(userNames, existingUserNames[i])
+		}
+	}
+
+	result := make(map[string]interface{}, len(data)+3)
+	for key, value := range data {
+		result[key] = value
+	}
+	if len(userIDs) > 0 {
+		result["user_id"] = userIDs[0]
+		result["user_name"] = userNames[0]
+	}
+	result["user_ids"] = userIDs
+	result["user_names"] = userNames
+
+	reactions := toStrings(data["reactions"])
+	for _, reaction := range toStrings(existing["reactions"]) {
+		if !containsString(reactions, reaction) {
+			reactions = append(reactions, reaction)
+		}
+	}
+	if len(reactions) > 0 {
+		result["reactions"] = reactions
+	}
+	return result
+}
+
+func notificationActors(data map[string]interface{}) (userIDs, userNames []string) {
+	userIDs = toStrings(data["user_ids"])
+	userNames = toStrings(data["user_names"])
+	if len(userIDs) == 0 {
+		if userID, _ := data["user_id"].(string); userID != "" {
+			userIDs = []string{userID}
+		}
+	}
+	if len(userNames) == 0 {
+		if userName, _ := data["user_name"].(string); userName != "" {
+			userNames = []string{userName}
+		}
+	}
+	for len(userNames) < len(userIDs) {
+		userNames = append(userNames, "")
+	}
+	return userIDs, userNames[:len(userIDs)]
+}
+
+func groupNotificationText(data map[string]interface{}, action, defaultText string) string {
+	userNames := toStrings(data["user_names"])
+	if len(userNames) < 2 || userNames[0] == "" {
+		return defaultText
+	}
+	if len(userNames) == 2 {
+		if userNames[1] == "" {
+			return userNames[0] + " and 1 other " + action
+		}
+		return userNames[0] + " and " + userNames[1] + " " + action
+	}
+	return userNames[0] + " and " + strconv.Itoa(len(userNames)-1) + " others " + action
+}
+
+func toStrings(value interface{}) []string {
+	switch value := value.(type) {
+	case []string:
+		return append([]string(nil), value...)
+	case []interface{}:
+		result := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+func containsString(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package common_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/dbtest"
+	"github.com/mreider/koto/backend/messagehub/migrate"
+)
+
+func TestNotificationRepo_Notifications(t *testing.T) {
+	te := dbtest.NewTestEnvironment("common", migrate.Migrate)
+	defer te.Cleanup()
+
+	notifications := common.NewNotifications(te.DB)
+	for _, text := range []string{"1", "2", "3"} {
+		require.Nil(t, notifications.AddNotifications(te.Ctx, []string{"1"}, text, "message/post", nil))
+		time.Sleep(time.Millisecond)
+	}
+
+	page, err := notifications.Notifications(te.Ctx, "1", "", 2)
+	require.Nil(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "2", page[0].Text)
+	assert.Equal(t, "3", page[1].Text)
+
+	page, err = notifications.Notifications(te.Ctx, "1", page[0].ID, 2)
+	require.Nil(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "1", page[0].Text)
+
+	require.Nil(t, notifications.DeleteNotifications(te.Ctx, "1", []string{page[0].ID}))
+	_, err = notifications.Notifications(te.Ctx, "1", page[0].ID, 2)
+	assert.True(t, merry.Is(err, common.ErrNotificationNotFound), "before_id is deleted")
+
+	page, err = notifications.Notifications(te.Ctx, "1", "", 2)
+	require.Nil(t, err)
+	_, err = notifications.Notifications(te.Ctx, "2", page[0].ID, 2)
+	assert.True(t, merry.Is(err, common.ErrNotificationNotFound), "before_id belongs to another user")
+}
+
+func TestNotificationRepo_Groups(t *testing.T) {
+	te := dbtest.NewTestEnvironment("common", migrate.Migrate)
+	defer te.Cleanup()
+
+	notifications := common.NewNotifications(te.DB)
+	like := func(userID string) {
+		require.Nil(t, notifications.AddNotifications(te.Ctx, []string{"1"}, "user"+userID+" liked your post", "message/like", map[string]interface{}{
+			"message_id": "m1",
+			"user_id":    userID,
+			"user_name":  "user" + userID,
+		}))
+		time.Sleep(time.Millisecond)
+	}
+
+	like("2")
+	require.Nil(t, notifications.AddNotifications(te.Ctx, []string{"1"}, "post", "message/post", nil))
+	time.Sleep(time.Millisecond)
+	like("3")
+
+	page, err := notifications.Notifications(te.Ctx, "1", "", 10)
+	require.Nil(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "post", page[0].Text)
+	group := page[1]
+	assert.Equal(t, "message/like", group.Type, "the updated group is the latest")
+	assert.True(t, group.UpdatedAt.After(group.CreatedAt), "the group keeps its created_at")
+	assert.True(t, group.CreatedAt.Before(page[0].CreatedAt))
+
+	require.Nil(t, notifications.MarkRead(te.Ctx, "1", page[0].ID))
+	total, unread, err := notifications.Counts(te.Ctx, "1")
+	require.Nil(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, 1, unread, "the notifications updated up to the last known one are read")
+
+	deleted, err := notifications.DeleteExpired(te.Ctx, page[0].UpdatedAt.Add(time.Microsecond))
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), deleted, "the group is expired by its updated_at")
+}
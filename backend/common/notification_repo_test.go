@@ -0,0 +1,168 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeNotificationData(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing map[string]interface{}
+		data     map[string]interface{}
+		want     map[string]interface{}
+	}{
+		{
+			name:     "second actor",
+			existing: map[string]interface{}{"message_id": "m1", "user_id": "1", "user_name": "Alice"},
+			data:     map[string]interface{}{"message_id": "m1", "user_id": "2", "user_name": "Bob"},
+			want: map[string]interface{}{
+				"message_id": "m1",
+				"user_id":    "2",
+				"user_name":  "Bob",
+				"user_ids":   []string{"2", "1"},
+				"user_names": []string{"Bob", "Alice"},
+			},
+		},
+		{
+			name: "actor of a group stored as JSON",
+			existing: map[string]interface{}{
+				"message_id": "m1",
+				"user_id":    "2",
+				"user_name":  "Bob",
+				"user_ids":   []interface{}{"2", "1"},
+				"user_names": []interface{}{"Bob", "Alice"},
+			},
+			data: map[string]interface{}{"message_id": "m1", "user_id": "3", "user_name": "Carol"},
+			want: map[string]interface{}{
+				"message_id": "m1",
+				"user_id":    "3",
+				"user_name":  "Carol",
+				"user_ids":   []string{"3", "2", "1"},
+				"user_names": []string{"Carol", "Bob", "Alice"},
+			},
+		},
+		{
+			name: "repeated actor moves first",
+			existing: map[string]interface{}{
+				"user_ids":   []interface{}{"2", "1"},
+				"user_names": []interface{}{"Bob", "Alice"},
+			},
+			data: map[string]interface{}{"user_id": "1", "user_name": "Alice"},
+			want: map[string]interface{}{
+				"user_id":    "1",
+				"user_name":  "Alice",
+				"user_ids":   []string{"1", "2"},
+				"user_names": []string{"Alice", "Bob"},
+			},
+		},
+		{
+			name:     "missing names",
+			existing: map[string]interface{}{"user_id": "1"},
+			data:     map[string]interface{}{"user_id": "2", "user_name": "Bob"},
+			want: map[string]interface{}{
+				"user_id":    "2",
+				"user_name":  "Bob",
+				"user_ids":   []string{"2", "1"},
+				"user_names": []string{"Bob", ""},
+			},
+		},
+		{
+			name:     "no actors",
+			existing: map[string]interface{}{"message_id": "m1"},
+			data:     map[string]interface{}{"message_id": "m1"},
+			want: map[string]interface{}{
+				"message_id": "m1",
+				"user_ids":   []string(nil),
+				"user_names": []string(nil),
+			},
+		},
+		{
+			name:     "reactions",
+			existing: map[string]interface{}{"user_id": "1", "user_name": "Alice", "reactions": []interface{}{"👍", "🎉"}},
+			data:     map[string]interface{}{"user_id": "2", "user_name": "Bob", "reactions": []string{"❤️", "👍"}},
+			want: map[string]interface{}{
+				"user_id":    "2",
+				"user_name":  "Bob",
+				"user_ids":   []string{"2", "1"},
+				"user_names": []string{"Bob", "Alice"},
+				"reactions":  []string{"❤️", "👍", "🎉"},
+			},
+		},
+		{
+			name:     "reactions of the existing group only",
+			existing: map[string]interface{}{"user_id": "1", "user_name": "Alice", "reactions": []interface{}{"👍"}},
+			data:     map[string]interface{}{"user_id": "2", "user_name": "Bob"},
+			want: map[string]interface{}{
+				"user_id":    "2",
+				"user_name":  "Bob",
+				"user_ids":   []string{"2", "1"},
+				"user_names": []string{"Bob", "Alice"},
+				"reactions":  []string{"👍"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := mergeNotificationData(test.existing, test.data)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGroupNotificationText(t *testing.T) {
+	tests := []struct {
+		name      string
+		userNames interface{}
+		want      string
+	}{
+		{"one actor", []string{"Alice"}, "Alice liked your post"},
+		{"two actors", []string{"Bob", "Alice"}, "Bob and Alice liked your post"},
+		{"two actors stored as JSON", []interface{}{"Bob", "Alice"}, "Bob and Alice liked your post"},
+		{"second name missing", []string{"Bob", ""}, "Bob and 1 other liked your post"},
+		{"first name missing", []string{"", "Alice"}, "Alice liked your post"},
+		{"three actors", []string{"Carol", "Bob", "Alice"}, "Carol and 2 others liked your post"},
+		{"many actors with missing names", []string{"Carol", "", "", "Alice"}, "Carol and 3 others liked your post"},
+		{"no actors", nil, "Alice liked your post"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := groupNotificationText(map[string]interface{}{"user_names": test.userNames}, "liked your post", "Alice liked your post")
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestToStrings(t *testing.T) {
+	items := []string{"a", "b"}
+	tests := []struct {
+		name  string
+		value interface{}
+		want  []string
+	}{
+		{"nil", nil, nil},
+		{"strings", items, []string{"a", "b"}},
+		{"JSON array", []interface{}{"a", "b"}, []string{"a", "b"}},
+		{"JSON array with other values", []interface{}{"a", 1.0, nil, "b"}, []string{"a", "b"}},
+		{"empty JSON array", []interface{}{}, []string{}},
+		{"string", "a", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := toStrings(test.value)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %#v, want %#v", got, test.want)
+			}
+		})
+	}
+
+	got := toStrings(items)
+	got[0] = "c"
+	if items[0] != "a" {
+		t.Error("toStrings returns a copy")
+	}
+}
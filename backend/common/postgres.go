@@ -1,11 +1,14 @@
 package common
 
 import (
+	"database/sql"
 	"fmt"
 
 	"github.com/ansel1/merry"
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq" // Nobody else knows about postgres
+	"github.com/lib/pq"
+
+	"github.com/mreider/koto/backend/common/tracing"
 )
 
 const (
@@ -31,12 +34,16 @@ func OpenDatabase(cfg DatabaseConfig, migrations ...func(db *sqlx.DB, dialect st
 		return nil, 0, ErrDatabaseNameIsEmpty.Here()
 	}
 
-	connectionStr := cfg.ConnectionString()
-
-	db, err = sqlx.Connect(dbDialect, connectionStr)
+	connector, err := pq.NewConnector(cfg.ConnectionString())
 	if err != nil {
 		return nil, 0, merry.Prepend(err, "can't connect to database")
 	}
+	db = sqlx.NewDb(sql.OpenDB(tracing.WrapConnector(connector)), dbDialect)
+	err = db.Ping()
+	if err != nil {
+		_ = db.Close()
+		return nil, 0, merry.Prepend(err, "can't connect to database")
+	}
 
 	var n int
 	for _, migrate := range migrations {
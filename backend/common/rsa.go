@@ -7,6 +7,7 @@ import (
 	"encoding/pem"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/ansel1/merry"
 	"github.com/dgrijalva/jwt-go"
@@ -48,6 +49,23 @@ func GenerateRSAKey(keyPath string) error {
 	return nil
 }
 
+// RotateRSAKey keeps the current key next to the new one, the backup name has a timestamp suffix.
+func RotateRSAKey(keyPath string) (backupPath string, err error) {
+	backupPath = keyPath + "." + time.Now().UTC().Format("20060102150405")
+	_, err = os.Stat(backupPath)
+	if err == nil {
+		return "", merry.Errorf("%s already exists", backupPath)
+	}
+	err = os.Rename(keyPath, backupPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return "", merry.Wrap(err)
+		}
+		backupPath = ""
+	}
+	return backupPath, GenerateRSAKey(keyPath)
+}
+
 func RSAKeysFromPrivateKeyFile(privateKeyPath string) (privateKey *rsa.PrivateKey, publicKey *rsa.PublicKey, publicKeyPEM []byte, err error) {
 	privateKeyBytes, err := ioutil.ReadFile(privateKeyPath)
 	if err != nil {
@@ -2,25 +2,33 @@ package common
 
 import (
 	"context"
-	"log"
 	"time"
 
+	"github.com/ansel1/merry"
 	"github.com/jmoiron/sqlx"
+
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/common/metrics"
 )
 
 const (
 	cleanInterval = time.Second * 10
 )
 
+var pendingDeletesGauge = metrics.NewGauge("koto_blob_pending_deletes", "Blobs waiting to be removed from the storage.")
+
 type S3Cleaner struct {
-	db        *sqlx.DB
-	s3Storage *S3Storage
+	db          *sqlx.DB
+	blobStorage BlobStorage
+	onRemoved   func(ctx context.Context, blobID string) error
 }
 
-func NewS3Cleaner(db *sqlx.DB, s3Storage *S3Storage) *S3Cleaner {
+// NewS3Cleaner calls onRemoved (if set) for each blob it removes from the storage.
+func NewS3Cleaner(db *sqlx.DB, blobStorage BlobStorage, onRemoved func(ctx context.Context, blobID string) error) *S3Cleaner {
 	return &S3Cleaner{
-		db:        db,
-		s3Storage: s3Storage,
+		db:          db,
+		blobStorage: blobStorage,
+		onRemoved:   onRemoved,
 	}
 }
 
@@ -28,38 +36,64 @@ func (c *S3Cleaner) Clean(ctx context.Context) {
 	ticker := time.NewTicker(cleanInterval)
 	defer ticker.Stop()
 
-	c.clean(ctx)
 	for {
+		_, err := c.Drain(ctx)
+		if err != nil {
+			logging.FromContext(ctx).Error(err)
+		}
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			c.clean(ctx)
 		}
 	}
 }
 
-func (c *S3Cleaner) clean(ctx context.Context) {
+func (c *S3Cleaner) Pending(ctx context.Context) ([]string, error) {
+	var blobIDs []string
+	err := c.db.SelectContext(ctx, &blobIDs, `
+		select blob_id
+		from blob_pending_deletes`)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return blobIDs, nil
+}
+
+// Drain removes the pending blobs once. Failed blobs are logged and stay pending, remaining counts them.
+func (c *S3Cleaner) Drain(ctx context.Context) (remaining int, err error) {
 	var pendingDeletes []struct {
 		ID     string `db:"id"`
 		BlobID string `db:"blob_id"`
 	}
-	err := c.db.SelectContext(ctx, &pendingDeletes, `
+	err = c.db.SelectContext(ctx, &pendingDeletes, `
 		select id, blob_id
 		from blob_pending_deletes`)
 	if err != nil {
-		log.Println(err)
+		return 0, merry.Wrap(err)
 	}
+	pendingDeletesGauge.Set(float64(len(pendingDeletes)))
+	removed := 0
 	for _, item := range pendingDeletes {
-		exists, err := c.s3Storage.Exists(ctx, item.BlobID)
+		if ctx.Err() != nil {
+			break
+		}
+		exists, err := c.blobStorage.Exists(ctx, item.BlobID)
 		if err != nil {
-			log.Println(err)
+			logging.FromContext(ctx).Error(err)
 			continue
 		}
 		if exists {
-			err = c.s3Storage.RemoveObject(ctx, item.BlobID)
+			err = c.blobStorage.RemoveObject(ctx, item.BlobID)
 			if err != nil {
-				log.Println(err)
+				logging.FromContext(ctx).Error(err)
+				continue
+			}
+		}
+		if c.onRemoved != nil {
+			err = c.onRemoved(ctx, item.BlobID)
+			if err != nil {
+				logging.FromContext(ctx).Error(err)
 				continue
 			}
 		}
@@ -67,7 +101,12 @@ func (c *S3Cleaner) clean(ctx context.Context) {
 			delete from blob_pending_deletes
 			where id = $1`, item.ID)
 		if err != nil {
-			log.Println(err)
+			logging.FromContext(ctx).Error(err)
+			continue
 		}
+		removed++
 	}
+	remaining = len(pendingDeletes) - removed
+	pendingDeletesGauge.Set(float64(remaining))
+	return remaining, nil
 }
@@ -4,18 +4,43 @@ import (
 	"bytes"
 	"context"
 	"io"
-	"log"
 	"sync"
 	"time"
 
 	"github.com/ansel1/merry"
 	"github.com/minio/minio-go/v7"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/common/metrics"
+	"github.com/mreider/koto/backend/common/tracing"
 )
 
 const (
 	defaultLinkExpiration = time.Minute * 30
 )
 
+var (
+	s3Duration = metrics.NewHistogram("koto_s3_operation_duration_seconds", "S3 operation latencies.",
+		metrics.DefaultBuckets, "operation")
+	s3Errors = metrics.NewCounter("koto_s3_operation_errors_total", "Failed S3 operations.", "operation")
+)
+
+// traceS3 starts a span for the operation. The returned function ends it and records the latency.
+func (s *S3Storage) traceS3(ctx context.Context, operation, blobID string) (context.Context, func(err error)) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "s3."+operation,
+		attribute.String("s3.bucket", s.bucket),
+		attribute.String("s3.key", blobID))
+	return ctx, func(err error) {
+		s3Duration.Observe(time.Since(start).Seconds(), operation)
+		if err != nil {
+			s3Errors.Inc(operation)
+		}
+		tracing.End(span, err)
+	}
+}
+
 type S3Storage struct {
 	client     *minio.Client
 	bucket     string
@@ -39,7 +64,7 @@ func (s *S3Storage) createBucketIfNotExist(ctx context.Context) {
 	s.bucketOnce.Do(func() {
 		exists, err := s.client.BucketExists(ctx, s.bucket)
 		if err != nil {
-			log.Println(err)
+			logging.FromContext(ctx).Error(err)
 			return
 		}
 		if exists {
@@ -48,7 +73,7 @@ func (s *S3Storage) createBucketIfNotExist(ctx context.Context) {
 
 		err = s.client.MakeBucket(ctx, s.bucket, minio.MakeBucketOptions{})
 		if err != nil {
-			log.Println(err)
+			logging.FromContext(ctx).Error(err)
 		}
 	})
 }
@@ -56,19 +81,37 @@ func (s *S3Storage) createBucketIfNotExist(ctx context.Context) {
 func (s *S3Storage) Exists(ctx context.Context, blobID string) (bool, error) {
 	s.createBucketIfNotExist(ctx)
 
+	ctx, end := s.traceS3(ctx, "stat", blobID)
 	info, err := s.client.StatObject(ctx, s.bucket, blobID, minio.StatObjectOptions{})
 	if err != nil {
 		if minioErr, ok := err.(minio.ErrorResponse); ok && minioErr.Code == "NoSuchKey" {
+			end(nil)
 			return false, nil
 		}
+		end(err)
 		return false, merry.Prepend(err, "can't StatObject")
 	}
+	end(nil)
 	return info.Key != "", nil
 }
 
-func (s *S3Storage) Read(ctx context.Context, blobID string, w io.Writer) error {
+func (s *S3Storage) Size(ctx context.Context, blobID string) (int64, error) {
 	s.createBucketIfNotExist(ctx)
 
+	ctx, end := s.traceS3(ctx, "stat", blobID)
+	info, err := s.client.StatObject(ctx, s.bucket, blobID, minio.StatObjectOptions{})
+	end(err)
+	if err != nil {
+		return 0, merry.Prepend(err, "can't StatObject")
+	}
+	return info.Size, nil
+}
+
+func (s *S3Storage) Read(ctx context.Context, blobID string, w io.Writer) (err error) {
+	s.createBucketIfNotExist(ctx)
+	ctx, end := s.traceS3(ctx, "get", blobID)
+	defer func() { end(err) }()
+
 	result, err := s.client.GetObject(ctx, s.bucket, blobID, minio.GetObjectOptions{})
 	if err != nil {
 		return merry.Prepend(err, "can't GetObject")
@@ -82,8 +125,10 @@ func (s *S3Storage) Read(ctx context.Context, blobID string, w io.Writer) error
 	return nil
 }
 
-func (s *S3Storage) ReadN(ctx context.Context, blobID string, n int) ([]byte, error) {
+func (s *S3Storage) ReadN(ctx context.Context, blobID string, n int) (_ []byte, err error) {
 	s.createBucketIfNotExist(ctx)
+	ctx, end := s.traceS3(ctx, "get", blobID)
+	defer func() { end(err) }()
 
 	result, err := s.client.GetObject(ctx, s.bucket, blobID, minio.GetObjectOptions{})
 	if err != nil {
@@ -115,7 +160,9 @@ func (s *S3Storage) CreateLink(ctx context.Context, blobID string, expiration ti
 		return s.cachedLinks[blobID], nil
 	}
 
+	ctx, end := s.traceS3(ctx, "presign_get", blobID)
 	u, err := s.client.PresignedGetObject(ctx, s.bucket, blobID, expiration, nil)
+	end(err)
 	if err != nil {
 		return "", merry.Prepend(err, "can't Presign")
 	}
@@ -129,16 +176,18 @@ func (s *S3Storage) CreateLink(ctx context.Context, blobID string, expiration ti
 func (s *S3Storage) PutObject(ctx context.Context, blobID string, content []byte, contentType string) error {
 	s.createBucketIfNotExist(ctx)
 
+	ctx, end := s.traceS3(ctx, "put", blobID)
 	_, err := s.client.PutObject(ctx, s.bucket, blobID, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{
 		ContentType: contentType,
 	})
+	end(err)
 	if err != nil {
 		return merry.Prepend(err, "can't PutObject")
 	}
 	return nil
 }
 
-func (s *S3Storage) CreateUploadLink(ctx context.Context, blobID, contentType string, metadata map[string]string) (uploadLink string, formData map[string]string, err error) {
+func (s *S3Storage) CreateUploadLink(ctx context.Context, blobID, contentType string, maxSize int64, metadata map[string]string) (uploadLink string, formData map[string]string, err error) {
 	s.createBucketIfNotExist(ctx)
 
 	expiration := defaultLinkExpiration
@@ -160,6 +209,12 @@ func (s *S3Storage) CreateUploadLink(ctx context.Context, blobID, contentType st
 	if err != nil {
 		return "", nil, merry.Prepend(err, "can't SetContentType for policy")
 	}
+	if maxSize > 0 {
+		err = policy.SetContentLengthRange(0, maxSize)
+		if err != nil {
+			return "", nil, merry.Prepend(err, "can't SetContentLengthRange for policy")
+		}
+	}
 
 	for key, value := range metadata {
 		err = policy.SetUserMetadata(key, value)
@@ -168,7 +223,9 @@ func (s *S3Storage) CreateUploadLink(ctx context.Context, blobID, contentType st
 		}
 	}
 
+	ctx, end := s.traceS3(ctx, "presign_post", blobID)
 	link, formData, err := s.client.PresignedPostPolicy(ctx, policy)
+	end(err)
 	if err != nil {
 		return "", nil, merry.Prepend(err, "can't Presign")
 	}
@@ -179,9 +236,45 @@ func (s *S3Storage) CreateUploadLink(ctx context.Context, blobID, contentType st
 func (s *S3Storage) RemoveObject(ctx context.Context, blobID string) error {
 	s.createBucketIfNotExist(ctx)
 
+	ctx, end := s.traceS3(ctx, "remove", blobID)
 	err := s.client.RemoveObject(ctx, s.bucket, blobID, minio.RemoveObjectOptions{})
+	end(err)
 	if err != nil {
 		return merry.Prepend(err, "can't RemoveObject")
 	}
 	return nil
 }
+
+func (s *S3Storage) Ping(ctx context.Context) (err error) {
+	s.createBucketIfNotExist(ctx)
+	ctx, end := s.traceS3(ctx, "bucket_exists", "")
+	defer func() { end(err) }()
+
+	exists, err := s.client.BucketExists(ctx, s.bucket)
+	if err != nil {
+		return merry.Prepend(err, "can't check bucket")
+	}
+	if !exists {
+		return merry.Errorf("bucket %s doesn't exist", s.bucket)
+	}
+	return nil
+}
+
+func (s *S3Storage) Walk(ctx context.Context, fn func(blobID string, size int64) error) (err error) {
+	s.createBucketIfNotExist(ctx)
+	ctx, end := s.traceS3(ctx, "list", "")
+	defer func() { end(err) }()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Recursive: true}) {
+		if object.Err != nil {
+			return merry.Prepend(object.Err, "can't ListObjects")
+		}
+		err = fn(object.Key, object.Size)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
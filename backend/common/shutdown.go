@@ -0,0 +1,116 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mreider/koto/backend/common/health"
+)
+
+// ShutdownContext returns a context which is cancelled on SIGINT or SIGTERM.
+func ShutdownContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-signals
+		signal.Stop(signals)
+		cancel()
+	}()
+	return ctx
+}
+
+// Serve runs server until ctx is done. Then it fails the readiness check and keeps serving for drainDelay,
+// so load balancers stop sending requests before the connections are refused. After that it stops accepting requests,
+// waits for the running ones and stops the worker groups one after another, all within timeout.
+func Serve(ctx context.Context, server *http.Server, checker *health.Checker, drainDelay, timeout time.Duration, workers ...*Workers) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+	logrus.Info("started on " + server.Addr)
+
+	select {
+	case err := <-serveErr:
+		return merry.Wrap(err)
+	case <-ctx.Done():
+	}
+
+	logrus.Info("shutting down")
+	checker.SetShuttingDown()
+	if drainDelay > 0 {
+		select {
+		case err := <-serveErr:
+			return merry.Wrap(err)
+		case <-time.After(drainDelay):
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := server.Shutdown(shutdownCtx)
+	if err != nil {
+		err = merry.Prepend(err, "can't stop the server")
+	}
+	for _, group := range workers {
+		stopErr := group.Stop(shutdownCtx)
+		if stopErr != nil && err == nil {
+			err = stopErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+	logrus.Info("stopped")
+	return nil
+}
+
+// Workers runs background jobs until they are stopped.
+type Workers struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewWorkers() *Workers {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Workers{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Go runs job in a goroutine. The job should return once its context is cancelled.
+func (w *Workers) Go(job func(ctx context.Context)) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		job(w.ctx)
+	}()
+}
+
+// Stop cancels the jobs and waits until they return or ctx is done.
+func (w *Workers) Stop(ctx context.Context) error {
+	w.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return merry.Prepend(ctx.Err(), "background jobs didn't stop in time")
+	}
+}
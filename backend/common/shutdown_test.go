@@ -0,0 +1,79 @@
+package common
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common/health"
+)
+
+func TestWorkers(t *testing.T) {
+	workers := NewWorkers()
+	stopped := make(chan struct{})
+	workers.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		close(stopped)
+	})
+	require.Nil(t, workers.Stop(context.Background()))
+	select {
+	case <-stopped:
+	default:
+		assert.Fail(t, "the job wasn't stopped")
+	}
+
+	workers = NewWorkers()
+	release := make(chan struct{})
+	defer close(release)
+	workers.Go(func(ctx context.Context) {
+		<-release
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	assert.NotNil(t, workers.Stop(ctx), "the job doesn't stop in time")
+}
+
+func TestServe_DrainDelay(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	addr := listener.Addr().String()
+	require.Nil(t, listener.Close())
+
+	checker := health.NewChecker()
+	mux := http.NewServeMux()
+	mux.Handle("/readyz", checker.ReadyHandler())
+	server := &http.Server{Addr: addr, Handler: mux}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- Serve(ctx, server, checker, time.Millisecond*300, time.Second)
+	}()
+	readyStatus := func() (int, error) {
+		resp, err := http.Get("http://" + addr + "/readyz")
+		if err != nil {
+			return 0, err
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+	require.Eventually(t, func() bool {
+		status, err := readyStatus()
+		return err == nil && status == http.StatusOK
+	}, time.Second, time.Millisecond*10)
+
+	cancel()
+	time.Sleep(time.Millisecond * 50)
+	status, err := readyStatus()
+	require.Nil(t, err, "the server keeps serving during the drain delay")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+
+	require.Nil(t, <-served)
+	_, err = readyStatus()
+	assert.NotNil(t, err, "the server is stopped after the drain delay")
+}
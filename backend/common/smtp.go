@@ -2,6 +2,7 @@ package common
 
 import (
 	"net/smtp"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -29,14 +30,18 @@ func (m *MailSender) Enabled() bool {
 }
 
 func (m *MailSender) SendTextEmail(recipients []string, subject string, message string) error {
-	return m.sendEmail(recipients, subject, false, message)
+	return m.sendEmail(recipients, subject, false, message, nil)
 }
 
 func (m *MailSender) SendHTMLEmail(recipients []string, subject string, message string) error {
-	return m.sendEmail(recipients, subject, true, message)
+	return m.sendEmail(recipients, subject, true, message, nil)
 }
 
-func (m *MailSender) sendEmail(recipients []string, subject string, isHTML bool, message string) error {
+func (m *MailSender) SendHTMLEmailWithHeaders(recipients []string, subject string, message string, headers map[string]string) error {
+	return m.sendEmail(recipients, subject, true, message, headers)
+}
+
+func (m *MailSender) sendEmail(recipients []string, subject string, isHTML bool, message string, headers map[string]string) error {
 	from := m.cfg.From
 	if from == "" {
 		from = m.cfg.User
@@ -53,6 +58,18 @@ func (m *MailSender) sendEmail(recipients []string, subject string, isHTML bool,
 	msg.WriteString(subject)
 	msg.WriteRune('\n')
 
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+	for _, name := range headerNames {
+		msg.WriteString(name)
+		msg.WriteString(": ")
+		msg.WriteString(headers[name])
+		msg.WriteRune('\n')
+	}
+
 	if isHTML {
 		msg.WriteString("MIME-version: 1.0;")
 		msg.WriteRune('\n')
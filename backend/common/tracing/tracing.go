@@ -0,0 +1,68 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/ansel1/merry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/mreider/koto/backend"
+
+type Config struct {
+	// Endpoint is the host:port of an OTLP/HTTP collector. Tracing is disabled if it's empty.
+	Endpoint    string  `yaml:"endpoint" env:"KOTO_OTLP_ENDPOINT"`
+	Insecure    bool    `yaml:"insecure" env:"KOTO_OTLP_INSECURE"`
+	SampleRatio float64 `yaml:"sample_ratio" default:"1" env:"KOTO_TRACE_SAMPLE_RATIO"`
+}
+
+// Setup installs the W3C trace context propagator and, if the endpoint is configured, the OTLP exporter.
+// The returned function flushes the pending spans.
+func (cfg Config) Setup(ctx context.Context, serviceName string) (shutdown func(ctx context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if cfg.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, merry.Prepend(err, "can't create OTLP exporter")
+	}
+	provider := NewProvider(serviceName, sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))))
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// NewProvider creates a tracer provider for the service. Tests can pass sdktrace.WithSyncer with an in-memory exporter.
+func NewProvider(serviceName string, options ...sdktrace.TracerProviderOption) *sdktrace.TracerProvider {
+	options = append([]sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+	}, options...)
+	return sdktrace.NewTracerProvider(options...)
+}
+
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err (if any) and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,111 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ansel1/merry"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/mreider/koto/backend/common/tracing"
+)
+
+func setupExporter(t *testing.T) *tracetest.InMemoryExporter {
+	_, err := tracing.Config{}.Setup(context.Background(), "test")
+	require.NoError(t, err)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.NewProvider("test", sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	return exporter
+}
+
+func TestPropagation(t *testing.T) {
+	exporter := setupExporter(t)
+
+	server := httptest.NewServer(tracing.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, span := tracing.Start(r.Context(), "db.select")
+		tracing.End(span, nil)
+		w.WriteHeader(http.StatusInternalServerError)
+	})))
+	defer server.Close()
+
+	ctx, span := tracing.Start(context.Background(), "client")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/rpc.MessageService/Messages", nil)
+	require.NoError(t, err)
+	client := &http.Client{Transport: tracing.Transport(nil)}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 4)
+	byName := make(map[string]tracetest.SpanStub)
+	for _, s := range spans {
+		byName[s.Name] = s
+		assert.Equal(t, span.SpanContext().TraceID(), s.SpanContext.TraceID(), s.Name)
+	}
+
+	serverSpan, ok := byName["POST"]
+	require.True(t, ok)
+	assert.Equal(t, codes.Error, serverSpan.Status.Code)
+	assert.Equal(t, serverSpan.SpanContext.SpanID(), byName["db.select"].Parent.SpanID())
+	clientSpan := byName["POST "+strings.TrimPrefix(server.URL, "http://")+"/rpc.MessageService/Messages"]
+	assert.Equal(t, span.SpanContext().SpanID(), clientSpan.Parent.SpanID())
+	assert.Equal(t, clientSpan.SpanContext.SpanID(), serverSpan.Parent.SpanID())
+}
+
+func TestMiddleware_Route(t *testing.T) {
+	exporter := setupExporter(t)
+
+	r := chi.NewRouter()
+	r.Use(tracing.Middleware)
+	r.Get("/blob/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	r.Post("/rpc.MessageService/*", func(w http.ResponseWriter, r *http.Request) {
+		tracing.SetRoute(r.Context(), "/rpc.MessageService/Messages")
+	})
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	for _, req := range []struct{ method, path string }{
+		{http.MethodGet, "/blob/1"},
+		{http.MethodGet, "/blob/2"},
+		{http.MethodPost, "/rpc.MessageService/Messages"},
+	} {
+		httpReq, err := http.NewRequest(req.method, server.URL+req.path, nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(httpReq)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "/blob/{id}", spans[0].Name)
+	assert.Equal(t, "/blob/{id}", spans[1].Name, "IDs don't make new span names")
+	assert.Equal(t, "/rpc.MessageService/Messages", spans[2].Name)
+}
+
+func TestEnd(t *testing.T) {
+	exporter := setupExporter(t)
+
+	_, span := tracing.Start(context.Background(), "failed")
+	tracing.End(span, merry.New("boom"))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "boom", spans[0].Status.Description)
+	require.Len(t, spans[0].Events, 1)
+}
@@ -0,0 +1,20 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/twitchtv/twirp"
+)
+
+// TwirpHooks names the request span after the Twirp method, e.g. "/rpc.MessageService/Messages".
+func TwirpHooks() *twirp.ServerHooks {
+	return &twirp.ServerHooks{
+		RequestRouted: func(ctx context.Context) (context.Context, error) {
+			pkg, _ := twirp.PackageName(ctx)
+			service, _ := twirp.ServiceName(ctx)
+			method, _ := twirp.MethodName(ctx)
+			SetRoute(ctx, "/"+pkg+"."+service+"/"+method)
+			return ctx, nil
+		},
+	}
+}
@@ -1,21 +1,27 @@
 package common
 
 import (
+	"context"
+
 	"github.com/ansel1/merry"
 	"github.com/jmoiron/sqlx"
 )
 
-func RunInTransaction(db *sqlx.DB, action func(tx *sqlx.Tx) error) error {
-	tx := db.MustBegin()
+func RunInTransaction(ctx context.Context, db *sqlx.DB, action func(tx *sqlx.Tx) error) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return merry.Wrap(err)
+	}
 	defer func() {
 		if tx != nil {
 			_ = tx.Rollback()
 		}
 	}()
 
-	err := action(tx)
+	// the action's error is returned as is, so twirp errors keep their codes
+	err = action(tx)
 	if err != nil {
-		return merry.Wrap(err)
+		return err
 	}
 
 	err = tx.Commit()
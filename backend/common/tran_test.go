@@ -0,0 +1,42 @@
+package common_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/dbtest"
+	"github.com/mreider/koto/backend/messagehub/migrate"
+)
+
+func TestRunInTransaction(t *testing.T) {
+	te := dbtest.NewTestEnvironment("common", migrate.Migrate)
+	defer te.Cleanup()
+
+	ctx, cancel := context.WithCancel(te.Ctx)
+	cancel()
+	var called bool
+	err := common.RunInTransaction(ctx, te.DB, func(tx *sqlx.Tx) error {
+		called = true
+		return nil
+	})
+	assert.NotNil(t, err, "a cancelled context fails the transaction instead of panicking")
+	assert.False(t, called)
+
+	err = common.RunInTransaction(te.Ctx, te.DB, func(tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(te.Ctx, `insert into users(id, name) values ('1', 'user1')`)
+		require.Nil(t, err)
+		return twirp.NotFoundError("not found")
+	})
+	require.NotNil(t, err)
+	assert.Equal(t, twirp.NotFound, err.(twirp.Error).Code(), "the action's error keeps its twirp code")
+
+	var count int
+	require.Nil(t, te.DB.Get(&count, `select count(*) from users`))
+	assert.Equal(t, 0, count, "the transaction is rolled back")
+}
@@ -1,6 +1,8 @@
 package common
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math"
@@ -13,6 +15,9 @@ import (
 	"time"
 
 	"github.com/ansel1/merry"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/mreider/koto/backend/common/tracing"
 )
 
 const (
@@ -25,7 +30,10 @@ var (
 	durationRe        = regexp.MustCompile(`^Duration: ((\d+):(\d+):(\d+)(.(\d+))?), .*`)
 )
 
-func VideoThumbnail(videoPath string) ([]byte, error) {
+func VideoThumbnail(ctx context.Context, videoPath string) (_ []byte, err error) {
+	ctx, span := tracing.Start(ctx, "VideoThumbnail")
+	defer func() { tracing.End(span, err) }()
+
 	tempDir, err := ioutil.TempDir("", "")
 	if err != nil {
 		return nil, merry.Wrap(err)
@@ -35,8 +43,7 @@ func VideoThumbnail(videoPath string) ([]byte, error) {
 	outputPath := filepath.Join(tempDir, "thumbnail.jpg")
 	const defaultPosition = "00:00:05.000"
 
-	cmd := exec.Command("ffmpeg", "-i", videoPath, "-vframes", "1", "-ss", defaultPosition, outputPath)
-	err = cmd.Run()
+	_, err = runFFmpeg(ctx, "-i", videoPath, "-vframes", "1", "-ss", defaultPosition, outputPath)
 	if err != nil {
 		return nil, merry.Wrap(err)
 	}
@@ -46,7 +53,7 @@ func VideoThumbnail(videoPath string) ([]byte, error) {
 		if !os.IsNotExist(err) {
 			return nil, merry.Wrap(err)
 		}
-		_, _, duration, _, err := videoMetadata(videoPath)
+		_, _, duration, _, err := videoMetadata(ctx, videoPath)
 		if err != nil {
 			return nil, merry.Wrap(err)
 		}
@@ -59,8 +66,7 @@ func VideoThumbnail(videoPath string) ([]byte, error) {
 		minutes := int(position.Minutes()) % 60
 		seconds := int(position.Seconds()) % 60
 		milliseconds := int(position.Milliseconds()) % 1000
-		cmd := exec.Command("ffmpeg", "-i", videoPath, "-vframes", "1", "-ss", fmt.Sprintf("00:%02d:%02d.%03d", minutes, seconds, milliseconds), outputPath)
-		err = cmd.Run()
+		_, err = runFFmpeg(ctx, "-i", videoPath, "-vframes", "1", "-ss", fmt.Sprintf("00:%02d:%02d.%03d", minutes, seconds, milliseconds), outputPath)
 		if err != nil {
 			return nil, merry.Wrap(err)
 		}
@@ -73,9 +79,9 @@ func VideoThumbnail(videoPath string) ([]byte, error) {
 	return data, nil
 }
 
-func videoMetadata(videoPath string) (width, height int, duration time.Duration, fps float64, err error) {
-	cmd := exec.Command("ffmpeg", "-i", videoPath)
-	output, _ := cmd.CombinedOutput()
+func videoMetadata(ctx context.Context, videoPath string) (width, height int, duration time.Duration, fps float64, err error) {
+	// ffmpeg exits with an error without an output file, but still prints the metadata
+	output, _ := runFFmpeg(ctx, "-i", videoPath)
 
 	width, height, duration, fps, ok := parseVideoMetadata(string(output))
 
@@ -86,6 +92,18 @@ func videoMetadata(videoPath string) (width, height int, duration time.Duration,
 	return width, height, duration, fps, nil
 }
 
+func runFFmpeg(ctx context.Context, args ...string) ([]byte, error) {
+	ctx, span := tracing.Start(ctx, "ffmpeg", attribute.StringSlice("ffmpeg.args", args))
+	defer span.End()
+
+	output, err := exec.CommandContext(ctx, "ffmpeg", args...).CombinedOutput()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		span.SetAttributes(attribute.Int("ffmpeg.exit_code", exitErr.ExitCode()))
+	}
+	return output, err
+}
+
 func parseVideoMetadata(output string) (width, height int, duration time.Duration, fps float64, ok bool) {
 	lines := strings.Split(output, "\n")
 	hasDuration, hasMetadata := false, false
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mreider/koto/backend/admin"
+	"github.com/mreider/koto/backend/messagehub/config"
+	"github.com/mreider/koto/backend/messagehub/migrate"
+	"github.com/mreider/koto/backend/messagehub/repo"
+	"github.com/mreider/koto/backend/messagehub/services"
+)
+
+const usage = `Usage: message-hub-admin [-config path] command [arguments]
+
+Message hub commands:
+  thumbnails [-all]          create thumbnails of attachments that have none, or of all attachments
+  backfill-blobs             record the sizes of attachments stored before storage usage was tracked
+`
+
+func main() {
+	admin.Main(usage, func(configPath string) (*admin.Hub, error) {
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			return nil, err
+		}
+		return &admin.Hub{
+			DB:              cfg.DB,
+			S3:              cfg.S3,
+			Blob:            cfg.Blob,
+			ExternalAddress: cfg.ExternalAddress,
+			PrivateKeyPath:  cfg.PrivateKeyPath,
+			Log:             cfg.Log,
+			Migrations:      migrate.Migrations(),
+			Migrate:         migrate.Migrate,
+			OnBlobRemoved: func(ctx context.Context, db *sqlx.DB, blobID string) error {
+				return repo.NewBlobs(db).RemoveBlob(ctx, blobID)
+			},
+			ReferencedBlobs: func(ctx context.Context, db *sqlx.DB) (map[string]int64, error) {
+				return repo.NewMaintenance(db).ReferencedBlobs(ctx)
+			},
+			Commands: map[string]admin.Command{
+				"thumbnails":     thumbnailsCommand,
+				"backfill-blobs": backfillBlobsCommand,
+			},
+		}, nil
+	})
+}
+
+func thumbnailsCommand(ctx context.Context, h *admin.Hub, args []string) error {
+	fs := flag.NewFlagSet("thumbnails", flag.ContinueOnError)
+	all := fs.Bool("all", false, "recreate existing thumbnails too")
+	err := admin.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return admin.ErrUsage.Here()
+	}
+
+	db, err := h.OpenDB()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	blobStorage, err := h.OpenBlobStorage()
+	if err != nil {
+		return err
+	}
+
+	repos := repo.Repos{
+		Blob:        repo.NewBlobs(db),
+		Maintenance: repo.NewMaintenance(db),
+	}
+	baseService := services.NewBase(repos, nil, nil, nil, h.ExternalAddress, blobStorage, nil, nil)
+	updated, failed, err := baseService.RegenerateThumbnails(ctx, *all)
+	logrus.Infof("updated %d thumbnails, %d attachments failed", updated, failed)
+	return err
+}
+
+func backfillBlobsCommand(ctx context.Context, h *admin.Hub, args []string) error {
+	if len(args) != 0 {
+		return admin.ErrUsage.Here()
+	}
+
+	db, err := h.OpenDB()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	blobStorage, err := h.OpenBlobStorage()
+	if err != nil {
+		return err
+	}
+
+	maintenanceRepo := repo.NewMaintenance(db)
+	blobs, err := maintenanceRepo.UnaccountedBlobs(ctx)
+	if err != nil {
+		return err
+	}
+	added, missing := 0, 0
+	for _, blob := range blobs {
+		if ctx.Err() != nil {
+			return merry.Wrap(ctx.Err())
+		}
+		exists, err := blobStorage.Exists(ctx, blob.BlobID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			logrus.Warnf("blob %s is missing in the storage", blob.BlobID)
+			missing++
+			continue
+		}
+		size, err := blobStorage.Size(ctx, blob.BlobID)
+		if err != nil {
+			return err
+		}
+		err = maintenanceRepo.AddAccountedBlob(ctx, blob, size)
+		if err != nil {
+			return err
+		}
+		added++
+	}
+	logrus.Infof("recorded %d blobs, %d blobs are missing in the storage", added, missing)
+	return nil
+}
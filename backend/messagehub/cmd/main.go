@@ -15,8 +15,11 @@ import (
 
 	"github.com/ansel1/merry"
 	"github.com/dgrijalva/jwt-go"
+	"github.com/sirupsen/logrus"
 
 	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/metrics"
+	"github.com/mreider/koto/backend/common/tracing"
 	"github.com/mreider/koto/backend/messagehub"
 	"github.com/mreider/koto/backend/messagehub/config"
 	"github.com/mreider/koto/backend/messagehub/migrate"
@@ -32,32 +35,45 @@ func main() {
 		log.Fatalln(err)
 	}
 
-	err = common.CreateDatabaseIfNotExist(cfg.DB)
+	err = cfg.Log.Setup()
 	if err != nil {
 		log.Fatalln(err)
 	}
 
-	db, n, err := common.OpenDatabase(cfg.DB, migrate.Migrate)
+	shutdownTracing, err := cfg.Tracing.Setup(context.Background(), "koto-message-hub")
 	if err != nil {
-		log.Fatalln(err)
+		logrus.Fatal(err)
 	}
-	log.Printf("Applied %d migrations to %s\n", n, cfg.DB.DBName)
 
-	s3Storage, err := cfg.S3.CreateStorage()
+	err = common.CreateDatabaseIfNotExist(cfg.DB)
 	if err != nil {
-		log.Fatalln(err)
+		logrus.Fatal(err)
 	}
 
+	db, n, err := common.OpenDatabase(cfg.DB, migrate.Migrate)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	logrus.Infof("applied %d migrations to %s", n, cfg.DB.DBName)
+
 	err = common.GenerateRSAKey(cfg.PrivateKeyPath)
 	if err != nil {
-		log.Fatalln(err)
+		logrus.Fatal(err)
 	}
 
 	privateKey, _, publicKeyPEM, err := common.RSAKeysFromPrivateKeyFile(cfg.PrivateKeyPath)
 	if err != nil {
-		log.Fatalln(err)
+		logrus.Fatal(err)
+	}
+
+	blobStorage, err := common.CreateBlobStorage(cfg.S3, cfg.Blob, cfg.ExternalAddress, privateKey)
+	if err != nil {
+		logrus.Fatal(err)
 	}
 	tokenGenerator := token.NewGenerator(privateKey)
+	hubTokenParser := token.NewParser(func() *rsa.PublicKey {
+		return &privateKey.PublicKey
+	})
 
 	var keyMu sync.Mutex
 	var userHubPublicKey *rsa.PublicKey
@@ -71,31 +87,47 @@ func main() {
 
 		key, err := loadUserHubPublicKey(context.TODO(), cfg.UserHubAddress)
 		if err != nil {
-			log.Println("can't load user hub public key:", err)
+			logrus.WithError(err).Error("can't load user hub public key")
 			return nil
 		}
 		userHubPublicKey = key
 		return userHubPublicKey
 	})
 	repos := repo.Repos{
-		Message:      repo.NewMessages(db),
-		Notification: common.NewNotifications(db),
-		User:         repo.NewUsers(db),
-	}
-
-	s3Cleaner := common.NewS3Cleaner(db, s3Storage)
-	go s3Cleaner.Clean(context.Background())
-
-	server := messagehub.NewServer(cfg, repos, tokenParser, s3Storage, tokenGenerator, string(publicKeyPEM))
-	err = server.Run()
+		DB:                 db,
+		Message:            repo.NewMessages(db),
+		Conversation:       repo.NewConversations(db),
+		Poll:               repo.NewPolls(db),
+		Event:              repo.NewEvents(db),
+		Notification:       common.NewNotifications(db),
+		NotificationOutbox: repo.NewNotificationOutbox(db),
+		User:               repo.NewUsers(db),
+		Blob:               repo.NewBlobs(db),
+		Retention:          repo.NewRetention(db),
+		Limit:              repo.NewLimits(db),
+		Maintenance:        repo.NewMaintenance(db),
+	}
+
+	metrics.RegisterDBStats(metrics.Default, db.DB)
+
+	userHubCheck := func(ctx context.Context) error {
+		_, err := loadUserHubPublicKey(ctx, cfg.UserHubAddress)
+		return err
+	}
+
+	server := messagehub.NewServer(cfg, db, repos, tokenParser, blobStorage, tokenGenerator, hubTokenParser, string(publicKeyPEM), userHubCheck)
+	err = server.Run(common.ShutdownContext())
+	_ = db.Close()
+	_ = shutdownTracing(context.Background())
 	if err != nil {
-		log.Fatalln(err)
+		logrus.Fatal(err)
 	}
 }
 
 func loadUserHubPublicKey(ctx context.Context, userHubAddress string) (*rsa.PublicKey, error) {
 	client := &http.Client{
-		Timeout: time.Second * 30,
+		Transport: tracing.Transport(nil),
+		Timeout:   time.Second * 30,
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, userHubAddress+"/rpc.InfoService/PublicKey", strings.NewReader("{}"))
@@ -1,21 +1,51 @@
 package config
 
 import (
+	"strings"
+	"time"
+
 	"github.com/ansel1/merry"
 	"github.com/jinzhu/configor"
 
 	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/common/tracing"
 )
 
 type Config struct {
-	ListenAddress        string `yaml:"address" default:":12002" env:"KOTO_LISTEN_ADDRESS"`
-	ExternalAddress      string `yaml:"external_address" default:"http://localhost:12002" env:"KOTO_EXTERNAL_ADDRESS"`
-	CentralServerAddress string `yaml:"central_address" env:"KOTO_CENTRAL_ADDRESS"`
-	UserHubAddress       string `yaml:"user_hub_address" env:"KOTO_USER_HUB_ADDRESS"`
-	PrivateKeyPath       string `yaml:"private_key_path" default:"message_hub.rsa" env:"KOTO_PRIVATE_KEY"`
+	ListenAddress             string `yaml:"address" default:":12002" env:"KOTO_LISTEN_ADDRESS"`
+	ExternalAddress           string `yaml:"external_address" default:"http://localhost:12002" env:"KOTO_EXTERNAL_ADDRESS"`
+	CentralServerAddress      string `yaml:"central_address" env:"KOTO_CENTRAL_ADDRESS"`
+	UserHubAddress            string `yaml:"user_hub_address" env:"KOTO_USER_HUB_ADDRESS"`
+	PrivateKeyPath            string `yaml:"private_key_path" default:"message_hub.rsa" env:"KOTO_PRIVATE_KEY"`
+	Reactions                 string `yaml:"reactions" default:"👍,❤️,😂,😮,😢,🎉" env:"KOTO_REACTIONS"`
+	ReactionDelaySeconds      int    `yaml:"reaction_notification_delay" default:"60" env:"KOTO_REACTION_NOTIFICATION_DELAY"`
+	MaxCommentDepth           int    `yaml:"max_comment_depth" default:"5" env:"KOTO_MAX_COMMENT_DEPTH"`
+	EventReminderMinutes      int    `yaml:"event_reminder_minutes" default:"60" env:"KOTO_EVENT_REMINDER_MINUTES"`
+	NotificationRetentionDays int    `yaml:"notification_retention_days" default:"90" env:"KOTO_NOTIFICATION_RETENTION_DAYS"`
+	Admins                    string `yaml:"admins" env:"KOTO_ADMINS"`
+	UserStorageQuotaMB        int    `yaml:"user_storage_quota_mb" default:"0" env:"KOTO_USER_STORAGE_QUOTA_MB"`
+	MaxUploadSizeMB           int    `yaml:"max_upload_size_mb" default:"100" env:"KOTO_MAX_UPLOAD_SIZE_MB"`
+	StoragePrices             string `yaml:"storage_prices" env:"KOTO_STORAGE_PRICES"`
+	RetentionRules            string `yaml:"retention_rules" env:"KOTO_RETENTION_RULES"`
+	RetentionDryRun           bool   `yaml:"retention_dry_run" env:"KOTO_RETENTION_DRY_RUN"`
+	PostsPerDay               int    `yaml:"posts_per_day" default:"100" env:"KOTO_POSTS_PER_DAY"`
+	CommentsPerHour           int    `yaml:"comments_per_hour" default:"120" env:"KOTO_COMMENTS_PER_HOUR"`
+	AttachmentMBPerDay        int    `yaml:"attachment_mb_per_day" default:"1024" env:"KOTO_ATTACHMENT_MB_PER_DAY"`
+	MetricsToken              string `yaml:"metrics_token" env:"KOTO_METRICS_TOKEN"`
+	ShutdownTimeoutSeconds    int    `yaml:"shutdown_timeout" default:"30" env:"KOTO_SHUTDOWN_TIMEOUT"`
+	ShutdownDrainDelaySeconds int    `yaml:"shutdown_drain_delay" default:"5" env:"KOTO_SHUTDOWN_DRAIN_DELAY"`
+
+	DB      common.DatabaseConfig  `yaml:"db"`
+	S3      common.S3Config        `yaml:"s3"`
+	Blob    common.FSStorageConfig `yaml:"blob"`
+	Log     logging.Config         `yaml:"log"`
+	Tracing tracing.Config         `yaml:"tracing"`
 
-	DB common.DatabaseConfig `yaml:"db"`
-	S3 common.S3Config       `yaml:"s3"`
+	reactionList []string
+	adminList    []string
+	priceList    []Price
+	retention    RetentionPolicy
 }
 
 func Load(cfgPath string) (Config, error) {
@@ -44,5 +74,86 @@ func Load(cfgPath string) (Config, error) {
 		return Config{}, merry.New("UserHubAddress is empty")
 	}
 
+	cfg.priceList, err = ParsePrices(cfg.StoragePrices)
+	if err != nil {
+		return Config{}, merry.Prepend(err, "can't parse storage prices")
+	}
+	if len(cfg.priceList) == 0 {
+		cfg.priceList = DefaultPrices
+	}
+
+	cfg.retention, err = ParseRetentionPolicy(cfg.RetentionRules)
+	if err != nil {
+		return Config{}, merry.Prepend(err, "can't parse retention rules")
+	}
+
+	for _, admin := range strings.Split(cfg.Admins, ",") {
+		admin = strings.TrimSpace(admin)
+		if admin != "" {
+			cfg.adminList = append(cfg.adminList, admin)
+		}
+	}
+
+	for _, reaction := range strings.Split(cfg.Reactions, ",") {
+		reaction = strings.TrimSpace(reaction)
+		if reaction != "" {
+			cfg.reactionList = append(cfg.reactionList, reaction)
+		}
+	}
+
 	return cfg, nil
 }
+
+func (cfg Config) ReactionList() []string {
+	return cfg.reactionList
+}
+
+func (cfg Config) ReactionNotificationDelay() time.Duration {
+	return time.Duration(cfg.ReactionDelaySeconds) * time.Second
+}
+
+func (cfg Config) EventReminderLeadTime() time.Duration {
+	return time.Duration(cfg.EventReminderMinutes) * time.Minute
+}
+
+func (cfg Config) NotificationRetention() time.Duration {
+	return time.Duration(cfg.NotificationRetentionDays) * time.Hour * 24
+}
+
+func (cfg Config) IsAdmin(userName string) bool {
+	for _, admin := range cfg.adminList {
+		if admin == userName {
+			return true
+		}
+	}
+	return false
+}
+
+// UserStorageQuota returns 0 if users' storage isn't limited.
+func (cfg Config) UserStorageQuota() int64 {
+	return int64(cfg.UserStorageQuotaMB) * 1024 * 1024
+}
+
+func (cfg Config) MaxUploadSize() int64 {
+	return int64(cfg.MaxUploadSizeMB) * 1024 * 1024
+}
+
+func (cfg Config) AttachmentBytesPerDay() int64 {
+	return int64(cfg.AttachmentMBPerDay) * 1024 * 1024
+}
+
+func (cfg Config) PriceList() []Price {
+	return cfg.priceList
+}
+
+func (cfg Config) RetentionPolicy() RetentionPolicy {
+	return cfg.retention
+}
+
+func (cfg Config) ShutdownTimeout() time.Duration {
+	return time.Duration(cfg.ShutdownTimeoutSeconds) * time.Second
+}
+
+func (cfg Config) ShutdownDrainDelay() time.Duration {
+	return time.Duration(cfg.ShutdownDrainDelaySeconds) * time.Second
+}
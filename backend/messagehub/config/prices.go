@@ -0,0 +1,51 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ansel1/merry"
+)
+
+// DefaultPrices are approximate list prices in USD: storage per GB-month and egress per GB.
+var DefaultPrices = []Price{
+	{Provider: "aws-s3", StoragePerGB: 0.023, EgressPerGB: 0.09},
+	{Provider: "digitalocean-spaces", StoragePerGB: 0.02, EgressPerGB: 0.01},
+	{Provider: "backblaze-b2", StoragePerGB: 0.006, EgressPerGB: 0.01},
+	{Provider: "wasabi", StoragePerGB: 0.0069, EgressPerGB: 0},
+}
+
+type Price struct {
+	Provider     string
+	StoragePerGB float64
+	EgressPerGB  float64
+}
+
+// ParsePrices parses "provider:storage_per_gb:egress_per_gb" items separated by commas.
+func ParsePrices(value string) ([]Price, error) {
+	var prices []Price
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) != 3 || strings.TrimSpace(parts[0]) == "" {
+			return nil, merry.Errorf("invalid price '%s'", item)
+		}
+		storage, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil {
+			return nil, merry.Prepend(err, "invalid storage price")
+		}
+		egress, err := strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
+		if err != nil {
+			return nil, merry.Prepend(err, "invalid egress price")
+		}
+		prices = append(prices, Price{
+			Provider:     strings.TrimSpace(parts[0]),
+			StoragePerGB: storage,
+			EgressPerGB:  egress,
+		})
+	}
+	return prices, nil
+}
@@ -0,0 +1,24 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/messagehub/config"
+)
+
+func TestParsePrices(t *testing.T) {
+	prices, err := config.ParsePrices("local:0.01:0, cheap : 0.005 : 0.02,")
+	require.NoError(t, err)
+	assert.Equal(t, []config.Price{
+		{Provider: "local", StoragePerGB: 0.01, EgressPerGB: 0},
+		{Provider: "cheap", StoragePerGB: 0.005, EgressPerGB: 0.02},
+	}, prices)
+
+	_, err = config.ParsePrices("local:0.01")
+	assert.Error(t, err)
+	_, err = config.ParsePrices("local:x:0")
+	assert.Error(t, err)
+}
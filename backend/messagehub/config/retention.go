@@ -0,0 +1,86 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ansel1/merry"
+)
+
+const anyMediaType = "*"
+
+// RetentionRule expires attachments of MediaType older than MaxAge.
+// MediaType is a full type ("video/mp4"), a top-level type ("video") or "*" for everything.
+type RetentionRule struct {
+	MediaType string
+	MaxAge    time.Duration
+}
+
+type RetentionPolicy []RetentionRule
+
+// ParseRetentionPolicy parses "media_type:days" items separated by commas, e.g. "video:90,image:365".
+func ParseRetentionPolicy(value string) (RetentionPolicy, error) {
+	var policy RetentionPolicy
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) != 2 {
+			return nil, merry.Errorf("invalid retention rule '%s'", item)
+		}
+		mediaType := strings.ToLower(strings.TrimSpace(parts[0]))
+		days, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || days <= 0 || mediaType == "" {
+			return nil, merry.Errorf("invalid retention rule '%s'", item)
+		}
+		policy = append(policy, RetentionRule{
+			MediaType: mediaType,
+			MaxAge:    time.Duration(days) * time.Hour * 24,
+		})
+	}
+	return policy, nil
+}
+
+// MinAge returns the shortest retention period, or 0 if there are no rules.
+func (p RetentionPolicy) MinAge() time.Duration {
+	var minAge time.Duration
+	for _, rule := range p {
+		if minAge == 0 || rule.MaxAge < minAge {
+			minAge = rule.MaxAge
+		}
+	}
+	return minAge
+}
+
+// Expired reports whether an attachment created at createdAt has outlived the most specific matching rule.
+func (p RetentionPolicy) Expired(attachmentType string, createdAt, now time.Time) bool {
+	rule, ok := p.match(strings.ToLower(attachmentType))
+	if !ok {
+		return false
+	}
+	return now.Sub(createdAt) > rule.MaxAge
+}
+
+func (p RetentionPolicy) match(attachmentType string) (RetentionRule, bool) {
+	topLevelType := strings.SplitN(attachmentType, "/", 2)[0]
+	var result RetentionRule
+	bestScore := 0
+	for _, rule := range p {
+		score := 0
+		switch rule.MediaType {
+		case attachmentType:
+			score = 3
+		case topLevelType:
+			score = 2
+		case anyMediaType:
+			score = 1
+		}
+		if score > bestScore {
+			result, bestScore = rule, score
+		}
+	}
+	return result, bestScore > 0
+}
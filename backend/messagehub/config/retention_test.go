@@ -0,0 +1,46 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/messagehub/config"
+)
+
+const day = time.Hour * 24
+
+func TestParseRetentionPolicy(t *testing.T) {
+	policy, err := config.ParseRetentionPolicy("video:90, image/gif:30,*:365,")
+	require.NoError(t, err)
+	assert.Equal(t, config.RetentionPolicy{
+		{MediaType: "video", MaxAge: 90 * day},
+		{MediaType: "image/gif", MaxAge: 30 * day},
+		{MediaType: "*", MaxAge: 365 * day},
+	}, policy)
+	assert.Equal(t, 30*day, policy.MinAge())
+
+	for _, value := range []string{"video", "video:x", "video:0", ":10"} {
+		_, err = config.ParseRetentionPolicy(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestRetentionPolicy_Expired(t *testing.T) {
+	policy, err := config.ParseRetentionPolicy("video:90,image/gif:30,*:365")
+	require.NoError(t, err)
+
+	now := time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)
+	assert.True(t, policy.Expired("video/mp4", now.Add(-91*day), now))
+	assert.False(t, policy.Expired("video/mp4", now.Add(-89*day), now))
+	assert.True(t, policy.Expired("image/gif", now.Add(-31*day), now))
+	assert.False(t, policy.Expired("image/png", now.Add(-31*day), now))
+	assert.True(t, policy.Expired("image/png", now.Add(-366*day), now))
+
+	policy, err = config.ParseRetentionPolicy("video:90")
+	require.NoError(t, err)
+	assert.False(t, policy.Expired("image/png", now.Add(-1000*day), now))
+	assert.Equal(t, time.Duration(0), config.RetentionPolicy(nil).MinAge())
+}
@@ -0,0 +1,34 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002f() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002f",
+		Up: []string{
+			`
+create table message_reactions
+(
+	message_id text not null constraint message_reactions_messages_id_fk_message_id references messages (id),
+	user_id text not null,
+	reaction text not null,
+	created_at timestamp with time zone not null,
+	constraint message_reactions_pk primary key (message_id, user_id, reaction)
+);
+
+create index message_reactions_message_id_reaction_index on message_reactions (message_id, reaction);
+`,
+			`
+insert into message_reactions(message_id, user_id, reaction, created_at)
+select message_id, user_id, '👍', created_at
+from message_likes;
+`,
+			`
+drop table message_likes;
+`,
+		},
+		Down: []string{},
+	}
+}
@@ -0,0 +1,20 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002g() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002g",
+		Up: []string{
+			`
+alter table messages add reply_to_id text constraint messages_messages_id_fk_reply_to_id references messages (id);
+alter table messages add depth int not null default 0;
+
+create index messages_reply_to_id_index on messages (reply_to_id);
+`,
+		},
+		Down: []string{},
+	}
+}
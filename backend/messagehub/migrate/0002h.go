@@ -0,0 +1,54 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002h() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002h",
+		Up: []string{
+			`
+create table conversations
+(
+	id text not null constraint conversations_pk primary key,
+	member_key text not null,
+	created_by text not null,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null
+);
+
+create unique index conversations_member_key_uindex on conversations (member_key);
+`,
+			`
+create table conversation_members
+(
+	conversation_id text not null constraint conversation_members_conversations_id_fk references conversations,
+	user_id text not null constraint conversation_members_users_id_fk references users,
+	last_read_at timestamp with time zone,
+	created_at timestamp with time zone not null,
+	constraint conversation_members_pk primary key (conversation_id, user_id)
+);
+
+create index conversation_members_user_id_index on conversation_members (user_id);
+`,
+			`
+create table conversation_messages
+(
+	id text not null constraint conversation_messages_pk primary key,
+	conversation_id text not null constraint conversation_messages_conversations_id_fk references conversations,
+	user_id text not null,
+	user_name text not null,
+	text text not null,
+	attachment_id text not null default '',
+	attachment_type text not null default '',
+	attachment_thumbnail_id text not null default '',
+	created_at timestamp with time zone not null
+);
+
+create index conversation_messages_conversation_id_created_at_index on conversation_messages (conversation_id, created_at);
+`,
+		},
+		Down: []string{},
+	}
+}
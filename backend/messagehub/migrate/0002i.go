@@ -0,0 +1,30 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002i() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002i",
+		Up: []string{
+			`
+alter table conversation_messages add encrypted boolean default false not null;
+alter table conversation_messages add sender_device_id text default '' not null;
+`,
+			`
+create table conversation_envelopes
+(
+	message_id text not null constraint conversation_envelopes_conversation_messages_id_fk references conversation_messages,
+	user_id text not null,
+	device_id text not null,
+	ciphertext text not null,
+	constraint conversation_envelopes_pk primary key (message_id, user_id, device_id)
+);
+
+create index conversation_envelopes_user_id_device_id_index on conversation_envelopes (user_id, device_id);
+`,
+		},
+		Down: []string{},
+	}
+}
@@ -0,0 +1,33 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002j() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002j",
+		Up: []string{
+			`
+alter table messages add published_at timestamp with time zone;
+alter table messages add is_draft boolean default false not null;
+`,
+			`
+update messages set published_at = created_at;
+`,
+			`
+create table scheduled_messages
+(
+	message_id text not null constraint scheduled_messages_pk primary key
+		constraint scheduled_messages_messages_id_fk references messages,
+	publish_at timestamp with time zone not null,
+	friend_ids json not null,
+	token_expires_at timestamp with time zone not null
+);
+
+create index scheduled_messages_publish_at_index on scheduled_messages (publish_at);
+`,
+		},
+		Down: []string{},
+	}
+}
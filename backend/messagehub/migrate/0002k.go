@@ -0,0 +1,51 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002k() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002k",
+		Up: []string{
+			`
+create table polls
+(
+	message_id text not null constraint polls_pk primary key
+		constraint polls_messages_id_fk references messages,
+	multiple_choice boolean not null,
+	anonymous boolean not null,
+	closes_at timestamp with time zone,
+	closed_at timestamp with time zone,
+	created_at timestamp with time zone not null
+);
+
+create index polls_closes_at_index on polls (closes_at) where closed_at is null;
+`,
+			`
+create table poll_options
+(
+	id text not null constraint poll_options_pk primary key,
+	message_id text not null constraint poll_options_polls_message_id_fk references polls,
+	text text not null,
+	position int not null
+);
+
+create index poll_options_message_id_index on poll_options (message_id);
+`,
+			`
+create table poll_votes
+(
+	message_id text not null constraint poll_votes_polls_message_id_fk references polls,
+	option_id text not null constraint poll_votes_poll_options_id_fk references poll_options,
+	user_id text not null,
+	created_at timestamp with time zone not null,
+	constraint poll_votes_pk primary key (option_id, user_id)
+);
+
+create index poll_votes_message_id_user_id_index on poll_votes (message_id, user_id);
+`,
+		},
+		Down: []string{},
+	}
+}
@@ -0,0 +1,44 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002l() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002l",
+		Up: []string{
+			`
+create table events
+(
+	message_id text not null constraint events_pk primary key
+		constraint events_messages_id_fk references messages,
+	title text not null,
+	starts_at timestamp with time zone not null,
+	ends_at timestamp with time zone,
+	location text not null,
+	description text not null,
+	reminded_at timestamp with time zone,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null
+);
+
+create index events_starts_at_index on events (starts_at) where reminded_at is null;
+`,
+			`
+create table event_rsvps
+(
+	message_id text not null constraint event_rsvps_events_message_id_fk references events,
+	user_id text not null,
+	status text not null,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null,
+	constraint event_rsvps_pk primary key (message_id, user_id)
+);
+
+create index event_rsvps_user_id_index on event_rsvps (user_id);
+`,
+		},
+		Down: []string{},
+	}
+}
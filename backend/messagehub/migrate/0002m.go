@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002m() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002m",
+		Up: []string{
+			`
+alter table notifications add group_key text;
+`,
+			`
+create index notifications_user_id_created_at_index on notifications (user_id, created_at);
+`,
+			`
+create index notifications_user_id_group_key_index on notifications (user_id, group_key) where read_at is null;
+`,
+		},
+		Down: []string{},
+	}
+}
@@ -0,0 +1,29 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002n() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002n",
+		Up: []string{
+			`
+create table notification_outbox
+(
+	id text not null constraint notification_outbox_pk primary key,
+	payload json not null,
+	created_at timestamp with time zone not null,
+	attempts integer default 0 not null,
+	next_attempt_at timestamp with time zone not null,
+	last_error text default '' not null,
+	delivered_at timestamp with time zone
+);
+`,
+			`
+create index notification_outbox_next_attempt_at_index on notification_outbox (next_attempt_at) where delivered_at is null;
+`,
+		},
+		Down: []string{},
+	}
+}
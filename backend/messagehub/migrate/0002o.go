@@ -0,0 +1,26 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002o() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002o",
+		Up: []string{
+			`
+create table blobs
+(
+	id text not null constraint blobs_pk primary key,
+	user_id text not null,
+	size bigint not null,
+	created_at timestamp with time zone not null
+);
+`,
+			`
+create index blobs_user_id_index on blobs (user_id);
+`,
+		},
+		Down: []string{},
+	}
+}
@@ -0,0 +1,22 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002p() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002p",
+		Up: []string{
+			`
+create table blob_egress
+(
+	day date not null constraint blob_egress_pk primary key,
+	links bigint default 0 not null,
+	size bigint default 0 not null
+);
+`,
+		},
+		Down: []string{},
+	}
+}
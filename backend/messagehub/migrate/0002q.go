@@ -0,0 +1,21 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002q() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002q",
+		Up: []string{
+			`
+alter table messages add media_expired boolean default false not null;
+alter table users add delete_expired_posts boolean default false not null;
+`,
+			`
+create index messages_created_at_attachment_index on messages (created_at, id) where attachment_id <> '';
+`,
+		},
+		Down: []string{},
+	}
+}
@@ -0,0 +1,18 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002r() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002r",
+		Up: []string{
+			`
+alter table scheduled_messages drop column friend_ids;
+alter table scheduled_messages drop column token_expires_at;
+`,
+		},
+		Down: []string{},
+	}
+}
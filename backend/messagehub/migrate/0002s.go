@@ -0,0 +1,27 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002s() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002s",
+		Up: []string{
+			`
+create table calendar_feeds
+(
+	user_id text not null
+		constraint calendar_feeds_pk
+			primary key,
+	secret text not null,
+	created_at timestamp with time zone not null
+);
+
+create unique index calendar_feeds_secret_uindex
+	on calendar_feeds (secret);
+`,
+		},
+		Down: []string{},
+	}
+}
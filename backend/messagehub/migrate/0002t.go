@@ -0,0 +1,32 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002t() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002t",
+		Up: []string{
+			`
+create table pending_reactions
+(
+	id bigserial not null
+		constraint pending_reactions_pk
+			primary key,
+	owner_id text not null,
+	message_id text not null,
+	comment_id text not null,
+	user_id text not null,
+	user_name text not null,
+	reaction text not null,
+	created_at timestamp with time zone not null
+);
+
+create index pending_reactions_created_at_index
+	on pending_reactions (created_at);
+`,
+		},
+		Down: []string{},
+	}
+}
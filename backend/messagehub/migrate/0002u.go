@@ -0,0 +1,19 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002u() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002u",
+		Up: []string{
+			`
+alter table blobs add attached_at timestamp with time zone;
+update blobs set attached_at = created_at;
+create index blobs_unattached_index on blobs (created_at) where attached_at is null;
+`,
+		},
+		Down: []string{},
+	}
+}
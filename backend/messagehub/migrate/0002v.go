@@ -0,0 +1,18 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002v() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002v",
+		Up: []string{
+			`
+alter table scheduled_messages add friend_ids text[];
+alter table scheduled_messages add token_expires_at timestamp with time zone;
+`,
+		},
+		Down: []string{},
+	}
+}
@@ -0,0 +1,18 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002w() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002w",
+		Up: []string{
+			`
+alter table calendar_feeds drop column secret;
+alter table calendar_feeds add version integer not null default 0;
+`,
+		},
+		Down: []string{},
+	}
+}
@@ -0,0 +1,17 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002y() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002y",
+		Up: []string{
+			`
+drop table if exists pending_reactions;
+`,
+		},
+		Down: []string{},
+	}
+}
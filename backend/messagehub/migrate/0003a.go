@@ -0,0 +1,17 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0003a() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0003a",
+		Up: []string{
+			`
+alter table conversation_messages add media_expired boolean default false not null;
+`,
+		},
+		Down: []string{},
+	}
+}
@@ -0,0 +1,276 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+type StorageUsage struct {
+	UserID    string `json:"user_id" db:"user_id"`
+	UserName  string `json:"user_name" db:"user_name"`
+	Size      int64  `json:"size" db:"size"`
+	BlobCount int    `json:"blob_count" db:"blob_count"`
+}
+
+type MediaTypeUsage struct {
+	MediaType string `json:"media_type" db:"media_type"`
+	Size      int64  `json:"size" db:"size"`
+	BlobCount int    `json:"blob_count" db:"blob_count"`
+}
+
+type DailyVolume struct {
+	Day   time.Time `json:"day" db:"day"`
+	Size  int64     `json:"size" db:"size"`
+	Count int       `json:"count" db:"count"`
+}
+
+type BlobRepo interface {
+	// LockUserBlobs serializes the reservations of the user until tx is finished.
+	LockUserBlobs(ctx context.Context, tx *sqlx.Tx, userID string) error
+	ReserveBlob(ctx context.Context, tx *sqlx.Tx, blobID, userID string, size int64) error
+	AddBlob(ctx context.Context, blobID, userID string, size int64) error
+	ExpireReservations(ctx context.Context, createdBefore time.Time) (int, error)
+	RemoveBlob(ctx context.Context, blobID string) error
+	UserUsage(ctx context.Context, userID string) (StorageUsage, error)
+	UsageByUser(ctx context.Context) ([]StorageUsage, error)
+	UsageByMediaType(ctx context.Context) ([]MediaTypeUsage, error)
+	UnaccountedBlobCount(ctx context.Context) (int, error)
+	BlobSizes(ctx context.Context, blobIDs []string) (map[string]int64, error)
+	DailyUploads(ctx context.Context, since time.Time) ([]DailyVolume, error)
+	AddEgress(ctx context.Context, day time.Time, links int, size int64) error
+	DailyEgress(ctx context.Context, since time.Time) ([]DailyVolume, error)
+}
+
+type blobRepo struct {
+	db *sqlx.DB
+}
+
+func NewBlobs(db *sqlx.DB) BlobRepo {
+	return &blobRepo{
+		db: db,
+	}
+}
+
+func (r *blobRepo) LockUserBlobs(ctx context.Context, tx *sqlx.Tx, userID string) error {
+	_, err := tx.ExecContext(ctx, `select pg_advisory_xact_lock(hashtext('blobs/' || $1))`, userID)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+// ReserveBlob accounts the size of an upload to the user until the blob is attached.
+func (r *blobRepo) ReserveBlob(ctx context.Context, tx *sqlx.Tx, blobID, userID string, size int64) error {
+	_, err := tx.ExecContext(ctx, `
+		insert into blobs(id, user_id, size, created_at)
+		values ($1, $2, $3, $4)
+		on conflict (id) do nothing`,
+		blobID, userID, size, common.CurrentTimestamp())
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+// AddBlob records the size of an attached blob instead of its reservation.
+// A blob stays accounted to the user who attached it first.
+func (r *blobRepo) AddBlob(ctx context.Context, blobID, userID string, size int64) error {
+	now := common.CurrentTimestamp()
+	_, err := r.db.ExecContext(ctx, `
+		insert into blobs(id, user_id, size, created_at, attached_at)
+		values ($1, $2, $3, $4, $4)
+		on conflict (id) do update set size = excluded.size, attached_at = coalesce(blobs.attached_at, excluded.attached_at)
+		where blobs.user_id = excluded.user_id`,
+		blobID, userID, size, now)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+// ExpireReservations releases the reservations of uploads that weren't attached
+// and queues the uploaded blobs for removal from the storage.
+func (r *blobRepo) ExpireReservations(ctx context.Context, createdBefore time.Time) (int, error) {
+	res, err := r.db.ExecContext(ctx, `
+		with expired as (
+			delete from blobs
+			where attached_at is null and created_at < $1
+			returning id
+		)
+		insert into blob_pending_deletes(blob_id, deleted_at)
+		select id, $2
+		from expired`,
+		createdBefore, common.CurrentTimestamp())
+	if err != nil {
+		return 0, merry.Wrap(err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, merry.Wrap(err)
+	}
+	return int(rowsAffected), nil
+}
+
+func (r *blobRepo) RemoveBlob(ctx context.Context, blobID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		delete from blobs
+		where id = $1`,
+		blobID)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+func (r *blobRepo) UserUsage(ctx context.Context, userID string) (StorageUsage, error) {
+	usage := StorageUsage{UserID: userID}
+	err := r.db.GetContext(ctx, &usage, `
+		select $1 user_id, coalesce(sum(size), 0) size, count(attached_at) blob_count
+		from blobs
+		where user_id = $1`,
+		userID)
+	if err != nil {
+		return StorageUsage{}, merry.Wrap(err)
+	}
+	return usage, nil
+}
+
+func (r *blobRepo) UsageByUser(ctx context.Context) ([]StorageUsage, error) {
+	var usage []StorageUsage
+	err := r.db.SelectContext(ctx, &usage, `
+		select b.user_id, coalesce(u.name, '') user_name, sum(b.size) size, count(b.attached_at) blob_count
+		from blobs b
+			left join users u on u.id = b.user_id
+		group by b.user_id, u.name
+		order by size desc, b.user_id`)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return usage, nil
+}
+
+// UsageByMediaType takes media types from the messages the blobs are attached to.
+// Generated video thumbnails are reported as "thumbnail".
+func (r *blobRepo) UsageByMediaType(ctx context.Context) ([]MediaTypeUsage, error) {
+	var usage []MediaTypeUsage
+	err := r.db.SelectContext(ctx, &usage, `
+		select media_type, sum(size) size, count(*) blob_count
+		from (
+			select b.size,
+			       case
+			           when t.attachment_type is not null then coalesce(nullif(split_part(t.attachment_type, '/', 1), ''), 'other')
+			           when exists(select * from messages m where m.attachment_thumbnail_id = b.id) then 'thumbnail'
+			           else 'other'
+			       end media_type
+			from blobs b
+				left join lateral (
+					select attachment_type from messages where attachment_id = b.id
+					union all
+					select attachment_type from conversation_messages where attachment_id = b.id
+					limit 1
+				) t on true
+			where b.attached_at is not null
+		) typed
+		group by media_type
+		order by size desc`)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return usage, nil
+}
+
+// UnaccountedBlobCount counts attachments and thumbnails without a recorded size,
+// they are missing in the usage until "backfill-blobs" records them.
+func (r *blobRepo) UnaccountedBlobCount(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.GetContext(ctx, &count, `
+		select count(*)
+		from (
+			select attachment_id id from messages
+			union
+			select attachment_thumbnail_id from messages
+			union
+			select attachment_id from conversation_messages
+			union
+			select attachment_thumbnail_id from conversation_messages
+		) a
+		where a.id <> '' and not exists(select * from blobs b where b.id = a.id)`)
+	if err != nil {
+		return 0, merry.Wrap(err)
+	}
+	return count, nil
+}
+
+func (r *blobRepo) BlobSizes(ctx context.Context, blobIDs []string) (map[string]int64, error) {
+	if len(blobIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(`
+		select id, size
+		from blobs
+		where id in (?)`, blobIDs)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	var blobs []struct {
+		ID   string `db:"id"`
+		Size int64  `db:"size"`
+	}
+	err = r.db.SelectContext(ctx, &blobs, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+
+	sizes := make(map[string]int64, len(blobs))
+	for _, blob := range blobs {
+		sizes[blob.ID] = blob.Size
+	}
+	return sizes, nil
+}
+
+func (r *blobRepo) DailyUploads(ctx context.Context, since time.Time) ([]DailyVolume, error) {
+	var volumes []DailyVolume
+	err := r.db.SelectContext(ctx, &volumes, `
+		select date_trunc('day', created_at at time zone 'UTC') as day, sum(size) size, count(*) count
+		from blobs
+		where attached_at is not null and created_at >= $1
+		group by 1
+		order by 1`,
+		since)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return volumes, nil
+}
+
+func (r *blobRepo) AddEgress(ctx context.Context, day time.Time, links int, size int64) error {
+	_, err := r.db.ExecContext(ctx, `
+		insert into blob_egress(day, links, size)
+		values ($1, $2, $3)
+		on conflict (day) do update set links = blob_egress.links + excluded.links, size = blob_egress.size + excluded.size`,
+		day, links, size)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+func (r *blobRepo) DailyEgress(ctx context.Context, since time.Time) ([]DailyVolume, error) {
+	var volumes []DailyVolume
+	err := r.db.SelectContext(ctx, &volumes, `
+		select day::timestamp as day, size, links as count
+		from blob_egress
+		where day >= $1::date
+		order by day`,
+		since)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return volumes, nil
+}
@@ -0,0 +1,10 @@
+package repo
+
+import (
+	"github.com/mreider/koto/backend/common/dbtest"
+	"github.com/mreider/koto/backend/messagehub/migrate"
+)
+
+func NewTestEnvironment() *dbtest.TestEnvironment {
+	return dbtest.NewTestEnvironment("messagehub_repo", migrate.Migrate)
+}
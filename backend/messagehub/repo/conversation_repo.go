@@ -0,0 +1,291 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+var (
+	ErrConversationNotFound = common.ErrNotFound.WithMessage("conversation not found")
+)
+
+type Conversation struct {
+	ID          string    `json:"id" db:"id"`
+	MemberKey   string    `json:"member_key" db:"member_key"`
+	CreatedBy   string    `json:"created_by" db:"created_by"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	UnreadCount int       `json:"unread_count" db:"unread_count"`
+}
+
+type ConversationMember struct {
+	ConversationID string       `json:"conversation_id" db:"conversation_id"`
+	UserID         string       `json:"user_id" db:"user_id"`
+	UserName       string       `json:"user_name" db:"user_name"`
+	LastReadAt     sql.NullTime `json:"last_read_at" db:"last_read_at"`
+}
+
+type ConversationMessage struct {
+	ID                    string    `json:"id" db:"id"`
+	ConversationID        string    `json:"conversation_id" db:"conversation_id"`
+	UserID                string    `json:"user_id" db:"user_id"`
+	UserName              string    `json:"user_name" db:"user_name"`
+	Text                  string    `json:"text" db:"text"`
+	AttachmentID          string    `json:"attachment_id" db:"attachment_id"`
+	AttachmentType        string    `json:"attachment_type" db:"attachment_type"`
+	AttachmentThumbnailID string    `json:"attachment_thumbnail_id" db:"attachment_thumbnail_id"`
+	MediaExpired          bool      `json:"media_expired" db:"media_expired"`
+	CreatedAt             time.Time `json:"created_at" db:"created_at"`
+	Encrypted             bool      `json:"encrypted" db:"encrypted"`
+	SenderDeviceID        string    `json:"sender_device_id" db:"sender_device_id"`
+	Ciphertext            string    `json:"ciphertext" db:"ciphertext"`
+}
+
+type ConversationEnvelope struct {
+	MessageID  string `json:"message_id" db:"message_id"`
+	UserID     string `json:"user_id" db:"user_id"`
+	DeviceID   string `json:"device_id" db:"device_id"`
+	Ciphertext string `json:"ciphertext" db:"ciphertext"`
+}
+
+type ConversationRepo interface {
+	AddConversation(ctx context.Context, conversation Conversation, memberIDs []string) (Conversation, error)
+	Conversations(ctx context.Context, userID string) ([]Conversation, error)
+	Conversation(ctx context.Context, userID, conversationID string) (Conversation, error)
+	ConversationsMembers(ctx context.Context, conversationIDs []string) (map[string][]ConversationMember, error)
+	LastMessages(ctx context.Context, conversationIDs []string) (map[string]ConversationMessage, error)
+	AddMessage(ctx context.Context, tx *sqlx.Tx, message ConversationMessage, envelopes []ConversationEnvelope) error
+	Messages(ctx context.Context, conversationID, userID, deviceID string, from time.Time, count int) ([]ConversationMessage, error)
+	MarkRead(ctx context.Context, userID, conversationID string, readAt time.Time) error
+}
+
+type conversationRepo struct {
+	db *sqlx.DB
+}
+
+func NewConversations(db *sqlx.DB) ConversationRepo {
+	return &conversationRepo{
+		db: db,
+	}
+}
+
+func (r *conversationRepo) AddConversation(ctx context.Context, conversation Conversation, memberIDs []string) (Conversation, error) {
+	err := common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			insert into conversations(id, member_key, created_by, created_at, updated_at)
+			values ($1, $2, $3, $4, $5)
+			on conflict (member_key) do nothing`,
+			conversation.ID, conversation.MemberKey, conversation.CreatedBy, conversation.CreatedAt, conversation.UpdatedAt)
+		if err != nil {
+			return merry.Wrap(err)
+		}
+
+		err = tx.GetContext(ctx, &conversation, `
+			select id, member_key, created_by, created_at, updated_at
+			from conversations
+			where member_key = $1`,
+			conversation.MemberKey)
+		if err != nil {
+			return merry.Wrap(err)
+		}
+
+		for _, memberID := range memberIDs {
+			_, err = tx.ExecContext(ctx, `
+				insert into conversation_members(conversation_id, user_id, created_at)
+				values ($1, $2, $3)
+				on conflict (conversation_id, user_id) do nothing`,
+				conversation.ID, memberID, conversation.CreatedAt)
+			if err != nil {
+				return merry.Wrap(err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Conversation{}, err
+	}
+	return conversation, nil
+}
+
+func (r *conversationRepo) Conversations(ctx context.Context, userID string) ([]Conversation, error) {
+	var conversations []Conversation
+	err := r.db.SelectContext(ctx, &conversations, `
+		select c.id, c.member_key, c.created_by, c.created_at, c.updated_at,
+		       (select count(*)
+		        from conversation_messages m
+		        where m.conversation_id = c.id and m.user_id <> $1
+		          and (cm.last_read_at is null or m.created_at > cm.last_read_at)) unread_count
+		from conversations c
+			inner join conversation_members cm on cm.conversation_id = c.id
+		where cm.user_id = $1
+		order by c.updated_at desc, c.id`,
+		userID)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return conversations, nil
+}
+
+func (r *conversationRepo) Conversation(ctx context.Context, userID, conversationID string) (Conversation, error) {
+	var conversation Conversation
+	err := r.db.GetContext(ctx, &conversation, `
+		select c.id, c.member_key, c.created_by, c.created_at, c.updated_at,
+		       (select count(*)
+		        from conversation_messages m
+		        where m.conversation_id = c.id and m.user_id <> $1
+		          and (cm.last_read_at is null or m.created_at > cm.last_read_at)) unread_count
+		from conversations c
+			inner join conversation_members cm on cm.conversation_id = c.id
+		where cm.user_id = $1 and c.id = $2`,
+		userID, conversationID)
+	if err != nil {
+		if merry.Is(err, sql.ErrNoRows) {
+			return conversation, ErrConversationNotFound.Here()
+		}
+		return conversation, merry.Wrap(err)
+	}
+	return conversation, nil
+}
+
+func (r *conversationRepo) ConversationsMembers(ctx context.Context, conversationIDs []string) (map[string][]ConversationMember, error) {
+	if len(conversationIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(`
+		select cm.conversation_id, cm.user_id, u.name user_name, cm.last_read_at
+		from conversation_members cm
+			inner join users u on u.id = cm.user_id
+		where cm.conversation_id in (?)
+		order by cm.conversation_id, u.name`, conversationIDs)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	query = r.db.Rebind(query)
+	var members []ConversationMember
+	err = r.db.SelectContext(ctx, &members, query, args...)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+
+	result := make(map[string][]ConversationMember)
+	for _, member := range members {
+		result[member.ConversationID] = append(result[member.ConversationID], member)
+	}
+	return result, nil
+}
+
+func (r *conversationRepo) LastMessages(ctx context.Context, conversationIDs []string) (map[string]ConversationMessage, error) {
+	if len(conversationIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(`
+		select distinct on (conversation_id) id, conversation_id, user_id, user_name, text,
+		       attachment_id, attachment_type, attachment_thumbnail_id, media_expired, created_at, encrypted, sender_device_id
+		from conversation_messages
+		where conversation_id in (?)
+		order by conversation_id, created_at desc, id`, conversationIDs)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	query = r.db.Rebind(query)
+	var messages []ConversationMessage
+	err = r.db.SelectContext(ctx, &messages, query, args...)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+
+	result := make(map[string]ConversationMessage, len(messages))
+	for _, message := range messages {
+		result[message.ConversationID] = message
+	}
+	return result, nil
+}
+
+func (r *conversationRepo) AddMessage(ctx context.Context, tx *sqlx.Tx, message ConversationMessage, envelopes []ConversationEnvelope) error {
+	_, err := tx.ExecContext(ctx, `
+		insert into conversation_messages(id, conversation_id, user_id, user_name, text,
+		                                  attachment_id, attachment_type, attachment_thumbnail_id, created_at,
+		                                  encrypted, sender_device_id)
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		message.ID, message.ConversationID, message.UserID, message.UserName, message.Text,
+		message.AttachmentID, message.AttachmentType, message.AttachmentThumbnailID, message.CreatedAt,
+		message.Encrypted, message.SenderDeviceID)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+
+	for _, envelope := range envelopes {
+		_, err = tx.ExecContext(ctx, `
+			insert into conversation_envelopes(message_id, user_id, device_id, ciphertext)
+			values ($1, $2, $3, $4)`,
+			message.ID, envelope.UserID, envelope.DeviceID, envelope.Ciphertext)
+		if err != nil {
+			return merry.Wrap(err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		update conversations
+		set updated_at = $1
+		where id = $2`,
+		message.CreatedAt, message.ConversationID)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		update conversation_members
+		set last_read_at = $1
+		where conversation_id = $2 and user_id = $3`,
+		message.CreatedAt, message.ConversationID, message.UserID)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+func (r *conversationRepo) Messages(ctx context.Context, conversationID, userID, deviceID string, from time.Time, count int) ([]ConversationMessage, error) {
+	if from.IsZero() {
+		from = maxTimestamp
+	}
+	if count <= 0 {
+		count = defaultMessageCount
+	}
+
+	var messages []ConversationMessage
+	err := r.db.SelectContext(ctx, &messages, `
+		select m.id, m.conversation_id, m.user_id, m.user_name, m.text,
+		       m.attachment_id, m.attachment_type, m.attachment_thumbnail_id, m.media_expired, m.created_at, m.encrypted, m.sender_device_id,
+		       coalesce(e.ciphertext, '') ciphertext
+		from conversation_messages m
+			left join conversation_envelopes e on e.message_id = m.id and e.user_id = $2 and e.device_id = $3
+		where m.conversation_id = $1 and m.created_at < $4
+		order by m.created_at desc, m.id
+		limit $5`,
+		conversationID, userID, deviceID, from, count)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return messages, nil
+}
+
+func (r *conversationRepo) MarkRead(ctx context.Context, userID, conversationID string, readAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		update conversation_members
+		set last_read_at = $1
+		where conversation_id = $2 and user_id = $3
+		  and (last_read_at is null or last_read_at < $1)`,
+		readAt, conversationID, userID)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
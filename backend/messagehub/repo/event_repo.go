@@ -0,0 +1,263 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+const (
+	RSVPYes   = "yes"
+	RSVPNo    = "no"
+	RSVPMaybe = "maybe"
+)
+
+var (
+	ErrEventNotFound = common.ErrNotFound.WithMessage("event not found")
+)
+
+type Event struct {
+	MessageID   string       `json:"message_id" db:"message_id"`
+	UserID      string       `json:"user_id" db:"user_id"`
+	UserName    string       `json:"user_name" db:"user_name"`
+	Title       string       `json:"title" db:"title"`
+	StartsAt    time.Time    `json:"starts_at" db:"starts_at"`
+	EndsAt      sql.NullTime `json:"ends_at" db:"ends_at"`
+	Location    string       `json:"location" db:"location"`
+	Description string       `json:"description" db:"description"`
+	RemindedAt  sql.NullTime `json:"reminded_at" db:"reminded_at"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at" db:"updated_at"`
+	MyRSVP      string       `json:"my_rsvp" db:"my_rsvp"`
+	RSVPs       []EventRSVP  `json:"rsvps" db:"-"`
+}
+
+type EventRSVP struct {
+	MessageID string    `json:"message_id" db:"message_id"`
+	UserID    string    `json:"user_id" db:"user_id"`
+	UserName  string    `json:"user_name" db:"user_name"`
+	Status    string    `json:"status" db:"status"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type EventRepo interface {
+	AddEvent(ctx context.Context, tx *sqlx.Tx, event Event) error
+	Events(ctx context.Context, currentUserID string, messageIDs []string) (map[string]Event, error)
+	Event(ctx context.Context, currentUserID, messageID string) (Event, error)
+	UserEvents(ctx context.Context, userID string, from time.Time) ([]Event, error)
+	SetRSVP(ctx context.Context, userID, messageID, status string) error
+	DueReminders(ctx context.Context, now, until time.Time) ([]Event, error)
+	MarkReminded(ctx context.Context, messageID string, remindedAt time.Time) (bool, error)
+	// CalendarFeedVersion returns the version of the calendar feed links of the user, it is 0 until they are rotated.
+	CalendarFeedVersion(ctx context.Context, userID string) (int, error)
+	RotateCalendarFeed(ctx context.Context, userID string, createdAt time.Time) (int, error)
+}
+
+type eventRepo struct {
+	db *sqlx.DB
+}
+
+func NewEvents(db *sqlx.DB) EventRepo {
+	return &eventRepo{
+		db: db,
+	}
+}
+
+func (r *eventRepo) AddEvent(ctx context.Context, tx *sqlx.Tx, event Event) error {
+	_, err := tx.ExecContext(ctx, `
+		insert into events(message_id, title, starts_at, ends_at, location, description, created_at, updated_at)
+		values ($1, $2, $3, $4, $5, $6, $7, $7)`,
+		event.MessageID, event.Title, event.StartsAt, event.EndsAt, event.Location, event.Description, event.CreatedAt)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+func (r *eventRepo) Events(ctx context.Context, currentUserID string, messageIDs []string) (map[string]Event, error) {
+	if len(messageIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(`
+		select e.message_id, m.user_id, m.user_name, e.title, e.starts_at, e.ends_at, e.location, e.description,
+		       e.reminded_at, e.created_at, e.updated_at,
+		       coalesce((select status from event_rsvps er where er.message_id = e.message_id and er.user_id = ?), '') my_rsvp
+		from events e
+			inner join messages m on m.id = e.message_id
+		where e.message_id in (?)`, currentUserID, messageIDs)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	var events []Event
+	err = r.db.SelectContext(ctx, &events, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	query, args, err = sqlx.In(`
+		select er.message_id, er.user_id, u.name user_name, er.status, er.updated_at
+		from event_rsvps er
+			inner join users u on u.id = er.user_id
+		where er.message_id in (?)
+		order by er.message_id, er.updated_at`, messageIDs)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	var rsvps []EventRSVP
+	err = r.db.SelectContext(ctx, &rsvps, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+
+	eventRSVPs := make(map[string][]EventRSVP)
+	for _, rsvp := range rsvps {
+		eventRSVPs[rsvp.MessageID] = append(eventRSVPs[rsvp.MessageID], rsvp)
+	}
+
+	result := make(map[string]Event, len(events))
+	for _, event := range events {
+		event.RSVPs = eventRSVPs[event.MessageID]
+		result[event.MessageID] = event
+	}
+	return result, nil
+}
+
+func (r *eventRepo) Event(ctx context.Context, currentUserID, messageID string) (Event, error) {
+	events, err := r.Events(ctx, currentUserID, []string{messageID})
+	if err != nil {
+		return Event{}, err
+	}
+	event, ok := events[messageID]
+	if !ok {
+		return Event{}, ErrEventNotFound.Here()
+	}
+	return event, nil
+}
+
+func (r *eventRepo) UserEvents(ctx context.Context, userID string, from time.Time) ([]Event, error) {
+	var events []Event
+	err := r.db.SelectContext(ctx, &events, `
+		select e.message_id, m.user_id, m.user_name, e.title, e.starts_at, e.ends_at, e.location, e.description,
+		       e.reminded_at, e.created_at, e.updated_at, coalesce(er.status, '') my_rsvp
+		from events e
+			inner join messages m on m.id = e.message_id
+			left join event_rsvps er on er.message_id = e.message_id and er.user_id = $1
+		where m.published_at is not null
+		  and (m.user_id = $1 or er.status in ($2, $3))
+		  and coalesce(e.ends_at, e.starts_at) >= $4
+		order by e.starts_at, e.message_id`,
+		userID, RSVPYes, RSVPMaybe, from)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return events, nil
+}
+
+func (r *eventRepo) SetRSVP(ctx context.Context, userID, messageID, status string) error {
+	now := common.CurrentTimestamp()
+	_, err := r.db.ExecContext(ctx, `
+		insert into event_rsvps(message_id, user_id, status, created_at, updated_at)
+		values ($1, $2, $3, $4, $4)
+		on conflict (message_id, user_id) do update set status = $3, updated_at = $4`,
+		messageID, userID, status, now)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+func (r *eventRepo) DueReminders(ctx context.Context, now, until time.Time) ([]Event, error) {
+	var events []Event
+	err := r.db.SelectContext(ctx, &events, `
+		select e.message_id, m.user_id, m.user_name, e.title, e.starts_at, e.ends_at, e.location, e.description,
+		       e.reminded_at, e.created_at, e.updated_at
+		from events e
+			inner join messages m on m.id = e.message_id
+		where e.reminded_at is null and e.starts_at > $1 and e.starts_at <= $2 and m.published_at is not null
+		order by e.starts_at, e.message_id`,
+		now, until)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	messageIDs := make([]string, len(events))
+	for i, event := range events {
+		messageIDs[i] = event.MessageID
+	}
+	query, args, err := sqlx.In(`
+		select message_id, user_id, status, updated_at
+		from event_rsvps
+		where message_id in (?) and status in (?, ?)
+		order by message_id, user_id`, messageIDs, RSVPYes, RSVPMaybe)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	var rsvps []EventRSVP
+	err = r.db.SelectContext(ctx, &rsvps, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+
+	eventRSVPs := make(map[string][]EventRSVP)
+	for _, rsvp := range rsvps {
+		eventRSVPs[rsvp.MessageID] = append(eventRSVPs[rsvp.MessageID], rsvp)
+	}
+	for i := range events {
+		events[i].RSVPs = eventRSVPs[events[i].MessageID]
+	}
+	return events, nil
+}
+
+func (r *eventRepo) MarkReminded(ctx context.Context, messageID string, remindedAt time.Time) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `
+		update events
+		set reminded_at = $1
+		where message_id = $2 and reminded_at is null`,
+		remindedAt, messageID)
+	if err != nil {
+		return false, merry.Wrap(err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, merry.Wrap(err)
+	}
+	return rowsAffected > 0, nil
+}
+
+func (r *eventRepo) CalendarFeedVersion(ctx context.Context, userID string) (int, error) {
+	var version int
+	err := r.db.GetContext(ctx, &version, "select version from calendar_feeds where user_id = $1", userID)
+	if err != nil {
+		if merry.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, merry.Wrap(err)
+	}
+	return version, nil
+}
+
+func (r *eventRepo) RotateCalendarFeed(ctx context.Context, userID string, createdAt time.Time) (int, error) {
+	var version int
+	err := r.db.GetContext(ctx, &version, `
+		insert into calendar_feeds(user_id, version, created_at)
+		values ($1, 1, $2)
+		on conflict (user_id) do update set version = calendar_feeds.version + 1, created_at = $2
+		returning version`,
+		userID, createdAt)
+	if err != nil {
+		return 0, merry.Wrap(err)
+	}
+	return version, nil
+}
@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+)
+
+type LimitRepo interface {
+	// LockUser holds a per-user lock until the transaction ends.
+	LockUser(ctx context.Context, tx *sqlx.Tx, userID string) error
+	PostCount(ctx context.Context, userID string, since time.Time) (int, error)
+	CommentCount(ctx context.Context, userID string, since time.Time) (int, error)
+	UploadedSize(ctx context.Context, userID string, since time.Time) (int64, error)
+}
+
+type limitRepo struct {
+	db *sqlx.DB
+}
+
+func NewLimits(db *sqlx.DB) LimitRepo {
+	return &limitRepo{
+		db: db,
+	}
+}
+
+func (r *limitRepo) LockUser(ctx context.Context, tx *sqlx.Tx, userID string) error {
+	_, err := tx.ExecContext(ctx, `select pg_advisory_xact_lock(hashtext('limits/' || $1))`, userID)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+// PostCount counts posts published since the given time and posts waiting to be published.
+func (r *limitRepo) PostCount(ctx context.Context, userID string, since time.Time) (int, error) {
+	var count int
+	err := r.db.GetContext(ctx, &count, `
+		select count(*)
+		from messages m
+		where m.user_id = $1 and m.parent_id is null
+		  and (m.published_at >= $2 or exists(select * from scheduled_messages sm where sm.message_id = m.id))`,
+		userID, since)
+	if err != nil {
+		return 0, merry.Wrap(err)
+	}
+	return count, nil
+}
+
+func (r *limitRepo) CommentCount(ctx context.Context, userID string, since time.Time) (int, error) {
+	var count int
+	err := r.db.GetContext(ctx, &count, `
+		select count(*)
+		from messages
+		where user_id = $1 and parent_id is not null and created_at >= $2`,
+		userID, since)
+	if err != nil {
+		return 0, merry.Wrap(err)
+	}
+	return count, nil
+}
+
+// UploadedSize sums the attached blobs, upload links that aren't used don't count.
+func (r *limitRepo) UploadedSize(ctx context.Context, userID string, since time.Time) (int64, error) {
+	var size int64
+	err := r.db.GetContext(ctx, &size, `
+		select coalesce(sum(size), 0)
+		from blobs
+		where user_id = $1 and attached_at is not null and created_at >= $2`,
+		userID, since)
+	if err != nil {
+		return 0, merry.Wrap(err)
+	}
+	return size, nil
+}
@@ -0,0 +1,66 @@
+package repo
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+func TestLimitRepo(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	messages := NewMessages(te.DB)
+	blobs := NewBlobs(te.DB)
+	limits := NewLimits(te.DB)
+
+	now := common.CurrentTimestamp()
+	add := func(id, parentID string, createdAt time.Time, published bool) {
+		require.Nil(t, common.RunInTransaction(te.Ctx, te.DB, func(tx *sqlx.Tx) error {
+			msg := Message{ID: id, UserID: "1", UserName: "user1", Text: id, CreatedAt: createdAt, UpdatedAt: createdAt}
+			if published {
+				msg.PublishedAt = sql.NullTime{Time: createdAt, Valid: true}
+			}
+			return messages.AddMessage(te.Ctx, tx, parentID, msg)
+		}))
+	}
+	add("old", "", now.Add(-time.Hour*25), true)
+	add("post", "", now, true)
+	add("draft", "", now, false)
+	add("scheduled", "", now.Add(-time.Hour*30), false)
+	require.Nil(t, common.RunInTransaction(te.Ctx, te.DB, func(tx *sqlx.Tx) error {
+		return messages.ScheduleMessage(te.Ctx, tx, "1", ScheduledMessage{MessageID: "scheduled", PublishAt: now.Add(time.Hour)})
+	}))
+	add("old-comment", "post", now.Add(-time.Hour*2), true)
+	add("comment", "post", now, true)
+
+	count, err := limits.PostCount(te.Ctx, "1", now.Add(-time.Hour*24))
+	require.Nil(t, err)
+	assert.Equal(t, 2, count, "old posts, drafts and comments aren't counted, scheduled posts are")
+
+	count, err = limits.CommentCount(te.Ctx, "1", now.Add(-time.Hour))
+	require.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	count, err = limits.PostCount(te.Ctx, "2", now.Add(-time.Hour*24))
+	require.Nil(t, err)
+	assert.Equal(t, 0, count)
+
+	require.Nil(t, common.RunInTransaction(te.Ctx, te.DB, func(tx *sqlx.Tx) error {
+		return blobs.ReserveBlob(te.Ctx, tx, "reserved", "1", 500)
+	}))
+	require.Nil(t, blobs.AddBlob(te.Ctx, "attached", "1", 300))
+	size, err := limits.UploadedSize(te.Ctx, "1", now.Add(-time.Hour*24))
+	require.Nil(t, err)
+	assert.Equal(t, int64(300), size, "reservations aren't counted")
+
+	size, err = limits.UploadedSize(te.Ctx, "1", common.CurrentTimestamp().Add(time.Second))
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), size)
+}
@@ -0,0 +1,210 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+type Attachment struct {
+	MessageID             string `db:"message_id"`
+	Conversation          bool   `db:"conversation"`
+	UserID                string `db:"user_id"`
+	AttachmentID          string `db:"attachment_id"`
+	AttachmentType        string `db:"attachment_type"`
+	AttachmentThumbnailID string `db:"attachment_thumbnail_id"`
+}
+
+type UnaccountedBlob struct {
+	BlobID    string    `db:"blob_id"`
+	UserID    string    `db:"user_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// MaintenanceRepo backs the admin CLI.
+type MaintenanceRepo interface {
+	MediaAttachments(ctx context.Context, withoutThumbnailOnly bool) ([]Attachment, error)
+	SetAttachmentThumbnail(ctx context.Context, attachment Attachment, attachmentThumbnailID string) error
+	ReferencedBlobs(ctx context.Context) (map[string]int64, error)
+	UnaccountedBlobs(ctx context.Context) ([]UnaccountedBlob, error)
+	AddAccountedBlob(ctx context.Context, blob UnaccountedBlob, size int64) error
+}
+
+type maintenanceRepo struct {
+	db *sqlx.DB
+}
+
+func NewMaintenance(db *sqlx.DB) MaintenanceRepo {
+	return &maintenanceRepo{
+		db: db,
+	}
+}
+
+// MediaAttachments returns image and video attachments of messages, comments and conversations.
+func (r *maintenanceRepo) MediaAttachments(ctx context.Context, withoutThumbnailOnly bool) ([]Attachment, error) {
+	var attachments []Attachment
+	err := r.db.SelectContext(ctx, &attachments, `
+		select message_id, conversation, user_id, attachment_id, attachment_type, attachment_thumbnail_id
+		from (
+			select id message_id, false conversation, user_id, attachment_id, attachment_type, attachment_thumbnail_id, created_at
+			from messages
+			union all
+			select id message_id, true conversation, user_id, attachment_id, attachment_type, attachment_thumbnail_id, created_at
+			from conversation_messages
+		) m
+		where attachment_id <> '' and (attachment_type like 'image/%' or attachment_type like 'video/%')
+			and (not $1 or attachment_thumbnail_id = '')
+		order by created_at`,
+		withoutThumbnailOnly)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return attachments, nil
+}
+
+// SetAttachmentThumbnail keeps updated_at, the message itself doesn't change.
+// A replaced thumbnail is queued for removal from the storage and no longer counts in the usage.
+// So is the new thumbnail if the message was deleted or its attachment changed in the meantime.
+func (r *maintenanceRepo) SetAttachmentThumbnail(ctx context.Context, attachment Attachment, attachmentThumbnailID string) error {
+	table := "messages"
+	if attachment.Conversation {
+		table = "conversation_messages"
+	}
+	return common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		var oldThumbnailID string
+		err := tx.GetContext(ctx, &oldThumbnailID, `
+			select attachment_thumbnail_id
+			from `+table+`
+			where id = $1 and attachment_id = $2
+			for update`,
+			attachment.MessageID, attachment.AttachmentID)
+		if err != nil {
+			if merry.Is(err, sql.ErrNoRows) {
+				if attachmentThumbnailID == attachment.AttachmentID {
+					return nil
+				}
+				return removeUnreferencedBlob(ctx, tx, attachmentThumbnailID)
+			}
+			return merry.Wrap(err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			update `+table+`
+			set attachment_thumbnail_id = $1
+			where id = $2 and attachment_id = $3`,
+			attachmentThumbnailID, attachment.MessageID, attachment.AttachmentID)
+		if err != nil {
+			return merry.Wrap(err)
+		}
+
+		if oldThumbnailID != attachment.AttachmentID && oldThumbnailID != attachmentThumbnailID {
+			return removeUnreferencedBlob(ctx, tx, oldThumbnailID)
+		}
+		return nil
+	})
+}
+
+// removeUnreferencedBlob queues the blob for removal from the storage and deletes its size from the usage,
+// unless a message still refers to it.
+func removeUnreferencedBlob(ctx context.Context, tx *sqlx.Tx, blobID string) error {
+	if blobID == "" {
+		return nil
+	}
+	var referenced bool
+	err := tx.GetContext(ctx, &referenced, `
+		select exists(select * from messages where attachment_id = $1 or attachment_thumbnail_id = $1)
+		    or exists(select * from conversation_messages where attachment_id = $1 or attachment_thumbnail_id = $1)`,
+		blobID)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	if referenced {
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		delete from blobs
+		where id = $1`,
+		blobID)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		insert into blob_pending_deletes(blob_id, deleted_at)
+		values ($1, $2)`,
+		blobID, common.CurrentTimestamp())
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+// ReferencedBlobs returns attached and accounted blobs with their recorded sizes, -1 if the size isn't recorded.
+// Reservations of pending uploads are skipped.
+func (r *maintenanceRepo) ReferencedBlobs(ctx context.Context) (map[string]int64, error) {
+	var blobs []struct {
+		ID   string `db:"id"`
+		Size int64  `db:"size"`
+	}
+	err := r.db.SelectContext(ctx, &blobs, `
+		select r.id, coalesce(b.size, -1) size
+		from (
+			select attachment_id id from messages
+			union
+			select attachment_thumbnail_id from messages
+			union
+			select attachment_id from conversation_messages
+			union
+			select attachment_thumbnail_id from conversation_messages
+			union
+			select id from blobs where attached_at is not null
+		) r
+			left join blobs b on b.id = r.id and b.attached_at is not null
+		where r.id <> ''`)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+
+	sizes := make(map[string]int64, len(blobs))
+	for _, blob := range blobs {
+		sizes[blob.ID] = blob.Size
+	}
+	return sizes, nil
+}
+
+// UnaccountedBlobs returns attachments and thumbnails without a size in blobs, e.g. attached before sizes were recorded.
+func (r *maintenanceRepo) UnaccountedBlobs(ctx context.Context) ([]UnaccountedBlob, error) {
+	var blobs []UnaccountedBlob
+	err := r.db.SelectContext(ctx, &blobs, `
+		select distinct on (a.blob_id) a.blob_id, a.user_id, a.created_at
+		from (
+			select attachment_id blob_id, user_id, created_at from messages
+			union all
+			select attachment_thumbnail_id, user_id, created_at from messages
+			union all
+			select attachment_id, user_id, created_at from conversation_messages
+			union all
+			select attachment_thumbnail_id, user_id, created_at from conversation_messages
+		) a
+		where a.blob_id <> '' and not exists(select * from blobs b where b.id = a.blob_id)
+		order by a.blob_id, a.created_at`)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return blobs, nil
+}
+
+// AddAccountedBlob records the blob as attached when its message was created.
+func (r *maintenanceRepo) AddAccountedBlob(ctx context.Context, blob UnaccountedBlob, size int64) error {
+	_, err := r.db.ExecContext(ctx, `
+		insert into blobs(id, user_id, size, created_at, attached_at)
+		values ($1, $2, $3, $4, $4)
+		on conflict (id) do nothing`,
+		blob.BlobID, blob.UserID, size, blob.CreatedAt)
+	return merry.Wrap(err)
+}
@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+func TestMaintenanceRepo_SetAttachmentThumbnail(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	messages := NewMessages(te.DB)
+	blobs := NewBlobs(te.DB)
+	maintenance := NewMaintenance(te.DB)
+	now := time.Now()
+	add := func(id, attachmentID, attachmentType, attachmentThumbnailID string) {
+		require.Nil(t, common.RunInTransaction(te.Ctx, te.DB, func(tx *sqlx.Tx) error {
+			return messages.AddMessage(te.Ctx, tx, "", Message{
+				ID:                    id,
+				UserID:                "1",
+				UserName:              "user1",
+				AttachmentID:          attachmentID,
+				AttachmentType:        attachmentType,
+				AttachmentThumbnailID: attachmentThumbnailID,
+				CreatedAt:             now,
+				UpdatedAt:             now,
+				PublishedAt:           sql.NullTime{Time: now, Valid: true},
+			})
+		}))
+	}
+	add("video", "video.mp4", "video/mp4", "video-uploaded.jpg")
+	add("image", "image.jpg", "image/jpeg", "image.jpg")
+	add("regenerated", "clip.mp4", "video/mp4", "clip-thumbnail.jpg")
+	require.Nil(t, blobs.AddBlob(te.Ctx, "video-uploaded.jpg", "1", 10))
+
+	attachments, err := maintenance.MediaAttachments(te.Ctx, false)
+	require.Nil(t, err)
+	require.Len(t, attachments, 3)
+	for _, attachment := range attachments {
+		attachmentThumbnailID := strings.TrimSuffix(attachment.AttachmentID, filepath.Ext(attachment.AttachmentID)) + "-thumbnail.jpg"
+		require.Nil(t, maintenance.SetAttachmentThumbnail(te.Ctx, attachment, attachmentThumbnailID))
+	}
+
+	pending, err := common.NewS3Cleaner(te.DB, nil, nil).Pending(te.Ctx)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"video-uploaded.jpg"}, pending,
+		"only the replaced thumbnail is removed, not the image itself or a thumbnail that is overwritten in place")
+	usage, err := blobs.UserUsage(te.Ctx, "1")
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), usage.Size, "the replaced thumbnail doesn't count in the usage")
+
+	var thumbnailID string
+	require.Nil(t, te.DB.Get(&thumbnailID, "select attachment_thumbnail_id from messages where id = 'video'"))
+	assert.Equal(t, "video-thumbnail.jpg", thumbnailID)
+
+	// the message is deleted while its thumbnail is created
+	require.Nil(t, messages.DeleteMessage(te.Ctx, "1", "video"))
+	require.Nil(t, blobs.AddBlob(te.Ctx, "video-thumbnail-2.jpg", "1", 20))
+	video := Attachment{MessageID: "video", UserID: "1", AttachmentID: "video.mp4", AttachmentType: "video/mp4"}
+	require.Nil(t, maintenance.SetAttachmentThumbnail(te.Ctx, video, "video-thumbnail-2.jpg"))
+
+	pending, err = common.NewS3Cleaner(te.DB, nil, nil).Pending(te.Ctx)
+	require.Nil(t, err)
+	assert.Contains(t, pending, "video-thumbnail-2.jpg", "the thumbnail of a deleted message is removed")
+	usage, err = blobs.UserUsage(te.Ctx, "1")
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), usage.Size)
+}
@@ -1,15 +1,21 @@
 package repo
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
 	"github.com/ansel1/merry"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"github.com/mreider/koto/backend/common"
 )
 
+const (
+	LikeReaction = "👍"
+)
+
 var (
 	ErrMessageNotFound = common.ErrNotFound.WithMessage("message not found")
 
@@ -20,37 +26,60 @@ var (
 type Message struct {
 	ID                    string         `json:"id" db:"id"`
 	ParentID              sql.NullString `json:"parent_id" db:"parent_id"`
+	ReplyToID             sql.NullString `json:"reply_to_id" db:"reply_to_id"`
+	Depth                 int            `json:"depth" db:"depth"`
 	UserID                string         `json:"user_id" db:"user_id"`
 	UserName              string         `json:"user_name" db:"user_name"`
 	Text                  string         `json:"text" db:"text"`
 	AttachmentID          string         `json:"attachment_id" db:"attachment_id"`
 	AttachmentType        string         `json:"attachment_type" db:"attachment_type"`
 	AttachmentThumbnailID string         `json:"attachment_thumbnail_id" db:"attachment_thumbnail_id"`
+	MediaExpired          bool           `json:"media_expired" db:"media_expired"`
 	CreatedAt             time.Time      `json:"created_at" db:"created_at"`
 	UpdatedAt             time.Time      `json:"updated_at" db:"updated_at"`
 	Likes                 int            `json:"likes" db:"likes"`
 	LikedByMe             bool           `json:"liked_by_me" db:"liked_by_me"`
+	PublishedAt           sql.NullTime   `json:"published_at" db:"published_at"`
+	IsDraft               bool           `json:"is_draft" db:"is_draft"`
+	PublishAt             sql.NullTime   `json:"publish_at" db:"publish_at"`
+}
+
+// ScheduledMessage keeps the friends and the expiry of the post-message token the message was scheduled with.
+// They are null for the messages scheduled before they were stored.
+type ScheduledMessage struct {
+	MessageID      string         `json:"message_id" db:"message_id"`
+	PublishAt      time.Time      `json:"publish_at" db:"publish_at"`
+	FriendIDs      pq.StringArray `json:"friend_ids" db:"friend_ids"`
+	TokenExpiresAt sql.NullTime   `json:"token_expires_at" db:"token_expires_at"`
 }
 
-type MessageLike struct {
+type MessageReaction struct {
 	MessageID string    `json:"message_id" db:"message_id"`
 	UserID    string    `json:"user_id" db:"user_id"`
 	UserName  string    `json:"user_name" db:"user_name"`
+	Reaction  string    `json:"reaction" db:"reaction"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
 
 type MessageRepo interface {
-	Messages(currentUserID string, userIDs []string, from time.Time, count int) ([]Message, error)
-	Message(currentUserID string, messageID string) (Message, error)
-	AddMessage(parentID string, message Message) error
-	EditMessageText(userID, messageID, text string, updatedAt time.Time) error
-	EditMessageAttachment(userID, messageID, attachmentID, attachmentType, attachmentThumbnailID string, updatedAt time.Time) error
-	DeleteMessage(userID, messageID string) error
-	Comments(currentUserID string, messageIDs []string) (map[string][]Message, error)
-	LikeMessage(userID, messageID string) (likes int, err error)
-	MessagesLikes(messageIDs []string) (likes map[string][]MessageLike, err error)
-	MessageLikes(messageID string) (likes []MessageLike, err error)
-	SetMessageVisibility(userID, messageID string, visibility bool) error
+	Messages(ctx context.Context, currentUserID string, userIDs []string, from time.Time, count int) ([]Message, error)
+	Message(ctx context.Context, currentUserID string, messageID string) (Message, error)
+	AddMessage(ctx context.Context, tx *sqlx.Tx, parentID string, message Message) error
+	EditMessageText(ctx context.Context, userID, messageID, text string, updatedAt time.Time) error
+	EditMessageAttachment(ctx context.Context, userID, messageID, attachmentID, attachmentType, attachmentThumbnailID string, updatedAt time.Time) error
+	DeleteMessage(ctx context.Context, userID, messageID string) error
+	Comments(ctx context.Context, currentUserID string, messageIDs []string) (map[string][]Message, error)
+	LikeMessage(ctx context.Context, userID, messageID string) (likes int, err error)
+	AddReaction(ctx context.Context, userID, messageID, reaction string) error
+	RemoveReaction(ctx context.Context, userID, messageID, reaction string) error
+	MessagesReactions(ctx context.Context, messageIDs []string) (reactions map[string][]MessageReaction, err error)
+	MessageReactions(ctx context.Context, messageID string) (reactions []MessageReaction, err error)
+	SetMessageVisibility(ctx context.Context, userID, messageID string, visibility bool) error
+	Drafts(ctx context.Context, userID string) ([]Message, error)
+	ScheduleMessage(ctx context.Context, tx *sqlx.Tx, userID string, schedule ScheduledMessage) error
+	MoveToDrafts(ctx context.Context, userID, messageID string) error
+	DueScheduledMessages(ctx context.Context, now time.Time) ([]ScheduledMessage, error)
+	PublishMessage(ctx context.Context, tx *sqlx.Tx, messageID string, publishedAt time.Time) (bool, error)
 }
 
 type messageRepo struct {
@@ -63,7 +92,7 @@ func NewMessages(db *sqlx.DB) MessageRepo {
 	}
 }
 
-func (r *messageRepo) Messages(currentUserID string, userIDs []string, from time.Time, count int) ([]Message, error) {
+func (r *messageRepo) Messages(ctx context.Context, currentUserID string, userIDs []string, from time.Time, count int) ([]Message, error) {
 	if len(userIDs) == 0 {
 		return nil, nil
 	}
@@ -77,35 +106,36 @@ func (r *messageRepo) Messages(currentUserID string, userIDs []string, from time
 
 	var messages []Message
 	query, args, err := sqlx.In(`
-			select id, parent_id, user_id, user_name, text, attachment_id, attachment_type, attachment_thumbnail_id, created_at, updated_at,
-				   (select count(*) from message_likes where message_id = m.id) likes,
-				   case when exists(select * from message_likes where message_id = m.id and user_id = ?) then true else false end liked_by_me
+			select id, parent_id, reply_to_id, depth, user_id, user_name, text, attachment_id, attachment_type, attachment_thumbnail_id, media_expired, created_at, updated_at, published_at, is_draft,
+				   (select count(*) from message_reactions where message_id = m.id and reaction = ?) likes,
+				   case when exists(select * from message_reactions where message_id = m.id and user_id = ? and reaction = ?) then true else false end liked_by_me
 			from messages m
-			where user_id in (?) and parent_id is null
+			where user_id in (?) and parent_id is null and published_at is not null
 				and created_at < ?
 				and not exists(select * from message_visibility mv where mv.user_id = ? and mv.message_id = m.id and mv.visibility = false)
 			order by created_at desc, "id"
 			limit ?`,
-		currentUserID, userIDs, from, currentUserID, count)
+		LikeReaction, currentUserID, LikeReaction, userIDs, from, currentUserID, count)
 	if err != nil {
 		return nil, merry.Wrap(err)
 	}
 	query = r.db.Rebind(query)
-	err = r.db.Select(&messages, query, args...)
+	err = r.db.SelectContext(ctx, &messages, query, args...)
 	if err != nil {
 		return nil, merry.Wrap(err)
 	}
 	return messages, nil
 }
 
-func (r *messageRepo) Message(currentUserID string, messageID string) (Message, error) {
+func (r *messageRepo) Message(ctx context.Context, currentUserID string, messageID string) (Message, error) {
 	var message Message
-	err := r.db.Get(&message, `
-		select id, parent_id, user_id, user_name, text, attachment_id, attachment_type, attachment_thumbnail_id, created_at, updated_at,
-		       (select count(*) from message_likes where message_id = messages.id) likes,
-		       case when exists(select * from message_likes where message_id = messages.id and user_id = $1) then true else false end liked_by_me
+	err := r.db.GetContext(ctx, &message, `
+		select id, parent_id, reply_to_id, depth, user_id, user_name, text, attachment_id, attachment_type, attachment_thumbnail_id, media_expired, created_at, updated_at,
+		       published_at, is_draft, (select publish_at from scheduled_messages where message_id = messages.id) publish_at,
+		       (select count(*) from message_reactions where message_id = messages.id and reaction = $1) likes,
+		       case when exists(select * from message_reactions where message_id = messages.id and user_id = $2 and reaction = $1) then true else false end liked_by_me
 		from messages
-		where id = $2`, currentUserID, messageID)
+		where id = $3`, LikeReaction, currentUserID, messageID)
 	if err != nil {
 		if merry.Is(err, sql.ErrNoRows) {
 			return message, ErrMessageNotFound.Here()
@@ -115,23 +145,24 @@ func (r *messageRepo) Message(currentUserID string, messageID string) (Message,
 	return message, nil
 }
 
-func (r *messageRepo) AddMessage(parentID string, message Message) error {
-	_, err := r.db.Exec(`
-		insert into messages(id, parent_id, user_id, user_name, text, attachment_id, attachment_type, attachment_thumbnail_id, created_at, updated_at)
-		select $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+func (r *messageRepo) AddMessage(ctx context.Context, tx *sqlx.Tx, parentID string, message Message) error {
+	_, err := tx.ExecContext(ctx, `
+		insert into messages(id, parent_id, reply_to_id, depth, user_id, user_name, text, attachment_id, attachment_type, attachment_thumbnail_id, created_at, updated_at,
+		                     published_at, is_draft)
+		select $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
 		where not exists(select * from messages where id = $1)`,
-		message.ID, sql.NullString{String: parentID, Valid: parentID != ""},
+		message.ID, sql.NullString{String: parentID, Valid: parentID != ""}, message.ReplyToID, message.Depth,
 		message.UserID, message.UserName,
 		message.Text, message.AttachmentID, message.AttachmentType, message.AttachmentThumbnailID,
-		message.CreatedAt, message.UpdatedAt)
+		message.CreatedAt, message.UpdatedAt, message.PublishedAt, message.IsDraft)
 	if err != nil {
 		return merry.Wrap(err)
 	}
 	return nil
 }
 
-func (r *messageRepo) EditMessageText(userID, messageID, text string, updatedAt time.Time) error {
-	res, err := r.db.Exec(`
+func (r *messageRepo) EditMessageText(ctx context.Context, userID, messageID, text string, updatedAt time.Time) error {
+	res, err := r.db.ExecContext(ctx, `
 		update messages
 		set text = $1, updated_at = $2
 		where id = $3 and user_id = $4`,
@@ -149,16 +180,16 @@ func (r *messageRepo) EditMessageText(userID, messageID, text string, updatedAt
 	return nil
 }
 
-func (r *messageRepo) EditMessageAttachment(userID, messageID, attachmentID, attachmentType, attachmentThumbnailID string, updatedAt time.Time) error {
-	return common.RunInTransaction(r.db, func(tx *sqlx.Tx) error {
+func (r *messageRepo) EditMessageAttachment(ctx context.Context, userID, messageID, attachmentID, attachmentType, attachmentThumbnailID string, updatedAt time.Time) error {
+	return common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
 		var message Message
-		err := tx.Get(&message, "select attachment_id, attachment_thumbnail_id from messages where id = $1 and user_id = $2",
+		err := tx.GetContext(ctx, &message, "select attachment_id, attachment_thumbnail_id from messages where id = $1 and user_id = $2",
 			messageID, userID)
 		if err != nil && !merry.Is(err, sql.ErrNoRows) {
 			return merry.Wrap(err)
 		}
 		if message.AttachmentID != "" && message.AttachmentID != attachmentID {
-			_, err = tx.Exec(`
+			_, err = tx.ExecContext(ctx, `
 				insert into blob_pending_deletes(blob_id, deleted_at)
 				values ($1, $2)`,
 				message.AttachmentID, updatedAt)
@@ -167,7 +198,7 @@ func (r *messageRepo) EditMessageAttachment(userID, messageID, attachmentID, att
 			}
 		}
 		if message.AttachmentThumbnailID != "" && message.AttachmentThumbnailID != message.AttachmentID && message.AttachmentThumbnailID != attachmentThumbnailID {
-			_, err = tx.Exec(`
+			_, err = tx.ExecContext(ctx, `
 				insert into blob_pending_deletes(blob_id, deleted_at)
 				values ($1, $2)`,
 				message.AttachmentThumbnailID, updatedAt)
@@ -176,9 +207,9 @@ func (r *messageRepo) EditMessageAttachment(userID, messageID, attachmentID, att
 			}
 		}
 
-		res, err := r.db.Exec(`
+		res, err := r.db.ExecContext(ctx, `
 		update messages
-		set attachment_id = $1, attachment_type = $2, attachment_thumbnail_id = $3, updated_at = $4
+		set attachment_id = $1, attachment_type = $2, attachment_thumbnail_id = $3, media_expired = false, updated_at = $4
 		where id = $5 and user_id = $6`,
 			attachmentID, attachmentType, attachmentThumbnailID, updatedAt, messageID, userID)
 		if err != nil {
@@ -195,18 +226,36 @@ func (r *messageRepo) EditMessageAttachment(userID, messageID, attachmentID, att
 	})
 }
 
-func (r *messageRepo) DeleteMessage(userID, messageID string) error {
-	return common.RunInTransaction(r.db, func(tx *sqlx.Tx) error {
+func (r *messageRepo) DeleteMessage(ctx context.Context, userID, messageID string) error {
+	return common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
 		var messages []Message
-		err := tx.Select(&messages, "select attachment_id, attachment_thumbnail_id from messages where (id = $1 and user_id = $2) or parent_id = $1",
+		err := tx.SelectContext(ctx, &messages, `
+			with recursive subtree(id) as (
+				select id
+				from messages
+				where id = $1 and user_id = $2
+				union
+				select m.id
+				from messages m
+					inner join subtree s on m.parent_id = s.id or m.reply_to_id = s.id
+			)
+			select id, attachment_id, attachment_thumbnail_id
+			from messages
+			where id in (select id from subtree)`,
 			messageID, userID)
 		if err != nil {
 			return merry.Wrap(err)
 		}
+		if len(messages) == 0 {
+			return ErrMessageNotFound.Here()
+		}
+
 		now := common.CurrentTimestamp()
-		for _, msg := range messages {
+		messageIDs := make([]string, len(messages))
+		for i, msg := range messages {
+			messageIDs[i] = msg.ID
 			if msg.AttachmentID != "" {
-				_, err = tx.Exec(`
+				_, err = tx.ExecContext(ctx, `
 				insert into blob_pending_deletes(blob_id, deleted_at)
 				values ($1, $2)`,
 					msg.AttachmentID, now)
@@ -215,7 +264,7 @@ func (r *messageRepo) DeleteMessage(userID, messageID string) error {
 				}
 			}
 			if msg.AttachmentThumbnailID != "" && msg.AttachmentThumbnailID != msg.AttachmentID {
-				_, err = tx.Exec(`
+				_, err = tx.ExecContext(ctx, `
 				insert into blob_pending_deletes(blob_id, deleted_at)
 				values ($1, $2)`,
 					msg.AttachmentThumbnailID, now)
@@ -225,77 +274,50 @@ func (r *messageRepo) DeleteMessage(userID, messageID string) error {
 			}
 		}
 
-		_, err = tx.Exec(`
-		delete from message_likes
-		where message_id in (
-		    select id     
-		    from messages
-			where parent_id = $1
-		  		and (select user_id from messages where messages.id = $1) = $2)`,
-			messageID, userID)
-		if err != nil {
-			return merry.Wrap(err)
-		}
-
-		_, err = tx.Exec(`
-		delete from messages
-		where parent_id = $1
-		  and (select user_id from messages where messages.id = $1) = $2`,
-			messageID, userID)
-		if err != nil {
-			return merry.Wrap(err)
-		}
-
-		_, err = tx.Exec(`
-		delete from message_likes
-		where message_id in (
-		    select id
-		    from messages
-			where id = $1 and user_id = $2)`,
-			messageID, userID)
-		if err != nil {
-			return merry.Wrap(err)
+		for _, table := range []string{"message_reactions", "message_visibility", "scheduled_messages", "poll_votes", "poll_options", "polls", "event_rsvps", "events"} {
+			query, args, err := sqlx.In("delete from "+table+" where message_id in (?)", messageIDs)
+			if err != nil {
+				return merry.Wrap(err)
+			}
+			_, err = tx.ExecContext(ctx, tx.Rebind(query), args...)
+			if err != nil {
+				return merry.Wrap(err)
+			}
 		}
 
-		res, err := tx.Exec(`
-		delete from messages
-		where id = $1 and user_id = $2`,
-			messageID, userID)
+		query, args, err := sqlx.In("delete from messages where id in (?)", messageIDs)
 		if err != nil {
 			return merry.Wrap(err)
 		}
-		rowsAffected, err := res.RowsAffected()
+		_, err = tx.ExecContext(ctx, tx.Rebind(query), args...)
 		if err != nil {
 			return merry.Wrap(err)
 		}
-		if rowsAffected < 1 {
-			return ErrMessageNotFound.Here()
-		}
 
 		return nil
 	})
 }
 
-func (r *messageRepo) Comments(currentUserID string, messageIDs []string) (map[string][]Message, error) {
+func (r *messageRepo) Comments(ctx context.Context, currentUserID string, messageIDs []string) (map[string][]Message, error) {
 	if len(messageIDs) == 0 {
 		return nil, nil
 	}
 
 	var comments []Message
 	query, args, err := sqlx.In(`
-			select id, parent_id, user_id, user_name, text, attachment_id, attachment_type, attachment_thumbnail_id, created_at, updated_at,
-				   (select count(*) from message_likes where message_id = m.id) likes,
-				   case when exists(select * from message_likes where message_id = m.id and user_id = ?) then true else false end liked_by_me
+			select id, parent_id, reply_to_id, depth, user_id, user_name, text, attachment_id, attachment_type, attachment_thumbnail_id, media_expired, created_at, updated_at, published_at, is_draft,
+				   (select count(*) from message_reactions where message_id = m.id and reaction = ?) likes,
+				   case when exists(select * from message_reactions where message_id = m.id and user_id = ? and reaction = ?) then true else false end liked_by_me
 			from messages m
 			where parent_id in (?)
 				and not exists(select * from message_visibility mv where mv.user_id = ? and mv.message_id = m.id and mv.visibility = false)
 			order by created_at, id`,
-		currentUserID, messageIDs, currentUserID)
+		LikeReaction, currentUserID, LikeReaction, messageIDs, currentUserID)
 	if err != nil {
 		return nil, merry.Wrap(err)
 	}
 	query = r.db.Rebind(query)
-	err = r.db.Select(&comments, query, args...)
+	err = r.db.SelectContext(ctx, &comments, query, args...)
 	if err != nil {
 		return nil, merry.Wrap(err)
 	}
@@ -304,75 +326,121 @@ func (r *messageRepo) Comments(currentUserID string, messageIDs []string) (map[s
 	for _, comment := range comments {
 		result[comment.ParentID.String] = append(result[comment.ParentID.String], comment)
 	}
+	for messageID, messageComments := range result {
+		result[messageID] = threadComments(messageComments)
+	}
 	return result, nil
 }
 
-func (r *messageRepo) LikeMessage(userID, messageID string) (likes int, err error) {
-	_, err = r.db.Exec(`
-		insert into message_likes(message_id, user_id, created_at)
-		select $1, $2, $3
-		where not exists(select * from message_likes where message_id = $1 and user_id = $2)`,
-		messageID, userID, common.CurrentTimestamp())
+// threadComments orders comments depth-first so that every reply follows the comment it replies to.
+// Replies to comments that are not in the list (e.g. hidden ones) are kept at the top level.
+func threadComments(comments []Message) []Message {
+	commentIDs := make(map[string]bool, len(comments))
+	for _, comment := range comments {
+		commentIDs[comment.ID] = true
+	}
+
+	var roots []Message
+	replies := make(map[string][]Message)
+	for _, comment := range comments {
+		if comment.ReplyToID.Valid && commentIDs[comment.ReplyToID.String] {
+			replies[comment.ReplyToID.String] = append(replies[comment.ReplyToID.String], comment)
+		} else {
+			roots = append(roots, comment)
+		}
+	}
+
+	result := make([]Message, 0, len(comments))
+	var walk func(comment Message)
+	walk = func(comment Message) {
+		result = append(result, comment)
+		for _, reply := range replies[comment.ID] {
+			walk(reply)
+		}
+	}
+	for _, root := range roots {
+		walk(root)
+	}
+	return result
+}
+
+func (r *messageRepo) LikeMessage(ctx context.Context, userID, messageID string) (likes int, err error) {
+	err = r.AddReaction(ctx, userID, messageID, LikeReaction)
 	if err != nil {
 		return -1, merry.Wrap(err)
 	}
-	err = r.db.Get(&likes, "select count(*) from message_likes where message_id = $1", messageID)
+	err = r.db.GetContext(ctx, &likes, "select count(*) from message_reactions where message_id = $1 and reaction = $2", messageID, LikeReaction)
 	if err != nil {
 		return -1, merry.Wrap(err)
 	}
 	return likes, nil
 }
 
-func (r *messageRepo) MessagesLikes(messageIDs []string) (likes map[string][]MessageLike, err error) {
+func (r *messageRepo) AddReaction(ctx context.Context, userID, messageID, reaction string) error {
+	_, err := r.db.ExecContext(ctx, `
+		insert into message_reactions(message_id, user_id, reaction, created_at)
+		select $1, $2, $3, $4
+		where not exists(select * from message_reactions where message_id = $1 and user_id = $2 and reaction = $3)`,
+		messageID, userID, reaction, common.CurrentTimestamp())
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+func (r *messageRepo) RemoveReaction(ctx context.Context, userID, messageID, reaction string) error {
+	_, err := r.db.ExecContext(ctx, `
+		delete from message_reactions
+		where message_id = $1 and user_id = $2 and reaction = $3`,
+		messageID, userID, reaction)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+func (r *messageRepo) MessagesReactions(ctx context.Context, messageIDs []string) (reactions map[string][]MessageReaction, err error) {
 	if len(messageIDs) == 0 {
 		return nil, nil
 	}
 
-	var plainLikes []MessageLike
+	var plainReactions []MessageReaction
 	query, args, err := sqlx.In(`
-		select ml.message_id, ml.user_id, u.name user_name, ml.created_at
-		from message_likes ml
-			inner join users u on u.id = ml.user_id
-		where ml.message_id in (?)
-		order by ml.message_id, ml.created_at`, messageIDs)
+		select mr.message_id, mr.user_id, u.name user_name, mr.reaction, mr.created_at
+		from message_reactions mr
+			inner join users u on u.id = mr.user_id
+		where mr.message_id in (?)
+		order by mr.message_id, mr.created_at`, messageIDs)
 	if err != nil {
 		return nil, merry.Wrap(err)
 	}
 	query = r.db.Rebind(query)
 
-	err = r.db.Select(&plainLikes, query, args...)
+	err = r.db.SelectContext(ctx, &plainReactions, query, args...)
 	if err != nil {
 		return nil, merry.Wrap(err)
 	}
-	if len(plainLikes) == 0 {
+	if len(plainReactions) == 0 {
 		return nil, nil
 	}
-	likes = make(map[string][]MessageLike)
-	for _, like := range plainLikes {
-		likes[like.MessageID] = append(likes[like.MessageID], like)
+	reactions = make(map[string][]MessageReaction)
+	for _, reaction := range plainReactions {
+		reactions[reaction.MessageID] = append(reactions[reaction.MessageID], reaction)
 	}
-	return likes, nil
+	return reactions, nil
 }
 
-func (r *messageRepo) MessageLikes(messageID string) (likes []MessageLike, err error) {
-	allLikes, err := r.MessagesLikes([]string{messageID})
+func (r *messageRepo) MessageReactions(ctx context.Context, messageID string) (reactions []MessageReaction, err error) {
+	allReactions, err := r.MessagesReactions(ctx, []string{messageID})
 	if err != nil {
 		return nil, merry.Wrap(err)
 	}
-	if len(allLikes) == 0 {
-		return nil, nil
-	}
-
-	for _, messageLikes := range allLikes {
-		return messageLikes, nil
-	}
-
-	return likes, nil
+	return allReactions[messageID], nil
 }
 
-func (r *messageRepo) SetMessageVisibility(userID, messageID string, visibility bool) error {
+func (r *messageRepo) SetMessageVisibility(ctx context.Context, userID, messageID string, visibility bool) error {
 	if visibility {
-		_, err := r.db.Exec(`
+		_, err := r.db.ExecContext(ctx, `
 			delete from message_visibility
 			where user_id = $1 and message_id = $2;`,
 			userID, messageID)
@@ -380,7 +448,7 @@ func (r *messageRepo) SetMessageVisibility(userID, messageID string, visibility
 			return merry.Wrap(err)
 		}
 	} else {
-		_, err := r.db.Exec(`
+		_, err := r.db.ExecContext(ctx, `
 			insert into message_visibility(user_id, message_id, visibility, created_at)
 			select $1, $2, false, $3
 			where not exists(select * from message_visibility where user_id = $1 and message_id = $2)`,
@@ -391,3 +459,114 @@ func (r *messageRepo) SetMessageVisibility(userID, messageID string, visibility
 	}
 	return nil
 }
+
+func (r *messageRepo) Drafts(ctx context.Context, userID string) ([]Message, error) {
+	var messages []Message
+	err := r.db.SelectContext(ctx, &messages, `
+		select m.id, m.user_id, m.user_name, m.text, m.attachment_id, m.attachment_type, m.attachment_thumbnail_id,
+		       m.created_at, m.updated_at, m.published_at, m.is_draft, sm.publish_at
+		from messages m
+			left join scheduled_messages sm on sm.message_id = m.id
+		where m.user_id = $1 and m.parent_id is null and m.published_at is null
+		order by coalesce(sm.publish_at, m.updated_at) desc, m.id`,
+		userID)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return messages, nil
+}
+
+func (r *messageRepo) ScheduleMessage(ctx context.Context, tx *sqlx.Tx, userID string, schedule ScheduledMessage) error {
+	res, err := tx.ExecContext(ctx, `
+		update messages
+		set is_draft = false
+		where id = $1 and user_id = $2 and published_at is null`,
+		schedule.MessageID, userID)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	if rowsAffected < 1 {
+		return ErrMessageNotFound.Here()
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		insert into scheduled_messages(message_id, publish_at, friend_ids, token_expires_at)
+		values ($1, $2, $3, $4)
+		on conflict (message_id) do update set publish_at = $2, friend_ids = $3, token_expires_at = $4`,
+		schedule.MessageID, schedule.PublishAt, schedule.FriendIDs, schedule.TokenExpiresAt)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+func (r *messageRepo) MoveToDrafts(ctx context.Context, userID, messageID string) error {
+	return common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		res, err := tx.ExecContext(ctx, `
+			update messages
+			set is_draft = true
+			where id = $1 and user_id = $2 and published_at is null`,
+			messageID, userID)
+		if err != nil {
+			return merry.Wrap(err)
+		}
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return merry.Wrap(err)
+		}
+		if rowsAffected < 1 {
+			return ErrMessageNotFound.Here()
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			delete from scheduled_messages
+			where message_id = $1`,
+			messageID)
+		if err != nil {
+			return merry.Wrap(err)
+		}
+		return nil
+	})
+}
+
+func (r *messageRepo) DueScheduledMessages(ctx context.Context, now time.Time) ([]ScheduledMessage, error) {
+	var messages []ScheduledMessage
+	err := r.db.SelectContext(ctx, &messages, `
+		select message_id, publish_at, friend_ids, token_expires_at
+		from scheduled_messages
+		where publish_at <= $1
+		order by publish_at, message_id`,
+		now)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return messages, nil
+}
+
+func (r *messageRepo) PublishMessage(ctx context.Context, tx *sqlx.Tx, messageID string, publishedAt time.Time) (published bool, err error) {
+	res, err := tx.ExecContext(ctx, `
+		update messages
+		set published_at = $1, created_at = $1, updated_at = $1, is_draft = false
+		where id = $2 and published_at is null`,
+		publishedAt, messageID)
+	if err != nil {
+		return false, merry.Wrap(err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, merry.Wrap(err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		delete from scheduled_messages
+		where message_id = $1`,
+		messageID)
+	if err != nil {
+		return false, merry.Wrap(err)
+	}
+	return rowsAffected > 0, nil
+}
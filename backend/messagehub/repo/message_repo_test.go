@@ -0,0 +1,100 @@
+package repo
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+func TestThreadComments(t *testing.T) {
+	comment := func(id, replyToID string) Message {
+		return Message{ID: id, ReplyToID: sql.NullString{String: replyToID, Valid: replyToID != ""}}
+	}
+	ids := func(comments []Message) []string {
+		result := make([]string, len(comments))
+		for i, c := range comments {
+			result[i] = c.ID
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		comments []Message
+		expected []string
+	}{
+		{"empty", nil, []string{}},
+		{"flat", []Message{comment("1", ""), comment("2", "")}, []string{"1", "2"}},
+		{
+			"nested replies follow their comment",
+			[]Message{comment("1", ""), comment("2", ""), comment("3", "1"), comment("4", "3"), comment("5", "1"), comment("6", "2")},
+			[]string{"1", "3", "4", "5", "2", "6"},
+		},
+		{
+			"replies to missing comments stay at the top level",
+			[]Message{comment("1", ""), comment("2", "deleted"), comment("3", "2")},
+			[]string{"1", "2", "3"},
+		},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, ids(threadComments(test.comments)), test.name)
+	}
+}
+
+func TestMessageRepo_DeleteMessage(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	messages := NewMessages(te.DB)
+	now := time.Now()
+	add := func(id, parentID, replyToID string, depth int, userID, attachmentID string) {
+		require.Nil(t, common.RunInTransaction(te.Ctx, te.DB, func(tx *sqlx.Tx) error {
+			return messages.AddMessage(te.Ctx, tx, parentID, Message{
+				ID:           id,
+				ReplyToID:    sql.NullString{String: replyToID, Valid: replyToID != ""},
+				Depth:        depth,
+				UserID:       userID,
+				UserName:     "user" + userID,
+				Text:         id,
+				AttachmentID: attachmentID,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+				PublishedAt:  sql.NullTime{Time: now, Valid: true},
+			})
+		}))
+	}
+	add("post", "", "", 0, "1", "")
+	add("comment1", "post", "", 0, "2", "")
+	add("reply1", "post", "comment1", 1, "3", "reply1.jpg")
+	add("reply2", "post", "reply1", 2, "1", "")
+	add("comment2", "post", "", 0, "3", "")
+
+	existing := func() []string {
+		var ids []string
+		require.Nil(t, te.DB.Select(&ids, "select id from messages order by id"))
+		return ids
+	}
+
+	err := messages.DeleteMessage(te.Ctx, "1", "comment1")
+	assert.True(t, merry.Is(err, ErrMessageNotFound), "only the author deletes a comment")
+
+	require.Nil(t, messages.DeleteMessage(te.Ctx, "2", "comment1"))
+	assert.Equal(t, []string{"comment2", "post"}, existing(), "replies are deleted with their comment")
+
+	var pendingDeletes []string
+	require.Nil(t, te.DB.Select(&pendingDeletes, "select blob_id from blob_pending_deletes"))
+	assert.Equal(t, []string{"reply1.jpg"}, pendingDeletes)
+
+	require.Nil(t, messages.DeleteMessage(te.Ctx, "1", "post"))
+	assert.Empty(t, existing(), "comments are deleted with their post")
+
+	err = messages.DeleteMessage(te.Ctx, "1", "post")
+	assert.True(t, merry.Is(err, ErrMessageNotFound))
+}
@@ -0,0 +1,159 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/types"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+type OutboxItem struct {
+	ID        string         `db:"id"`
+	Payload   types.JSONText `db:"payload"`
+	CreatedAt time.Time      `db:"created_at"`
+	Attempts  int            `db:"attempts"`
+}
+
+type OutboxStats struct {
+	Depth  int          `db:"depth"`
+	Oldest sql.NullTime `db:"oldest"`
+	Stale  int          `db:"stale"`
+	Dead   int          `db:"dead"`
+}
+
+type NotificationOutboxRepo interface {
+	Enqueue(ctx context.Context, tx *sqlx.Tx, payload interface{}) error
+	ClaimDueItems(ctx context.Context, now time.Time, count int, claimFor time.Duration) ([]OutboxItem, error)
+	MarkDelivered(ctx context.Context, ids []string, deliveredAt time.Time) error
+	MarkFailed(ctx context.Context, id string, now time.Time, lastError string, maxBackoff time.Duration, maxAttempts int) error
+	DeleteDelivered(ctx context.Context, deliveredBefore time.Time) error
+	Stats(ctx context.Context, staleBefore time.Time) (OutboxStats, error)
+}
+
+type notificationOutboxRepo struct {
+	db *sqlx.DB
+}
+
+func NewNotificationOutbox(db *sqlx.DB) NotificationOutboxRepo {
+	return &notificationOutboxRepo{
+		db: db,
+	}
+}
+
+// Enqueue adds the item in tx, so it's delivered only if the write that caused it is committed.
+func (r *notificationOutboxRepo) Enqueue(ctx context.Context, tx *sqlx.Tx, payload interface{}) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	id, err := uuid.NewV4()
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	now := common.CurrentTimestamp()
+	_, err = tx.ExecContext(ctx, `
+		insert into notification_outbox(id, payload, created_at, next_attempt_at)
+		values ($1, $2, $3, $3)`,
+		id.String(), types.JSONText(jsonPayload), now)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+// ClaimDueItems postpones the next attempt of the due items by claimFor, so other hub replicas don't send them at the same time.
+// Items that are neither delivered nor failed in that time are due again.
+func (r *notificationOutboxRepo) ClaimDueItems(ctx context.Context, now time.Time, count int, claimFor time.Duration) ([]OutboxItem, error) {
+	var items []OutboxItem
+	err := r.db.SelectContext(ctx, &items, `
+		with claimed as (
+			update notification_outbox
+			set next_attempt_at = $3
+			where id in (
+				select id
+				from notification_outbox
+				where delivered_at is null and dead_at is null and next_attempt_at <= $1
+				order by created_at, id
+				limit $2
+				for update skip locked)
+			returning id, payload, created_at, attempts)
+		select id, payload, created_at, attempts
+		from claimed
+		order by created_at, id`,
+		now, count, now.Add(claimFor))
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return items, nil
+}
+
+func (r *notificationOutboxRepo) MarkDelivered(ctx context.Context, ids []string, deliveredAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query, args, err := sqlx.In(`
+		update notification_outbox
+		set delivered_at = ?, last_error = ''
+		where id in (?)`,
+		deliveredAt, ids)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	_, err = r.db.ExecContext(ctx, r.db.Rebind(query), args...)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+// MarkFailed schedules the next attempt with exponential backoff (10s, 20s, 40s... up to maxBackoff).
+// After maxAttempts the item is moved to the dead letter and isn't sent anymore.
+func (r *notificationOutboxRepo) MarkFailed(ctx context.Context, id string, now time.Time, lastError string, maxBackoff time.Duration, maxAttempts int) error {
+	_, err := r.db.ExecContext(ctx, `
+		update notification_outbox
+		set attempts = attempts + 1,
+		    next_attempt_at = $1::timestamptz + least(power(2, least(attempts, 20)) * 10, $2::double precision) * interval '1 second',
+		    last_error = $3,
+		    dead_at = case when attempts + 1 >= $4 then $1::timestamptz end
+		where id = $5`,
+		now, maxBackoff.Seconds(), lastError, maxAttempts, id)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+func (r *notificationOutboxRepo) DeleteDelivered(ctx context.Context, deliveredBefore time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		delete from notification_outbox
+		where delivered_at < $1`,
+		deliveredBefore)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+// Stats counts undelivered items, Stale counts the ones created before staleBefore and Dead the ones in the dead letter.
+func (r *notificationOutboxRepo) Stats(ctx context.Context, staleBefore time.Time) (OutboxStats, error) {
+	var stats OutboxStats
+	err := r.db.GetContext(ctx, &stats, `
+		select count(*) filter (where dead_at is null) depth,
+		       min(created_at) filter (where dead_at is null) oldest,
+		       count(*) filter (where dead_at is null and created_at < $1) stale,
+		       count(*) filter (where dead_at is not null) dead
+		from notification_outbox
+		where delivered_at is null`,
+		staleBefore)
+	if err != nil {
+		return OutboxStats{}, merry.Wrap(err)
+	}
+	return stats, nil
+}
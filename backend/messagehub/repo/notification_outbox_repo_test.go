@@ -0,0 +1,66 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+func TestNotificationOutboxRepo(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	outbox := NewNotificationOutbox(te.DB)
+	for _, text := range []string{"first", "second", "third"} {
+		require.Nil(t, common.RunInTransaction(te.Ctx, te.DB, func(tx *sqlx.Tx) error {
+			return outbox.Enqueue(te.Ctx, tx, map[string]string{"text": text})
+		}))
+		time.Sleep(time.Millisecond)
+	}
+
+	now := common.CurrentTimestamp().Add(time.Second)
+	claimed, err := outbox.ClaimDueItems(te.Ctx, now, 2, time.Minute)
+	require.Nil(t, err)
+	require.Len(t, claimed, 2)
+	assert.JSONEq(t, `{"text": "first"}`, string(claimed[0].Payload))
+	assert.JSONEq(t, `{"text": "second"}`, string(claimed[1].Payload))
+
+	others, err := outbox.ClaimDueItems(te.Ctx, now, 10, time.Minute)
+	require.Nil(t, err)
+	require.Len(t, others, 1, "claimed items aren't claimed again")
+	assert.JSONEq(t, `{"text": "third"}`, string(others[0].Payload))
+
+	reclaimed, err := outbox.ClaimDueItems(te.Ctx, now.Add(time.Minute), 10, time.Minute)
+	require.Nil(t, err)
+	assert.Len(t, reclaimed, 3, "items are due again when the claim is over")
+
+	require.Nil(t, outbox.MarkDelivered(te.Ctx, []string{claimed[0].ID}, now))
+	require.Nil(t, outbox.MarkFailed(te.Ctx, claimed[1].ID, now, "rejected", time.Hour, 2))
+	require.Nil(t, outbox.MarkFailed(te.Ctx, others[0].ID, now, "rejected", time.Hour, 2))
+
+	due, err := outbox.ClaimDueItems(te.Ctx, now.Add(time.Second*5), 10, time.Minute)
+	require.Nil(t, err)
+	assert.Empty(t, due, "failed items wait for the backoff")
+
+	due, err = outbox.ClaimDueItems(te.Ctx, now.Add(time.Second*10), 1, time.Minute)
+	require.Nil(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, claimed[1].ID, due[0].ID)
+	assert.Equal(t, 1, due[0].Attempts)
+	require.Nil(t, outbox.MarkFailed(te.Ctx, due[0].ID, now.Add(time.Second*10), "rejected", time.Hour, 2))
+
+	stats, err := outbox.Stats(te.Ctx, now)
+	require.Nil(t, err)
+	assert.Equal(t, 1, stats.Depth)
+	assert.Equal(t, 1, stats.Dead, "the item is moved to the dead letter after the max attempts")
+
+	due, err = outbox.ClaimDueItems(te.Ctx, now.Add(time.Hour*24), 10, time.Minute)
+	require.Nil(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, others[0].ID, due[0].ID, "dead items aren't sent anymore")
+}
@@ -0,0 +1,230 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+var (
+	ErrPollNotFound      = common.ErrNotFound.WithMessage("poll not found")
+	ErrPollOptionInvalid = merry.New("invalid poll option")
+)
+
+type Poll struct {
+	MessageID      string       `json:"message_id" db:"message_id"`
+	MultipleChoice bool         `json:"multiple_choice" db:"multiple_choice"`
+	Anonymous      bool         `json:"anonymous" db:"anonymous"`
+	ClosesAt       sql.NullTime `json:"closes_at" db:"closes_at"`
+	ClosedAt       sql.NullTime `json:"closed_at" db:"closed_at"`
+	CreatedAt      time.Time    `json:"created_at" db:"created_at"`
+	TotalVoters    int          `json:"total_voters" db:"total_voters"`
+	Options        []PollOption `json:"options" db:"-"`
+}
+
+type PollOption struct {
+	ID        string     `json:"id" db:"id"`
+	MessageID string     `json:"message_id" db:"message_id"`
+	Text      string     `json:"text" db:"text"`
+	Votes     int        `json:"votes" db:"votes"`
+	VotedByMe bool       `json:"voted_by_me" db:"voted_by_me"`
+	Voters    []PollVote `json:"voters" db:"-"`
+}
+
+type PollVote struct {
+	OptionID string `json:"option_id" db:"option_id"`
+	UserID   string `json:"user_id" db:"user_id"`
+	UserName string `json:"user_name" db:"user_name"`
+}
+
+type PollRepo interface {
+	AddPoll(ctx context.Context, tx *sqlx.Tx, poll Poll) error
+	Polls(ctx context.Context, currentUserID string, messageIDs []string) (map[string]Poll, error)
+	Poll(ctx context.Context, currentUserID, messageID string) (Poll, error)
+	Vote(ctx context.Context, userID, messageID string, optionIDs []string) error
+	DuePolls(ctx context.Context, now time.Time) ([]Poll, error)
+	ClosePoll(ctx context.Context, messageID string, closedAt time.Time) (bool, error)
+}
+
+type pollRepo struct {
+	db *sqlx.DB
+}
+
+func NewPolls(db *sqlx.DB) PollRepo {
+	return &pollRepo{
+		db: db,
+	}
+}
+
+func (r *pollRepo) AddPoll(ctx context.Context, tx *sqlx.Tx, poll Poll) error {
+	_, err := tx.ExecContext(ctx, `
+		insert into polls(message_id, multiple_choice, anonymous, closes_at, created_at)
+		values ($1, $2, $3, $4, $5)`,
+		poll.MessageID, poll.MultipleChoice, poll.Anonymous, poll.ClosesAt, poll.CreatedAt)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+
+	for i, option := range poll.Options {
+		_, err = tx.ExecContext(ctx, `
+			insert into poll_options(id, message_id, text, position)
+			values ($1, $2, $3, $4)`,
+			option.ID, poll.MessageID, option.Text, i)
+		if err != nil {
+			return merry.Wrap(err)
+		}
+	}
+	return nil
+}
+
+func (r *pollRepo) Polls(ctx context.Context, currentUserID string, messageIDs []string) (map[string]Poll, error) {
+	if len(messageIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(`
+		select p.message_id, p.multiple_choice, p.anonymous, p.closes_at, p.closed_at, p.created_at,
+		       (select count(distinct v.user_id) from poll_votes v where v.message_id = p.message_id) total_voters
+		from polls p
+		where p.message_id in (?)`, messageIDs)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	var polls []Poll
+	err = r.db.SelectContext(ctx, &polls, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	if len(polls) == 0 {
+		return nil, nil
+	}
+
+	query, args, err = sqlx.In(`
+		select o.id, o.message_id, o.text,
+		       (select count(*) from poll_votes v where v.option_id = o.id) votes,
+		       exists(select * from poll_votes v where v.option_id = o.id and v.user_id = ?) voted_by_me
+		from poll_options o
+		where o.message_id in (?)
+		order by o.message_id, o.position`, currentUserID, messageIDs)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	var options []PollOption
+	err = r.db.SelectContext(ctx, &options, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+
+	query, args, err = sqlx.In(`
+		select v.option_id, v.user_id, u.name user_name
+		from poll_votes v
+			inner join polls p on p.message_id = v.message_id
+			inner join users u on u.id = v.user_id
+		where v.message_id in (?) and not p.anonymous
+		order by v.created_at`, messageIDs)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	var votes []PollVote
+	err = r.db.SelectContext(ctx, &votes, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+
+	optionVotes := make(map[string][]PollVote)
+	for _, vote := range votes {
+		optionVotes[vote.OptionID] = append(optionVotes[vote.OptionID], vote)
+	}
+	pollOptions := make(map[string][]PollOption)
+	for _, option := range options {
+		option.Voters = optionVotes[option.ID]
+		pollOptions[option.MessageID] = append(pollOptions[option.MessageID], option)
+	}
+
+	result := make(map[string]Poll, len(polls))
+	for _, poll := range polls {
+		poll.Options = pollOptions[poll.MessageID]
+		result[poll.MessageID] = poll
+	}
+	return result, nil
+}
+
+func (r *pollRepo) Poll(ctx context.Context, currentUserID, messageID string) (Poll, error) {
+	polls, err := r.Polls(ctx, currentUserID, []string{messageID})
+	if err != nil {
+		return Poll{}, err
+	}
+	poll, ok := polls[messageID]
+	if !ok {
+		return Poll{}, ErrPollNotFound.Here()
+	}
+	return poll, nil
+}
+
+func (r *pollRepo) Vote(ctx context.Context, userID, messageID string, optionIDs []string) error {
+	return common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			delete from poll_votes
+			where message_id = $1 and user_id = $2`,
+			messageID, userID)
+		if err != nil {
+			return merry.Wrap(err)
+		}
+
+		now := common.CurrentTimestamp()
+		for _, optionID := range optionIDs {
+			res, err := tx.ExecContext(ctx, `
+				insert into poll_votes(message_id, option_id, user_id, created_at)
+				select $1, $2, $3, $4
+				where exists(select * from poll_options where id = $2 and message_id = $1)`,
+				messageID, optionID, userID, now)
+			if err != nil {
+				return merry.Wrap(err)
+			}
+			rowsAffected, err := res.RowsAffected()
+			if err != nil {
+				return merry.Wrap(err)
+			}
+			if rowsAffected < 1 {
+				return ErrPollOptionInvalid.Here()
+			}
+		}
+		return nil
+	})
+}
+
+func (r *pollRepo) DuePolls(ctx context.Context, now time.Time) ([]Poll, error) {
+	var polls []Poll
+	err := r.db.SelectContext(ctx, &polls, `
+		select p.message_id, p.multiple_choice, p.anonymous, p.closes_at, p.closed_at, p.created_at
+		from polls p
+			inner join messages m on m.id = p.message_id
+		where p.closed_at is null and p.closes_at <= $1 and m.published_at is not null
+		order by p.closes_at, p.message_id`,
+		now)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return polls, nil
+}
+
+func (r *pollRepo) ClosePoll(ctx context.Context, messageID string, closedAt time.Time) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `
+		update polls
+		set closed_at = $1
+		where message_id = $2 and closed_at is null`,
+		closedAt, messageID)
+	if err != nil {
+		return false, merry.Wrap(err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, merry.Wrap(err)
+	}
+	return rowsAffected > 0, nil
+}
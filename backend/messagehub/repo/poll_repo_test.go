@@ -0,0 +1,96 @@
+package repo
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+func TestPollRepo(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	users := NewUsers(te.DB)
+	messages := NewMessages(te.DB)
+	polls := NewPolls(te.DB)
+	for _, id := range []string{"1", "2", "3"} {
+		require.Nil(t, users.AddUser(te.Ctx, id, "user"+id))
+	}
+
+	now := common.CurrentTimestamp()
+	addPoll := func(messageID string, published, anonymous bool) {
+		require.Nil(t, common.RunInTransaction(te.Ctx, te.DB, func(tx *sqlx.Tx) error {
+			msg := Message{ID: messageID, UserID: "1", UserName: "user1", Text: messageID, CreatedAt: now, UpdatedAt: now}
+			if published {
+				msg.PublishedAt = sql.NullTime{Time: now, Valid: true}
+			}
+			err := messages.AddMessage(te.Ctx, tx, "", msg)
+			if err != nil {
+				return err
+			}
+			return polls.AddPoll(te.Ctx, tx, Poll{
+				MessageID: messageID,
+				Anonymous: anonymous,
+				ClosesAt:  sql.NullTime{Time: now.Add(time.Hour), Valid: true},
+				CreatedAt: now,
+				Options:   []PollOption{{ID: messageID + "-yes", Text: "yes"}, {ID: messageID + "-no", Text: "no"}},
+			})
+		}))
+	}
+	addPoll("public", true, false)
+	addPoll("anonymous", true, true)
+	addPoll("scheduled", false, false)
+
+	err := common.RunInTransaction(te.Ctx, te.DB, func(tx *sqlx.Tx) error {
+		err := polls.AddPoll(te.Ctx, tx, Poll{MessageID: "public", CreatedAt: now})
+		if err != nil {
+			return err
+		}
+		return merry.New("rollback")
+	})
+	require.NotNil(t, err)
+
+	require.Nil(t, polls.Vote(te.Ctx, "2", "public", []string{"public-yes"}))
+	err = polls.Vote(te.Ctx, "2", "public", []string{"public-no", "anonymous-no"})
+	assert.True(t, merry.Is(err, ErrPollOptionInvalid))
+	require.Nil(t, polls.Vote(te.Ctx, "3", "public", []string{"public-no"}))
+	require.Nil(t, polls.Vote(te.Ctx, "2", "anonymous", []string{"anonymous-no"}))
+
+	poll, err := polls.Poll(te.Ctx, "2", "public")
+	require.Nil(t, err)
+	require.Len(t, poll.Options, 2)
+	assert.Equal(t, 2, poll.TotalVoters)
+	assert.Equal(t, 1, poll.Options[0].Votes, "an invalid vote doesn't replace the old one")
+	assert.True(t, poll.Options[0].VotedByMe)
+	assert.Equal(t, []PollVote{{OptionID: "public-yes", UserID: "2", UserName: "user2"}}, poll.Options[0].Voters)
+
+	poll, err = polls.Poll(te.Ctx, "1", "anonymous")
+	require.Nil(t, err)
+	assert.Equal(t, 1, poll.Options[1].Votes)
+	assert.Empty(t, poll.Options[1].Voters, "the voters of an anonymous poll are hidden")
+
+	_, err = polls.Poll(te.Ctx, "1", "unknown")
+	assert.True(t, merry.Is(err, ErrPollNotFound))
+
+	duePolls, err := polls.DuePolls(te.Ctx, now.Add(time.Hour))
+	require.Nil(t, err)
+	dueIDs := make([]string, len(duePolls))
+	for i, poll := range duePolls {
+		dueIDs[i] = poll.MessageID
+	}
+	assert.ElementsMatch(t, []string{"public", "anonymous"}, dueIDs, "the polls of unpublished messages aren't closed")
+
+	closed, err := polls.ClosePoll(te.Ctx, "public", now.Add(time.Hour))
+	require.Nil(t, err)
+	assert.True(t, closed)
+	closed, err = polls.ClosePoll(te.Ctx, "public", now.Add(time.Hour))
+	require.Nil(t, err)
+	assert.False(t, closed, "a poll is closed once")
+}
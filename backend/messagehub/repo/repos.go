@@ -1,11 +1,24 @@
 package repo
 
 import (
+	"github.com/jmoiron/sqlx"
+
 	"github.com/mreider/koto/backend/common"
 )
 
 type Repos struct {
-	Message      MessageRepo
-	Notification common.NotificationRepo
-	User         UserRepo
+	// DB runs the writes of several repos in one transaction.
+	DB *sqlx.DB
+
+	Message            MessageRepo
+	Conversation       ConversationRepo
+	Poll               PollRepo
+	Event              EventRepo
+	Notification       common.NotificationRepo
+	NotificationOutbox NotificationOutboxRepo
+	User               UserRepo
+	Blob               BlobRepo
+	Retention          RetentionRepo
+	Limit              LimitRepo
+	Maintenance        MaintenanceRepo
 }
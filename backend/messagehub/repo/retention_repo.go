@@ -0,0 +1,139 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+type RetentionCandidate struct {
+	MessageID          string         `json:"message_id" db:"message_id"`
+	ParentID           sql.NullString `json:"parent_id" db:"parent_id"`
+	UserID             string         `json:"user_id" db:"user_id"`
+	AttachmentType     string         `json:"attachment_type" db:"attachment_type"`
+	CreatedAt          time.Time      `json:"created_at" db:"created_at"`
+	Size               int64          `json:"size" db:"size"`
+	DeleteExpiredPosts bool           `json:"delete_expired_posts" db:"delete_expired_posts"`
+	Conversation       bool           `json:"conversation" db:"conversation"`
+}
+
+type RetentionRepo interface {
+	// Candidates returns published messages and conversation messages with attachments created before the given time,
+	// ordered by (created_at, id) and starting after (afterCreatedAt, afterID).
+	Candidates(ctx context.Context, before, afterCreatedAt time.Time, afterID string, count int) ([]RetentionCandidate, error)
+	ExpireMedia(ctx context.Context, messageID string) error
+	ExpireConversationMedia(ctx context.Context, messageID string) error
+	SetDeleteExpiredPosts(ctx context.Context, userID string, enabled bool) error
+}
+
+type retentionRepo struct {
+	db *sqlx.DB
+}
+
+func NewRetention(db *sqlx.DB) RetentionRepo {
+	return &retentionRepo{
+		db: db,
+	}
+}
+
+func (r *retentionRepo) Candidates(ctx context.Context, before, afterCreatedAt time.Time, afterID string, count int) ([]RetentionCandidate, error) {
+	var candidates []RetentionCandidate
+	err := r.db.SelectContext(ctx, &candidates, `
+		select *
+		from (
+			select m.id message_id, m.parent_id, m.user_id, m.attachment_type, m.created_at,
+			       coalesce(b.size, 0) + coalesce(t.size, 0) size, coalesce(u.delete_expired_posts, false) delete_expired_posts,
+			       false conversation
+			from messages m
+				left join blobs b on b.id = m.attachment_id
+				left join blobs t on t.id = m.attachment_thumbnail_id and m.attachment_thumbnail_id <> m.attachment_id
+				left join users u on u.id = m.user_id
+			where m.attachment_id <> '' and m.published_at is not null and m.created_at < $1
+			  and (m.created_at, m.id) > ($2, $3)
+			union all
+			select m.id, null, m.user_id, m.attachment_type, m.created_at,
+			       coalesce(b.size, 0) + coalesce(t.size, 0), false,
+			       true
+			from conversation_messages m
+				left join blobs b on b.id = m.attachment_id
+				left join blobs t on t.id = m.attachment_thumbnail_id and m.attachment_thumbnail_id <> m.attachment_id
+			where m.attachment_id <> '' and m.created_at < $1
+			  and (m.created_at, m.id) > ($2, $3)
+		) c
+		order by created_at, message_id
+		limit $4`,
+		before, afterCreatedAt, afterID, count)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return candidates, nil
+}
+
+// ExpireMedia queues the message attachment for deletion and keeps its type so clients can show "media expired".
+func (r *retentionRepo) ExpireMedia(ctx context.Context, messageID string) error {
+	return r.expireMedia(ctx, "messages", messageID)
+}
+
+func (r *retentionRepo) ExpireConversationMedia(ctx context.Context, messageID string) error {
+	return r.expireMedia(ctx, "conversation_messages", messageID)
+}
+
+func (r *retentionRepo) expireMedia(ctx context.Context, table, messageID string) error {
+	return common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		var message Message
+		err := tx.GetContext(ctx, &message, `
+			select attachment_id, attachment_thumbnail_id
+			from `+table+`
+			where id = $1
+			for update`,
+			messageID)
+		if err != nil {
+			if merry.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return merry.Wrap(err)
+		}
+
+		now := common.CurrentTimestamp()
+		blobIDs := []string{message.AttachmentID}
+		if message.AttachmentThumbnailID != message.AttachmentID {
+			blobIDs = append(blobIDs, message.AttachmentThumbnailID)
+		}
+		for _, blobID := range blobIDs {
+			if blobID == "" {
+				continue
+			}
+			_, err = tx.ExecContext(ctx, `
+				insert into blob_pending_deletes(blob_id, deleted_at)
+				values ($1, $2)`,
+				blobID, now)
+			if err != nil {
+				return merry.Wrap(err)
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			update `+table+`
+			set attachment_id = '', attachment_thumbnail_id = '', media_expired = true
+			where id = $1`,
+			messageID)
+		return merry.Wrap(err)
+	})
+}
+
+func (r *retentionRepo) SetDeleteExpiredPosts(ctx context.Context, userID string, enabled bool) error {
+	_, err := r.db.ExecContext(ctx, `
+		update users
+		set delete_expired_posts = $1
+		where id = $2`,
+		enabled, userID)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package repo
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+func TestRetentionRepo(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	users := NewUsers(te.DB)
+	messages := NewMessages(te.DB)
+	conversations := NewConversations(te.DB)
+	blobs := NewBlobs(te.DB)
+	retention := NewRetention(te.DB)
+	for _, id := range []string{"1", "2"} {
+		require.Nil(t, users.AddUser(te.Ctx, id, "user"+id))
+	}
+	require.Nil(t, blobs.AddBlob(te.Ctx, "post.mp4", "1", 100))
+	require.Nil(t, blobs.AddBlob(te.Ctx, "post-thumbnail.jpg", "1", 10))
+	require.Nil(t, blobs.AddBlob(te.Ctx, "chat.mp4", "2", 200))
+
+	now := common.CurrentTimestamp()
+	require.Nil(t, common.RunInTransaction(te.Ctx, te.DB, func(tx *sqlx.Tx) error {
+		return messages.AddMessage(te.Ctx, tx, "", Message{
+			ID: "post", UserID: "1", UserName: "user1", Text: "post",
+			AttachmentID: "post.mp4", AttachmentType: "video/mp4", AttachmentThumbnailID: "post-thumbnail.jpg",
+			CreatedAt: now.Add(-time.Hour * 2), UpdatedAt: now.Add(-time.Hour * 2),
+			PublishedAt: sql.NullTime{Time: now.Add(-time.Hour * 2), Valid: true},
+		})
+	}))
+	conversation, err := conversations.AddConversation(te.Ctx, Conversation{
+		ID: "conversation", MemberKey: "1,2", CreatedBy: "2", CreatedAt: now, UpdatedAt: now,
+	}, []string{"1", "2"})
+	require.Nil(t, err)
+	require.Nil(t, common.RunInTransaction(te.Ctx, te.DB, func(tx *sqlx.Tx) error {
+		return conversations.AddMessage(te.Ctx, tx, ConversationMessage{
+			ID: "chat", ConversationID: conversation.ID, UserID: "2", UserName: "user2",
+			AttachmentID: "chat.mp4", AttachmentType: "video/mp4", AttachmentThumbnailID: "chat.mp4",
+			CreatedAt: now.Add(-time.Hour),
+		}, nil)
+	}))
+
+	candidates, err := retention.Candidates(te.Ctx, now, time.Time{}, "", 10)
+	require.Nil(t, err)
+	require.Len(t, candidates, 2)
+	assert.Equal(t, "post", candidates[0].MessageID)
+	assert.Equal(t, int64(110), candidates[0].Size)
+	assert.False(t, candidates[0].Conversation)
+	assert.Equal(t, "chat", candidates[1].MessageID)
+	assert.Equal(t, int64(200), candidates[1].Size, "a thumbnail that is the attachment itself is counted once")
+	assert.True(t, candidates[1].Conversation)
+
+	candidates, err = retention.Candidates(te.Ctx, now, candidates[0].CreatedAt, candidates[0].MessageID, 10)
+	require.Nil(t, err)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, "chat", candidates[0].MessageID)
+
+	require.Nil(t, retention.ExpireMedia(te.Ctx, "post"))
+	require.Nil(t, retention.ExpireConversationMedia(te.Ctx, "chat"))
+
+	post, err := messages.Message(te.Ctx, "1", "post")
+	require.Nil(t, err)
+	assert.Empty(t, post.AttachmentID)
+	assert.Empty(t, post.AttachmentThumbnailID)
+	assert.Equal(t, "video/mp4", post.AttachmentType)
+	assert.True(t, post.MediaExpired)
+
+	chat, err := conversations.LastMessages(te.Ctx, []string{conversation.ID})
+	require.Nil(t, err)
+	assert.Empty(t, chat[conversation.ID].AttachmentID)
+	assert.Equal(t, "video/mp4", chat[conversation.ID].AttachmentType)
+	assert.True(t, chat[conversation.ID].MediaExpired)
+
+	var pendingDeletes []string
+	require.Nil(t, te.DB.Select(&pendingDeletes, "select blob_id from blob_pending_deletes order by blob_id"))
+	assert.Equal(t, []string{"chat.mp4", "post-thumbnail.jpg", "post.mp4"}, pendingDeletes)
+
+	candidates, err = retention.Candidates(te.Ctx, now, time.Time{}, "", 10)
+	require.Nil(t, err)
+	assert.Empty(t, candidates)
+}
@@ -1,6 +1,8 @@
 package repo
 
 import (
+	"context"
+
 	"github.com/ansel1/merry"
 	"github.com/jmoiron/sqlx"
 )
@@ -11,8 +13,8 @@ type User struct {
 }
 
 type UserRepo interface {
-	AddUser(id, name string) error
-	FindUsersByName(names []string) ([]User, error)
+	AddUser(ctx context.Context, id, name string) error
+	FindUsersByName(ctx context.Context, names []string) ([]User, error)
 }
 
 type userRepo struct {
@@ -25,8 +27,8 @@ func NewUsers(db *sqlx.DB) UserRepo {
 	}
 }
 
-func (r *userRepo) AddUser(id, name string) error {
-	_, err := r.db.Exec(`
+func (r *userRepo) AddUser(ctx context.Context, id, name string) error {
+	_, err := r.db.ExecContext(ctx, `
 			insert into users(id, name)
 			values($1, $2)
 			on conflict (id) do update set name = excluded.name where users.name <> excluded.name;`,
@@ -37,7 +39,7 @@ func (r *userRepo) AddUser(id, name string) error {
 	return nil
 }
 
-func (r *userRepo) FindUsersByName(names []string) ([]User, error) {
+func (r *userRepo) FindUsersByName(ctx context.Context, names []string) ([]User, error) {
 	if len(names) == 0 {
 		return nil, nil
 	}
@@ -51,7 +53,7 @@ func (r *userRepo) FindUsersByName(names []string) ([]User, error) {
 	}
 	query = r.db.Rebind(query)
 	var users []User
-	err = r.db.Select(&users, query, args...)
+	err = r.db.SelectContext(ctx, &users, query, args...)
 	if err != nil {
 		return nil, merry.Wrap(err)
 	}
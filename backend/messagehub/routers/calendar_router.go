@@ -0,0 +1,124 @@
+package routers
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/go-chi/chi"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/messagehub/repo"
+	"github.com/mreider/koto/backend/messagehub/services/event"
+	"github.com/mreider/koto/backend/token"
+)
+
+const (
+	calendarFeedHistory = time.Hour * 24 * 30
+)
+
+func Calendar(repos repo.Repos, tokenParser token.Parser, externalAddress string) http.Handler {
+	h := &calendarRouter{
+		repos:       repos,
+		tokenParser: tokenParser,
+	}
+	if u, err := url.Parse(externalAddress); err == nil {
+		h.uidDomain = u.Host
+	}
+
+	r := chi.NewRouter()
+	r.Get("/event/{messageID}.ics", h.Event)
+	r.Get("/feed.ics", h.Feed)
+	return r
+}
+
+type calendarRouter struct {
+	repos       repo.Repos
+	tokenParser token.Parser
+	uidDomain   string
+}
+
+func (cr *calendarRouter) Event(w http.ResponseWriter, r *http.Request) {
+	messageID := chi.URLParam(r, "messageID")
+	_, claims, err := cr.tokenParser.Parse(r.URL.Query().Get("token"), "event-calendar")
+	if err != nil || !tokenHasMessage(claims, messageID) {
+		http.Error(w, "invalid token", http.StatusForbidden)
+		return
+	}
+
+	e, err := cr.repos.Event.Event(r.Context(), claims["id"].(string), messageID)
+	if err != nil {
+		if merry.Is(err, repo.ErrEventNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		logging.FromContext(r.Context()).WithError(err).Error("can't load event")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="event.ics"`)
+	_, _ = w.Write(event.Calendar("", []event.CalendarEvent{cr.calendarEvent(e)}))
+}
+
+func (cr *calendarRouter) Feed(w http.ResponseWriter, r *http.Request) {
+	_, claims, err := cr.tokenParser.Parse(r.URL.Query().Get("token"), "calendar-feed")
+	if err != nil {
+		http.Error(w, "invalid token", http.StatusForbidden)
+		return
+	}
+	userID := claims["id"].(string)
+	version, err := cr.repos.Event.CalendarFeedVersion(r.Context(), userID)
+	if err != nil {
+		logging.FromContext(r.Context()).WithError(err).Error("can't load calendar feed")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	// the links with the previous versions are rotated
+	if tokenVersion, ok := claims["version"].(float64); !ok || int(tokenVersion) != version {
+		http.Error(w, "invalid token", http.StatusForbidden)
+		return
+	}
+
+	events, err := cr.repos.Event.UserEvents(r.Context(), userID, common.CurrentTimestamp().Add(-calendarFeedHistory))
+	if err != nil {
+		logging.FromContext(r.Context()).WithError(err).Error("can't load events")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	calendarEvents := make([]event.CalendarEvent, len(events))
+	for i, e := range events {
+		calendarEvents[i] = cr.calendarEvent(e)
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "max-age=300")
+	_, _ = w.Write(event.Calendar("Koto", calendarEvents))
+}
+
+func (cr *calendarRouter) calendarEvent(e repo.Event) event.CalendarEvent {
+	return event.CalendarEvent{
+		UID:         e.MessageID + "@" + cr.uidDomain,
+		Summary:     e.Title,
+		Description: e.Description,
+		Location:    e.Location,
+		StartsAt:    e.StartsAt,
+		EndsAt:      e.EndsAt.Time,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+func tokenHasMessage(claims map[string]interface{}, messageID string) bool {
+	messageIDs, _ := claims["message_ids"].([]interface{})
+	for _, id := range messageIDs {
+		if id == messageID {
+			return true
+		}
+	}
+	return false
+}
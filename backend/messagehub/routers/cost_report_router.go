@@ -0,0 +1,35 @@
+package routers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/messagehub/services"
+	"github.com/mreider/koto/backend/messagehub/services/cost"
+)
+
+// CostReport serves the storage cost report as CSV to hub admins.
+func CostReport(costReporter *services.CostReporter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isAdmin, _ := r.Context().Value(services.ContextIsAdminKey).(bool); !isAdmin {
+			http.Error(w, "", http.StatusForbidden)
+			return
+		}
+
+		days, _ := strconv.Atoi(r.URL.Query().Get("days"))
+		report, err := costReporter.Report(r.Context(), days)
+		if err != nil {
+			logging.FromContext(r.Context()).WithError(err).Error("can't build cost report")
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="cost-report.csv"`)
+		err = cost.WriteCSV(w, report)
+		if err != nil {
+			logging.FromContext(r.Context()).WithError(err).Error("can't write cost report")
+		}
+	})
+}
@@ -32,6 +32,7 @@ type BlobUploadLinkRequest struct {
 
 	ContentType string `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
 	FileName    string `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Size        int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
 }
 
 func (x *BlobUploadLinkRequest) Reset() {
@@ -80,33 +81,730 @@ func (x *BlobUploadLinkRequest) GetFileName() string {
 	return ""
 }
 
+func (x *BlobUploadLinkRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
 type BlobUploadLinkResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BlobId   string            `protobuf:"bytes,1,opt,name=blob_id,json=blobId,proto3" json:"blob_id,omitempty"`
-	Link     string            `protobuf:"bytes,2,opt,name=link,proto3" json:"link,omitempty"`
-	FormData map[string]string `protobuf:"bytes,3,rep,name=form_data,json=formData,proto3" json:"form_data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	BlobId   string            `protobuf:"bytes,1,opt,name=blob_id,json=blobId,proto3" json:"blob_id,omitempty"`
+	Link     string            `protobuf:"bytes,2,opt,name=link,proto3" json:"link,omitempty"`
+	FormData map[string]string `protobuf:"bytes,3,rep,name=form_data,json=formData,proto3" json:"form_data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *BlobUploadLinkResponse) Reset() {
+	*x = BlobUploadLinkResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blob_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobUploadLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobUploadLinkResponse) ProtoMessage() {}
+
+func (x *BlobUploadLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blob_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobUploadLinkResponse.ProtoReflect.Descriptor instead.
+func (*BlobUploadLinkResponse) Descriptor() ([]byte, []int) {
+	return file_blob_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BlobUploadLinkResponse) GetBlobId() string {
+	if x != nil {
+		return x.BlobId
+	}
+	return ""
+}
+
+func (x *BlobUploadLinkResponse) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *BlobUploadLinkResponse) GetFormData() map[string]string {
+	if x != nil {
+		return x.FormData
+	}
+	return nil
+}
+
+type BlobStorageUsageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Used        int64 `protobuf:"varint,1,opt,name=used,proto3" json:"used,omitempty"`
+	Quota       int64 `protobuf:"varint,2,opt,name=quota,proto3" json:"quota,omitempty"`
+	MaxFileSize int64 `protobuf:"varint,3,opt,name=max_file_size,json=maxFileSize,proto3" json:"max_file_size,omitempty"`
+	BlobCount   int32 `protobuf:"varint,4,opt,name=blob_count,json=blobCount,proto3" json:"blob_count,omitempty"`
+}
+
+func (x *BlobStorageUsageResponse) Reset() {
+	*x = BlobStorageUsageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blob_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobStorageUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobStorageUsageResponse) ProtoMessage() {}
+
+func (x *BlobStorageUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blob_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobStorageUsageResponse.ProtoReflect.Descriptor instead.
+func (*BlobStorageUsageResponse) Descriptor() ([]byte, []int) {
+	return file_blob_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BlobStorageUsageResponse) GetUsed() int64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *BlobStorageUsageResponse) GetQuota() int64 {
+	if x != nil {
+		return x.Quota
+	}
+	return 0
+}
+
+func (x *BlobStorageUsageResponse) GetMaxFileSize() int64 {
+	if x != nil {
+		return x.MaxFileSize
+	}
+	return 0
+}
+
+func (x *BlobStorageUsageResponse) GetBlobCount() int32 {
+	if x != nil {
+		return x.BlobCount
+	}
+	return 0
+}
+
+type BlobUserStorageUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId    string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserName  string `protobuf:"bytes,2,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	Used      int64  `protobuf:"varint,3,opt,name=used,proto3" json:"used,omitempty"`
+	BlobCount int32  `protobuf:"varint,4,opt,name=blob_count,json=blobCount,proto3" json:"blob_count,omitempty"`
+}
+
+func (x *BlobUserStorageUsage) Reset() {
+	*x = BlobUserStorageUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blob_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobUserStorageUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobUserStorageUsage) ProtoMessage() {}
+
+func (x *BlobUserStorageUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_blob_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobUserStorageUsage.ProtoReflect.Descriptor instead.
+func (*BlobUserStorageUsage) Descriptor() ([]byte, []int) {
+	return file_blob_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BlobUserStorageUsage) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BlobUserStorageUsage) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *BlobUserStorageUsage) GetUsed() int64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *BlobUserStorageUsage) GetBlobCount() int32 {
+	if x != nil {
+		return x.BlobCount
+	}
+	return 0
+}
+
+type BlobUsersStorageUsageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Users []*BlobUserStorageUsage `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Total int64                   `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *BlobUsersStorageUsageResponse) Reset() {
+	*x = BlobUsersStorageUsageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blob_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobUsersStorageUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobUsersStorageUsageResponse) ProtoMessage() {}
+
+func (x *BlobUsersStorageUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blob_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobUsersStorageUsageResponse.ProtoReflect.Descriptor instead.
+func (*BlobUsersStorageUsageResponse) Descriptor() ([]byte, []int) {
+	return file_blob_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BlobUsersStorageUsageResponse) GetUsers() []*BlobUserStorageUsage {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *BlobUsersStorageUsageResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type BlobCostReportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Days int32 `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+}
+
+func (x *BlobCostReportRequest) Reset() {
+	*x = BlobCostReportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blob_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobCostReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobCostReportRequest) ProtoMessage() {}
+
+func (x *BlobCostReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blob_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobCostReportRequest.ProtoReflect.Descriptor instead.
+func (*BlobCostReportRequest) Descriptor() ([]byte, []int) {
+	return file_blob_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BlobCostReportRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type BlobMediaTypeUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MediaType string `protobuf:"bytes,1,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
+	Size      int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	BlobCount int32  `protobuf:"varint,3,opt,name=blob_count,json=blobCount,proto3" json:"blob_count,omitempty"`
+}
+
+func (x *BlobMediaTypeUsage) Reset() {
+	*x = BlobMediaTypeUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blob_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobMediaTypeUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobMediaTypeUsage) ProtoMessage() {}
+
+func (x *BlobMediaTypeUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_blob_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobMediaTypeUsage.ProtoReflect.Descriptor instead.
+func (*BlobMediaTypeUsage) Descriptor() ([]byte, []int) {
+	return file_blob_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BlobMediaTypeUsage) GetMediaType() string {
+	if x != nil {
+		return x.MediaType
+	}
+	return ""
+}
+
+func (x *BlobMediaTypeUsage) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *BlobMediaTypeUsage) GetBlobCount() int32 {
+	if x != nil {
+		return x.BlobCount
+	}
+	return 0
+}
+
+type BlobDailyVolume struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Day   string `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	Size  int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Count int32  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *BlobDailyVolume) Reset() {
+	*x = BlobDailyVolume{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blob_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobDailyVolume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobDailyVolume) ProtoMessage() {}
+
+func (x *BlobDailyVolume) ProtoReflect() protoreflect.Message {
+	mi := &file_blob_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobDailyVolume.ProtoReflect.Descriptor instead.
+func (*BlobDailyVolume) Descriptor() ([]byte, []int) {
+	return file_blob_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BlobDailyVolume) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *BlobDailyVolume) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *BlobDailyVolume) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type BlobStorageProjection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Days       int32 `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	StoredSize int64 `protobuf:"varint,2,opt,name=stored_size,json=storedSize,proto3" json:"stored_size,omitempty"`
+}
+
+func (x *BlobStorageProjection) Reset() {
+	*x = BlobStorageProjection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blob_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobStorageProjection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobStorageProjection) ProtoMessage() {}
+
+func (x *BlobStorageProjection) ProtoReflect() protoreflect.Message {
+	mi := &file_blob_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobStorageProjection.ProtoReflect.Descriptor instead.
+func (*BlobStorageProjection) Descriptor() ([]byte, []int) {
+	return file_blob_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BlobStorageProjection) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *BlobStorageProjection) GetStoredSize() int64 {
+	if x != nil {
+		return x.StoredSize
+	}
+	return 0
+}
+
+type BlobProviderCost struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Provider              string  `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	MonthlyStorage        float64 `protobuf:"fixed64,2,opt,name=monthly_storage,json=monthlyStorage,proto3" json:"monthly_storage,omitempty"`
+	MonthlyEgress         float64 `protobuf:"fixed64,3,opt,name=monthly_egress,json=monthlyEgress,proto3" json:"monthly_egress,omitempty"`
+	MonthlyTotal          float64 `protobuf:"fixed64,4,opt,name=monthly_total,json=monthlyTotal,proto3" json:"monthly_total,omitempty"`
+	ProjectedMonthlyTotal float64 `protobuf:"fixed64,5,opt,name=projected_monthly_total,json=projectedMonthlyTotal,proto3" json:"projected_monthly_total,omitempty"`
+}
+
+func (x *BlobProviderCost) Reset() {
+	*x = BlobProviderCost{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blob_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobProviderCost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobProviderCost) ProtoMessage() {}
+
+func (x *BlobProviderCost) ProtoReflect() protoreflect.Message {
+	mi := &file_blob_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobProviderCost.ProtoReflect.Descriptor instead.
+func (*BlobProviderCost) Descriptor() ([]byte, []int) {
+	return file_blob_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BlobProviderCost) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *BlobProviderCost) GetMonthlyStorage() float64 {
+	if x != nil {
+		return x.MonthlyStorage
+	}
+	return 0
+}
+
+func (x *BlobProviderCost) GetMonthlyEgress() float64 {
+	if x != nil {
+		return x.MonthlyEgress
+	}
+	return 0
+}
+
+func (x *BlobProviderCost) GetMonthlyTotal() float64 {
+	if x != nil {
+		return x.MonthlyTotal
+	}
+	return 0
+}
+
+func (x *BlobProviderCost) GetProjectedMonthlyTotal() float64 {
+	if x != nil {
+		return x.ProjectedMonthlyTotal
+	}
+	return 0
+}
+
+type BlobCostReportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stored               []*BlobMediaTypeUsage    `protobuf:"bytes,1,rep,name=stored,proto3" json:"stored,omitempty"`
+	StoredSize           int64                    `protobuf:"varint,2,opt,name=stored_size,json=storedSize,proto3" json:"stored_size,omitempty"`
+	Uploads              []*BlobDailyVolume       `protobuf:"bytes,3,rep,name=uploads,proto3" json:"uploads,omitempty"`
+	Egress               []*BlobDailyVolume       `protobuf:"bytes,4,rep,name=egress,proto3" json:"egress,omitempty"`
+	Days                 int32                    `protobuf:"varint,5,opt,name=days,proto3" json:"days,omitempty"`
+	AvgDailyUploadGb     float64                  `protobuf:"fixed64,6,opt,name=avg_daily_upload_gb,json=avgDailyUploadGb,proto3" json:"avg_daily_upload_gb,omitempty"`
+	AvgDailyEgressGb     float64                  `protobuf:"fixed64,7,opt,name=avg_daily_egress_gb,json=avgDailyEgressGb,proto3" json:"avg_daily_egress_gb,omitempty"`
+	Projections          []*BlobStorageProjection `protobuf:"bytes,8,rep,name=projections,proto3" json:"projections,omitempty"`
+	Costs                []*BlobProviderCost      `protobuf:"bytes,9,rep,name=costs,proto3" json:"costs,omitempty"`
+	UnaccountedBlobCount int32                    `protobuf:"varint,10,opt,name=unaccounted_blob_count,json=unaccountedBlobCount,proto3" json:"unaccounted_blob_count,omitempty"`
+}
+
+func (x *BlobCostReportResponse) Reset() {
+	*x = BlobCostReportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blob_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobCostReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobCostReportResponse) ProtoMessage() {}
+
+func (x *BlobCostReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blob_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobCostReportResponse.ProtoReflect.Descriptor instead.
+func (*BlobCostReportResponse) Descriptor() ([]byte, []int) {
+	return file_blob_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BlobCostReportResponse) GetStored() []*BlobMediaTypeUsage {
+	if x != nil {
+		return x.Stored
+	}
+	return nil
+}
+
+func (x *BlobCostReportResponse) GetStoredSize() int64 {
+	if x != nil {
+		return x.StoredSize
+	}
+	return 0
+}
+
+func (x *BlobCostReportResponse) GetUploads() []*BlobDailyVolume {
+	if x != nil {
+		return x.Uploads
+	}
+	return nil
+}
+
+func (x *BlobCostReportResponse) GetEgress() []*BlobDailyVolume {
+	if x != nil {
+		return x.Egress
+	}
+	return nil
+}
+
+func (x *BlobCostReportResponse) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *BlobCostReportResponse) GetAvgDailyUploadGb() float64 {
+	if x != nil {
+		return x.AvgDailyUploadGb
+	}
+	return 0
+}
+
+func (x *BlobCostReportResponse) GetAvgDailyEgressGb() float64 {
+	if x != nil {
+		return x.AvgDailyEgressGb
+	}
+	return 0
+}
+
+func (x *BlobCostReportResponse) GetProjections() []*BlobStorageProjection {
+	if x != nil {
+		return x.Projections
+	}
+	return nil
+}
+
+func (x *BlobCostReportResponse) GetCosts() []*BlobProviderCost {
+	if x != nil {
+		return x.Costs
+	}
+	return nil
+}
+
+func (x *BlobCostReportResponse) GetUnaccountedBlobCount() int32 {
+	if x != nil {
+		return x.UnaccountedBlobCount
+	}
+	return 0
+}
+
+type BlobRetentionItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MessageId      string `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	UserId         string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AttachmentType string `protobuf:"bytes,3,opt,name=attachment_type,json=attachmentType,proto3" json:"attachment_type,omitempty"`
+	CreatedAt      string `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Size           int64  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	DeletePost     bool   `protobuf:"varint,6,opt,name=delete_post,json=deletePost,proto3" json:"delete_post,omitempty"`
+	Conversation   bool   `protobuf:"varint,7,opt,name=conversation,proto3" json:"conversation,omitempty"`
 }
 
-func (x *BlobUploadLinkResponse) Reset() {
-	*x = BlobUploadLinkResponse{}
+func (x *BlobRetentionItem) Reset() {
+	*x = BlobRetentionItem{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_blob_proto_msgTypes[1]
+		mi := &file_blob_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BlobUploadLinkResponse) String() string {
+func (x *BlobRetentionItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BlobUploadLinkResponse) ProtoMessage() {}
+func (*BlobRetentionItem) ProtoMessage() {}
 
-func (x *BlobUploadLinkResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_blob_proto_msgTypes[1]
+func (x *BlobRetentionItem) ProtoReflect() protoreflect.Message {
+	mi := &file_blob_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -117,61 +815,291 @@ func (x *BlobUploadLinkResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BlobUploadLinkResponse.ProtoReflect.Descriptor instead.
-func (*BlobUploadLinkResponse) Descriptor() ([]byte, []int) {
-	return file_blob_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use BlobRetentionItem.ProtoReflect.Descriptor instead.
+func (*BlobRetentionItem) Descriptor() ([]byte, []int) {
+	return file_blob_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *BlobUploadLinkResponse) GetBlobId() string {
+func (x *BlobRetentionItem) GetMessageId() string {
 	if x != nil {
-		return x.BlobId
+		return x.MessageId
 	}
 	return ""
 }
 
-func (x *BlobUploadLinkResponse) GetLink() string {
+func (x *BlobRetentionItem) GetUserId() string {
 	if x != nil {
-		return x.Link
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *BlobUploadLinkResponse) GetFormData() map[string]string {
+func (x *BlobRetentionItem) GetAttachmentType() string {
 	if x != nil {
-		return x.FormData
+		return x.AttachmentType
+	}
+	return ""
+}
+
+func (x *BlobRetentionItem) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *BlobRetentionItem) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *BlobRetentionItem) GetDeletePost() bool {
+	if x != nil {
+		return x.DeletePost
+	}
+	return false
+}
+
+func (x *BlobRetentionItem) GetConversation() bool {
+	if x != nil {
+		return x.Conversation
+	}
+	return false
+}
+
+type BlobRetentionDryRunResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items           []*BlobRetentionItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	AttachmentCount int32                `protobuf:"varint,2,opt,name=attachment_count,json=attachmentCount,proto3" json:"attachment_count,omitempty"`
+	PostCount       int32                `protobuf:"varint,3,opt,name=post_count,json=postCount,proto3" json:"post_count,omitempty"`
+	Size            int64                `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *BlobRetentionDryRunResponse) Reset() {
+	*x = BlobRetentionDryRunResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blob_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlobRetentionDryRunResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlobRetentionDryRunResponse) ProtoMessage() {}
+
+func (x *BlobRetentionDryRunResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blob_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlobRetentionDryRunResponse.ProtoReflect.Descriptor instead.
+func (*BlobRetentionDryRunResponse) Descriptor() ([]byte, []int) {
+	return file_blob_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BlobRetentionDryRunResponse) GetItems() []*BlobRetentionItem {
+	if x != nil {
+		return x.Items
 	}
 	return nil
 }
 
+func (x *BlobRetentionDryRunResponse) GetAttachmentCount() int32 {
+	if x != nil {
+		return x.AttachmentCount
+	}
+	return 0
+}
+
+func (x *BlobRetentionDryRunResponse) GetPostCount() int32 {
+	if x != nil {
+		return x.PostCount
+	}
+	return 0
+}
+
+func (x *BlobRetentionDryRunResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
 var File_blob_proto protoreflect.FileDescriptor
 
 var file_blob_proto_rawDesc = []byte{
 	0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x72, 0x70,
-	0x63, 0x22, 0x57, 0x0a, 0x15, 0x42, 0x6c, 0x6f, 0x62, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4c,
-	0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f,
-	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a,
-	0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xca, 0x01, 0x0a, 0x16, 0x42,
-	0x6c, 0x6f, 0x62, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x12,
-	0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69,
-	0x6e, 0x6b, 0x12, 0x46, 0x0a, 0x09, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x6c, 0x6f, 0x62,
-	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x2e, 0x46, 0x6f, 0x72, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x08, 0x66, 0x6f, 0x72, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x46, 0x6f,
-	0x72, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0x54, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x62, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x45, 0x0a, 0x0a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
-	0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x1a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x55,
-	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x55, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x08, 0x5a,
-	0x06, 0x2e, 0x2e, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x63, 0x1a, 0x0b, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x6b,
+	0x0a, 0x15, 0x42, 0x6c, 0x6f, 0x62, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4c, 0x69, 0x6e, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69,
+	0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66,
+	0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0xca, 0x01, 0x0a, 0x16,
+	0x42, 0x6c, 0x6f, 0x62, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x62, 0x49, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c,
+	0x69, 0x6e, 0x6b, 0x12, 0x46, 0x0a, 0x09, 0x66, 0x6f, 0x72, 0x6d, 0x5f, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x6c, 0x6f,
+	0x62, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x2e, 0x46, 0x6f, 0x72, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x08, 0x66, 0x6f, 0x72, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x46,
+	0x6f, 0x72, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x87, 0x01, 0x0a, 0x18, 0x42, 0x6c, 0x6f,
+	0x62, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x75, 0x73, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x6f,
+	0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x12,
+	0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x46, 0x69, 0x6c, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0x7f, 0x0a, 0x14, 0x42, 0x6c, 0x6f, 0x62, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x75, 0x73, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x66, 0x0a, 0x1d, 0x42, 0x6c, 0x6f, 0x62, 0x55, 0x73, 0x65, 0x72, 0x73,
+	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x55, 0x73,
+	0x65, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05,
+	0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x2b, 0x0a, 0x15, 0x42,
+	0x6c, 0x6f, 0x62, 0x43, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x64, 0x61, 0x79, 0x73, 0x22, 0x66, 0x0a, 0x12, 0x42, 0x6c, 0x6f, 0x62,
+	0x4d, 0x65, 0x64, 0x69, 0x61, 0x54, 0x79, 0x70, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x22, 0x4d, 0x0a, 0x0f, 0x42, 0x6c, 0x6f, 0x62, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x61, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x64, 0x61, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0x4c, 0x0a, 0x15, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x50, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x79, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x64, 0x61, 0x79, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x22, 0xdb, 0x01,
+	0x0a, 0x10, 0x42, 0x6c, 0x6f, 0x62, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x27,
+	0x0a, 0x0f, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79,
+	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x6f, 0x6e, 0x74, 0x68,
+	0x6c, 0x79, 0x5f, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0d, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x54, 0x6f,
+	0x74, 0x61, 0x6c, 0x12, 0x36, 0x0a, 0x17, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x5f, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x15, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4d,
+	0x6f, 0x6e, 0x74, 0x68, 0x6c, 0x79, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0xdb, 0x03, 0x0a, 0x16,
+	0x42, 0x6c, 0x6f, 0x62, 0x43, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x6c, 0x6f,
+	0x62, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x54, 0x79, 0x70, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x06, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x75, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x42, 0x6c, 0x6f, 0x62, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52,
+	0x07, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x2c, 0x0a, 0x06, 0x65, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x42,
+	0x6c, 0x6f, 0x62, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x06,
+	0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x79, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x64, 0x61, 0x79, 0x73, 0x12, 0x2d, 0x0a, 0x13, 0x61, 0x76,
+	0x67, 0x5f, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x67,
+	0x62, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x61, 0x76, 0x67, 0x44, 0x61, 0x69, 0x6c,
+	0x79, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x47, 0x62, 0x12, 0x2d, 0x0a, 0x13, 0x61, 0x76, 0x67,
+	0x5f, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x67, 0x62,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x61, 0x76, 0x67, 0x44, 0x61, 0x69, 0x6c, 0x79,
+	0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x47, 0x62, 0x12, 0x3c, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x50,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6a, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x05, 0x63, 0x6f, 0x73, 0x74, 0x73, 0x18,
+	0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x6c, 0x6f, 0x62,
+	0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x73, 0x74, 0x52, 0x05, 0x63, 0x6f,
+	0x73, 0x74, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x75, 0x6e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x64, 0x5f, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x14, 0x75, 0x6e, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64,
+	0x42, 0x6c, 0x6f, 0x62, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xec, 0x01, 0x0a, 0x11, 0x42, 0x6c,
+	0x6f, 0x62, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x74, 0x65, 0x6d, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x74, 0x74, 0x61, 0x63,
+	0x68, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x70, 0x6f,
+	0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x50, 0x6f, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xa9, 0x01, 0x0a, 0x1b, 0x42, 0x6c, 0x6f,
+	0x62, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x6c,
+	0x6f, 0x62, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x74, 0x65, 0x6d, 0x52,
+	0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0f, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x32, 0xdc, 0x02, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x45, 0x0a, 0x0a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4c, 0x69,
+	0x6e, 0x6b, 0x12, 0x1a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4c,
+	0x69, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0c, 0x53,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0a, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x6c,
+	0x6f, 0x62, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x11, 0x55, 0x73, 0x65, 0x72, 0x73, 0x53,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0a, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x22, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x6c,
+	0x6f, 0x62, 0x55, 0x73, 0x65, 0x72, 0x73, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x55, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x0a, 0x43,
+	0x6f, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x42, 0x6c, 0x6f, 0x62, 0x43, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x6c, 0x6f, 0x62,
+	0x43, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0f, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x44,
+	0x72, 0x79, 0x52, 0x75, 0x6e, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x20, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x74, 0x65,
+	0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x42, 0x08, 0x5a, 0x06, 0x2e, 0x2e, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -186,21 +1114,48 @@ func file_blob_proto_rawDescGZIP() []byte {
 	return file_blob_proto_rawDescData
 }
 
-var file_blob_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_blob_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_blob_proto_goTypes = []interface{}{
-	(*BlobUploadLinkRequest)(nil),  // 0: rpc.BlobUploadLinkRequest
-	(*BlobUploadLinkResponse)(nil), // 1: rpc.BlobUploadLinkResponse
-	nil,                            // 2: rpc.BlobUploadLinkResponse.FormDataEntry
+	(*BlobUploadLinkRequest)(nil),         // 0: rpc.BlobUploadLinkRequest
+	(*BlobUploadLinkResponse)(nil),        // 1: rpc.BlobUploadLinkResponse
+	(*BlobStorageUsageResponse)(nil),      // 2: rpc.BlobStorageUsageResponse
+	(*BlobUserStorageUsage)(nil),          // 3: rpc.BlobUserStorageUsage
+	(*BlobUsersStorageUsageResponse)(nil), // 4: rpc.BlobUsersStorageUsageResponse
+	(*BlobCostReportRequest)(nil),         // 5: rpc.BlobCostReportRequest
+	(*BlobMediaTypeUsage)(nil),            // 6: rpc.BlobMediaTypeUsage
+	(*BlobDailyVolume)(nil),               // 7: rpc.BlobDailyVolume
+	(*BlobStorageProjection)(nil),         // 8: rpc.BlobStorageProjection
+	(*BlobProviderCost)(nil),              // 9: rpc.BlobProviderCost
+	(*BlobCostReportResponse)(nil),        // 10: rpc.BlobCostReportResponse
+	(*BlobRetentionItem)(nil),             // 11: rpc.BlobRetentionItem
+	(*BlobRetentionDryRunResponse)(nil),   // 12: rpc.BlobRetentionDryRunResponse
+	nil,                                   // 13: rpc.BlobUploadLinkResponse.FormDataEntry
+	(*Empty)(nil),                         // 14: rpc.Empty
 }
 var file_blob_proto_depIdxs = []int32{
-	2, // 0: rpc.BlobUploadLinkResponse.form_data:type_name -> rpc.BlobUploadLinkResponse.FormDataEntry
-	0, // 1: rpc.BlobService.UploadLink:input_type -> rpc.BlobUploadLinkRequest
-	1, // 2: rpc.BlobService.UploadLink:output_type -> rpc.BlobUploadLinkResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	13, // 0: rpc.BlobUploadLinkResponse.form_data:type_name -> rpc.BlobUploadLinkResponse.FormDataEntry
+	3,  // 1: rpc.BlobUsersStorageUsageResponse.users:type_name -> rpc.BlobUserStorageUsage
+	6,  // 2: rpc.BlobCostReportResponse.stored:type_name -> rpc.BlobMediaTypeUsage
+	7,  // 3: rpc.BlobCostReportResponse.uploads:type_name -> rpc.BlobDailyVolume
+	7,  // 4: rpc.BlobCostReportResponse.egress:type_name -> rpc.BlobDailyVolume
+	8,  // 5: rpc.BlobCostReportResponse.projections:type_name -> rpc.BlobStorageProjection
+	9,  // 6: rpc.BlobCostReportResponse.costs:type_name -> rpc.BlobProviderCost
+	11, // 7: rpc.BlobRetentionDryRunResponse.items:type_name -> rpc.BlobRetentionItem
+	0,  // 8: rpc.BlobService.UploadLink:input_type -> rpc.BlobUploadLinkRequest
+	14, // 9: rpc.BlobService.StorageUsage:input_type -> rpc.Empty
+	14, // 10: rpc.BlobService.UsersStorageUsage:input_type -> rpc.Empty
+	5,  // 11: rpc.BlobService.CostReport:input_type -> rpc.BlobCostReportRequest
+	14, // 12: rpc.BlobService.RetentionDryRun:input_type -> rpc.Empty
+	1,  // 13: rpc.BlobService.UploadLink:output_type -> rpc.BlobUploadLinkResponse
+	2,  // 14: rpc.BlobService.StorageUsage:output_type -> rpc.BlobStorageUsageResponse
+	4,  // 15: rpc.BlobService.UsersStorageUsage:output_type -> rpc.BlobUsersStorageUsageResponse
+	10, // 16: rpc.BlobService.CostReport:output_type -> rpc.BlobCostReportResponse
+	12, // 17: rpc.BlobService.RetentionDryRun:output_type -> rpc.BlobRetentionDryRunResponse
+	13, // [13:18] is the sub-list for method output_type
+	8,  // [8:13] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_blob_proto_init() }
@@ -208,6 +1163,7 @@ func file_blob_proto_init() {
 	if File_blob_proto != nil {
 		return
 	}
+	file_model_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_blob_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BlobUploadLinkRequest); i {
@@ -233,6 +1189,138 @@ func file_blob_proto_init() {
 				return nil
 			}
 		}
+		file_blob_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobStorageUsageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blob_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobUserStorageUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blob_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobUsersStorageUsageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blob_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobCostReportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blob_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobMediaTypeUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blob_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobDailyVolume); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blob_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobStorageProjection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blob_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobProviderCost); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blob_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobCostReportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blob_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobRetentionItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blob_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlobRetentionDryRunResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -240,7 +1328,7 @@ func file_blob_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_blob_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
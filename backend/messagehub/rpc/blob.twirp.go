@@ -6,7 +6,9 @@ Package rpc is a generated twirp stub package.
 This code was generated with github.com/twitchtv/twirp/protoc-gen-twirp v5.12.0.
 
 It is generated from these files:
+
 	blob.proto
+	conversation.proto
 	info.proto
 	message.proto
 	model.proto
@@ -38,6 +40,14 @@ import url "net/url"
 
 type BlobService interface {
 	UploadLink(context.Context, *BlobUploadLinkRequest) (*BlobUploadLinkResponse, error)
+
+	StorageUsage(context.Context, *Empty) (*BlobStorageUsageResponse, error)
+
+	UsersStorageUsage(context.Context, *Empty) (*BlobUsersStorageUsageResponse, error)
+
+	CostReport(context.Context, *BlobCostReportRequest) (*BlobCostReportResponse, error)
+
+	RetentionDryRun(context.Context, *Empty) (*BlobRetentionDryRunResponse, error)
 }
 
 // ===========================
@@ -46,7 +56,7 @@ type BlobService interface {
 
 type blobServiceProtobufClient struct {
 	client HTTPClient
-	urls   [1]string
+	urls   [5]string
 	opts   twirp.ClientOptions
 }
 
@@ -63,8 +73,12 @@ func NewBlobServiceProtobufClient(addr string, client HTTPClient, opts ...twirp.
 	}
 
 	prefix := urlBase(addr) + BlobServicePathPrefix
-	urls := [1]string{
+	urls := [5]string{
 		prefix + "UploadLink",
+		prefix + "StorageUsage",
+		prefix + "UsersStorageUsage",
+		prefix + "CostReport",
+		prefix + "RetentionDryRun",
 	}
 
 	return &blobServiceProtobufClient{
@@ -94,13 +108,93 @@ func (c *blobServiceProtobufClient) UploadLink(ctx context.Context, in *BlobUplo
 	return out, nil
 }
 
+func (c *blobServiceProtobufClient) StorageUsage(ctx context.Context, in *Empty) (*BlobStorageUsageResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "BlobService")
+	ctx = ctxsetters.WithMethodName(ctx, "StorageUsage")
+	out := new(BlobStorageUsageResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blobServiceProtobufClient) UsersStorageUsage(ctx context.Context, in *Empty) (*BlobUsersStorageUsageResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "BlobService")
+	ctx = ctxsetters.WithMethodName(ctx, "UsersStorageUsage")
+	out := new(BlobUsersStorageUsageResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blobServiceProtobufClient) CostReport(ctx context.Context, in *BlobCostReportRequest) (*BlobCostReportResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "BlobService")
+	ctx = ctxsetters.WithMethodName(ctx, "CostReport")
+	out := new(BlobCostReportResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blobServiceProtobufClient) RetentionDryRun(ctx context.Context, in *Empty) (*BlobRetentionDryRunResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "BlobService")
+	ctx = ctxsetters.WithMethodName(ctx, "RetentionDryRun")
+	out := new(BlobRetentionDryRunResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // =======================
 // BlobService JSON Client
 // =======================
 
 type blobServiceJSONClient struct {
 	client HTTPClient
-	urls   [1]string
+	urls   [5]string
 	opts   twirp.ClientOptions
 }
 
@@ -117,8 +211,12 @@ func NewBlobServiceJSONClient(addr string, client HTTPClient, opts ...twirp.Clie
 	}
 
 	prefix := urlBase(addr) + BlobServicePathPrefix
-	urls := [1]string{
+	urls := [5]string{
 		prefix + "UploadLink",
+		prefix + "StorageUsage",
+		prefix + "UsersStorageUsage",
+		prefix + "CostReport",
+		prefix + "RetentionDryRun",
 	}
 
 	return &blobServiceJSONClient{
@@ -148,6 +246,86 @@ func (c *blobServiceJSONClient) UploadLink(ctx context.Context, in *BlobUploadLi
 	return out, nil
 }
 
+func (c *blobServiceJSONClient) StorageUsage(ctx context.Context, in *Empty) (*BlobStorageUsageResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "BlobService")
+	ctx = ctxsetters.WithMethodName(ctx, "StorageUsage")
+	out := new(BlobStorageUsageResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blobServiceJSONClient) UsersStorageUsage(ctx context.Context, in *Empty) (*BlobUsersStorageUsageResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "BlobService")
+	ctx = ctxsetters.WithMethodName(ctx, "UsersStorageUsage")
+	out := new(BlobUsersStorageUsageResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blobServiceJSONClient) CostReport(ctx context.Context, in *BlobCostReportRequest) (*BlobCostReportResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "BlobService")
+	ctx = ctxsetters.WithMethodName(ctx, "CostReport")
+	out := new(BlobCostReportResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blobServiceJSONClient) RetentionDryRun(ctx context.Context, in *Empty) (*BlobRetentionDryRunResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "BlobService")
+	ctx = ctxsetters.WithMethodName(ctx, "RetentionDryRun")
+	out := new(BlobRetentionDryRunResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ==========================
 // BlobService Server Handler
 // ==========================
@@ -199,6 +377,18 @@ func (s *blobServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Reques
 	case "/rpc.BlobService/UploadLink":
 		s.serveUploadLink(ctx, resp, req)
 		return
+	case "/rpc.BlobService/StorageUsage":
+		s.serveStorageUsage(ctx, resp, req)
+		return
+	case "/rpc.BlobService/UsersStorageUsage":
+		s.serveUsersStorageUsage(ctx, resp, req)
+		return
+	case "/rpc.BlobService/CostReport":
+		s.serveCostReport(ctx, resp, req)
+		return
+	case "/rpc.BlobService/RetentionDryRun":
+		s.serveRetentionDryRun(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		err = badRouteError(msg, req.Method, req.URL.Path)
@@ -336,6 +526,522 @@ func (s *blobServiceServer) serveUploadLinkProtobuf(ctx context.Context, resp ht
 	callResponseSent(ctx, s.hooks)
 }
 
+func (s *blobServiceServer) serveStorageUsage(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveStorageUsageJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveStorageUsageProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *blobServiceServer) serveStorageUsageJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "StorageUsage")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(Empty)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *BlobStorageUsageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.BlobService.StorageUsage(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BlobStorageUsageResponse and nil error while calling StorageUsage. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blobServiceServer) serveStorageUsageProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "StorageUsage")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(Empty)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *BlobStorageUsageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.BlobService.StorageUsage(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BlobStorageUsageResponse and nil error while calling StorageUsage. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blobServiceServer) serveUsersStorageUsage(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveUsersStorageUsageJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveUsersStorageUsageProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *blobServiceServer) serveUsersStorageUsageJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UsersStorageUsage")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(Empty)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *BlobUsersStorageUsageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.BlobService.UsersStorageUsage(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BlobUsersStorageUsageResponse and nil error while calling UsersStorageUsage. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blobServiceServer) serveUsersStorageUsageProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UsersStorageUsage")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(Empty)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *BlobUsersStorageUsageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.BlobService.UsersStorageUsage(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BlobUsersStorageUsageResponse and nil error while calling UsersStorageUsage. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blobServiceServer) serveCostReport(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveCostReportJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveCostReportProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *blobServiceServer) serveCostReportJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "CostReport")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(BlobCostReportRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *BlobCostReportResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.BlobService.CostReport(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BlobCostReportResponse and nil error while calling CostReport. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blobServiceServer) serveCostReportProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "CostReport")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(BlobCostReportRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *BlobCostReportResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.BlobService.CostReport(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BlobCostReportResponse and nil error while calling CostReport. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blobServiceServer) serveRetentionDryRun(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveRetentionDryRunJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveRetentionDryRunProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *blobServiceServer) serveRetentionDryRunJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RetentionDryRun")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(Empty)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *BlobRetentionDryRunResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.BlobService.RetentionDryRun(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BlobRetentionDryRunResponse and nil error while calling RetentionDryRun. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blobServiceServer) serveRetentionDryRunProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RetentionDryRun")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(Empty)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *BlobRetentionDryRunResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.BlobService.RetentionDryRun(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BlobRetentionDryRunResponse and nil error while calling RetentionDryRun. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
 func (s *blobServiceServer) ServiceDescriptor() ([]byte, int) {
 	return twirpFileDescriptor0, 0
 }
@@ -864,23 +1570,71 @@ func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error)
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 279 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x91, 0xcf, 0x4a, 0xc3, 0x40,
-	0x10, 0x87, 0x49, 0xa3, 0xb5, 0x99, 0x28, 0xc8, 0xe0, 0x9f, 0x90, 0x5e, 0x6a, 0x4f, 0xf5, 0xb2,
-	0x42, 0xbd, 0x88, 0xde, 0x8a, 0x2d, 0x08, 0xe2, 0x21, 0x56, 0x04, 0x2f, 0x61, 0x93, 0x4c, 0x20,
-	0x64, 0xb3, 0xbb, 0x6e, 0xb6, 0x85, 0xbc, 0xa2, 0x4f, 0x25, 0x89, 0x11, 0x51, 0x8a, 0xb7, 0xf9,
-	0xcd, 0xc0, 0x37, 0xdf, 0xee, 0x00, 0x24, 0x42, 0x25, 0x4c, 0x1b, 0x65, 0x15, 0xba, 0x46, 0xa7,
-	0xd3, 0x57, 0x38, 0x5d, 0x08, 0x95, 0xbc, 0x68, 0xa1, 0x78, 0xf6, 0x58, 0xc8, 0x32, 0xa2, 0xf7,
-	0x0d, 0xd5, 0x16, 0x2f, 0xe0, 0x30, 0x55, 0xd2, 0x92, 0xb4, 0xb1, 0x6d, 0x34, 0x05, 0xce, 0xc4,
-	0x99, 0x79, 0x91, 0xdf, 0xf7, 0xd6, 0x8d, 0x26, 0x1c, 0x83, 0x97, 0x17, 0x82, 0x62, 0xc9, 0x2b,
-	0x0a, 0x06, 0xdd, 0x7c, 0xd4, 0x36, 0x9e, 0x78, 0x45, 0xd3, 0x0f, 0x07, 0xce, 0xfe, 0x92, 0x6b,
-	0xad, 0x64, 0x4d, 0x78, 0x0e, 0x07, 0xad, 0x46, 0x5c, 0x64, 0x3d, 0x75, 0xd8, 0xc6, 0x87, 0x0c,
-	0x11, 0xf6, 0x44, 0x21, 0xcb, 0x9e, 0xd5, 0xd5, 0xb8, 0x02, 0x2f, 0x57, 0xa6, 0x8a, 0x33, 0x6e,
-	0x79, 0xe0, 0x4e, 0xdc, 0x99, 0x3f, 0xbf, 0x64, 0x46, 0xa7, 0x6c, 0x37, 0x9c, 0xad, 0x94, 0xa9,
-	0xee, 0xb9, 0xe5, 0x4b, 0x69, 0x4d, 0x13, 0x8d, 0xf2, 0x3e, 0x86, 0x77, 0x70, 0xf4, 0x6b, 0x84,
-	0xc7, 0xe0, 0x96, 0xd4, 0xf4, 0x06, 0x6d, 0x89, 0x27, 0xb0, 0xbf, 0xe5, 0x62, 0xf3, 0xfd, 0x96,
-	0xaf, 0x70, 0x3b, 0xb8, 0x71, 0xe6, 0x6b, 0xf0, 0xdb, 0x75, 0xcf, 0x64, 0xb6, 0x45, 0x4a, 0xb8,
-	0x04, 0xf8, 0xd9, 0x8c, 0xe1, 0x4e, 0x9d, 0xee, 0x17, 0xc3, 0xf1, 0x3f, 0xaa, 0x8b, 0xd1, 0xdb,
-	0x90, 0xb1, 0x2b, 0xa3, 0xd3, 0x64, 0xd8, 0x5d, 0xe4, 0xfa, 0x33, 0x00, 0x00, 0xff, 0xff, 0x56,
-	0x37, 0x12, 0xec, 0x9f, 0x01, 0x00, 0x00,
+	// 1048 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x56, 0xdb, 0x6e, 0xdb, 0x46,
+	0x13, 0x06, 0x45, 0x49, 0x96, 0x46, 0x76, 0xec, 0xec, 0xef, 0x83, 0x7e, 0x19, 0x46, 0x5c, 0x16,
+	0x45, 0x1c, 0x24, 0x55, 0x80, 0xb4, 0x28, 0x7a, 0x02, 0x8a, 0xfa, 0x14, 0x18, 0x88, 0x0b, 0x63,
+	0x93, 0xf4, 0xa2, 0x37, 0xc4, 0x4a, 0x5c, 0x29, 0xac, 0x49, 0x2e, 0xb3, 0xbb, 0x14, 0xc2, 0xdc,
+	0xf4, 0xb2, 0xcf, 0xd2, 0x57, 0xe9, 0x2b, 0xa4, 0x8f, 0xd0, 0x87, 0x28, 0xf6, 0x40, 0x6a, 0x29,
+	0x0b, 0xc9, 0xdd, 0xce, 0xb7, 0xa3, 0xe1, 0xcc, 0x37, 0xdf, 0xce, 0x08, 0x60, 0x92, 0xb0, 0xc9,
+	0x38, 0xe7, 0x4c, 0x32, 0xe4, 0xf3, 0x7c, 0x3a, 0x1a, 0xa4, 0x2c, 0xa2, 0x89, 0x41, 0x82, 0x5b,
+	0xd8, 0x3b, 0x4d, 0xd8, 0xe4, 0x75, 0x9e, 0x30, 0x12, 0xbd, 0x88, 0xb3, 0x5b, 0x4c, 0xdf, 0x16,
+	0x54, 0x48, 0xf4, 0x19, 0x6c, 0x4e, 0x59, 0x26, 0x69, 0x26, 0x43, 0x59, 0xe6, 0x74, 0xe8, 0x1d,
+	0x7b, 0x27, 0x7d, 0x3c, 0xb0, 0xd8, 0xab, 0x32, 0xa7, 0xe8, 0x10, 0xfa, 0xb3, 0x38, 0xa1, 0x61,
+	0x46, 0x52, 0x3a, 0x6c, 0xe9, 0xfb, 0x9e, 0x02, 0x7e, 0x21, 0x29, 0x45, 0x08, 0xda, 0x22, 0x7e,
+	0x4f, 0x87, 0xfe, 0xb1, 0x77, 0xe2, 0x63, 0x7d, 0x0e, 0xfe, 0xf6, 0x60, 0x7f, 0xf5, 0x6b, 0x22,
+	0x67, 0x99, 0xa0, 0xe8, 0x00, 0x36, 0x54, 0x9e, 0x61, 0x1c, 0xd9, 0x2f, 0x75, 0x95, 0x79, 0x15,
+	0xa9, 0x38, 0x49, 0x9c, 0xdd, 0xda, 0xf8, 0xfa, 0x8c, 0x2e, 0xa1, 0x3f, 0x63, 0x3c, 0x0d, 0x23,
+	0x22, 0xc9, 0xd0, 0x3f, 0xf6, 0x4f, 0x06, 0xcf, 0x1e, 0x8d, 0x79, 0x3e, 0x1d, 0xaf, 0x0f, 0x3e,
+	0xbe, 0x64, 0x3c, 0x3d, 0x27, 0x92, 0x5c, 0x64, 0x92, 0x97, 0xb8, 0x37, 0xb3, 0xe6, 0xe8, 0x07,
+	0xd8, 0x6a, 0x5c, 0xa1, 0x1d, 0xf0, 0x6f, 0x69, 0x69, 0x33, 0x50, 0x47, 0xb4, 0x0b, 0x9d, 0x05,
+	0x49, 0x8a, 0xaa, 0x3e, 0x63, 0x7c, 0xdf, 0xfa, 0xd6, 0x0b, 0xfe, 0xf4, 0x60, 0xa8, 0xbe, 0xf7,
+	0x52, 0x32, 0x4e, 0xe6, 0xf4, 0xb5, 0x20, 0x73, 0x5a, 0x97, 0x83, 0xa0, 0x5d, 0x08, 0x6a, 0x6a,
+	0xf1, 0xb1, 0x3e, 0xab, 0x50, 0x6f, 0x0b, 0x26, 0x89, 0x0e, 0xe5, 0x63, 0x63, 0xa0, 0x00, 0xb6,
+	0x52, 0xf2, 0x2e, 0xd4, 0x44, 0x3a, 0x84, 0x0d, 0x52, 0xf2, 0xee, 0x32, 0x4e, 0xe8, 0xcb, 0xf8,
+	0x3d, 0x45, 0x47, 0xa6, 0x89, 0xe1, 0x94, 0x15, 0x99, 0x1c, 0xb6, 0x8f, 0xbd, 0x93, 0x0e, 0xee,
+	0x2b, 0xe4, 0x4c, 0x01, 0xc1, 0x1f, 0xb0, 0xab, 0x0b, 0x17, 0x94, 0xbb, 0xc9, 0x28, 0x4e, 0x0b,
+	0x41, 0xb9, 0xc3, 0xa9, 0x32, 0xaf, 0x22, 0xd5, 0x38, 0x7d, 0xe1, 0x36, 0x4e, 0x01, 0x55, 0xe3,
+	0x74, 0xea, 0xbe, 0x93, 0xfa, 0x27, 0x12, 0x98, 0xc1, 0x51, 0x95, 0x80, 0x58, 0x4b, 0xc7, 0x53,
+	0xe8, 0xa8, 0xf8, 0x62, 0xe8, 0xe9, 0x66, 0xfd, 0x7f, 0xd9, 0xac, 0x95, 0x9c, 0xb1, 0xf1, 0x53,
+	0x5c, 0x49, 0x26, 0x49, 0x52, 0x71, 0xa5, 0x8d, 0xe0, 0xb1, 0x11, 0xeb, 0x19, 0x13, 0x12, 0xd3,
+	0x9c, 0x71, 0x59, 0x89, 0x15, 0x41, 0x3b, 0x22, 0xa5, 0xd0, 0x65, 0x76, 0xb0, 0x3e, 0x07, 0x33,
+	0x40, 0xca, 0xf9, 0x9a, 0x46, 0x31, 0x51, 0x72, 0x35, 0x9c, 0x1c, 0x01, 0xa4, 0x0a, 0x71, 0x45,
+	0xdd, 0x4f, 0x2b, 0x9f, 0x5a, 0xb5, 0xad, 0xa5, 0x6a, 0x57, 0x8a, 0xf7, 0x57, 0x8b, 0xbf, 0x86,
+	0x6d, 0xf5, 0x9d, 0x73, 0x12, 0x27, 0xe5, 0xaf, 0x2c, 0x29, 0x52, 0xaa, 0x64, 0x14, 0x91, 0x5a,
+	0x46, 0x11, 0x29, 0xd7, 0xc6, 0xdd, 0x85, 0x8e, 0x1b, 0xd2, 0x18, 0xc1, 0x0b, 0x53, 0xa3, 0x25,
+	0xe5, 0x86, 0xb3, 0xdf, 0xe9, 0x54, 0xc6, 0x2c, 0x5b, 0x57, 0x23, 0x7a, 0x00, 0x03, 0x21, 0x19,
+	0xa7, 0x51, 0xe8, 0x44, 0x07, 0x03, 0x29, 0xe5, 0x04, 0x1f, 0x3c, 0xd8, 0x51, 0xe1, 0x6e, 0x38,
+	0x5b, 0xc4, 0x11, 0xe5, 0x8a, 0x3a, 0x34, 0x82, 0x5e, 0x6e, 0x6d, 0x9b, 0x63, 0x6d, 0xa3, 0x87,
+	0xb0, 0x9d, 0xb2, 0x4c, 0xbe, 0x49, 0xca, 0x50, 0x98, 0x14, 0x74, 0x54, 0x0f, 0xdf, 0xb3, 0xb0,
+	0x4d, 0x0c, 0x7d, 0x01, 0x15, 0x12, 0xd2, 0x39, 0xa7, 0x42, 0xe8, 0x32, 0x3c, 0xbc, 0x65, 0xd1,
+	0x0b, 0x0d, 0xa2, 0xcf, 0xa1, 0x02, 0x42, 0xd3, 0xd0, 0xb6, 0xf6, 0xda, 0xb4, 0xe0, 0x2b, 0x85,
+	0xa1, 0x6f, 0xe0, 0x20, 0x37, 0x85, 0xd2, 0x28, 0x6c, 0xba, 0x77, 0xb4, 0xfb, 0x5e, 0x7d, 0x7d,
+	0xed, 0xfc, 0x2e, 0xf8, 0xe0, 0x9b, 0x79, 0xe2, 0x0a, 0xa2, 0x56, 0x5c, 0xd7, 0xd0, 0x60, 0x25,
+	0x77, 0x50, 0x4b, 0xae, 0x29, 0x08, 0x6c, 0xdd, 0x3e, 0x49, 0x25, 0x1a, 0xc3, 0x46, 0xa1, 0x47,
+	0x8b, 0xb0, 0x23, 0x67, 0xb7, 0x0e, 0xe9, 0xf4, 0x1e, 0x57, 0x4e, 0xe8, 0x09, 0x74, 0x2d, 0x31,
+	0xed, 0x8f, 0xb8, 0x5b, 0x9f, 0xba, 0xbb, 0x1d, 0xa7, 0xbb, 0x5f, 0xc2, 0xff, 0xc8, 0x62, 0x1e,
+	0x46, 0xca, 0x3d, 0x34, 0x61, 0xc3, 0xf9, 0x64, 0xd8, 0xd5, 0x94, 0xec, 0x90, 0xc5, 0x5c, 0x07,
+	0x32, 0xf3, 0xee, 0xf9, 0xa4, 0xe9, 0x6e, 0xc2, 0x2a, 0xf7, 0x8d, 0xa6, 0xbb, 0xe9, 0xcb, 0xf3,
+	0x09, 0xfa, 0x11, 0x06, 0x79, 0xad, 0x2e, 0x31, 0xec, 0xe9, 0x24, 0x47, 0x75, 0x92, 0x77, 0x04,
+	0x88, 0x5d, 0x77, 0xf4, 0x58, 0x89, 0x57, 0x48, 0x31, 0xec, 0xeb, 0xdf, 0xed, 0xd5, 0xbf, 0x73,
+	0x95, 0x86, 0x8d, 0x0f, 0xfa, 0x1a, 0xf6, 0x8b, 0x8c, 0x4c, 0xb5, 0xc0, 0x69, 0x14, 0x3a, 0xaf,
+	0x09, 0x74, 0xb9, 0xbb, 0xce, 0xed, 0x69, 0xfd, 0xb0, 0xfe, 0xf5, 0xe0, 0xbe, 0xb2, 0x30, 0x55,
+	0x1b, 0x27, 0x66, 0xd9, 0x95, 0xa4, 0xa9, 0x79, 0xc0, 0x42, 0xb5, 0x6e, 0x39, 0xd7, 0xfa, 0x16,
+	0xb9, 0x8a, 0xdc, 0x99, 0xd7, 0x6a, 0xcc, 0xbc, 0x87, 0xb0, 0x4d, 0xa4, 0x24, 0xd3, 0x37, 0x69,
+	0xbd, 0xd2, 0x7c, 0xed, 0x70, 0x6f, 0x09, 0xeb, 0x11, 0x70, 0x04, 0x30, 0xe5, 0x94, 0xa8, 0x44,
+	0x89, 0x99, 0x75, 0x7d, 0xdc, 0xb7, 0xc8, 0xcf, 0xb2, 0x7e, 0xc9, 0x1d, 0xe7, 0x25, 0x3f, 0x80,
+	0x41, 0x44, 0x13, 0x2a, 0x69, 0x98, 0x33, 0x21, 0x75, 0x83, 0x7a, 0x18, 0x0c, 0x74, 0xa3, 0x5e,
+	0x5c, 0xa0, 0x97, 0xe9, 0x82, 0x72, 0x41, 0x54, 0x21, 0xba, 0x27, 0x3d, 0xdc, 0xc0, 0x82, 0xbf,
+	0x3c, 0x38, 0x6c, 0x94, 0x7b, 0xce, 0x4b, 0x5c, 0x64, 0xb5, 0xa2, 0x9f, 0x40, 0x27, 0x96, 0x34,
+	0xad, 0x66, 0xe8, 0x7e, 0xcd, 0x78, 0x83, 0x1f, 0x6c, 0x9c, 0xd0, 0x23, 0xd8, 0x71, 0xca, 0x35,
+	0x64, 0xb7, 0x34, 0xd9, 0x0e, 0x0d, 0x9a, 0x67, 0x55, 0xb0, 0x4a, 0xbb, 0x39, 0xdf, 0x14, 0x62,
+	0xae, 0xab, 0x82, 0xdb, 0xcb, 0x82, 0x9f, 0xfd, 0xd3, 0x82, 0x81, 0x16, 0x09, 0xe5, 0x8b, 0x78,
+	0x4a, 0xd1, 0x05, 0xc0, 0x72, 0xed, 0xa2, 0xd1, 0xda, 0x5d, 0xac, 0x27, 0xf5, 0xe8, 0xf0, 0x23,
+	0x7b, 0x1a, 0x7d, 0x07, 0x9b, 0x8d, 0x05, 0x06, 0xda, 0xf9, 0x22, 0xcd, 0x65, 0x39, 0x3a, 0x5a,
+	0x55, 0x66, 0x73, 0xc3, 0x9c, 0xc1, 0xfd, 0x3b, 0xeb, 0xa7, 0xf1, 0xfb, 0xa0, 0xb1, 0x73, 0xd6,
+	0xaf, 0xa9, 0x0b, 0x80, 0xe5, 0x28, 0x71, 0xca, 0xb8, 0xb3, 0x70, 0x46, 0x87, 0x6b, 0xef, 0x6c,
+	0x98, 0x9f, 0x60, 0x7b, 0xa5, 0x89, 0x8d, 0x4c, 0x8e, 0xef, 0x76, 0xae, 0xd9, 0xea, 0xd3, 0xde,
+	0x6f, 0xdd, 0xf1, 0xf8, 0x29, 0xcf, 0xa7, 0x93, 0xae, 0xfe, 0x97, 0xf6, 0xd5, 0x7f, 0x03, 0x00,
+	0x1c, 0xa5, 0x52, 0x0b, 0xc5, 0x09, 0x00, 0x00,
 }
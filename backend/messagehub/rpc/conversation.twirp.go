@@ -0,0 +1,1072 @@
+// Code generated by protoc-gen-twirp v5.12.0, DO NOT EDIT.
+// source: conversation.proto
+
+package rpc
+
+import bytes "bytes"
+import strings "strings"
+import context "context"
+import fmt "fmt"
+import ioutil "io/ioutil"
+import http "net/http"
+import strconv "strconv"
+
+import jsonpb "github.com/golang/protobuf/jsonpb"
+import proto "github.com/golang/protobuf/proto"
+import twirp "github.com/twitchtv/twirp"
+import ctxsetters "github.com/twitchtv/twirp/ctxsetters"
+
+// =============================
+// ConversationService Interface
+// =============================
+
+type ConversationService interface {
+	Create(context.Context, *ConversationCreateRequest) (*ConversationCreateResponse, error)
+
+	Conversations(context.Context, *Empty) (*ConversationConversationsResponse, error)
+
+	Send(context.Context, *ConversationSendRequest) (*ConversationSendResponse, error)
+
+	Messages(context.Context, *ConversationMessagesRequest) (*ConversationMessagesResponse, error)
+
+	MarkRead(context.Context, *ConversationMarkReadRequest) (*Empty, error)
+}
+
+// ===================================
+// ConversationService Protobuf Client
+// ===================================
+
+type conversationServiceProtobufClient struct {
+	client HTTPClient
+	urls   [5]string
+	opts   twirp.ClientOptions
+}
+
+// NewConversationServiceProtobufClient creates a Protobuf client that implements the ConversationService interface.
+// It communicates using Protobuf and can be configured with a custom HTTPClient.
+func NewConversationServiceProtobufClient(addr string, client HTTPClient, opts ...twirp.ClientOption) ConversationService {
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+
+	clientOpts := twirp.ClientOptions{}
+	for _, o := range opts {
+		o(&clientOpts)
+	}
+
+	prefix := urlBase(addr) + ConversationServicePathPrefix
+	urls := [5]string{
+		prefix + "Create",
+		prefix + "Conversations",
+		prefix + "Send",
+		prefix + "Messages",
+		prefix + "MarkRead",
+	}
+
+	return &conversationServiceProtobufClient{
+		client: client,
+		urls:   urls,
+		opts:   clientOpts,
+	}
+}
+
+func (c *conversationServiceProtobufClient) Create(ctx context.Context, in *ConversationCreateRequest) (*ConversationCreateResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "ConversationService")
+	ctx = ctxsetters.WithMethodName(ctx, "Create")
+	out := new(ConversationCreateResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *conversationServiceProtobufClient) Conversations(ctx context.Context, in *Empty) (*ConversationConversationsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "ConversationService")
+	ctx = ctxsetters.WithMethodName(ctx, "Conversations")
+	out := new(ConversationConversationsResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *conversationServiceProtobufClient) Send(ctx context.Context, in *ConversationSendRequest) (*ConversationSendResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "ConversationService")
+	ctx = ctxsetters.WithMethodName(ctx, "Send")
+	out := new(ConversationSendResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *conversationServiceProtobufClient) Messages(ctx context.Context, in *ConversationMessagesRequest) (*ConversationMessagesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "ConversationService")
+	ctx = ctxsetters.WithMethodName(ctx, "Messages")
+	out := new(ConversationMessagesResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *conversationServiceProtobufClient) MarkRead(ctx context.Context, in *ConversationMarkReadRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "ConversationService")
+	ctx = ctxsetters.WithMethodName(ctx, "MarkRead")
+	out := new(Empty)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// ===============================
+// ConversationService JSON Client
+// ===============================
+
+type conversationServiceJSONClient struct {
+	client HTTPClient
+	urls   [5]string
+	opts   twirp.ClientOptions
+}
+
+// NewConversationServiceJSONClient creates a JSON client that implements the ConversationService interface.
+// It communicates using JSON and can be configured with a custom HTTPClient.
+func NewConversationServiceJSONClient(addr string, client HTTPClient, opts ...twirp.ClientOption) ConversationService {
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+
+	clientOpts := twirp.ClientOptions{}
+	for _, o := range opts {
+		o(&clientOpts)
+	}
+
+	prefix := urlBase(addr) + ConversationServicePathPrefix
+	urls := [5]string{
+		prefix + "Create",
+		prefix + "Conversations",
+		prefix + "Send",
+		prefix + "Messages",
+		prefix + "MarkRead",
+	}
+
+	return &conversationServiceJSONClient{
+		client: client,
+		urls:   urls,
+		opts:   clientOpts,
+	}
+}
+
+func (c *conversationServiceJSONClient) Create(ctx context.Context, in *ConversationCreateRequest) (*ConversationCreateResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "ConversationService")
+	ctx = ctxsetters.WithMethodName(ctx, "Create")
+	out := new(ConversationCreateResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *conversationServiceJSONClient) Conversations(ctx context.Context, in *Empty) (*ConversationConversationsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "ConversationService")
+	ctx = ctxsetters.WithMethodName(ctx, "Conversations")
+	out := new(ConversationConversationsResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *conversationServiceJSONClient) Send(ctx context.Context, in *ConversationSendRequest) (*ConversationSendResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "ConversationService")
+	ctx = ctxsetters.WithMethodName(ctx, "Send")
+	out := new(ConversationSendResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *conversationServiceJSONClient) Messages(ctx context.Context, in *ConversationMessagesRequest) (*ConversationMessagesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "ConversationService")
+	ctx = ctxsetters.WithMethodName(ctx, "Messages")
+	out := new(ConversationMessagesResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *conversationServiceJSONClient) MarkRead(ctx context.Context, in *ConversationMarkReadRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "ConversationService")
+	ctx = ctxsetters.WithMethodName(ctx, "MarkRead")
+	out := new(Empty)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// ==================================
+// ConversationService Server Handler
+// ==================================
+
+type conversationServiceServer struct {
+	ConversationService
+	hooks *twirp.ServerHooks
+}
+
+func NewConversationServiceServer(svc ConversationService, hooks *twirp.ServerHooks) TwirpServer {
+	return &conversationServiceServer{
+		ConversationService: svc,
+		hooks:               hooks,
+	}
+}
+
+// writeError writes an HTTP response with a valid Twirp error format, and triggers hooks.
+// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
+func (s *conversationServiceServer) writeError(ctx context.Context, resp http.ResponseWriter, err error) {
+	writeError(ctx, resp, err, s.hooks)
+}
+
+// ConversationServicePathPrefix is used for all URL paths on a twirp ConversationService server.
+// Requests are always: POST ConversationServicePathPrefix/method
+// It can be used in an HTTP mux to route twirp requests along with non-twirp requests on other routes.
+const ConversationServicePathPrefix = "/rpc.ConversationService/"
+
+func (s *conversationServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "ConversationService")
+	ctx = ctxsetters.WithResponseWriter(ctx, resp)
+
+	var err error
+	ctx, err = callRequestReceived(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	if req.Method != "POST" {
+		msg := fmt.Sprintf("unsupported method %q (only POST is allowed)", req.Method)
+		err = badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	switch req.URL.Path {
+	case "/rpc.ConversationService/Create":
+		s.serveCreate(ctx, resp, req)
+		return
+	case "/rpc.ConversationService/Conversations":
+		s.serveConversations(ctx, resp, req)
+		return
+	case "/rpc.ConversationService/Send":
+		s.serveSend(ctx, resp, req)
+		return
+	case "/rpc.ConversationService/Messages":
+		s.serveMessages(ctx, resp, req)
+		return
+	case "/rpc.ConversationService/MarkRead":
+		s.serveMarkRead(ctx, resp, req)
+		return
+	default:
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		err = badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, err)
+		return
+	}
+}
+
+func (s *conversationServiceServer) serveCreate(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveCreateJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveCreateProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *conversationServiceServer) serveCreateJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Create")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(ConversationCreateRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *ConversationCreateResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.ConversationService.Create(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ConversationCreateResponse and nil error while calling Create. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *conversationServiceServer) serveCreateProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Create")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(ConversationCreateRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *ConversationCreateResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.ConversationService.Create(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ConversationCreateResponse and nil error while calling Create. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *conversationServiceServer) serveConversations(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveConversationsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveConversationsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *conversationServiceServer) serveConversationsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Conversations")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(Empty)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *ConversationConversationsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.ConversationService.Conversations(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ConversationConversationsResponse and nil error while calling Conversations. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *conversationServiceServer) serveConversationsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Conversations")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(Empty)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *ConversationConversationsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.ConversationService.Conversations(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ConversationConversationsResponse and nil error while calling Conversations. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *conversationServiceServer) serveSend(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveSendJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveSendProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *conversationServiceServer) serveSendJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Send")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(ConversationSendRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *ConversationSendResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.ConversationService.Send(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ConversationSendResponse and nil error while calling Send. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *conversationServiceServer) serveSendProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Send")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(ConversationSendRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *ConversationSendResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.ConversationService.Send(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ConversationSendResponse and nil error while calling Send. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *conversationServiceServer) serveMessages(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveMessagesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveMessagesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *conversationServiceServer) serveMessagesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Messages")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(ConversationMessagesRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *ConversationMessagesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.ConversationService.Messages(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ConversationMessagesResponse and nil error while calling Messages. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *conversationServiceServer) serveMessagesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Messages")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(ConversationMessagesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *ConversationMessagesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.ConversationService.Messages(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ConversationMessagesResponse and nil error while calling Messages. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *conversationServiceServer) serveMarkRead(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveMarkReadJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveMarkReadProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *conversationServiceServer) serveMarkReadJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "MarkRead")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(ConversationMarkReadRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.ConversationService.MarkRead(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling MarkRead. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *conversationServiceServer) serveMarkReadProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "MarkRead")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(ConversationMarkReadRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.ConversationService.MarkRead(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling MarkRead. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *conversationServiceServer) ServiceDescriptor() ([]byte, int) {
+	return twirpFileDescriptor1, 0
+}
+
+func (s *conversationServiceServer) ProtocGenTwirpVersion() string {
+	return "v5.12.0"
+}
+
+func (s *conversationServiceServer) PathPrefix() string {
+	return ConversationServicePathPrefix
+}
+
+var twirpFileDescriptor1 = []byte{
+	// 493 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0x95, 0x9b, 0x0f, 0x9c, 0x49, 0xc2, 0xc7, 0x82, 0x84, 0xeb, 0x16, 0x48, 0x8c, 0x04, 0x39,
+	0x19, 0x11, 0x40, 0xe5, 0x8c, 0x29, 0x52, 0x0e, 0x80, 0xe4, 0x72, 0xe2, 0x12, 0x19, 0xef, 0x00,
+	0x51, 0xeb, 0x5d, 0xb3, 0xbb, 0x89, 0xe0, 0xc4, 0x2f, 0xe0, 0x2f, 0xf2, 0x2f, 0xb8, 0x23, 0x7b,
+	0xd7, 0xed, 0xba, 0xb6, 0x91, 0xe8, 0x6d, 0x3d, 0xfb, 0xe6, 0xed, 0xbc, 0x37, 0x33, 0x06, 0x92,
+	0x72, 0xb6, 0x43, 0x21, 0x13, 0xb5, 0xe1, 0x2c, 0xcc, 0x05, 0x57, 0x9c, 0xf4, 0x44, 0x9e, 0xfa,
+	0xe3, 0x8c, 0x53, 0x3c, 0xd3, 0x91, 0xe0, 0x29, 0xec, 0x47, 0x16, 0x2e, 0x12, 0x98, 0x28, 0x8c,
+	0xf1, 0xdb, 0x16, 0xa5, 0x22, 0x77, 0x60, 0xa0, 0xf8, 0x29, 0x32, 0xcf, 0x99, 0x39, 0x8b, 0x51,
+	0xac, 0x3f, 0x82, 0x13, 0xf0, 0xdb, 0x52, 0x64, 0xce, 0x99, 0x44, 0xf2, 0x02, 0x26, 0xf6, 0xc3,
+	0x65, 0xea, 0x78, 0x79, 0x2b, 0x14, 0x79, 0x1a, 0xda, 0x69, 0x71, 0x0d, 0x16, 0xfc, 0x84, 0x79,
+	0x8d, 0xd4, 0x3a, 0xcb, 0x73, 0xee, 0x23, 0x98, 0xda, 0x49, 0xd2, 0x73, 0x66, 0xbd, 0x76, 0xf2,
+	0x3a, 0x8e, 0xcc, 0x61, 0xb2, 0x65, 0x02, 0x13, 0xba, 0x4e, 0xf9, 0x96, 0x29, 0x6f, 0x6f, 0xe6,
+	0x2c, 0x06, 0xf1, 0x58, 0xc7, 0xa2, 0x22, 0x14, 0xfc, 0x76, 0xe0, 0xae, 0x4d, 0x71, 0x82, 0x8c,
+	0x56, 0x3e, 0x3c, 0x86, 0x1b, 0x36, 0xdf, 0x7a, 0x43, 0x8d, 0x23, 0xd7, 0xed, 0xf0, 0x8a, 0x12,
+	0x02, 0x7d, 0x85, 0xdf, 0x35, 0xff, 0x28, 0x2e, 0xcf, 0xe4, 0x21, 0x4c, 0x13, 0xa5, 0x92, 0xf4,
+	0x6b, 0x86, 0x4c, 0x15, 0xa9, 0xbd, 0xf2, 0x72, 0x72, 0x11, 0x5c, 0x51, 0xb2, 0x80, 0x9b, 0x12,
+	0x19, 0x45, 0xb1, 0xa6, 0xb8, 0xdb, 0xa4, 0x58, 0xe0, 0xfa, 0xfa, 0x09, 0x1d, 0x7f, 0x5d, 0x86,
+	0x57, 0x94, 0x1c, 0xc1, 0x08, 0xd9, 0x0e, 0xcf, 0x78, 0x8e, 0xd2, 0x1b, 0x94, 0xfa, 0xf7, 0x1b,
+	0xfa, 0x8f, 0x0d, 0x22, 0xbe, 0xc0, 0x06, 0xef, 0xc0, 0x6b, 0xea, 0x33, 0xc6, 0x2e, 0xe1, 0x5a,
+	0x86, 0x52, 0x26, 0x5f, 0xd0, 0xf4, 0xcb, 0x6b, 0x50, 0xbe, 0xd5, 0xf7, 0x71, 0x05, 0x0c, 0x7e,
+	0x39, 0x70, 0xd0, 0x02, 0x90, 0x57, 0x31, 0xed, 0xb3, 0xe0, 0x59, 0x65, 0x5a, 0x71, 0x2e, 0x26,
+	0x4f, 0x77, 0xaa, 0x57, 0x76, 0x4a, 0x7f, 0x90, 0x03, 0x18, 0x5d, 0xb6, 0xc7, 0xa5, 0xc6, 0x98,
+	0xe0, 0x03, 0x1c, 0xb6, 0x97, 0x63, 0x34, 0x3e, 0x07, 0xd7, 0x94, 0x5e, 0xcd, 0x4d, 0xb7, 0xc8,
+	0x73, 0x64, 0xf0, 0xe6, 0x92, 0xc8, 0x44, 0x9c, 0xc6, 0x98, 0xfc, 0xf7, 0x64, 0x2c, 0xff, 0xec,
+	0xc1, 0xed, 0xba, 0xfd, 0xa2, 0xa8, 0x9b, 0xac, 0x60, 0xa8, 0x17, 0x88, 0xdc, 0x6f, 0x54, 0x53,
+	0x5b, 0x46, 0xff, 0x41, 0xe7, 0xbd, 0x11, 0x18, 0xc1, 0x34, 0xaa, 0x4d, 0x3d, 0x94, 0x19, 0xc7,
+	0x59, 0xae, 0x7e, 0xf8, 0x8f, 0x9a, 0xd9, 0xad, 0x2b, 0x16, 0x41, 0xbf, 0x98, 0x0c, 0x72, 0xd8,
+	0xc0, 0x5b, 0x0b, 0xe1, 0xdf, 0xeb, 0xb8, 0x35, 0x24, 0xef, 0xc1, 0xad, 0xec, 0x27, 0xb3, 0x2e,
+	0x93, 0xab, 0x41, 0xf1, 0xe7, 0xff, 0x40, 0x18, 0xc2, 0x97, 0xe0, 0x56, 0xce, 0xb7, 0x11, 0xd6,
+	0x9b, 0xe2, 0x5b, 0xba, 0x5f, 0xb9, 0x1f, 0x87, 0x61, 0xf8, 0x44, 0xe4, 0xe9, 0xa7, 0x61, 0xf9,
+	0xc3, 0x7b, 0xf6, 0x77, 0x00, 0xb4, 0x02, 0x8e, 0x3d, 0x18, 0x05, 0x00, 0x00,
+}
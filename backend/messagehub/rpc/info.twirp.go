@@ -497,7 +497,7 @@ func (s *infoServiceServer) serveVersionProtobuf(ctx context.Context, resp http.
 }
 
 func (s *infoServiceServer) ServiceDescriptor() ([]byte, int) {
-	return twirpFileDescriptor1, 0
+	return twirpFileDescriptor2, 0
 }
 
 func (s *infoServiceServer) ProtocGenTwirpVersion() string {
@@ -508,8 +508,8 @@ func (s *infoServiceServer) PathPrefix() string {
 	return InfoServicePathPrefix
 }
 
-var twirpFileDescriptor1 = []byte{
-	// 202 bytes of a gzipped FileDescriptorProto
+var twirpFileDescriptor2 = []byte{
+	// 199 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xca, 0xcc, 0x4b, 0xcb,
 	0xd7, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2e, 0x2a, 0x48, 0x96, 0xe2, 0xce, 0xcd, 0x4f,
 	0x49, 0xcd, 0x81, 0x88, 0x28, 0x99, 0x71, 0x89, 0x7a, 0xe6, 0xa5, 0xe5, 0x07, 0x94, 0x26, 0xe5,
@@ -521,6 +521,6 @@ var twirpFileDescriptor1 = []byte{
 	0xee, 0xe0, 0xd4, 0xa2, 0xb2, 0xcc, 0xe4, 0x54, 0x21, 0x63, 0x2e, 0x4e, 0xb8, 0x03, 0x84, 0xb8,
 	0xf4, 0x8a, 0x0a, 0x92, 0xf5, 0x5c, 0x73, 0x0b, 0x4a, 0x2a, 0xa5, 0xa4, 0xc0, 0x6c, 0xec, 0x0e,
 	0xd4, 0xe7, 0x62, 0x87, 0xda, 0x8e, 0xa2, 0x45, 0x02, 0xae, 0x05, 0xcd, 0x6d, 0x4e, 0x1c, 0x51,
-	0x6c, 0x7a, 0x7a, 0xfa, 0x45, 0x05, 0xc9, 0x49, 0x6c, 0x60, 0xbf, 0x1b, 0x03, 0x02, 0x00, 0x00,
-	0xff, 0xff, 0x88, 0x9d, 0x05, 0x9e, 0x1b, 0x01, 0x00, 0x00,
+	0x6c, 0x7a, 0x7a, 0xfa, 0x45, 0x05, 0xc9, 0x49, 0x6c, 0x60, 0xbf, 0x1b, 0x03, 0x06, 0x00, 0x88,
+	0x9d, 0x05, 0x9e, 0x1b, 0x01, 0x00, 0x00,
 }
@@ -48,6 +48,30 @@ type MessageService interface {
 	SetMessageVisibility(context.Context, *MessageSetMessageVisibilityRequest) (*Empty, error)
 
 	SetCommentVisibility(context.Context, *MessageSetCommentVisibilityRequest) (*Empty, error)
+
+	AvailableReactions(context.Context, *Empty) (*MessageAvailableReactionsResponse, error)
+
+	AddReaction(context.Context, *MessageAddReactionRequest) (*MessageReactionResponse, error)
+
+	RemoveReaction(context.Context, *MessageRemoveReactionRequest) (*MessageReactionResponse, error)
+
+	MessageReactions(context.Context, *MessageMessageReactionsRequest) (*MessageMessageReactionsResponse, error)
+
+	SaveDraft(context.Context, *MessageSaveDraftRequest) (*MessageSaveDraftResponse, error)
+
+	Drafts(context.Context, *Empty) (*MessageDraftsResponse, error)
+
+	Vote(context.Context, *MessageVoteRequest) (*MessageVoteResponse, error)
+
+	Rsvp(context.Context, *MessageRsvpRequest) (*MessageRsvpResponse, error)
+
+	CalendarFeed(context.Context, *Empty) (*MessageCalendarFeedResponse, error)
+
+	RotateCalendarFeed(context.Context, *Empty) (*MessageCalendarFeedResponse, error)
+
+	SetDeleteExpiredPosts(context.Context, *MessageSetDeleteExpiredPostsRequest) (*Empty, error)
+
+	PostingLimits(context.Context, *Empty) (*MessagePostingLimitsResponse, error)
 }
 
 // ==============================
@@ -56,7 +80,7 @@ type MessageService interface {
 
 type messageServiceProtobufClient struct {
 	client HTTPClient
-	urls   [14]string
+	urls   [26]string
 	opts   twirp.ClientOptions
 }
 
@@ -73,7 +97,7 @@ func NewMessageServiceProtobufClient(addr string, client HTTPClient, opts ...twi
 	}
 
 	prefix := urlBase(addr) + MessageServicePathPrefix
-	urls := [14]string{
+	urls := [26]string{
 		prefix + "Messages",
 		prefix + "Message",
 		prefix + "Post",
@@ -88,6 +112,18 @@ func NewMessageServiceProtobufClient(addr string, client HTTPClient, opts ...twi
 		prefix + "CommentLikes",
 		prefix + "SetMessageVisibility",
 		prefix + "SetCommentVisibility",
+		prefix + "AvailableReactions",
+		prefix + "AddReaction",
+		prefix + "RemoveReaction",
+		prefix + "MessageReactions",
+		prefix + "SaveDraft",
+		prefix + "Drafts",
+		prefix + "Vote",
+		prefix + "Rsvp",
+		prefix + "CalendarFeed",
+		prefix + "RotateCalendarFeed",
+		prefix + "SetDeleteExpiredPosts",
+		prefix + "PostingLimits",
 	}
 
 	return &messageServiceProtobufClient{
@@ -377,13 +413,253 @@ func (c *messageServiceProtobufClient) SetCommentVisibility(ctx context.Context,
 	return out, nil
 }
 
+func (c *messageServiceProtobufClient) AvailableReactions(ctx context.Context, in *Empty) (*MessageAvailableReactionsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "AvailableReactions")
+	out := new(MessageAvailableReactionsResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[14], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceProtobufClient) AddReaction(ctx context.Context, in *MessageAddReactionRequest) (*MessageReactionResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "AddReaction")
+	out := new(MessageReactionResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[15], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceProtobufClient) RemoveReaction(ctx context.Context, in *MessageRemoveReactionRequest) (*MessageReactionResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "RemoveReaction")
+	out := new(MessageReactionResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[16], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceProtobufClient) MessageReactions(ctx context.Context, in *MessageMessageReactionsRequest) (*MessageMessageReactionsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "MessageReactions")
+	out := new(MessageMessageReactionsResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[17], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceProtobufClient) SaveDraft(ctx context.Context, in *MessageSaveDraftRequest) (*MessageSaveDraftResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "SaveDraft")
+	out := new(MessageSaveDraftResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[18], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceProtobufClient) Drafts(ctx context.Context, in *Empty) (*MessageDraftsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "Drafts")
+	out := new(MessageDraftsResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[19], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceProtobufClient) Vote(ctx context.Context, in *MessageVoteRequest) (*MessageVoteResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "Vote")
+	out := new(MessageVoteResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[20], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceProtobufClient) Rsvp(ctx context.Context, in *MessageRsvpRequest) (*MessageRsvpResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "Rsvp")
+	out := new(MessageRsvpResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[21], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceProtobufClient) CalendarFeed(ctx context.Context, in *Empty) (*MessageCalendarFeedResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "CalendarFeed")
+	out := new(MessageCalendarFeedResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[22], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceProtobufClient) RotateCalendarFeed(ctx context.Context, in *Empty) (*MessageCalendarFeedResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "RotateCalendarFeed")
+	out := new(MessageCalendarFeedResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[23], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceProtobufClient) SetDeleteExpiredPosts(ctx context.Context, in *MessageSetDeleteExpiredPostsRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetDeleteExpiredPosts")
+	out := new(Empty)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[24], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceProtobufClient) PostingLimits(ctx context.Context, in *Empty) (*MessagePostingLimitsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "PostingLimits")
+	out := new(MessagePostingLimitsResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[25], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ==========================
 // MessageService JSON Client
 // ==========================
 
 type messageServiceJSONClient struct {
 	client HTTPClient
-	urls   [14]string
+	urls   [26]string
 	opts   twirp.ClientOptions
 }
 
@@ -400,7 +676,7 @@ func NewMessageServiceJSONClient(addr string, client HTTPClient, opts ...twirp.C
 	}
 
 	prefix := urlBase(addr) + MessageServicePathPrefix
-	urls := [14]string{
+	urls := [26]string{
 		prefix + "Messages",
 		prefix + "Message",
 		prefix + "Post",
@@ -415,6 +691,18 @@ func NewMessageServiceJSONClient(addr string, client HTTPClient, opts ...twirp.C
 		prefix + "CommentLikes",
 		prefix + "SetMessageVisibility",
 		prefix + "SetCommentVisibility",
+		prefix + "AvailableReactions",
+		prefix + "AddReaction",
+		prefix + "RemoveReaction",
+		prefix + "MessageReactions",
+		prefix + "SaveDraft",
+		prefix + "Drafts",
+		prefix + "Vote",
+		prefix + "Rsvp",
+		prefix + "CalendarFeed",
+		prefix + "RotateCalendarFeed",
+		prefix + "SetDeleteExpiredPosts",
+		prefix + "PostingLimits",
 	}
 
 	return &messageServiceJSONClient{
@@ -704,41 +992,281 @@ func (c *messageServiceJSONClient) SetCommentVisibility(ctx context.Context, in
 	return out, nil
 }
 
-// =============================
-// MessageService Server Handler
-// =============================
+func (c *messageServiceJSONClient) AvailableReactions(ctx context.Context, in *Empty) (*MessageAvailableReactionsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "AvailableReactions")
+	out := new(MessageAvailableReactionsResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[14], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
 
-type messageServiceServer struct {
-	MessageService
-	hooks *twirp.ServerHooks
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
 }
 
-func NewMessageServiceServer(svc MessageService, hooks *twirp.ServerHooks) TwirpServer {
-	return &messageServiceServer{
-		MessageService: svc,
-		hooks:          hooks,
+func (c *messageServiceJSONClient) AddReaction(ctx context.Context, in *MessageAddReactionRequest) (*MessageReactionResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "AddReaction")
+	out := new(MessageReactionResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[15], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
 	}
-}
 
-// writeError writes an HTTP response with a valid Twirp error format, and triggers hooks.
-// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
-func (s *messageServiceServer) writeError(ctx context.Context, resp http.ResponseWriter, err error) {
-	writeError(ctx, resp, err, s.hooks)
-}
+	callClientResponseReceived(ctx, c.opts.Hooks)
 
-// MessageServicePathPrefix is used for all URL paths on a twirp MessageService server.
-// Requests are always: POST MessageServicePathPrefix/method
-// It can be used in an HTTP mux to route twirp requests along with non-twirp requests on other routes.
-const MessageServicePathPrefix = "/rpc.MessageService/"
+	return out, nil
+}
 
-func (s *messageServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
-	ctx := req.Context()
+func (c *messageServiceJSONClient) RemoveReaction(ctx context.Context, in *MessageRemoveReactionRequest) (*MessageReactionResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "rpc")
 	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
-	ctx = ctxsetters.WithResponseWriter(ctx, resp)
-
-	var err error
-	ctx, err = callRequestReceived(ctx, s.hooks)
+	ctx = ctxsetters.WithMethodName(ctx, "RemoveReaction")
+	out := new(MessageReactionResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[16], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceJSONClient) MessageReactions(ctx context.Context, in *MessageMessageReactionsRequest) (*MessageMessageReactionsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "MessageReactions")
+	out := new(MessageMessageReactionsResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[17], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceJSONClient) SaveDraft(ctx context.Context, in *MessageSaveDraftRequest) (*MessageSaveDraftResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "SaveDraft")
+	out := new(MessageSaveDraftResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[18], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceJSONClient) Drafts(ctx context.Context, in *Empty) (*MessageDraftsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "Drafts")
+	out := new(MessageDraftsResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[19], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceJSONClient) Vote(ctx context.Context, in *MessageVoteRequest) (*MessageVoteResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "Vote")
+	out := new(MessageVoteResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[20], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceJSONClient) Rsvp(ctx context.Context, in *MessageRsvpRequest) (*MessageRsvpResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "Rsvp")
+	out := new(MessageRsvpResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[21], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceJSONClient) CalendarFeed(ctx context.Context, in *Empty) (*MessageCalendarFeedResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "CalendarFeed")
+	out := new(MessageCalendarFeedResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[22], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceJSONClient) RotateCalendarFeed(ctx context.Context, in *Empty) (*MessageCalendarFeedResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "RotateCalendarFeed")
+	out := new(MessageCalendarFeedResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[23], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceJSONClient) SetDeleteExpiredPosts(ctx context.Context, in *MessageSetDeleteExpiredPostsRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetDeleteExpiredPosts")
+	out := new(Empty)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[24], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *messageServiceJSONClient) PostingLimits(ctx context.Context, in *Empty) (*MessagePostingLimitsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithMethodName(ctx, "PostingLimits")
+	out := new(MessagePostingLimitsResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[25], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// =============================
+// MessageService Server Handler
+// =============================
+
+type messageServiceServer struct {
+	MessageService
+	hooks *twirp.ServerHooks
+}
+
+func NewMessageServiceServer(svc MessageService, hooks *twirp.ServerHooks) TwirpServer {
+	return &messageServiceServer{
+		MessageService: svc,
+		hooks:          hooks,
+	}
+}
+
+// writeError writes an HTTP response with a valid Twirp error format, and triggers hooks.
+// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
+func (s *messageServiceServer) writeError(ctx context.Context, resp http.ResponseWriter, err error) {
+	writeError(ctx, resp, err, s.hooks)
+}
+
+// MessageServicePathPrefix is used for all URL paths on a twirp MessageService server.
+// Requests are always: POST MessageServicePathPrefix/method
+// It can be used in an HTTP mux to route twirp requests along with non-twirp requests on other routes.
+const MessageServicePathPrefix = "/rpc.MessageService/"
+
+func (s *messageServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageService")
+	ctx = ctxsetters.WithResponseWriter(ctx, resp)
+
+	var err error
+	ctx, err = callRequestReceived(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
@@ -794,6 +1322,42 @@ func (s *messageServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Req
 	case "/rpc.MessageService/SetCommentVisibility":
 		s.serveSetCommentVisibility(ctx, resp, req)
 		return
+	case "/rpc.MessageService/AvailableReactions":
+		s.serveAvailableReactions(ctx, resp, req)
+		return
+	case "/rpc.MessageService/AddReaction":
+		s.serveAddReaction(ctx, resp, req)
+		return
+	case "/rpc.MessageService/RemoveReaction":
+		s.serveRemoveReaction(ctx, resp, req)
+		return
+	case "/rpc.MessageService/MessageReactions":
+		s.serveMessageReactions(ctx, resp, req)
+		return
+	case "/rpc.MessageService/SaveDraft":
+		s.serveSaveDraft(ctx, resp, req)
+		return
+	case "/rpc.MessageService/Drafts":
+		s.serveDrafts(ctx, resp, req)
+		return
+	case "/rpc.MessageService/Vote":
+		s.serveVote(ctx, resp, req)
+		return
+	case "/rpc.MessageService/Rsvp":
+		s.serveRsvp(ctx, resp, req)
+		return
+	case "/rpc.MessageService/CalendarFeed":
+		s.serveCalendarFeed(ctx, resp, req)
+		return
+	case "/rpc.MessageService/RotateCalendarFeed":
+		s.serveRotateCalendarFeed(ctx, resp, req)
+		return
+	case "/rpc.MessageService/SetDeleteExpiredPosts":
+		s.serveSetDeleteExpiredPosts(ctx, resp, req)
+		return
+	case "/rpc.MessageService/PostingLimits":
+		s.servePostingLimits(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		err = badRouteError(msg, req.Method, req.URL.Path)
@@ -802,7 +1366,1555 @@ func (s *messageServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Req
 	}
 }
 
-func (s *messageServiceServer) serveMessages(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveMessages(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveMessagesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveMessagesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *messageServiceServer) serveMessagesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Messages")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(MessageMessagesRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageMessagesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.Messages(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageMessagesResponse and nil error while calling Messages. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveMessagesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Messages")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(MessageMessagesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageMessagesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.Messages(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageMessagesResponse and nil error while calling Messages. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveMessage(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveMessageJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveMessageProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *messageServiceServer) serveMessageJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Message")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(MessageMessageRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageMessageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.Message(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageMessageResponse and nil error while calling Message. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveMessageProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Message")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(MessageMessageRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageMessageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.Message(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageMessageResponse and nil error while calling Message. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) servePost(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.servePostJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.servePostProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *messageServiceServer) servePostJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Post")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(MessagePostRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessagePostResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.Post(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessagePostResponse and nil error while calling Post. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) servePostProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Post")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(MessagePostRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessagePostResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.Post(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessagePostResponse and nil error while calling Post. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveEdit(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveEditJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveEditProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *messageServiceServer) serveEditJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Edit")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(MessageEditRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageEditResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.Edit(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageEditResponse and nil error while calling Edit. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveEditProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Edit")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(MessageEditRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageEditResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.Edit(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageEditResponse and nil error while calling Edit. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveDelete(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveDeleteJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveDeleteProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *messageServiceServer) serveDeleteJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Delete")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(MessageDeleteRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.Delete(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling Delete. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveDeleteProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Delete")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(MessageDeleteRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.Delete(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling Delete. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) servePostComment(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.servePostCommentJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.servePostCommentProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *messageServiceServer) servePostCommentJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "PostComment")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(MessagePostCommentRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessagePostCommentResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.PostComment(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessagePostCommentResponse and nil error while calling PostComment. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) servePostCommentProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "PostComment")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(MessagePostCommentRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessagePostCommentResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.PostComment(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessagePostCommentResponse and nil error while calling PostComment. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveEditComment(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveEditCommentJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveEditCommentProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *messageServiceServer) serveEditCommentJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "EditComment")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(MessageEditCommentRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageEditCommentResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.EditComment(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageEditCommentResponse and nil error while calling EditComment. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveEditCommentProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "EditComment")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(MessageEditCommentRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageEditCommentResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.EditComment(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageEditCommentResponse and nil error while calling EditComment. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveDeleteComment(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveDeleteCommentJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveDeleteCommentProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *messageServiceServer) serveDeleteCommentJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteComment")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(MessageDeleteCommentRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.DeleteComment(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling DeleteComment. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveDeleteCommentProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteComment")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(MessageDeleteCommentRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.DeleteComment(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling DeleteComment. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveLikeMessage(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveLikeMessageJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveLikeMessageProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *messageServiceServer) serveLikeMessageJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "LikeMessage")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(MessageLikeMessageRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageLikeMessageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.LikeMessage(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageLikeMessageResponse and nil error while calling LikeMessage. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveLikeMessageProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "LikeMessage")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(MessageLikeMessageRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageLikeMessageResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.LikeMessage(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageLikeMessageResponse and nil error while calling LikeMessage. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveLikeComment(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveLikeCommentJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveLikeCommentProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *messageServiceServer) serveLikeCommentJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "LikeComment")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(MessageLikeCommentRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageLikeCommentResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.LikeComment(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageLikeCommentResponse and nil error while calling LikeComment. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveLikeCommentProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "LikeComment")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(MessageLikeCommentRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageLikeCommentResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.LikeComment(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageLikeCommentResponse and nil error while calling LikeComment. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveMessageLikes(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveMessageLikesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveMessageLikesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *messageServiceServer) serveMessageLikesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "MessageLikes")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(MessageMessageLikesRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageMessageLikesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.MessageLikes(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageMessageLikesResponse and nil error while calling MessageLikes. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveMessageLikesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "MessageLikes")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(MessageMessageLikesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageMessageLikesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.MessageLikes(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageMessageLikesResponse and nil error while calling MessageLikes. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveCommentLikes(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveCommentLikesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveCommentLikesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *messageServiceServer) serveCommentLikesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "CommentLikes")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(MessageCommentLikesRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageCommentLikesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.CommentLikes(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageCommentLikesResponse and nil error while calling CommentLikes. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveCommentLikesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "CommentLikes")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(MessageCommentLikesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageCommentLikesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageService.CommentLikes(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageCommentLikesResponse and nil error while calling CommentLikes. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageServiceServer) serveSetMessageVisibility(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -810,9 +2922,9 @@ func (s *messageServiceServer) serveMessages(ctx context.Context, resp http.Resp
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveMessagesJSON(ctx, resp, req)
+		s.serveSetMessageVisibilityJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveMessagesProtobuf(ctx, resp, req)
+		s.serveSetMessageVisibilityProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -820,16 +2932,16 @@ func (s *messageServiceServer) serveMessages(ctx context.Context, resp http.Resp
 	}
 }
 
-func (s *messageServiceServer) serveMessagesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveSetMessageVisibilityJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Messages")
+	ctx = ctxsetters.WithMethodName(ctx, "SetMessageVisibility")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(MessageMessagesRequest)
+	reqContent := new(MessageSetMessageVisibilityRequest)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
@@ -837,10 +2949,10 @@ func (s *messageServiceServer) serveMessagesJSON(ctx context.Context, resp http.
 	}
 
 	// Call service method
-	var respContent *MessageMessagesResponse
+	var respContent *Empty
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.Messages(ctx, reqContent)
+		respContent, err = s.MessageService.SetMessageVisibility(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -848,7 +2960,7 @@ func (s *messageServiceServer) serveMessagesJSON(ctx context.Context, resp http.
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageMessagesResponse and nil error while calling Messages. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling SetMessageVisibility. nil responses are not supported"))
 		return
 	}
 
@@ -875,9 +2987,9 @@ func (s *messageServiceServer) serveMessagesJSON(ctx context.Context, resp http.
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveMessagesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveSetMessageVisibilityProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Messages")
+	ctx = ctxsetters.WithMethodName(ctx, "SetMessageVisibility")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -889,17 +3001,17 @@ func (s *messageServiceServer) serveMessagesProtobuf(ctx context.Context, resp h
 		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
 		return
 	}
-	reqContent := new(MessageMessagesRequest)
+	reqContent := new(MessageSetMessageVisibilityRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
 	// Call service method
-	var respContent *MessageMessagesResponse
+	var respContent *Empty
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.Messages(ctx, reqContent)
+		respContent, err = s.MessageService.SetMessageVisibility(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -907,7 +3019,7 @@ func (s *messageServiceServer) serveMessagesProtobuf(ctx context.Context, resp h
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageMessagesResponse and nil error while calling Messages. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling SetMessageVisibility. nil responses are not supported"))
 		return
 	}
 
@@ -931,7 +3043,7 @@ func (s *messageServiceServer) serveMessagesProtobuf(ctx context.Context, resp h
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveMessage(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveSetCommentVisibility(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -939,9 +3051,9 @@ func (s *messageServiceServer) serveMessage(ctx context.Context, resp http.Respo
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveMessageJSON(ctx, resp, req)
+		s.serveSetCommentVisibilityJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveMessageProtobuf(ctx, resp, req)
+		s.serveSetCommentVisibilityProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -949,16 +3061,16 @@ func (s *messageServiceServer) serveMessage(ctx context.Context, resp http.Respo
 	}
 }
 
-func (s *messageServiceServer) serveMessageJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveSetCommentVisibilityJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Message")
+	ctx = ctxsetters.WithMethodName(ctx, "SetCommentVisibility")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(MessageMessageRequest)
+	reqContent := new(MessageSetCommentVisibilityRequest)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
@@ -966,10 +3078,10 @@ func (s *messageServiceServer) serveMessageJSON(ctx context.Context, resp http.R
 	}
 
 	// Call service method
-	var respContent *MessageMessageResponse
+	var respContent *Empty
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.Message(ctx, reqContent)
+		respContent, err = s.MessageService.SetCommentVisibility(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -977,7 +3089,7 @@ func (s *messageServiceServer) serveMessageJSON(ctx context.Context, resp http.R
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageMessageResponse and nil error while calling Message. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling SetCommentVisibility. nil responses are not supported"))
 		return
 	}
 
@@ -1004,9 +3116,9 @@ func (s *messageServiceServer) serveMessageJSON(ctx context.Context, resp http.R
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveMessageProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveSetCommentVisibilityProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Message")
+	ctx = ctxsetters.WithMethodName(ctx, "SetCommentVisibility")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1018,17 +3130,17 @@ func (s *messageServiceServer) serveMessageProtobuf(ctx context.Context, resp ht
 		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
 		return
 	}
-	reqContent := new(MessageMessageRequest)
+	reqContent := new(MessageSetCommentVisibilityRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
 	// Call service method
-	var respContent *MessageMessageResponse
+	var respContent *Empty
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.Message(ctx, reqContent)
+		respContent, err = s.MessageService.SetCommentVisibility(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -1036,7 +3148,7 @@ func (s *messageServiceServer) serveMessageProtobuf(ctx context.Context, resp ht
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageMessageResponse and nil error while calling Message. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling SetCommentVisibility. nil responses are not supported"))
 		return
 	}
 
@@ -1060,7 +3172,7 @@ func (s *messageServiceServer) serveMessageProtobuf(ctx context.Context, resp ht
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) servePost(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveAvailableReactions(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -1068,9 +3180,9 @@ func (s *messageServiceServer) servePost(ctx context.Context, resp http.Response
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.servePostJSON(ctx, resp, req)
+		s.serveAvailableReactionsJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.servePostProtobuf(ctx, resp, req)
+		s.serveAvailableReactionsProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -1078,16 +3190,16 @@ func (s *messageServiceServer) servePost(ctx context.Context, resp http.Response
 	}
 }
 
-func (s *messageServiceServer) servePostJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveAvailableReactionsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Post")
+	ctx = ctxsetters.WithMethodName(ctx, "AvailableReactions")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(MessagePostRequest)
+	reqContent := new(Empty)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
@@ -1095,10 +3207,10 @@ func (s *messageServiceServer) servePostJSON(ctx context.Context, resp http.Resp
 	}
 
 	// Call service method
-	var respContent *MessagePostResponse
+	var respContent *MessageAvailableReactionsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.Post(ctx, reqContent)
+		respContent, err = s.MessageService.AvailableReactions(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -1106,7 +3218,7 @@ func (s *messageServiceServer) servePostJSON(ctx context.Context, resp http.Resp
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessagePostResponse and nil error while calling Post. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageAvailableReactionsResponse and nil error while calling AvailableReactions. nil responses are not supported"))
 		return
 	}
 
@@ -1133,9 +3245,9 @@ func (s *messageServiceServer) servePostJSON(ctx context.Context, resp http.Resp
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) servePostProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveAvailableReactionsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Post")
+	ctx = ctxsetters.WithMethodName(ctx, "AvailableReactions")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1147,17 +3259,17 @@ func (s *messageServiceServer) servePostProtobuf(ctx context.Context, resp http.
 		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
 		return
 	}
-	reqContent := new(MessagePostRequest)
+	reqContent := new(Empty)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
 	// Call service method
-	var respContent *MessagePostResponse
+	var respContent *MessageAvailableReactionsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.Post(ctx, reqContent)
+		respContent, err = s.MessageService.AvailableReactions(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -1165,7 +3277,7 @@ func (s *messageServiceServer) servePostProtobuf(ctx context.Context, resp http.
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessagePostResponse and nil error while calling Post. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageAvailableReactionsResponse and nil error while calling AvailableReactions. nil responses are not supported"))
 		return
 	}
 
@@ -1189,7 +3301,7 @@ func (s *messageServiceServer) servePostProtobuf(ctx context.Context, resp http.
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveEdit(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveAddReaction(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -1197,9 +3309,9 @@ func (s *messageServiceServer) serveEdit(ctx context.Context, resp http.Response
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveEditJSON(ctx, resp, req)
+		s.serveAddReactionJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveEditProtobuf(ctx, resp, req)
+		s.serveAddReactionProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -1207,16 +3319,16 @@ func (s *messageServiceServer) serveEdit(ctx context.Context, resp http.Response
 	}
 }
 
-func (s *messageServiceServer) serveEditJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveAddReactionJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Edit")
+	ctx = ctxsetters.WithMethodName(ctx, "AddReaction")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(MessageEditRequest)
+	reqContent := new(MessageAddReactionRequest)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
@@ -1224,10 +3336,10 @@ func (s *messageServiceServer) serveEditJSON(ctx context.Context, resp http.Resp
 	}
 
 	// Call service method
-	var respContent *MessageEditResponse
+	var respContent *MessageReactionResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.Edit(ctx, reqContent)
+		respContent, err = s.MessageService.AddReaction(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -1235,7 +3347,7 @@ func (s *messageServiceServer) serveEditJSON(ctx context.Context, resp http.Resp
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageEditResponse and nil error while calling Edit. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageReactionResponse and nil error while calling AddReaction. nil responses are not supported"))
 		return
 	}
 
@@ -1262,9 +3374,9 @@ func (s *messageServiceServer) serveEditJSON(ctx context.Context, resp http.Resp
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveEditProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveAddReactionProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Edit")
+	ctx = ctxsetters.WithMethodName(ctx, "AddReaction")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1276,17 +3388,17 @@ func (s *messageServiceServer) serveEditProtobuf(ctx context.Context, resp http.
 		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
 		return
 	}
-	reqContent := new(MessageEditRequest)
+	reqContent := new(MessageAddReactionRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
 	// Call service method
-	var respContent *MessageEditResponse
+	var respContent *MessageReactionResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.Edit(ctx, reqContent)
+		respContent, err = s.MessageService.AddReaction(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -1294,7 +3406,7 @@ func (s *messageServiceServer) serveEditProtobuf(ctx context.Context, resp http.
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageEditResponse and nil error while calling Edit. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageReactionResponse and nil error while calling AddReaction. nil responses are not supported"))
 		return
 	}
 
@@ -1318,7 +3430,7 @@ func (s *messageServiceServer) serveEditProtobuf(ctx context.Context, resp http.
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveDelete(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveRemoveReaction(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -1326,9 +3438,9 @@ func (s *messageServiceServer) serveDelete(ctx context.Context, resp http.Respon
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveDeleteJSON(ctx, resp, req)
+		s.serveRemoveReactionJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveDeleteProtobuf(ctx, resp, req)
+		s.serveRemoveReactionProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -1336,16 +3448,16 @@ func (s *messageServiceServer) serveDelete(ctx context.Context, resp http.Respon
 	}
 }
 
-func (s *messageServiceServer) serveDeleteJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveRemoveReactionJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Delete")
+	ctx = ctxsetters.WithMethodName(ctx, "RemoveReaction")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(MessageDeleteRequest)
+	reqContent := new(MessageRemoveReactionRequest)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
@@ -1353,10 +3465,10 @@ func (s *messageServiceServer) serveDeleteJSON(ctx context.Context, resp http.Re
 	}
 
 	// Call service method
-	var respContent *Empty
+	var respContent *MessageReactionResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.Delete(ctx, reqContent)
+		respContent, err = s.MessageService.RemoveReaction(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -1364,7 +3476,7 @@ func (s *messageServiceServer) serveDeleteJSON(ctx context.Context, resp http.Re
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling Delete. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageReactionResponse and nil error while calling RemoveReaction. nil responses are not supported"))
 		return
 	}
 
@@ -1391,9 +3503,9 @@ func (s *messageServiceServer) serveDeleteJSON(ctx context.Context, resp http.Re
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveDeleteProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveRemoveReactionProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "Delete")
+	ctx = ctxsetters.WithMethodName(ctx, "RemoveReaction")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1405,17 +3517,17 @@ func (s *messageServiceServer) serveDeleteProtobuf(ctx context.Context, resp htt
 		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
 		return
 	}
-	reqContent := new(MessageDeleteRequest)
+	reqContent := new(MessageRemoveReactionRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
 	// Call service method
-	var respContent *Empty
+	var respContent *MessageReactionResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.Delete(ctx, reqContent)
+		respContent, err = s.MessageService.RemoveReaction(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -1423,7 +3535,7 @@ func (s *messageServiceServer) serveDeleteProtobuf(ctx context.Context, resp htt
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling Delete. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageReactionResponse and nil error while calling RemoveReaction. nil responses are not supported"))
 		return
 	}
 
@@ -1447,7 +3559,7 @@ func (s *messageServiceServer) serveDeleteProtobuf(ctx context.Context, resp htt
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) servePostComment(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveMessageReactions(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -1455,9 +3567,9 @@ func (s *messageServiceServer) servePostComment(ctx context.Context, resp http.R
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.servePostCommentJSON(ctx, resp, req)
+		s.serveMessageReactionsJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.servePostCommentProtobuf(ctx, resp, req)
+		s.serveMessageReactionsProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -1465,16 +3577,16 @@ func (s *messageServiceServer) servePostComment(ctx context.Context, resp http.R
 	}
 }
 
-func (s *messageServiceServer) servePostCommentJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveMessageReactionsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "PostComment")
+	ctx = ctxsetters.WithMethodName(ctx, "MessageReactions")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(MessagePostCommentRequest)
+	reqContent := new(MessageMessageReactionsRequest)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
@@ -1482,10 +3594,10 @@ func (s *messageServiceServer) servePostCommentJSON(ctx context.Context, resp ht
 	}
 
 	// Call service method
-	var respContent *MessagePostCommentResponse
+	var respContent *MessageMessageReactionsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.PostComment(ctx, reqContent)
+		respContent, err = s.MessageService.MessageReactions(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -1493,7 +3605,7 @@ func (s *messageServiceServer) servePostCommentJSON(ctx context.Context, resp ht
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessagePostCommentResponse and nil error while calling PostComment. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageMessageReactionsResponse and nil error while calling MessageReactions. nil responses are not supported"))
 		return
 	}
 
@@ -1520,9 +3632,9 @@ func (s *messageServiceServer) servePostCommentJSON(ctx context.Context, resp ht
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) servePostCommentProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveMessageReactionsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "PostComment")
+	ctx = ctxsetters.WithMethodName(ctx, "MessageReactions")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1534,17 +3646,17 @@ func (s *messageServiceServer) servePostCommentProtobuf(ctx context.Context, res
 		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
 		return
 	}
-	reqContent := new(MessagePostCommentRequest)
+	reqContent := new(MessageMessageReactionsRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
 	// Call service method
-	var respContent *MessagePostCommentResponse
+	var respContent *MessageMessageReactionsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.PostComment(ctx, reqContent)
+		respContent, err = s.MessageService.MessageReactions(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -1552,7 +3664,7 @@ func (s *messageServiceServer) servePostCommentProtobuf(ctx context.Context, res
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessagePostCommentResponse and nil error while calling PostComment. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageMessageReactionsResponse and nil error while calling MessageReactions. nil responses are not supported"))
 		return
 	}
 
@@ -1576,7 +3688,7 @@ func (s *messageServiceServer) servePostCommentProtobuf(ctx context.Context, res
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveEditComment(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveSaveDraft(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -1584,9 +3696,9 @@ func (s *messageServiceServer) serveEditComment(ctx context.Context, resp http.R
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveEditCommentJSON(ctx, resp, req)
+		s.serveSaveDraftJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveEditCommentProtobuf(ctx, resp, req)
+		s.serveSaveDraftProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -1594,16 +3706,16 @@ func (s *messageServiceServer) serveEditComment(ctx context.Context, resp http.R
 	}
 }
 
-func (s *messageServiceServer) serveEditCommentJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveSaveDraftJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "EditComment")
+	ctx = ctxsetters.WithMethodName(ctx, "SaveDraft")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(MessageEditCommentRequest)
+	reqContent := new(MessageSaveDraftRequest)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
@@ -1611,10 +3723,10 @@ func (s *messageServiceServer) serveEditCommentJSON(ctx context.Context, resp ht
 	}
 
 	// Call service method
-	var respContent *MessageEditCommentResponse
+	var respContent *MessageSaveDraftResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.EditComment(ctx, reqContent)
+		respContent, err = s.MessageService.SaveDraft(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -1622,7 +3734,7 @@ func (s *messageServiceServer) serveEditCommentJSON(ctx context.Context, resp ht
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageEditCommentResponse and nil error while calling EditComment. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageSaveDraftResponse and nil error while calling SaveDraft. nil responses are not supported"))
 		return
 	}
 
@@ -1649,9 +3761,9 @@ func (s *messageServiceServer) serveEditCommentJSON(ctx context.Context, resp ht
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveEditCommentProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveSaveDraftProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "EditComment")
+	ctx = ctxsetters.WithMethodName(ctx, "SaveDraft")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1663,17 +3775,17 @@ func (s *messageServiceServer) serveEditCommentProtobuf(ctx context.Context, res
 		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
 		return
 	}
-	reqContent := new(MessageEditCommentRequest)
+	reqContent := new(MessageSaveDraftRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
 	// Call service method
-	var respContent *MessageEditCommentResponse
+	var respContent *MessageSaveDraftResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.EditComment(ctx, reqContent)
+		respContent, err = s.MessageService.SaveDraft(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -1681,7 +3793,7 @@ func (s *messageServiceServer) serveEditCommentProtobuf(ctx context.Context, res
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageEditCommentResponse and nil error while calling EditComment. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageSaveDraftResponse and nil error while calling SaveDraft. nil responses are not supported"))
 		return
 	}
 
@@ -1705,7 +3817,7 @@ func (s *messageServiceServer) serveEditCommentProtobuf(ctx context.Context, res
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveDeleteComment(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveDrafts(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -1713,9 +3825,9 @@ func (s *messageServiceServer) serveDeleteComment(ctx context.Context, resp http
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveDeleteCommentJSON(ctx, resp, req)
+		s.serveDraftsJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveDeleteCommentProtobuf(ctx, resp, req)
+		s.serveDraftsProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -1723,16 +3835,16 @@ func (s *messageServiceServer) serveDeleteComment(ctx context.Context, resp http
 	}
 }
 
-func (s *messageServiceServer) serveDeleteCommentJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveDraftsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "DeleteComment")
+	ctx = ctxsetters.WithMethodName(ctx, "Drafts")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(MessageDeleteCommentRequest)
+	reqContent := new(Empty)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
@@ -1740,10 +3852,10 @@ func (s *messageServiceServer) serveDeleteCommentJSON(ctx context.Context, resp
 	}
 
 	// Call service method
-	var respContent *Empty
+	var respContent *MessageDraftsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.DeleteComment(ctx, reqContent)
+		respContent, err = s.MessageService.Drafts(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -1751,7 +3863,7 @@ func (s *messageServiceServer) serveDeleteCommentJSON(ctx context.Context, resp
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling DeleteComment. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageDraftsResponse and nil error while calling Drafts. nil responses are not supported"))
 		return
 	}
 
@@ -1778,9 +3890,9 @@ func (s *messageServiceServer) serveDeleteCommentJSON(ctx context.Context, resp
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveDeleteCommentProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveDraftsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "DeleteComment")
+	ctx = ctxsetters.WithMethodName(ctx, "Drafts")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1792,17 +3904,17 @@ func (s *messageServiceServer) serveDeleteCommentProtobuf(ctx context.Context, r
 		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
 		return
 	}
-	reqContent := new(MessageDeleteCommentRequest)
+	reqContent := new(Empty)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
 	// Call service method
-	var respContent *Empty
+	var respContent *MessageDraftsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.DeleteComment(ctx, reqContent)
+		respContent, err = s.MessageService.Drafts(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -1810,7 +3922,7 @@ func (s *messageServiceServer) serveDeleteCommentProtobuf(ctx context.Context, r
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling DeleteComment. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageDraftsResponse and nil error while calling Drafts. nil responses are not supported"))
 		return
 	}
 
@@ -1834,7 +3946,7 @@ func (s *messageServiceServer) serveDeleteCommentProtobuf(ctx context.Context, r
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveLikeMessage(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveVote(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -1842,9 +3954,9 @@ func (s *messageServiceServer) serveLikeMessage(ctx context.Context, resp http.R
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveLikeMessageJSON(ctx, resp, req)
+		s.serveVoteJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveLikeMessageProtobuf(ctx, resp, req)
+		s.serveVoteProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -1852,16 +3964,16 @@ func (s *messageServiceServer) serveLikeMessage(ctx context.Context, resp http.R
 	}
 }
 
-func (s *messageServiceServer) serveLikeMessageJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveVoteJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "LikeMessage")
+	ctx = ctxsetters.WithMethodName(ctx, "Vote")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(MessageLikeMessageRequest)
+	reqContent := new(MessageVoteRequest)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
@@ -1869,10 +3981,10 @@ func (s *messageServiceServer) serveLikeMessageJSON(ctx context.Context, resp ht
 	}
 
 	// Call service method
-	var respContent *MessageLikeMessageResponse
+	var respContent *MessageVoteResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.LikeMessage(ctx, reqContent)
+		respContent, err = s.MessageService.Vote(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -1880,7 +3992,7 @@ func (s *messageServiceServer) serveLikeMessageJSON(ctx context.Context, resp ht
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageLikeMessageResponse and nil error while calling LikeMessage. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageVoteResponse and nil error while calling Vote. nil responses are not supported"))
 		return
 	}
 
@@ -1907,9 +4019,9 @@ func (s *messageServiceServer) serveLikeMessageJSON(ctx context.Context, resp ht
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveLikeMessageProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveVoteProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "LikeMessage")
+	ctx = ctxsetters.WithMethodName(ctx, "Vote")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1921,17 +4033,17 @@ func (s *messageServiceServer) serveLikeMessageProtobuf(ctx context.Context, res
 		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
 		return
 	}
-	reqContent := new(MessageLikeMessageRequest)
+	reqContent := new(MessageVoteRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
 	// Call service method
-	var respContent *MessageLikeMessageResponse
+	var respContent *MessageVoteResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.LikeMessage(ctx, reqContent)
+		respContent, err = s.MessageService.Vote(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -1939,7 +4051,7 @@ func (s *messageServiceServer) serveLikeMessageProtobuf(ctx context.Context, res
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageLikeMessageResponse and nil error while calling LikeMessage. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageVoteResponse and nil error while calling Vote. nil responses are not supported"))
 		return
 	}
 
@@ -1963,7 +4075,7 @@ func (s *messageServiceServer) serveLikeMessageProtobuf(ctx context.Context, res
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveLikeComment(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveRsvp(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -1971,9 +4083,9 @@ func (s *messageServiceServer) serveLikeComment(ctx context.Context, resp http.R
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveLikeCommentJSON(ctx, resp, req)
+		s.serveRsvpJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveLikeCommentProtobuf(ctx, resp, req)
+		s.serveRsvpProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -1981,16 +4093,16 @@ func (s *messageServiceServer) serveLikeComment(ctx context.Context, resp http.R
 	}
 }
 
-func (s *messageServiceServer) serveLikeCommentJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveRsvpJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "LikeComment")
+	ctx = ctxsetters.WithMethodName(ctx, "Rsvp")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(MessageLikeCommentRequest)
+	reqContent := new(MessageRsvpRequest)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
@@ -1998,10 +4110,10 @@ func (s *messageServiceServer) serveLikeCommentJSON(ctx context.Context, resp ht
 	}
 
 	// Call service method
-	var respContent *MessageLikeCommentResponse
+	var respContent *MessageRsvpResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.LikeComment(ctx, reqContent)
+		respContent, err = s.MessageService.Rsvp(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -2009,7 +4121,7 @@ func (s *messageServiceServer) serveLikeCommentJSON(ctx context.Context, resp ht
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageLikeCommentResponse and nil error while calling LikeComment. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageRsvpResponse and nil error while calling Rsvp. nil responses are not supported"))
 		return
 	}
 
@@ -2036,9 +4148,9 @@ func (s *messageServiceServer) serveLikeCommentJSON(ctx context.Context, resp ht
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveLikeCommentProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveRsvpProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "LikeComment")
+	ctx = ctxsetters.WithMethodName(ctx, "Rsvp")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -2050,17 +4162,17 @@ func (s *messageServiceServer) serveLikeCommentProtobuf(ctx context.Context, res
 		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
 		return
 	}
-	reqContent := new(MessageLikeCommentRequest)
+	reqContent := new(MessageRsvpRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
 	// Call service method
-	var respContent *MessageLikeCommentResponse
+	var respContent *MessageRsvpResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.LikeComment(ctx, reqContent)
+		respContent, err = s.MessageService.Rsvp(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -2068,7 +4180,7 @@ func (s *messageServiceServer) serveLikeCommentProtobuf(ctx context.Context, res
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageLikeCommentResponse and nil error while calling LikeComment. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageRsvpResponse and nil error while calling Rsvp. nil responses are not supported"))
 		return
 	}
 
@@ -2092,7 +4204,7 @@ func (s *messageServiceServer) serveLikeCommentProtobuf(ctx context.Context, res
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveMessageLikes(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveCalendarFeed(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -2100,9 +4212,9 @@ func (s *messageServiceServer) serveMessageLikes(ctx context.Context, resp http.
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveMessageLikesJSON(ctx, resp, req)
+		s.serveCalendarFeedJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveMessageLikesProtobuf(ctx, resp, req)
+		s.serveCalendarFeedProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -2110,16 +4222,16 @@ func (s *messageServiceServer) serveMessageLikes(ctx context.Context, resp http.
 	}
 }
 
-func (s *messageServiceServer) serveMessageLikesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveCalendarFeedJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "MessageLikes")
+	ctx = ctxsetters.WithMethodName(ctx, "CalendarFeed")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(MessageMessageLikesRequest)
+	reqContent := new(Empty)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
@@ -2127,10 +4239,10 @@ func (s *messageServiceServer) serveMessageLikesJSON(ctx context.Context, resp h
 	}
 
 	// Call service method
-	var respContent *MessageMessageLikesResponse
+	var respContent *MessageCalendarFeedResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.MessageLikes(ctx, reqContent)
+		respContent, err = s.MessageService.CalendarFeed(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -2138,7 +4250,7 @@ func (s *messageServiceServer) serveMessageLikesJSON(ctx context.Context, resp h
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageMessageLikesResponse and nil error while calling MessageLikes. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageCalendarFeedResponse and nil error while calling CalendarFeed. nil responses are not supported"))
 		return
 	}
 
@@ -2165,9 +4277,9 @@ func (s *messageServiceServer) serveMessageLikesJSON(ctx context.Context, resp h
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveMessageLikesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveCalendarFeedProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "MessageLikes")
+	ctx = ctxsetters.WithMethodName(ctx, "CalendarFeed")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -2179,17 +4291,17 @@ func (s *messageServiceServer) serveMessageLikesProtobuf(ctx context.Context, re
 		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
 		return
 	}
-	reqContent := new(MessageMessageLikesRequest)
+	reqContent := new(Empty)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
 	// Call service method
-	var respContent *MessageMessageLikesResponse
+	var respContent *MessageCalendarFeedResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.MessageLikes(ctx, reqContent)
+		respContent, err = s.MessageService.CalendarFeed(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -2197,7 +4309,7 @@ func (s *messageServiceServer) serveMessageLikesProtobuf(ctx context.Context, re
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageMessageLikesResponse and nil error while calling MessageLikes. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageCalendarFeedResponse and nil error while calling CalendarFeed. nil responses are not supported"))
 		return
 	}
 
@@ -2221,7 +4333,7 @@ func (s *messageServiceServer) serveMessageLikesProtobuf(ctx context.Context, re
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveCommentLikes(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveRotateCalendarFeed(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -2229,9 +4341,9 @@ func (s *messageServiceServer) serveCommentLikes(ctx context.Context, resp http.
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveCommentLikesJSON(ctx, resp, req)
+		s.serveRotateCalendarFeedJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveCommentLikesProtobuf(ctx, resp, req)
+		s.serveRotateCalendarFeedProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -2239,16 +4351,16 @@ func (s *messageServiceServer) serveCommentLikes(ctx context.Context, resp http.
 	}
 }
 
-func (s *messageServiceServer) serveCommentLikesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveRotateCalendarFeedJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "CommentLikes")
+	ctx = ctxsetters.WithMethodName(ctx, "RotateCalendarFeed")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(MessageCommentLikesRequest)
+	reqContent := new(Empty)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
@@ -2256,10 +4368,10 @@ func (s *messageServiceServer) serveCommentLikesJSON(ctx context.Context, resp h
 	}
 
 	// Call service method
-	var respContent *MessageCommentLikesResponse
+	var respContent *MessageCalendarFeedResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.CommentLikes(ctx, reqContent)
+		respContent, err = s.MessageService.RotateCalendarFeed(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -2267,7 +4379,7 @@ func (s *messageServiceServer) serveCommentLikesJSON(ctx context.Context, resp h
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageCommentLikesResponse and nil error while calling CommentLikes. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageCalendarFeedResponse and nil error while calling RotateCalendarFeed. nil responses are not supported"))
 		return
 	}
 
@@ -2294,9 +4406,9 @@ func (s *messageServiceServer) serveCommentLikesJSON(ctx context.Context, resp h
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveCommentLikesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveRotateCalendarFeedProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "CommentLikes")
+	ctx = ctxsetters.WithMethodName(ctx, "RotateCalendarFeed")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -2308,17 +4420,17 @@ func (s *messageServiceServer) serveCommentLikesProtobuf(ctx context.Context, re
 		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
 		return
 	}
-	reqContent := new(MessageCommentLikesRequest)
+	reqContent := new(Empty)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
 	// Call service method
-	var respContent *MessageCommentLikesResponse
+	var respContent *MessageCalendarFeedResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.CommentLikes(ctx, reqContent)
+		respContent, err = s.MessageService.RotateCalendarFeed(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -2326,7 +4438,7 @@ func (s *messageServiceServer) serveCommentLikesProtobuf(ctx context.Context, re
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageCommentLikesResponse and nil error while calling CommentLikes. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageCalendarFeedResponse and nil error while calling RotateCalendarFeed. nil responses are not supported"))
 		return
 	}
 
@@ -2350,7 +4462,7 @@ func (s *messageServiceServer) serveCommentLikesProtobuf(ctx context.Context, re
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveSetMessageVisibility(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveSetDeleteExpiredPosts(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -2358,9 +4470,9 @@ func (s *messageServiceServer) serveSetMessageVisibility(ctx context.Context, re
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveSetMessageVisibilityJSON(ctx, resp, req)
+		s.serveSetDeleteExpiredPostsJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveSetMessageVisibilityProtobuf(ctx, resp, req)
+		s.serveSetDeleteExpiredPostsProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -2368,16 +4480,16 @@ func (s *messageServiceServer) serveSetMessageVisibility(ctx context.Context, re
 	}
 }
 
-func (s *messageServiceServer) serveSetMessageVisibilityJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveSetDeleteExpiredPostsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "SetMessageVisibility")
+	ctx = ctxsetters.WithMethodName(ctx, "SetDeleteExpiredPosts")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(MessageSetMessageVisibilityRequest)
+	reqContent := new(MessageSetDeleteExpiredPostsRequest)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
@@ -2388,7 +4500,7 @@ func (s *messageServiceServer) serveSetMessageVisibilityJSON(ctx context.Context
 	var respContent *Empty
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.SetMessageVisibility(ctx, reqContent)
+		respContent, err = s.MessageService.SetDeleteExpiredPosts(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -2396,7 +4508,7 @@ func (s *messageServiceServer) serveSetMessageVisibilityJSON(ctx context.Context
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling SetMessageVisibility. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling SetDeleteExpiredPosts. nil responses are not supported"))
 		return
 	}
 
@@ -2423,9 +4535,9 @@ func (s *messageServiceServer) serveSetMessageVisibilityJSON(ctx context.Context
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveSetMessageVisibilityProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) serveSetDeleteExpiredPostsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "SetMessageVisibility")
+	ctx = ctxsetters.WithMethodName(ctx, "SetDeleteExpiredPosts")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -2437,7 +4549,7 @@ func (s *messageServiceServer) serveSetMessageVisibilityProtobuf(ctx context.Con
 		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
 		return
 	}
-	reqContent := new(MessageSetMessageVisibilityRequest)
+	reqContent := new(MessageSetDeleteExpiredPostsRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
@@ -2447,7 +4559,7 @@ func (s *messageServiceServer) serveSetMessageVisibilityProtobuf(ctx context.Con
 	var respContent *Empty
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.SetMessageVisibility(ctx, reqContent)
+		respContent, err = s.MessageService.SetDeleteExpiredPosts(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -2455,7 +4567,7 @@ func (s *messageServiceServer) serveSetMessageVisibilityProtobuf(ctx context.Con
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling SetMessageVisibility. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling SetDeleteExpiredPosts. nil responses are not supported"))
 		return
 	}
 
@@ -2479,7 +4591,7 @@ func (s *messageServiceServer) serveSetMessageVisibilityProtobuf(ctx context.Con
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveSetCommentVisibility(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) servePostingLimits(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -2487,9 +4599,9 @@ func (s *messageServiceServer) serveSetCommentVisibility(ctx context.Context, re
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveSetCommentVisibilityJSON(ctx, resp, req)
+		s.servePostingLimitsJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveSetCommentVisibilityProtobuf(ctx, resp, req)
+		s.servePostingLimitsProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -2497,16 +4609,16 @@ func (s *messageServiceServer) serveSetCommentVisibility(ctx context.Context, re
 	}
 }
 
-func (s *messageServiceServer) serveSetCommentVisibilityJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) servePostingLimitsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "SetCommentVisibility")
+	ctx = ctxsetters.WithMethodName(ctx, "PostingLimits")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(MessageSetCommentVisibilityRequest)
+	reqContent := new(Empty)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
@@ -2514,10 +4626,10 @@ func (s *messageServiceServer) serveSetCommentVisibilityJSON(ctx context.Context
 	}
 
 	// Call service method
-	var respContent *Empty
+	var respContent *MessagePostingLimitsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.SetCommentVisibility(ctx, reqContent)
+		respContent, err = s.MessageService.PostingLimits(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -2525,7 +4637,7 @@ func (s *messageServiceServer) serveSetCommentVisibilityJSON(ctx context.Context
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling SetCommentVisibility. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessagePostingLimitsResponse and nil error while calling PostingLimits. nil responses are not supported"))
 		return
 	}
 
@@ -2552,9 +4664,9 @@ func (s *messageServiceServer) serveSetCommentVisibilityJSON(ctx context.Context
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *messageServiceServer) serveSetCommentVisibilityProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *messageServiceServer) servePostingLimitsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "SetCommentVisibility")
+	ctx = ctxsetters.WithMethodName(ctx, "PostingLimits")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -2566,17 +4678,17 @@ func (s *messageServiceServer) serveSetCommentVisibilityProtobuf(ctx context.Con
 		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
 		return
 	}
-	reqContent := new(MessageSetCommentVisibilityRequest)
+	reqContent := new(Empty)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
 	// Call service method
-	var respContent *Empty
+	var respContent *MessagePostingLimitsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
-		respContent, err = s.MessageService.SetCommentVisibility(ctx, reqContent)
+		respContent, err = s.MessageService.PostingLimits(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -2584,7 +4696,7 @@ func (s *messageServiceServer) serveSetCommentVisibilityProtobuf(ctx context.Con
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling SetCommentVisibility. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessagePostingLimitsResponse and nil error while calling PostingLimits. nil responses are not supported"))
 		return
 	}
 
@@ -2609,7 +4721,7 @@ func (s *messageServiceServer) serveSetCommentVisibilityProtobuf(ctx context.Con
 }
 
 func (s *messageServiceServer) ServiceDescriptor() ([]byte, int) {
-	return twirpFileDescriptor2, 0
+	return twirpFileDescriptor3, 0
 }
 
 func (s *messageServiceServer) ProtocGenTwirpVersion() string {
@@ -2620,53 +4732,105 @@ func (s *messageServiceServer) PathPrefix() string {
 	return MessageServicePathPrefix
 }
 
-var twirpFileDescriptor2 = []byte{
-	// 742 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x56, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x95, 0x49, 0xd2, 0xa6, 0x93, 0x14, 0xc1, 0x12, 0x68, 0xea, 0xd2, 0x36, 0x18, 0x09, 0x72,
-	0x21, 0x95, 0x8a, 0x38, 0xf0, 0x51, 0x09, 0xb5, 0xcd, 0xa1, 0xa2, 0x54, 0x10, 0x24, 0x84, 0xb8,
-	0x54, 0xa9, 0xb3, 0xb4, 0x56, 0xe3, 0xd8, 0xd8, 0xdb, 0x8a, 0x1e, 0x39, 0xf2, 0xa7, 0xb8, 0xf1,
-	0xbf, 0x90, 0xed, 0xf5, 0x7a, 0x67, 0x77, 0xf3, 0x61, 0x71, 0xe1, 0xd4, 0x78, 0x3e, 0xde, 0xbc,
-	0x79, 0x5e, 0xbf, 0x2d, 0xac, 0xfa, 0x34, 0x8e, 0x87, 0xe7, 0xb4, 0x17, 0x46, 0x01, 0x0b, 0x48,
-	0x25, 0x0a, 0x5d, 0xbb, 0xe1, 0x07, 0x23, 0x3a, 0xce, 0x22, 0xce, 0x17, 0x78, 0xf0, 0x3e, 0x2b,
-	0xe1, 0x7f, 0xe2, 0x01, 0xfd, 0x7e, 0x45, 0x63, 0x46, 0x5a, 0x50, 0x63, 0xc1, 0x25, 0x9d, 0xb4,
-	0xad, 0x8e, 0xd5, 0x5d, 0x19, 0x64, 0x0f, 0x84, 0x40, 0xf5, 0x5b, 0x14, 0xf8, 0xed, 0x5b, 0x69,
-	0x30, 0xfd, 0x9d, 0x54, 0xba, 0xc1, 0xd5, 0x84, 0xb5, 0x2b, 0x1d, 0xab, 0x5b, 0x1b, 0x64, 0x0f,
-	0xce, 0x01, 0xac, 0x69, 0xc8, 0x71, 0x18, 0x4c, 0x62, 0x4a, 0xba, 0x50, 0xe7, 0xbc, 0xe2, 0xb6,
-	0xd5, 0xa9, 0x74, 0x1b, 0xbb, 0xcd, 0x5e, 0x14, 0xba, 0x3d, 0x5e, 0x38, 0x10, 0x59, 0xe7, 0x18,
-	0xee, 0x63, 0x90, 0xd9, 0xec, 0x36, 0x01, 0x78, 0xeb, 0xa9, 0x37, 0xe2, 0x1c, 0x57, 0x78, 0xe4,
-	0x68, 0xe4, 0xbc, 0x55, 0x97, 0x15, 0x8c, 0x9e, 0xc0, 0x32, 0x2f, 0x4b, 0x01, 0x55, 0x42, 0x79,
-	0xd2, 0x71, 0x81, 0xf0, 0xd8, 0x87, 0x20, 0x66, 0x73, 0xa5, 0x62, 0xf4, 0x07, 0xcb, 0xa5, 0x4a,
-	0x7e, 0x93, 0xc7, 0xb0, 0x3a, 0x64, 0x6c, 0xe8, 0x5e, 0xf8, 0x74, 0xc2, 0x12, 0x8e, 0x95, 0x34,
-	0xd9, 0x2c, 0x82, 0x47, 0x23, 0x67, 0x0f, 0xee, 0xa1, 0x21, 0x25, 0x39, 0xfe, 0xb6, 0x04, 0xc9,
-	0xfe, 0xc8, 0x13, 0x24, 0xb1, 0x36, 0x96, 0xa2, 0x0d, 0x79, 0x04, 0xcd, 0x84, 0xe1, 0xa9, 0x7b,
-	0x31, 0x9c, 0x9c, 0xd3, 0x4c, 0xbc, 0xfa, 0xa0, 0x91, 0xc4, 0x0e, 0xb2, 0x90, 0x58, 0xa8, 0x22,
-	0x2d, 0xf4, 0x0c, 0x88, 0xb4, 0x50, 0xde, 0x5c, 0x4d, 0x9b, 0xef, 0x16, 0x99, 0x1c, 0x42, 0xdb,
-	0xbf, 0x36, 0x73, 0xff, 0x8c, 0x7f, 0xc9, 0xfd, 0x5f, 0x40, 0x8b, 0xc7, 0x0e, 0xe9, 0x98, 0x32,
-	0xba, 0x98, 0x00, 0xce, 0x2f, 0x0b, 0xd6, 0x25, 0xd9, 0x0f, 0x02, 0x3f, 0xa1, 0xf3, 0x2f, 0xe7,
-	0xcd, 0x28, 0x98, 0xa6, 0x40, 0xd5, 0xa0, 0xc0, 0x21, 0xd8, 0x26, 0x2a, 0x85, 0x10, 0x6e, 0x16,
-	0x32, 0x0b, 0xc1, 0x93, 0xce, 0x9f, 0x62, 0xa3, 0x44, 0x48, 0x65, 0xa3, 0x4d, 0x00, 0x5e, 0x28,
-	0xc9, 0xc1, 0x23, 0xff, 0xd7, 0x79, 0x28, 0xd4, 0x40, 0x6b, 0x94, 0x54, 0xe3, 0x0d, 0x6c, 0xa0,
-	0x63, 0x51, 0x4a, 0x0e, 0xe7, 0x95, 0x90, 0xf2, 0xd8, 0xbb, 0x54, 0xcd, 0x68, 0xce, 0xc9, 0xda,
-	0x15, 0xfc, 0x51, 0x2f, 0xe7, 0xdf, 0x82, 0xda, 0xd8, 0xbb, 0x4c, 0x9d, 0x30, 0x75, 0xcf, 0xf4,
-	0x41, 0x99, 0x57, 0x8e, 0x2b, 0x9e, 0xa7, 0xea, 0x65, 0x9e, 0xf7, 0x5a, 0xf4, 0x48, 0xad, 0xf1,
-	0x82, 0x0b, 0xf6, 0x85, 0xb4, 0xb8, 0x59, 0xbc, 0x21, 0x31, 0x31, 0xf1, 0xfa, 0x3b, 0xf2, 0xfb,
-	0x49, 0x2a, 0x75, 0x0e, 0x9c, 0xb3, 0xca, 0x61, 0xd6, 0xd2, 0x05, 0x07, 0xdc, 0x5c, 0x92, 0x83,
-	0x0b, 0x0e, 0x8f, 0x7e, 0xa2, 0x8c, 0xff, 0xfa, 0xec, 0xc5, 0xde, 0x99, 0x37, 0xf6, 0xd8, 0xcd,
-	0x82, 0x5e, 0xba, 0x05, 0x70, 0x2d, 0x7a, 0xf8, 0x97, 0x23, 0x45, 0xf0, 0x10, 0x4e, 0xd7, 0x38,
-	0x64, 0xd6, 0x07, 0x3a, 0x67, 0xc8, 0xee, 0xcf, 0x3a, 0xdc, 0x16, 0x53, 0xa2, 0x6b, 0xcf, 0xa5,
-	0xa4, 0x0f, 0xf5, 0xfc, 0x2e, 0x26, 0x1b, 0xb2, 0x02, 0xca, 0xdd, 0x6f, 0x3f, 0x34, 0x27, 0xb9,
-	0x96, 0xfb, 0xb0, 0xcc, 0x63, 0xc4, 0x36, 0x14, 0xe6, 0x20, 0x1b, 0xc6, 0x1c, 0xc7, 0x78, 0x09,
-	0xd5, 0xc4, 0xda, 0xc8, 0x9a, 0x5c, 0x24, 0xdd, 0xa9, 0x76, 0x5b, 0x4f, 0x14, 0xad, 0x89, 0x0f,
-	0xe0, 0x56, 0xe9, 0xa6, 0xc3, 0xad, 0xe8, 0x0a, 0xd9, 0x81, 0xa5, 0xec, 0xe3, 0x27, 0xeb, 0x72,
-	0x0d, 0xba, 0x27, 0x6c, 0x48, 0x53, 0x7d, 0x3f, 0x64, 0x37, 0xe4, 0x04, 0x1a, 0x92, 0x03, 0x93,
-	0x2d, 0x95, 0x14, 0xfe, 0x30, 0xed, 0xed, 0xa9, 0x79, 0x4e, 0xe0, 0x04, 0x1a, 0x92, 0x87, 0x61,
-	0x3c, 0xdd, 0xa3, 0x31, 0x9e, 0xc9, 0xfc, 0xf6, 0x60, 0x15, 0xb9, 0x19, 0xe9, 0xe8, 0x7b, 0x29,
-	0x98, 0xca, 0x7a, 0x92, 0x25, 0x61, 0x3a, 0xba, 0xcf, 0x61, 0x3a, 0x26, 0x2f, 0xe3, 0x78, 0xc6,
-	0xf5, 0x74, 0x1f, 0xd3, 0xf1, 0xd4, 0xf5, 0x3e, 0x42, 0x53, 0x76, 0x14, 0xb2, 0x6d, 0x38, 0x52,
-	0xb2, 0x49, 0xd8, 0x9d, 0xe9, 0x05, 0x05, 0xa4, 0x6c, 0x10, 0x18, 0xd2, 0xe0, 0x3b, 0x18, 0xd2,
-	0xe8, 0x2d, 0xef, 0xa0, 0x65, 0x32, 0x0b, 0xf2, 0x54, 0xee, 0x9c, 0x61, 0x27, 0xe8, 0x95, 0x64,
-	0x60, 0x9a, 0x29, 0x68, 0x60, 0xd3, 0x6c, 0x43, 0x06, 0xdb, 0xaf, 0x7f, 0x5d, 0xea, 0xf5, 0x76,
-	0xa2, 0xd0, 0x3d, 0x5b, 0x4a, 0xff, 0xdd, 0x7f, 0xfe, 0x37, 0x00, 0x00, 0xff, 0xff, 0xad, 0x49,
-	0x6e, 0x5b, 0x11, 0x0c, 0x00, 0x00,
+var twirpFileDescriptor3 = []byte{
+	// 1581 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0xeb, 0x6e, 0xdc, 0x44,
+	0x14, 0xd6, 0x36, 0xbb, 0x9b, 0xdd, 0xb3, 0x49, 0xda, 0x4e, 0x93, 0xd6, 0x75, 0x6e, 0x1b, 0xb7,
+	0x6a, 0x23, 0x10, 0x29, 0x04, 0x50, 0x55, 0xe8, 0x85, 0xdc, 0x50, 0xa3, 0x5e, 0x94, 0xba, 0xa8,
+	0x02, 0x24, 0xb4, 0x72, 0xec, 0x69, 0x62, 0xc5, 0x37, 0xec, 0xd9, 0xa8, 0xfb, 0x14, 0xbc, 0x00,
+	0x2f, 0xc0, 0x43, 0xc0, 0x3f, 0x7e, 0xf2, 0x36, 0x3c, 0x00, 0x9a, 0xf1, 0x78, 0x2e, 0xb6, 0x77,
+	0x93, 0x10, 0x7e, 0xf0, 0x2b, 0x3b, 0xe7, 0x36, 0xdf, 0x39, 0x73, 0xe6, 0xcc, 0xe7, 0xc0, 0x6c,
+	0x88, 0xb3, 0xcc, 0x39, 0xc2, 0x1b, 0x49, 0x1a, 0x93, 0x18, 0x4d, 0xa5, 0x89, 0x6b, 0xf6, 0xc2,
+	0xd8, 0xc3, 0x41, 0x2e, 0xb1, 0xbe, 0x87, 0x9b, 0xaf, 0x72, 0x13, 0xfe, 0x27, 0xb3, 0xf1, 0xcf,
+	0x43, 0x9c, 0x11, 0x34, 0x0f, 0x2d, 0x12, 0x9f, 0xe0, 0xc8, 0x68, 0xf4, 0x1b, 0xeb, 0x5d, 0x3b,
+	0x5f, 0x20, 0x04, 0xcd, 0xf7, 0x69, 0x1c, 0x1a, 0x57, 0x98, 0x90, 0xfd, 0xa6, 0x96, 0x6e, 0x3c,
+	0x8c, 0x88, 0x31, 0xd5, 0x6f, 0xac, 0xb7, 0xec, 0x7c, 0x61, 0xed, 0xc0, 0xad, 0x4a, 0xe4, 0x2c,
+	0x89, 0xa3, 0x0c, 0xa3, 0x75, 0xe8, 0x70, 0x5c, 0x99, 0xd1, 0xe8, 0x4f, 0xad, 0xf7, 0x36, 0x67,
+	0x36, 0xd2, 0xc4, 0xdd, 0xe0, 0x86, 0xb6, 0xd0, 0x5a, 0x2f, 0x61, 0x41, 0x0f, 0x32, 0x19, 0xdd,
+	0x32, 0x00, 0x77, 0x1d, 0xf8, 0x1e, 0xc7, 0xd8, 0xe5, 0x92, 0x7d, 0xcf, 0xfa, 0xa6, 0x9c, 0xac,
+	0x40, 0x74, 0x0f, 0xa6, 0xb9, 0x19, 0x0b, 0x58, 0x06, 0x54, 0x28, 0xad, 0xbf, 0x1b, 0x80, 0xb8,
+	0xf0, 0x20, 0xce, 0xc8, 0x99, 0xb5, 0x22, 0xf8, 0x03, 0x29, 0x6a, 0x45, 0x7f, 0xa3, 0x3b, 0x30,
+	0xeb, 0x10, 0xe2, 0xb8, 0xc7, 0x21, 0x8e, 0x08, 0x05, 0x39, 0xc5, 0x94, 0x33, 0x52, 0xb8, 0xef,
+	0xd1, 0x34, 0x92, 0xe1, 0x61, 0xe0, 0x67, 0xc7, 0x03, 0x87, 0x18, 0xcd, 0x3c, 0x0d, 0x2e, 0xd9,
+	0x22, 0xe8, 0x36, 0x74, 0xbc, 0xd4, 0x79, 0xcf, 0xdc, 0x5b, 0x4c, 0x39, 0xcd, 0xd6, 0xfb, 0x1e,
+	0x5a, 0x87, 0x66, 0x12, 0x07, 0x81, 0xd1, 0x66, 0x49, 0xcc, 0xab, 0x49, 0x50, 0xbc, 0x07, 0x71,
+	0x10, 0xd8, 0xcc, 0x02, 0x7d, 0x0c, 0x2d, 0x7c, 0x8a, 0x23, 0x62, 0x4c, 0x33, 0xd3, 0x85, 0xb2,
+	0xe9, 0x1e, 0x55, 0xda, 0xb9, 0x8d, 0xf5, 0x4b, 0x03, 0xae, 0x96, 0xc2, 0x20, 0x03, 0xa6, 0xe3,
+	0x84, 0xf8, 0x71, 0x94, 0x9f, 0x61, 0xd7, 0x2e, 0x96, 0xe8, 0x3e, 0x5c, 0x0d, 0x87, 0x01, 0xf1,
+	0x93, 0x00, 0x0f, 0xdc, 0xe3, 0xd8, 0x77, 0x31, 0x2b, 0x41, 0xc7, 0x9e, 0x2b, 0xc4, 0x3b, 0x4c,
+	0x8a, 0x96, 0xa0, 0xeb, 0x44, 0x71, 0x34, 0x0a, 0xe3, 0x61, 0xc6, 0x0a, 0xd1, 0xb1, 0xa5, 0x00,
+	0x2d, 0x42, 0xd7, 0x0d, 0xe2, 0x0c, 0x67, 0xb2, 0x08, 0x9d, 0x5c, 0xb0, 0x45, 0xac, 0x5f, 0x1b,
+	0x70, 0xad, 0x8c, 0x96, 0x1d, 0x83, 0x4f, 0x02, 0x2c, 0x8e, 0x81, 0x2e, 0x68, 0x9c, 0x8c, 0x38,
+	0x29, 0x61, 0x71, 0xf2, 0xb3, 0xe8, 0xe4, 0x82, 0x2d, 0x82, 0x6e, 0xc1, 0x34, 0x8e, 0x3c, 0xa6,
+	0xca, 0x4f, 0xa2, 0x4d, 0x97, 0x5b, 0x04, 0x99, 0xd0, 0x09, 0x62, 0xd7, 0xa1, 0x19, 0x15, 0x9b,
+	0x17, 0x6b, 0xd4, 0x87, 0x9e, 0x87, 0x33, 0x37, 0xf5, 0x59, 0xc2, 0xfc, 0x0c, 0x54, 0x91, 0xf5,
+	0x04, 0x6e, 0x68, 0x6d, 0x72, 0xc1, 0x36, 0xfb, 0x43, 0xb6, 0xd9, 0x9e, 0xe7, 0x8b, 0x36, 0xd3,
+	0xdb, 0xbb, 0x51, 0x6a, 0x6f, 0xb4, 0x06, 0x33, 0xb4, 0xc7, 0x06, 0xee, 0xb1, 0x13, 0x1d, 0x61,
+	0x8f, 0x17, 0xbd, 0x47, 0x65, 0x3b, 0xb9, 0x48, 0xb4, 0xe4, 0x94, 0xd2, 0x92, 0x9f, 0x00, 0x52,
+	0x5a, 0xb2, 0x70, 0x6e, 0x32, 0xe7, 0xeb, 0x52, 0x53, 0x84, 0xa8, 0x74, 0x70, 0xab, 0xda, 0xc1,
+	0x4a, 0xfe, 0x39, 0xfe, 0x0b, 0xe6, 0xff, 0x25, 0xcc, 0x73, 0xd9, 0x2e, 0x0e, 0x30, 0xc1, 0xe7,
+	0x2b, 0x80, 0xf5, 0x5b, 0x03, 0x6e, 0x2b, 0x65, 0xdf, 0x89, 0x43, 0x0a, 0xe7, 0x32, 0x23, 0xa3,
+	0xb6, 0x60, 0x95, 0x0a, 0x34, 0x6b, 0xee, 0xf0, 0x0a, 0xf4, 0x52, 0x9c, 0x04, 0xa3, 0x01, 0x89,
+	0x65, 0x91, 0xba, 0x4c, 0xf4, 0x5d, 0xbc, 0xef, 0x59, 0xbb, 0x60, 0xd6, 0x41, 0x95, 0x85, 0x72,
+	0x73, 0x51, 0x7d, 0xa1, 0xb8, 0xd2, 0xfa, 0x53, 0x66, 0x4c, 0x0b, 0x5d, 0xca, 0x78, 0x19, 0x80,
+	0x1b, 0x2a, 0xe5, 0xe2, 0x92, 0xff, 0x57, 0xbf, 0xc8, 0x6a, 0x68, 0x69, 0x5c, 0xb0, 0x1a, 0x8f,
+	0x61, 0x51, 0x6b, 0x9b, 0x0b, 0x95, 0xc3, 0xfa, 0x4a, 0x94, 0xf2, 0xa5, 0x7f, 0x52, 0x7e, 0x6f,
+	0xce, 0xe8, 0xbc, 0x4d, 0x30, 0xeb, 0x7c, 0x39, 0xfe, 0x79, 0x68, 0x05, 0xfe, 0x09, 0x7b, 0xec,
+	0xd8, 0x03, 0xc9, 0x16, 0xa5, 0xfd, 0x2e, 0x86, 0x55, 0xdf, 0xaf, 0x5c, 0xaf, 0xfa, 0xfd, 0xbe,
+	0x16, 0x3e, 0x8a, 0x6b, 0x76, 0xce, 0x04, 0xf7, 0x60, 0xb1, 0xd6, 0x59, 0x9c, 0x90, 0xd8, 0x91,
+	0x3e, 0xe7, 0xd7, 0xd4, 0xf3, 0xa1, 0x96, 0x55, 0x0c, 0x1c, 0x73, 0x19, 0xc3, 0xa4, 0xa4, 0x25,
+	0x06, 0xdd, 0xf9, 0x82, 0x18, 0x5c, 0xb0, 0xb8, 0xf4, 0x2d, 0x26, 0xfc, 0xd7, 0x3b, 0x3f, 0xf3,
+	0x0f, 0xfd, 0xc0, 0x27, 0xa3, 0x73, 0xce, 0xda, 0x15, 0x80, 0x53, 0xe1, 0xc3, 0x6f, 0x8e, 0x22,
+	0xd1, 0x37, 0xe1, 0x70, 0x6b, 0x37, 0x99, 0x74, 0x41, 0xcf, 0xda, 0x64, 0x0b, 0xd6, 0xf8, 0x26,
+	0x5b, 0xa7, 0x8e, 0x1f, 0x38, 0x87, 0x01, 0xb6, 0xb1, 0xe3, 0xb2, 0x57, 0x58, 0x94, 0x65, 0x09,
+	0xba, 0x69, 0x21, 0xe4, 0x2f, 0xb5, 0x14, 0x58, 0xef, 0x44, 0x13, 0x6e, 0x79, 0x5e, 0xe1, 0x7c,
+	0xce, 0x1a, 0x98, 0xd0, 0x29, 0x02, 0x15, 0xef, 0x6a, 0xb1, 0xb6, 0x7e, 0x80, 0x25, 0x71, 0x0b,
+	0xc2, 0xf8, 0x14, 0xff, 0x87, 0xa1, 0x87, 0x82, 0x58, 0xca, 0xa0, 0x3c, 0xd7, 0x87, 0xe5, 0x5c,
+	0x7b, 0x9b, 0xb7, 0xb5, 0x51, 0xc1, 0x95, 0x3b, 0x94, 0xa1, 0x2a, 0x65, 0xa0, 0xa3, 0x30, 0x1c,
+	0x0d, 0xa4, 0xef, 0x15, 0x56, 0xa7, 0x5e, 0x38, 0xb2, 0x45, 0xa5, 0x9e, 0xc1, 0x4a, 0x99, 0x3c,
+	0x8a, 0x52, 0x9f, 0xeb, 0x0a, 0xbd, 0x81, 0xd5, 0xb1, 0x01, 0x38, 0xfe, 0x8d, 0x2a, 0xfe, 0x6a,
+	0x1b, 0x2b, 0xa7, 0x17, 0x8a, 0x52, 0xbc, 0x75, 0x4e, 0xf1, 0x2e, 0x25, 0x81, 0x05, 0x18, 0x95,
+	0x24, 0x36, 0x74, 0x92, 0xf8, 0x6f, 0x79, 0xa9, 0xf5, 0x14, 0x8c, 0xea, 0x76, 0x1c, 0xba, 0x05,
+	0x2d, 0x16, 0xbf, 0x76, 0x42, 0xe7, 0x2a, 0xeb, 0x89, 0x60, 0xf3, 0xcc, 0x57, 0xe6, 0x7d, 0x17,
+	0xda, 0xcc, 0xa2, 0xfe, 0x73, 0x80, 0xeb, 0xac, 0x63, 0x41, 0x8a, 0xde, 0xc5, 0xe4, 0x52, 0x5f,
+	0x02, 0x54, 0x9d, 0xb3, 0xd5, 0x81, 0xef, 0x51, 0xea, 0xc9, 0x6e, 0x45, 0x2e, 0xd9, 0xf7, 0x32,
+	0xeb, 0x0b, 0xb8, 0xa1, 0xed, 0xc4, 0x61, 0x2e, 0x73, 0x76, 0x9d, 0xa7, 0xd8, 0x65, 0x20, 0x25,
+	0xa5, 0xb6, 0x1c, 0x81, 0xcf, 0xce, 0x4e, 0x93, 0x4b, 0xe1, 0xbb, 0x09, 0xed, 0x8c, 0x38, 0x84,
+	0xd3, 0xe2, 0xae, 0xcd, 0x57, 0xd6, 0x43, 0xb8, 0xa1, 0x6d, 0xc1, 0x81, 0xf5, 0x0b, 0x32, 0x9f,
+	0x23, 0x03, 0x86, 0x4c, 0x63, 0xf0, 0x9f, 0xc9, 0xd9, 0xe9, 0x04, 0x38, 0xf2, 0x9c, 0xf4, 0x5b,
+	0x8c, 0x3d, 0x11, 0x00, 0x41, 0x33, 0xf0, 0xa3, 0x13, 0x8e, 0x91, 0xfd, 0xb6, 0x9e, 0xc1, 0x1d,
+	0x39, 0xc2, 0xf2, 0x07, 0x75, 0xef, 0x43, 0xe2, 0xa7, 0xd8, 0xa3, 0x8c, 0x45, 0x74, 0xbd, 0x41,
+	0x19, 0x34, 0x1d, 0x3d, 0x79, 0x9f, 0x75, 0xec, 0x62, 0x69, 0xfd, 0x7e, 0x45, 0x0c, 0x01, 0xea,
+	0xe1, 0x47, 0x47, 0x2f, 0xfd, 0xd0, 0x57, 0x8e, 0xdd, 0x82, 0xd9, 0x84, 0x86, 0x1a, 0x24, 0x38,
+	0x1d, 0x78, 0xce, 0x88, 0xbf, 0x57, 0x3d, 0x26, 0x3c, 0xc0, 0xe9, 0xae, 0x33, 0xa2, 0x1f, 0x13,
+	0xb9, 0x4d, 0x8a, 0x43, 0xc7, 0x8f, 0xfc, 0xe8, 0x88, 0x55, 0xab, 0x65, 0xcf, 0x25, 0x39, 0x0a,
+	0x2e, 0x45, 0x1f, 0xc1, 0x75, 0x3e, 0x39, 0xf3, 0x78, 0xc7, 0xf1, 0x30, 0xe5, 0x5f, 0xa4, 0x57,
+	0x0b, 0xc5, 0x01, 0x4e, 0x9f, 0xc7, 0xc3, 0x94, 0x52, 0x18, 0x61, 0x2b, 0xe3, 0x36, 0x99, 0xb1,
+	0x88, 0x22, 0x43, 0x3f, 0x04, 0x43, 0xb9, 0x1c, 0x87, 0x23, 0x82, 0x25, 0x64, 0xca, 0x66, 0xa6,
+	0xec, 0x05, 0xa9, 0xdf, 0xa6, 0x6a, 0x0e, 0xfe, 0x31, 0x98, 0x15, 0x47, 0xb9, 0x5f, 0x9b, 0xb9,
+	0x1a, 0x25, 0x57, 0xb1, 0xed, 0xe6, 0x5f, 0x73, 0x30, 0x27, 0x4e, 0x20, 0x3d, 0xa5, 0x5f, 0x4c,
+	0x7b, 0xd0, 0xe1, 0x92, 0x0c, 0x2d, 0xaa, 0x97, 0xa4, 0xf4, 0xf5, 0x6e, 0x2e, 0xd5, 0x2b, 0x79,
+	0xe1, 0xb7, 0x61, 0x9a, 0xcb, 0x90, 0x59, 0x63, 0x58, 0x04, 0x59, 0xac, 0xd5, 0xf1, 0x18, 0x8f,
+	0xa0, 0x49, 0x4f, 0x15, 0xdd, 0x2a, 0x7f, 0x39, 0x16, 0xde, 0x46, 0x55, 0x21, 0x5d, 0x29, 0xcd,
+	0xd3, 0x5d, 0x95, 0x0f, 0x1d, 0xd3, 0xa8, 0x2a, 0xb8, 0xeb, 0x03, 0x68, 0xe7, 0xad, 0x88, 0xb4,
+	0xc1, 0xae, 0x7d, 0x26, 0x98, 0xbc, 0xff, 0xc3, 0x84, 0x8c, 0xd0, 0x6b, 0xe8, 0x29, 0x04, 0x1b,
+	0xad, 0x94, 0x41, 0xe9, 0xbc, 0xcb, 0x5c, 0x1d, 0xab, 0xe7, 0x00, 0x5e, 0x43, 0x4f, 0xa1, 0xa8,
+	0x7a, 0xbc, 0x2a, 0x05, 0x37, 0x57, 0xc7, 0xea, 0x79, 0xbc, 0x27, 0x30, 0xab, 0x91, 0x55, 0xd4,
+	0xaf, 0xe6, 0x55, 0x8a, 0x59, 0x4a, 0x4f, 0x61, 0x9c, 0x3a, 0x9c, 0x2a, 0x8d, 0x35, 0x57, 0xc7,
+	0xea, 0x65, 0x7a, 0x0a, 0xa3, 0xac, 0xc6, 0x9b, 0x94, 0x5e, 0x1d, 0x15, 0x7d, 0x03, 0x33, 0x8a,
+	0x36, 0x43, 0xab, 0x35, 0x2d, 0xa5, 0x72, 0x40, 0xb3, 0x3f, 0xde, 0x40, 0x86, 0x54, 0xf9, 0x9f,
+	0x1e, 0xb2, 0x86, 0x56, 0x9a, 0xfd, 0xf1, 0x06, 0x3c, 0xe4, 0x0b, 0x98, 0xaf, 0xe3, 0x82, 0xe8,
+	0xbe, 0xea, 0x39, 0x81, 0x2d, 0x6a, 0x47, 0x92, 0x07, 0xab, 0x70, 0xbe, 0x4a, 0xb0, 0x71, 0xac,
+	0x50, 0x0b, 0xf6, 0x1c, 0x50, 0x95, 0xdb, 0x21, 0xc5, 0xc2, 0xbc, 0xa7, 0x86, 0x9d, 0xc0, 0x03,
+	0x5f, 0x40, 0x4f, 0xa1, 0x78, 0xfa, 0xc9, 0x56, 0xb9, 0x9f, 0x3e, 0x40, 0x2a, 0x44, 0xeb, 0x0d,
+	0xcc, 0xe9, 0xbc, 0x0e, 0xad, 0xe9, 0xf6, 0x35, 0x9c, 0xef, 0x8c, 0x90, 0x3f, 0xc1, 0xb5, 0x92,
+	0x2a, 0x43, 0x77, 0x6a, 0x07, 0x90, 0x4e, 0xbb, 0xcc, 0xbb, 0x93, 0x8d, 0x78, 0xf8, 0xe7, 0xd0,
+	0x15, 0xa4, 0x05, 0x69, 0x48, 0xca, 0xd4, 0xc9, 0x5c, 0x1e, 0xa3, 0xe5, 0x91, 0x3e, 0x85, 0x36,
+	0x13, 0xe8, 0xc7, 0xa0, 0xcd, 0xd1, 0x12, 0xbd, 0x79, 0x04, 0x4d, 0xca, 0x23, 0xf4, 0x79, 0xa7,
+	0x70, 0x18, 0xd3, 0xa8, 0x2a, 0xa4, 0x2b, 0x7d, 0xe9, 0x75, 0x57, 0x85, 0x5e, 0x98, 0x46, 0x55,
+	0xc1, 0x5d, 0x1f, 0xc3, 0x8c, 0xfa, 0xd6, 0x6b, 0x68, 0xf5, 0x2b, 0x51, 0xc7, 0x08, 0xb6, 0x01,
+	0xd9, 0x31, 0x71, 0x08, 0xbe, 0x44, 0x8c, 0x57, 0xb0, 0x50, 0x4b, 0x1d, 0xd0, 0x7a, 0xe9, 0x2a,
+	0x8c, 0x65, 0x17, 0xda, 0x5d, 0x78, 0x0a, 0xb3, 0x1a, 0x8f, 0xd0, 0xd0, 0xac, 0x95, 0x07, 0x77,
+	0x85, 0x6e, 0x6c, 0x77, 0x7e, 0x6c, 0x6f, 0x6c, 0x3c, 0x48, 0x13, 0xf7, 0xb0, 0xcd, 0xfe, 0xf9,
+	0xfd, 0xf9, 0x3f, 0x03, 0x00, 0x0d, 0xa7, 0xc0, 0x82, 0x1f, 0x17, 0x00, 0x00,
 }
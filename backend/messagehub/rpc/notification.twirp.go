@@ -23,7 +23,7 @@ import ctxsetters "github.com/twitchtv/twirp/ctxsetters"
 type NotificationService interface {
 	Count(context.Context, *Empty) (*NotificationCountResponse, error)
 
-	Notifications(context.Context, *Empty) (*NotificationNotificationsResponse, error)
+	Notifications(context.Context, *NotificationNotificationsRequest) (*NotificationNotificationsResponse, error)
 
 	Clean(context.Context, *NotificationCleanRequest) (*Empty, error)
 
@@ -87,7 +87,7 @@ func (c *notificationServiceProtobufClient) Count(ctx context.Context, in *Empty
 	return out, nil
 }
 
-func (c *notificationServiceProtobufClient) Notifications(ctx context.Context, in *Empty) (*NotificationNotificationsResponse, error) {
+func (c *notificationServiceProtobufClient) Notifications(ctx context.Context, in *NotificationNotificationsRequest) (*NotificationNotificationsResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "rpc")
 	ctx = ctxsetters.WithServiceName(ctx, "NotificationService")
 	ctx = ctxsetters.WithMethodName(ctx, "Notifications")
@@ -204,7 +204,7 @@ func (c *notificationServiceJSONClient) Count(ctx context.Context, in *Empty) (*
 	return out, nil
 }
 
-func (c *notificationServiceJSONClient) Notifications(ctx context.Context, in *Empty) (*NotificationNotificationsResponse, error) {
+func (c *notificationServiceJSONClient) Notifications(ctx context.Context, in *NotificationNotificationsRequest) (*NotificationNotificationsResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "rpc")
 	ctx = ctxsetters.WithServiceName(ctx, "NotificationService")
 	ctx = ctxsetters.WithMethodName(ctx, "Notifications")
@@ -488,7 +488,7 @@ func (s *notificationServiceServer) serveNotificationsJSON(ctx context.Context,
 		return
 	}
 
-	reqContent := new(Empty)
+	reqContent := new(NotificationNotificationsRequest)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
@@ -548,7 +548,7 @@ func (s *notificationServiceServer) serveNotificationsProtobuf(ctx context.Conte
 		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
 		return
 	}
-	reqContent := new(Empty)
+	reqContent := new(NotificationNotificationsRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
@@ -849,7 +849,7 @@ func (s *notificationServiceServer) serveMarkReadProtobuf(ctx context.Context, r
 }
 
 func (s *notificationServiceServer) ServiceDescriptor() ([]byte, int) {
-	return twirpFileDescriptor3, 0
+	return twirpFileDescriptor4, 0
 }
 
 func (s *notificationServiceServer) ProtocGenTwirpVersion() string {
@@ -860,25 +860,28 @@ func (s *notificationServiceServer) PathPrefix() string {
 	return NotificationServicePathPrefix
 }
 
-var twirpFileDescriptor3 = []byte{
-	// 298 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xc1, 0x4b, 0xf4, 0x30,
-	0x10, 0xc5, 0xe9, 0xb7, 0xb4, 0xec, 0x37, 0xa5, 0x07, 0xa3, 0x48, 0xad, 0x28, 0xb5, 0x07, 0xd9,
-	0x53, 0x85, 0xee, 0x41, 0x4f, 0x82, 0x2e, 0x1e, 0x16, 0xd1, 0x43, 0xbc, 0x89, 0xb0, 0xc4, 0x36,
-	0x42, 0xd9, 0x6e, 0x12, 0x93, 0x54, 0xf1, 0x8f, 0x17, 0x64, 0xb3, 0x2a, 0x13, 0xeb, 0xc5, 0x5b,
-	0xe7, 0x31, 0xef, 0x75, 0xde, 0x8f, 0x00, 0x11, 0xd2, 0xb6, 0x4f, 0x6d, 0xcd, 0x6c, 0x2b, 0x45,
-	0xa9, 0xb4, 0xb4, 0x92, 0x8c, 0xb4, 0xaa, 0xb3, 0x78, 0x25, 0x1b, 0xde, 0x6d, 0x94, 0x62, 0x0e,
-	0x7b, 0xb7, 0x68, 0x6f, 0x26, 0x7b, 0x61, 0x29, 0x37, 0x4a, 0x0a, 0xc3, 0xc9, 0x0e, 0x84, 0x56,
-	0x5a, 0xd6, 0xa5, 0x41, 0x1e, 0x4c, 0x42, 0xba, 0x19, 0xc8, 0x2e, 0x44, 0xbd, 0xd0, 0x9c, 0x35,
-	0xe9, 0x3f, 0x27, 0x7f, 0x4e, 0xc5, 0x03, 0x1c, 0xe1, 0x28, 0xfc, 0x6d, 0xbe, 0x23, 0x4f, 0x21,
-	0xc1, 0x77, 0x99, 0x34, 0xc8, 0x47, 0x93, 0xb8, 0xda, 0x2a, 0xb5, 0xaa, 0x4b, 0x6c, 0xa1, 0xfe,
-	0x5e, 0x71, 0x0e, 0xa9, 0x77, 0x68, 0xc7, 0x99, 0xa0, 0xfc, 0xb9, 0xe7, 0xc6, 0x92, 0x02, 0x92,
-	0x8e, 0x19, 0xbb, 0x58, 0x0a, 0xf9, 0x2a, 0x16, 0x6d, 0xe3, 0xee, 0xfd, 0x4f, 0xe3, 0xb5, 0x78,
-	0xbd, 0xd6, 0xe6, 0x4d, 0x71, 0x01, 0xfb, 0xd8, 0x7f, 0xc3, 0xf4, 0x92, 0x72, 0xd6, 0xfc, 0x21,
-	0xa2, 0x7a, 0x0f, 0x60, 0x1b, 0x67, 0xdc, 0x71, 0xfd, 0xd2, 0xd6, 0x9c, 0x4c, 0x21, 0x74, 0xdc,
-	0x08, 0xb8, 0x16, 0x57, 0x2b, 0x65, 0xdf, 0xb2, 0xc3, 0x41, 0x23, 0x9f, 0xed, 0x0c, 0x12, 0x8f,
-	0x90, 0x67, 0x3e, 0x1e, 0x98, 0x7f, 0xa7, 0x59, 0x41, 0xe8, 0x40, 0x90, 0x83, 0xe1, 0xdf, 0x10,
-	0xa0, 0x0c, 0x65, 0x93, 0x33, 0x18, 0x7f, 0x95, 0x27, 0xf9, 0xc0, 0xf6, 0x83, 0x0b, 0x76, 0x5e,
-	0x8e, 0xef, 0xa3, 0xb2, 0x3c, 0xd1, 0xaa, 0x7e, 0x8c, 0xdc, 0xe3, 0x99, 0x7e, 0x04, 0x00, 0x00,
-	0xff, 0xff, 0x69, 0xfc, 0x8e, 0xda, 0x64, 0x02, 0x00, 0x00,
+var twirpFileDescriptor4 = []byte{
+	// 350 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x53, 0x51, 0x4b, 0xf3, 0x30,
+	0x14, 0x65, 0x1b, 0x1d, 0xdb, 0x1d, 0xe3, 0xfb, 0x8c, 0x22, 0xb5, 0x43, 0xa9, 0x05, 0x65, 0xbe,
+	0x54, 0xd8, 0x1e, 0xf4, 0x59, 0xf1, 0xa1, 0x88, 0x3e, 0x44, 0x7c, 0x11, 0x71, 0x64, 0x4d, 0x06,
+	0x61, 0x5d, 0x52, 0x93, 0x4c, 0xf1, 0x9f, 0xf8, 0x73, 0xa5, 0x69, 0x87, 0xa9, 0x15, 0x7d, 0xf2,
+	0xad, 0xf7, 0x70, 0xef, 0x39, 0xe7, 0x9e, 0xdb, 0x00, 0x12, 0xd2, 0xf0, 0x05, 0x4f, 0x89, 0xe1,
+	0x52, 0xc4, 0xb9, 0x92, 0x46, 0xa2, 0x8e, 0xca, 0xd3, 0x60, 0xb0, 0x92, 0x94, 0x65, 0x25, 0x12,
+	0x25, 0xb0, 0x77, 0xeb, 0xf4, 0x5d, 0xca, 0xb5, 0x30, 0x98, 0xe9, 0x5c, 0x0a, 0xcd, 0xd0, 0x0e,
+	0x78, 0x46, 0x1a, 0x92, 0xf9, 0xad, 0xb0, 0x35, 0xf6, 0x70, 0x59, 0xa0, 0x5d, 0xe8, 0xae, 0x85,
+	0x62, 0x84, 0xfa, 0x6d, 0x0b, 0x57, 0x55, 0x74, 0x0f, 0xa1, 0x4b, 0xe5, 0x7e, 0x6b, 0xcc, 0x9e,
+	0xd7, 0x4c, 0x1b, 0x34, 0x82, 0xfe, 0x9c, 0x2d, 0xa4, 0x62, 0x33, 0x4e, 0x2d, 0x6b, 0x1f, 0xf7,
+	0x4a, 0x20, 0xa1, 0x85, 0x5c, 0x5a, 0xe8, 0x57, 0xbc, 0x65, 0x11, 0x3d, 0xc2, 0xe1, 0x0f, 0xb4,
+	0x95, 0xd3, 0x33, 0x18, 0xba, 0xeb, 0x6a, 0xbf, 0x15, 0x76, 0xc6, 0x83, 0xc9, 0x56, 0xac, 0xf2,
+	0x34, 0x76, 0x47, 0x70, 0xbd, 0x2f, 0xe2, 0xe0, 0xd7, 0xf6, 0xcf, 0x18, 0x11, 0x1b, 0xb3, 0x11,
+	0x0c, 0x33, 0xa2, 0xcd, 0x6c, 0x29, 0xe4, 0xab, 0xf8, 0x34, 0x3c, 0x28, 0xc0, 0xeb, 0x02, 0x4b,
+	0x28, 0x3a, 0x81, 0xff, 0x2e, 0xe1, 0x8c, 0x53, 0xed, 0xb7, 0xc3, 0xce, 0xb8, 0x8f, 0xff, 0xb9,
+	0x78, 0x42, 0x75, 0x94, 0xc1, 0xc8, 0x95, 0xba, 0x21, 0x6a, 0x89, 0x19, 0xa1, 0x7f, 0xa3, 0x36,
+	0x79, 0x6f, 0xc3, 0xb6, 0x2b, 0x77, 0xc7, 0xd4, 0x0b, 0x4f, 0x19, 0x9a, 0x82, 0x67, 0x8f, 0x8c,
+	0xc0, 0x66, 0x73, 0xb5, 0xca, 0xcd, 0x5b, 0x70, 0xd0, 0xc8, 0xa9, 0xfe, 0x23, 0x3c, 0xc1, 0xb0,
+	0x96, 0x3b, 0x3a, 0x6a, 0x0c, 0x7c, 0x77, 0xee, 0xe0, 0xf8, 0xb7, 0xb6, 0x8a, 0x7f, 0x02, 0x9e,
+	0x4d, 0x1e, 0xed, 0x37, 0x8d, 0x38, 0x17, 0x09, 0x1c, 0xcf, 0xe8, 0x1c, 0x7a, 0x9b, 0x08, 0x51,
+	0xd8, 0x18, 0xfb, 0x92, 0xae, 0x3b, 0x79, 0xd1, 0x7b, 0xe8, 0xc6, 0xf1, 0xa9, 0xca, 0xd3, 0x79,
+	0xd7, 0x3e, 0x82, 0xe9, 0xc7, 0x00, 0xeb, 0x61, 0x04, 0xd6, 0x2c, 0x03, 0x00, 0x00,
 }
@@ -2,21 +2,24 @@ package messagehub
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"strings"
 
-	"github.com/ansel1/merry"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/cors"
+	"github.com/jmoiron/sqlx"
 	"github.com/twitchtv/twirp"
 
 	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/health"
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/common/metrics"
+	"github.com/mreider/koto/backend/common/tracing"
 	"github.com/mreider/koto/backend/messagehub/config"
 	"github.com/mreider/koto/backend/messagehub/repo"
+	"github.com/mreider/koto/backend/messagehub/routers"
 	"github.com/mreider/koto/backend/messagehub/rpc"
 	"github.com/mreider/koto/backend/messagehub/services"
 	"github.com/mreider/koto/backend/token"
@@ -24,59 +27,90 @@ import (
 
 type Server struct {
 	cfg            config.Config
+	db             *sqlx.DB
 	repos          repo.Repos
 	tokenParser    token.Parser
-	s3Storage      *common.S3Storage
+	blobStorage    common.BlobStorage
 	tokenGenerator token.Generator
+	hubTokenParser token.Parser
 	pubKeyPEM      string
+	userHubCheck   health.Check
 }
 
-func NewServer(cfg config.Config, repos repo.Repos, tokenParser token.Parser, s3Storage *common.S3Storage,
-	tokenGenerator token.Generator, pubKeyPEM string) *Server {
+// NewServer creates the server. userHubCheck reports whether the user hub public key can be loaded.
+func NewServer(cfg config.Config, db *sqlx.DB, repos repo.Repos, tokenParser token.Parser, blobStorage common.BlobStorage,
+	tokenGenerator token.Generator, hubTokenParser token.Parser, pubKeyPEM string, userHubCheck health.Check) *Server {
 	return &Server{
 		cfg:            cfg,
+		db:             db,
 		repos:          repos,
 		tokenParser:    tokenParser,
-		s3Storage:      s3Storage,
+		blobStorage:    blobStorage,
 		tokenGenerator: tokenGenerator,
+		hubTokenParser: hubTokenParser,
 		pubKeyPEM:      pubKeyPEM,
+		userHubCheck:   userHubCheck,
 	}
 }
 
-func (s *Server) Run() error {
+// Run serves requests until ctx is done, then stops accepting new requests, waits for the running ones
+// and stops the background jobs. The whole shutdown is limited by the configured timeout.
+func (s *Server) Run(ctx context.Context) error {
 	r := chi.NewRouter()
 	s.setupMiddlewares(r)
 
-	rpcHooks := &twirp.ServerHooks{
-		Error: func(ctx context.Context, err twirp.Error) context.Context {
-			cause := errors.Unwrap(err)
-			if cause != nil {
-				if err.Code() == twirp.Internal {
-					log.Print(merry.Details(cause))
-				} else {
-					sourceLine := merry.SourceLine(cause)
-					if sourceLine != "" {
-						log.Printf("%s: %s\n", cause, sourceLine)
-					}
-				}
-			} else {
-				log.Println(err)
-			}
-			return ctx
-		},
-	}
+	checker := health.NewChecker()
+	checker.Add("postgres", s.db.PingContext)
+	checker.Add("storage", s.blobStorage.Ping)
+	checker.Add("user_hub", s.userHubCheck)
+	r.Handle("/healthz", checker.LiveHandler())
+	r.Handle("/readyz", checker.ReadyHandler())
+
+	// notifications are sent by the other jobs, so the sender stops last
+	workers := common.NewWorkers()
+	senders := common.NewWorkers()
 
-	notificationSender := services.NewNotificationSender(s.repos.Notification, s.cfg.ExternalAddress,
+	rpcHooks := twirp.ChainHooks(metrics.TwirpHooks(), logging.TwirpHooks(), tracing.TwirpHooks())
+
+	notificationSender := services.NewNotificationSender(s.repos.DB, s.repos.Notification, s.repos.NotificationOutbox, s.cfg.ExternalAddress,
 		fmt.Sprintf("%s/rpc.MessageHubNotificationService/PostNotifications", s.cfg.UserHubAddress),
 		s.tokenGenerator)
-	notificationSender.Start()
-	baseService := services.NewBase(s.repos, s.tokenParser, s.cfg.ExternalAddress, s.s3Storage, notificationSender)
+	senders.Go(notificationSender.Run)
+	egressCounter := services.NewEgressCounter(s.repos)
+	workers.Go(egressCounter.Flush)
+	baseService := services.NewBase(s.repos, s.tokenParser, s.tokenGenerator, s.hubTokenParser, s.cfg.ExternalAddress, s.blobStorage,
+		notificationSender, egressCounter)
+
+	userHubClient := services.NewUserHubClient(s.cfg.ExternalAddress, s.cfg.UserHubAddress, s.tokenGenerator)
+	messagePublisher := services.NewMessagePublisher(s.repos, notificationSender, userHubClient)
+	workers.Go(messagePublisher.Publish)
 
-	messageService := services.NewMessage(baseService)
+	pollCloser := services.NewPollCloser(s.repos, notificationSender)
+	workers.Go(pollCloser.Close)
+
+	eventReminder := services.NewEventReminder(s.repos, notificationSender, s.cfg.EventReminderLeadTime())
+	workers.Go(eventReminder.Remind)
+
+	reactionNotifier := services.NewReactionNotifier(notificationSender, s.cfg.ReactionNotificationDelay())
+	workers.Go(reactionNotifier.Run)
+
+	limiter := services.NewLimiter(s.repos, services.PostingLimits{
+		PostsPerDay:           s.cfg.PostsPerDay,
+		CommentsPerHour:       s.cfg.CommentsPerHour,
+		AttachmentBytesPerDay: s.cfg.AttachmentBytesPerDay(),
+	})
+	messageService := services.NewMessage(baseService, s.cfg.ReactionList(), reactionNotifier, s.cfg.MaxCommentDepth, limiter)
 	messageServiceHandler := rpc.NewMessageServiceServer(messageService, rpcHooks)
 	r.Handle(messageServiceHandler.PathPrefix()+"*", s.checkAuth(messageServiceHandler))
 
-	blobService := services.NewBlob(baseService)
+	conversationService := services.NewConversation(baseService)
+	conversationServiceHandler := rpc.NewConversationServiceServer(conversationService, rpcHooks)
+	r.Handle(conversationServiceHandler.PathPrefix()+"*", s.checkAuth(conversationServiceHandler))
+
+	costReporter := services.NewCostReporter(s.repos, s.cfg.PriceList())
+	retentionEngine := services.NewRetentionEngine(s.repos, s.cfg.RetentionPolicy(), s.cfg.RetentionDryRun)
+	workers.Go(retentionEngine.Run)
+	blobService := services.NewBlob(baseService, s.cfg.UserStorageQuota(), s.cfg.MaxUploadSize(), costReporter, retentionEngine, limiter)
 	blobServiceHandler := rpc.NewBlobServiceServer(blobService, rpcHooks)
 	r.Handle(blobServiceHandler.PathPrefix()+"*", s.checkAuth(blobServiceHandler))
 
@@ -88,15 +122,37 @@ func (s *Server) Run() error {
 	infoServiceHandler := rpc.NewInfoServiceServer(infoService, rpcHooks)
 	r.Handle(infoServiceHandler.PathPrefix()+"*", infoServiceHandler)
 
-	log.Println("started on " + s.cfg.ListenAddress)
-	return http.ListenAndServe(s.cfg.ListenAddress, r)
+	r.Mount("/calendar", routers.Calendar(s.repos, s.hubTokenParser, s.cfg.ExternalAddress))
+	if s.cfg.MetricsToken != "" {
+		r.Handle("/metrics", metrics.Handler(metrics.Default, s.cfg.MetricsToken))
+	}
+	r.Handle("/admin/cost-report.csv", s.checkAuth(routers.CostReport(costReporter)))
+	if fsStorage, ok := s.blobStorage.(*common.FSStorage); ok {
+		r.Mount("/blob", fsStorage.Handler())
+	}
+
+	s3Cleaner := common.NewS3Cleaner(s.db, s.blobStorage, s.repos.Blob.RemoveBlob)
+	workers.Go(s3Cleaner.Clean)
+
+	uploadCleaner := services.NewUploadCleaner(s.repos)
+	workers.Go(uploadCleaner.Clean)
+
+	notificationCleaner := common.NewNotificationCleaner(s.repos.Notification, s.cfg.NotificationRetention())
+	workers.Go(notificationCleaner.Clean)
+
+	server := &http.Server{
+		Addr:    s.cfg.ListenAddress,
+		Handler: r,
+	}
+	return common.Serve(ctx, server, checker, s.cfg.ShutdownDrainDelay(), s.cfg.ShutdownTimeout(), workers, senders)
 }
 
 func (s *Server) setupMiddlewares(r *chi.Mux) {
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
+	r.Use(logging.Middleware)
 	r.Use(middleware.Recoverer)
+	r.Use(tracing.Middleware)
 	r.Use(middleware.Compress(5))
 
 	corsOptions := cors.Options{
@@ -126,13 +182,15 @@ func (s *Server) checkAuth(next http.Handler) http.Handler {
 
 		userID := claims["id"].(string)
 		userName, _ := claims["name"].(string)
+		logging.AddField(r.Context(), "user_id", userID)
 
-		err = s.repos.User.AddUser(userID, userName)
+		err = s.repos.User.AddUser(r.Context(), userID, userName)
 		if err != nil {
-			log.Println(err)
+			logging.FromContext(r.Context()).WithError(err).Error("can't add user")
 		}
 
 		ctx := context.WithValue(r.Context(), services.ContextUserKey, services.User{ID: userID, Name: userName})
+		ctx = context.WithValue(ctx, services.ContextIsAdminKey, s.cfg.IsAdmin(userName))
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/ansel1/merry"
+
 	"github.com/mreider/koto/backend/common"
 	"github.com/mreider/koto/backend/messagehub/repo"
 	"github.com/mreider/koto/backend/token"
@@ -12,7 +14,8 @@ import (
 type ContextKey string
 
 const (
-	ContextUserKey ContextKey = "user"
+	ContextUserKey    ContextKey = "user"
+	ContextIsAdminKey ContextKey = "isAdmin"
 )
 
 type User struct {
@@ -23,18 +26,25 @@ type User struct {
 type BaseService struct {
 	repos              repo.Repos
 	tokenParser        token.Parser
+	tokenGenerator     token.Generator
+	hubTokenParser     token.Parser
 	externalAddress    string
-	s3Storage          *common.S3Storage
+	blobStorage        common.BlobStorage
 	notificationSender NotificationSender
+	egressCounter      *EgressCounter
 }
 
-func NewBase(repos repo.Repos, tokenParser token.Parser, externalAddress string, s3Storage *common.S3Storage, notificationSender NotificationSender) *BaseService {
+func NewBase(repos repo.Repos, tokenParser token.Parser, tokenGenerator token.Generator, hubTokenParser token.Parser,
+	externalAddress string, blobStorage common.BlobStorage, notificationSender NotificationSender, egressCounter *EgressCounter) *BaseService {
 	return &BaseService{
 		repos:              repos,
 		tokenParser:        tokenParser,
+		tokenGenerator:     tokenGenerator,
+		hubTokenParser:     hubTokenParser,
 		externalAddress:    externalAddress,
-		s3Storage:          s3Storage,
+		blobStorage:        blobStorage,
 		notificationSender: notificationSender,
+		egressCounter:      egressCounter,
 	}
 }
 
@@ -42,9 +52,29 @@ func (s *BaseService) getUser(ctx context.Context) User {
 	return ctx.Value(ContextUserKey).(User)
 }
 
+func (s *BaseService) isAdmin(ctx context.Context) bool {
+	isAdmin, _ := ctx.Value(ContextIsAdminKey).(bool)
+	return isAdmin
+}
+
+// recordBlob keeps the size of an accepted blob for the storage usage.
+func (s *BaseService) recordBlob(ctx context.Context, userID, blobID string) error {
+	if blobID == "" {
+		return nil
+	}
+	size, err := s.blobStorage.Size(ctx, blobID)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return s.repos.Blob.AddBlob(ctx, blobID, userID, size)
+}
+
 func (s *BaseService) createBlobLink(ctx context.Context, blobID string) (string, error) {
 	if blobID == "" {
 		return "", nil
 	}
-	return s.s3Storage.CreateLink(ctx, blobID, time.Hour*24)
+	if s.egressCounter != nil {
+		s.egressCounter.Count(blobID)
+	}
+	return s.blobStorage.CreateLink(ctx, blobID, time.Hour*24)
 }
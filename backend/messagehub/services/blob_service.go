@@ -6,28 +6,51 @@ import (
 	"strings"
 
 	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+	"github.com/twitchtv/twirp"
 
 	"github.com/mreider/koto/backend/common"
 	"github.com/mreider/koto/backend/messagehub/rpc"
+	"github.com/mreider/koto/backend/messagehub/services/cost"
 )
 
 const (
-	blobIDLength = 10
+	blobIDLength            = 10
+	maxRetentionDryRunItems = 1000
 )
 
 type blobService struct {
 	*BaseService
+	userQuota       int64
+	maxUploadSize   int64
+	costReporter    *CostReporter
+	retentionEngine *RetentionEngine
+	limiter         *Limiter
 }
 
-func NewBlob(base *BaseService) rpc.BlobService {
+func NewBlob(base *BaseService, userQuota, maxUploadSize int64, costReporter *CostReporter, retentionEngine *RetentionEngine, limiter *Limiter) rpc.BlobService {
 	return &blobService{
-		BaseService: base,
+		BaseService:     base,
+		userQuota:       userQuota,
+		maxUploadSize:   maxUploadSize,
+		costReporter:    costReporter,
+		retentionEngine: retentionEngine,
+		limiter:         limiter,
 	}
 }
 
+// UploadLink reserves the declared upload size (or the maximum upload size) in the user's quota until the blob is attached.
+// Links of a user are created one at a time, so parallel uploads can't exceed the quota together.
 func (s *blobService) UploadLink(ctx context.Context, r *rpc.BlobUploadLinkRequest) (*rpc.BlobUploadLinkResponse, error) {
 	user := s.getUser(ctx)
 
+	if r.Size < 0 {
+		return nil, twirp.InvalidArgumentError("size", "is negative")
+	}
+	if s.maxUploadSize > 0 && r.Size > s.maxUploadSize {
+		return nil, twirp.InvalidArgumentError("size", "is larger than the maximum upload size")
+	}
+
 	blobID, err := common.GenerateRandomString(blobIDLength)
 	if err != nil {
 		return nil, merry.Wrap(err)
@@ -38,13 +61,30 @@ func (s *blobService) UploadLink(ctx context.Context, r *rpc.BlobUploadLinkReque
 		blobID = strings.TrimSuffix(r.FileName, ext) + "-" + blobID + ext
 	}
 
-	uploadLink, formData, err := s.s3Storage.CreateUploadLink(ctx, blobID, r.ContentType,
-		map[string]string{
-			"user-id":   user.ID,
-			"user-name": user.Name,
-		})
+	var uploadLink string
+	var formData map[string]string
+	err = common.RunInTransaction(ctx, s.repos.DB, func(tx *sqlx.Tx) error {
+		err := s.repos.Blob.LockUserBlobs(ctx, tx, user.ID)
+		if err != nil {
+			return err
+		}
+		maxSize, err := s.uploadSize(ctx, user.ID, r.Size)
+		if err != nil {
+			return err
+		}
+
+		uploadLink, formData, err = s.blobStorage.CreateUploadLink(ctx, blobID, r.ContentType, maxSize,
+			map[string]string{
+				"user-id":   user.ID,
+				"user-name": user.Name,
+			})
+		if err != nil {
+			return merry.Wrap(err)
+		}
+		return s.repos.Blob.ReserveBlob(ctx, tx, blobID, user.ID, maxSize)
+	})
 	if err != nil {
-		return nil, merry.Wrap(err)
+		return nil, err
 	}
 	return &rpc.BlobUploadLinkResponse{
 		BlobId:   blobID,
@@ -52,3 +92,172 @@ func (s *blobService) UploadLink(ctx context.Context, r *rpc.BlobUploadLinkReque
 		FormData: formData,
 	}, nil
 }
+
+// uploadSize returns the size limit of a new upload, 0 means unlimited.
+// The declared size is used if it's set, otherwise the upload can take all that's left.
+func (s *blobService) uploadSize(ctx context.Context, userID string, declaredSize int64) (int64, error) {
+	maxSize := s.maxUploadSize
+	if declaredSize > 0 {
+		maxSize = declaredSize
+	}
+
+	if s.userQuota > 0 {
+		usage, err := s.repos.Blob.UserUsage(ctx, userID)
+		if err != nil {
+			return 0, err
+		}
+		available := s.userQuota - usage.Size
+		if available <= 0 || available < declaredSize {
+			return 0, twirp.NewError(twirp.ResourceExhausted, "storage quota exceeded")
+		}
+		if maxSize == 0 || available < maxSize {
+			maxSize = available
+		}
+	}
+
+	dailyRemaining, err := s.limiter.RemainingUploadBytes(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if dailyRemaining > 0 && dailyRemaining < declaredSize {
+		return 0, limitError("daily attachment limit reached", int(s.limiter.Limits().AttachmentBytesPerDay), uploadLimitPeriod)
+	}
+	if dailyRemaining > 0 && (maxSize == 0 || dailyRemaining < maxSize) {
+		maxSize = dailyRemaining
+	}
+	return maxSize, nil
+}
+
+func (s *blobService) StorageUsage(ctx context.Context, _ *rpc.Empty) (*rpc.BlobStorageUsageResponse, error) {
+	user := s.getUser(ctx)
+	usage, err := s.repos.Blob.UserUsage(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.BlobStorageUsageResponse{
+		Used:        usage.Size,
+		Quota:       s.userQuota,
+		MaxFileSize: s.maxUploadSize,
+		BlobCount:   int32(usage.BlobCount),
+	}, nil
+}
+
+func (s *blobService) UsersStorageUsage(ctx context.Context, _ *rpc.Empty) (*rpc.BlobUsersStorageUsageResponse, error) {
+	if !s.isAdmin(ctx) {
+		return nil, twirp.NewError(twirp.PermissionDenied, "")
+	}
+
+	usage, err := s.repos.Blob.UsageByUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var total int64
+	rpcUsers := make([]*rpc.BlobUserStorageUsage, len(usage))
+	for i, item := range usage {
+		rpcUsers[i] = &rpc.BlobUserStorageUsage{
+			UserId:    item.UserID,
+			UserName:  item.UserName,
+			Used:      item.Size,
+			BlobCount: int32(item.BlobCount),
+		}
+		total += item.Size
+	}
+	return &rpc.BlobUsersStorageUsageResponse{
+		Users: rpcUsers,
+		Total: total,
+	}, nil
+}
+
+func (s *blobService) CostReport(ctx context.Context, r *rpc.BlobCostReportRequest) (*rpc.BlobCostReportResponse, error) {
+	if !s.isAdmin(ctx) {
+		return nil, twirp.NewError(twirp.PermissionDenied, "")
+	}
+
+	report, err := s.costReporter.Report(ctx, int(r.Days))
+	if err != nil {
+		return nil, err
+	}
+
+	rpcStored := make([]*rpc.BlobMediaTypeUsage, len(report.Stored))
+	for i, item := range report.Stored {
+		rpcStored[i] = &rpc.BlobMediaTypeUsage{
+			MediaType: item.MediaType,
+			Size:      item.Bytes,
+			BlobCount: int32(item.Blobs),
+		}
+	}
+	rpcProjections := make([]*rpc.BlobStorageProjection, len(report.Projections))
+	for i, item := range report.Projections {
+		rpcProjections[i] = &rpc.BlobStorageProjection{
+			Days:       int32(item.Days),
+			StoredSize: item.StoredBytes,
+		}
+	}
+	rpcCosts := make([]*rpc.BlobProviderCost, len(report.Costs))
+	for i, item := range report.Costs {
+		rpcCosts[i] = &rpc.BlobProviderCost{
+			Provider:              item.Provider,
+			MonthlyStorage:        item.MonthlyStorage,
+			MonthlyEgress:         item.MonthlyEgress,
+			MonthlyTotal:          item.MonthlyTotal,
+			ProjectedMonthlyTotal: item.ProjectedMonthlyTotal,
+		}
+	}
+	return &rpc.BlobCostReportResponse{
+		Stored:               rpcStored,
+		StoredSize:           report.StoredBytes,
+		Uploads:              dailyVolumesToRPC(report.Uploads),
+		Egress:               dailyVolumesToRPC(report.Egress),
+		Days:                 int32(report.Days),
+		AvgDailyUploadGb:     report.AvgDailyUploadGB,
+		AvgDailyEgressGb:     report.AvgDailyEgressGB,
+		Projections:          rpcProjections,
+		Costs:                rpcCosts,
+		UnaccountedBlobCount: int32(report.UnaccountedBlobs),
+	}, nil
+}
+
+func (s *blobService) RetentionDryRun(ctx context.Context, _ *rpc.Empty) (*rpc.BlobRetentionDryRunResponse, error) {
+	if !s.isAdmin(ctx) {
+		return nil, twirp.NewError(twirp.PermissionDenied, "")
+	}
+
+	items, err := s.retentionEngine.Plan(ctx, common.CurrentTimestamp())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &rpc.BlobRetentionDryRunResponse{
+		AttachmentCount: int32(len(items)),
+	}
+	for _, item := range items {
+		resp.Size += item.Size
+		if item.DeletePost {
+			resp.PostCount++
+		}
+		if len(resp.Items) < maxRetentionDryRunItems {
+			resp.Items = append(resp.Items, &rpc.BlobRetentionItem{
+				MessageId:      item.MessageID,
+				UserId:         item.UserID,
+				AttachmentType: item.AttachmentType,
+				CreatedAt:      common.TimeToRPCString(item.CreatedAt),
+				Size:           item.Size,
+				DeletePost:     item.DeletePost,
+				Conversation:   item.Conversation,
+			})
+		}
+	}
+	return resp, nil
+}
+
+func dailyVolumesToRPC(volumes []cost.DailyVolume) []*rpc.BlobDailyVolume {
+	result := make([]*rpc.BlobDailyVolume, len(volumes))
+	for i, item := range volumes {
+		result[i] = &rpc.BlobDailyVolume{
+			Day:   item.Day.Format("2006-01-02"),
+			Size:  item.Bytes,
+			Count: int32(item.Count),
+		}
+	}
+	return result
+}
@@ -0,0 +1,141 @@
+package services_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/messagehub/repo"
+	"github.com/mreider/koto/backend/messagehub/rpc"
+	"github.com/mreider/koto/backend/messagehub/services"
+)
+
+func TestBlobService_Quota(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	repos := repo.Repos{
+		DB:    te.DB,
+		Blob:  repo.NewBlobs(te.DB),
+		Limit: repo.NewLimits(te.DB),
+	}
+	blobStorage, err := common.NewFSStorage(te.TempDir, hubAddress+"/blob", []byte("secret"))
+	require.Nil(t, err)
+	base := services.NewBase(repos, nil, nil, nil, hubAddress, blobStorage, nil, nil)
+	s := services.NewBlob(base, 1000, 600, nil, nil, services.NewLimiter(repos, services.PostingLimits{}))
+
+	user1Ctx := context.WithValue(te.Ctx, services.ContextUserKey, services.User{ID: "1", Name: "user1"})
+	usage := func() *rpc.BlobStorageUsageResponse {
+		resp, err := s.StorageUsage(user1Ctx, &rpc.Empty{})
+		require.Nil(t, err)
+		return resp
+	}
+
+	link1, err := s.UploadLink(user1Ctx, &rpc.BlobUploadLinkRequest{ContentType: "image/jpeg", FileName: "photo1.jpg"})
+	require.Nil(t, err)
+	assert.Equal(t, int64(600), usage().Used, "the link reserves the maximum upload size")
+
+	link2, err := s.UploadLink(user1Ctx, &rpc.BlobUploadLinkRequest{ContentType: "image/jpeg", FileName: "photo2.jpg"})
+	require.Nil(t, err)
+	assert.Equal(t, int64(1000), usage().Used, "a parallel link gets only the rest of the quota")
+
+	_, err = s.UploadLink(user1Ctx, &rpc.BlobUploadLinkRequest{ContentType: "image/jpeg", FileName: "photo3.jpg"})
+	require.NotNil(t, err)
+	assert.Equal(t, twirp.ResourceExhausted, err.(twirp.Error).Code())
+
+	require.Nil(t, blobStorage.PutObject(te.Ctx, link1.BlobId, make([]byte, 100), "image/jpeg"))
+	require.Nil(t, blobStorage.PutObject(te.Ctx, link2.BlobId, make([]byte, 200), "image/jpeg"))
+	require.Nil(t, repos.Blob.AddBlob(te.Ctx, link1.BlobId, "1", 100))
+	resp := usage()
+	assert.Equal(t, int64(500), resp.Used, "the attached blob is accounted by its size")
+	assert.Equal(t, int32(1), resp.BlobCount)
+
+	expired, err := repos.Blob.ExpireReservations(te.Ctx, time.Now().Add(time.Minute))
+	require.Nil(t, err)
+	assert.Equal(t, 1, expired)
+	assert.Equal(t, int64(100), usage().Used, "the unattached upload is released")
+
+	_, err = te.DB.Exec(`
+		insert into blob_pending_deletes(blob_id, deleted_at)
+		values ($1, $2)`,
+		link1.BlobId, time.Now())
+	require.Nil(t, err)
+
+	cleaner := common.NewS3Cleaner(te.DB, blobStorage, repos.Blob.RemoveBlob)
+	remaining, err := cleaner.Drain(te.Ctx)
+	require.Nil(t, err)
+	assert.Equal(t, 0, remaining)
+	assert.Equal(t, int64(0), usage().Used, "the cleaner releases the removed blobs")
+	for _, blobID := range []string{link1.BlobId, link2.BlobId} {
+		exists, err := blobStorage.Exists(te.Ctx, blobID)
+		require.Nil(t, err)
+		assert.False(t, exists)
+	}
+}
+
+func TestBlobService_DeclaredSize(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	repos := repo.Repos{
+		DB:    te.DB,
+		Blob:  repo.NewBlobs(te.DB),
+		Limit: repo.NewLimits(te.DB),
+	}
+	blobStorage, err := common.NewFSStorage(te.TempDir, hubAddress+"/blob", []byte("secret"))
+	require.Nil(t, err)
+	base := services.NewBase(repos, nil, nil, nil, hubAddress, blobStorage, nil, nil)
+	s := services.NewBlob(base, 1000, 600, nil, nil, services.NewLimiter(repos, services.PostingLimits{AttachmentBytesPerDay: 500}))
+
+	user1Ctx := context.WithValue(te.Ctx, services.ContextUserKey, services.User{ID: "1", Name: "user1"})
+	usage := func() int64 {
+		resp, err := s.StorageUsage(user1Ctx, &rpc.Empty{})
+		require.Nil(t, err)
+		return resp.Used
+	}
+
+	_, err = s.UploadLink(user1Ctx, &rpc.BlobUploadLinkRequest{ContentType: "image/jpeg", Size: 700})
+	require.NotNil(t, err)
+	assert.Equal(t, twirp.InvalidArgument, err.(twirp.Error).Code(), "the size is larger than the maximum upload size")
+
+	// parallel links reserve their declared size and don't take the daily limit before they're attached
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = s.UploadLink(user1Ctx, &rpc.BlobUploadLinkRequest{ContentType: "image/jpeg", Size: 100})
+		}(i)
+	}
+	wg.Wait()
+	var created int
+	for _, err := range errs {
+		if err == nil {
+			created++
+			continue
+		}
+		assert.Equal(t, twirp.ResourceExhausted, err.(twirp.Error).Code())
+	}
+	assert.Equal(t, 10, created, "the links don't exceed the quota together")
+	assert.Equal(t, int64(1000), usage())
+
+	expired, err := repos.Blob.ExpireReservations(te.Ctx, time.Now().Add(time.Minute))
+	require.Nil(t, err)
+	assert.Equal(t, 10, expired)
+
+	link, err := s.UploadLink(user1Ctx, &rpc.BlobUploadLinkRequest{ContentType: "image/jpeg", Size: 400})
+	require.Nil(t, err)
+	require.Nil(t, repos.Blob.AddBlob(te.Ctx, link.BlobId, "1", 400))
+
+	_, err = s.UploadLink(user1Ctx, &rpc.BlobUploadLinkRequest{ContentType: "image/jpeg", Size: 200})
+	require.NotNil(t, err)
+	assert.Equal(t, twirp.ResourceExhausted, err.(twirp.Error).Code(), "the attached blob takes the daily limit")
+	assert.Equal(t, "500", err.(twirp.Error).Meta("limit"))
+}
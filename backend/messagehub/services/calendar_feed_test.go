@@ -0,0 +1,86 @@
+package services_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/messagehub/repo"
+	"github.com/mreider/koto/backend/messagehub/routers"
+	"github.com/mreider/koto/backend/messagehub/rpc"
+	"github.com/mreider/koto/backend/messagehub/services"
+	"github.com/mreider/koto/backend/token"
+)
+
+func TestMessageService_CalendarFeed(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	repos := repo.Repos{
+		DB:      te.DB,
+		Message: repo.NewMessages(te.DB),
+		Poll:    repo.NewPolls(te.DB),
+		Event:   repo.NewEvents(te.DB),
+		User:    repo.NewUsers(te.DB),
+		Blob:    repo.NewBlobs(te.DB),
+		Limit:   repo.NewLimits(te.DB),
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	tokenGenerator := token.NewGenerator(privateKey)
+	tokenParser := token.NewParser(func() *rsa.PublicKey { return &privateKey.PublicKey })
+	sender := &notificationSender{}
+	base := services.NewBase(repos, tokenParser, tokenGenerator, nil, hubAddress, nil, sender, nil)
+	s := services.NewMessage(base, nil, services.NewReactionNotifier(sender, 0), 3, services.NewLimiter(repos, services.PostingLimits{}))
+
+	user1Ctx := context.WithValue(te.Ctx, services.ContextUserKey, services.User{ID: "1", Name: "user1"})
+	user2Ctx := context.WithValue(te.Ctx, services.ContextUserKey, services.User{ID: "2", Name: "user2"})
+
+	calendar := routers.Calendar(repos, tokenParser, hubAddress)
+	feedStatus := func(link string) int {
+		u, err := url.Parse(link)
+		require.Nil(t, err)
+		assert.Equal(t, "/calendar/feed.ics", u.Path)
+		w := httptest.NewRecorder()
+		calendar.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/feed.ics?"+u.RawQuery, nil))
+		return w.Code
+	}
+
+	resp, err := s.CalendarFeed(user1Ctx, &rpc.Empty{})
+	require.Nil(t, err)
+	link1 := resp.Link
+	assert.Equal(t, http.StatusOK, feedStatus(link1))
+
+	resp, err = s.CalendarFeed(user2Ctx, &rpc.Empty{})
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusOK, feedStatus(resp.Link))
+
+	resp, err = s.RotateCalendarFeed(user1Ctx, &rpc.Empty{})
+	require.Nil(t, err)
+	link2 := resp.Link
+	assert.Equal(t, http.StatusForbidden, feedStatus(link1), "the rotated link stops working")
+	assert.Equal(t, http.StatusOK, feedStatus(link2))
+
+	resp, err = s.CalendarFeed(user1Ctx, &rpc.Empty{})
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusOK, feedStatus(resp.Link))
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	forged, err := token.NewGenerator(otherKey).Generate("1", "user1", "calendar-feed", time.Now().Add(time.Hour), map[string]interface{}{"version": 1})
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusForbidden, feedStatus(hubAddress+"/calendar/feed.ics?token="+url.QueryEscape(forged)))
+
+	eventToken, err := tokenGenerator.Generate("1", "user1", "event-calendar", time.Now().Add(time.Hour), map[string]interface{}{"version": 1})
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusForbidden, feedStatus(hubAddress+"/calendar/feed.ics?token="+url.QueryEscape(eventToken)), "the token has another scope")
+}
@@ -0,0 +1,343 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/twitchtv/twirp"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/messagehub/repo"
+	"github.com/mreider/koto/backend/messagehub/rpc"
+	"github.com/mreider/koto/backend/token"
+)
+
+type conversationService struct {
+	*BaseService
+}
+
+func NewConversation(base *BaseService) rpc.ConversationService {
+	return &conversationService{
+		BaseService: base,
+	}
+}
+
+func (s *conversationService) Create(ctx context.Context, r *rpc.ConversationCreateRequest) (*rpc.ConversationCreateResponse, error) {
+	user := s.getUser(ctx)
+
+	_, claims, err := s.tokenParser.Parse(r.Token, "conversation")
+	if err != nil {
+		if merry.Is(err, token.ErrInvalidToken) {
+			return nil, twirp.NewError(twirp.InvalidArgument, "invalid token")
+		}
+		return nil, err
+	}
+
+	if user.ID != claims["id"].(string) ||
+		strings.TrimSuffix(s.externalAddress, "/") != strings.TrimSuffix(claims["hub"].(string), "/") {
+		return nil, twirp.NewError(twirp.InvalidArgument, "invalid token")
+	}
+
+	rawMembers := claims["members"].(map[string]interface{})
+	memberIDs := make([]string, 0, len(rawMembers))
+	for memberID, rawMemberName := range rawMembers {
+		err = s.repos.User.AddUser(ctx, memberID, rawMemberName.(string))
+		if err != nil {
+			return nil, err
+		}
+		memberIDs = append(memberIDs, memberID)
+	}
+	sort.Strings(memberIDs)
+
+	conversationID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	now := common.CurrentTimestamp()
+	conversation, err := s.repos.Conversation.AddConversation(ctx, repo.Conversation{
+		ID:        conversationID.String(),
+		MemberKey: strings.Join(memberIDs, ","),
+		CreatedBy: user.ID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	conversation, err = s.repos.Conversation.Conversation(ctx, user.ID, conversation.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcConversations, err := s.conversationsToRPC(ctx, []repo.Conversation{conversation})
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.ConversationCreateResponse{
+		Conversation: rpcConversations[0],
+	}, nil
+}
+
+func (s *conversationService) Conversations(ctx context.Context, _ *rpc.Empty) (*rpc.ConversationConversationsResponse, error) {
+	user := s.getUser(ctx)
+
+	conversations, err := s.repos.Conversation.Conversations(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcConversations, err := s.conversationsToRPC(ctx, conversations)
+	if err != nil {
+		return nil, err
+	}
+
+	var unreadCount int
+	for _, conversation := range conversations {
+		unreadCount += conversation.UnreadCount
+	}
+	return &rpc.ConversationConversationsResponse{
+		Conversations: rpcConversations,
+		UnreadCount:   int32(unreadCount),
+	}, nil
+}
+
+func (s *conversationService) Send(ctx context.Context, r *rpc.ConversationSendRequest) (*rpc.ConversationSendResponse, error) {
+	user := s.getUser(ctx)
+
+	encrypted := len(r.Envelopes) > 0
+	if encrypted {
+		if r.Text != "" {
+			return nil, twirp.InvalidArgumentError("text", "should be empty for encrypted messages")
+		}
+		if r.SenderDeviceId == "" {
+			return nil, twirp.InvalidArgumentError("sender_device_id", "is empty")
+		}
+	} else if r.Text == "" && r.AttachmentId == "" {
+		return nil, twirp.InvalidArgumentError("text", "is empty")
+	}
+
+	_, err := s.repos.Conversation.Conversation(ctx, user.ID, r.ConversationId)
+	if err != nil {
+		if merry.Is(err, repo.ErrConversationNotFound) {
+			return nil, twirp.NotFoundError(err.Error())
+		}
+		return nil, err
+	}
+
+	members, err := s.repos.Conversation.ConversationsMembers(ctx, []string{r.ConversationId})
+	if err != nil {
+		return nil, err
+	}
+	memberIDs := make(map[string]bool, len(members[r.ConversationId]))
+	for _, member := range members[r.ConversationId] {
+		memberIDs[member.UserID] = true
+	}
+
+	envelopes := make([]repo.ConversationEnvelope, len(r.Envelopes))
+	for i, envelope := range r.Envelopes {
+		if !memberIDs[envelope.UserId] {
+			return nil, twirp.InvalidArgumentError("envelopes", "should be addressed to conversation members")
+		}
+		if envelope.DeviceId == "" || envelope.Ciphertext == "" {
+			return nil, twirp.InvalidArgumentError("envelopes", "device_id and ciphertext shouldn't be empty")
+		}
+		envelopes[i] = repo.ConversationEnvelope{
+			UserID:     envelope.UserId,
+			DeviceID:   envelope.DeviceId,
+			Ciphertext: envelope.Ciphertext,
+		}
+	}
+
+	messageID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	// encrypted attachments are opaque to the hub, so they aren't inspected or thumbnailed
+	var attachmentThumbnailID, attachmentType string
+	if !encrypted {
+		attachmentThumbnailID, attachmentType, err = s.processAttachment(ctx, r.AttachmentId)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		err = s.recordBlob(ctx, user.ID, r.AttachmentId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	msg := repo.ConversationMessage{
+		ID:                    messageID.String(),
+		ConversationID:        r.ConversationId,
+		UserID:                user.ID,
+		UserName:              user.Name,
+		Text:                  r.Text,
+		AttachmentID:          r.AttachmentId,
+		AttachmentType:        attachmentType,
+		AttachmentThumbnailID: attachmentThumbnailID,
+		CreatedAt:             common.CurrentTimestamp(),
+		Encrypted:             encrypted,
+		SenderDeviceID:        r.SenderDeviceId,
+	}
+	notifyUsers := make([]string, 0, len(members[r.ConversationId]))
+	for _, member := range members[r.ConversationId] {
+		if member.UserID != user.ID {
+			notifyUsers = append(notifyUsers, member.UserID)
+		}
+	}
+	err = common.RunInTransaction(ctx, s.repos.DB, func(tx *sqlx.Tx) error {
+		err := s.repos.Conversation.AddMessage(ctx, tx, msg, envelopes)
+		if err != nil {
+			return err
+		}
+		return s.notificationSender.EnqueueNotification(ctx, tx, notifyUsers, user.Name+" sent you a message", "conversation/message", map[string]interface{}{
+			"user_id":         user.ID,
+			"conversation_id": msg.ConversationID,
+			"message_id":      msg.ID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rpcMessage, err := s.conversationMessageToRPC(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.ConversationSendResponse{
+		Message: rpcMessage,
+	}, nil
+}
+
+func (s *conversationService) Messages(ctx context.Context, r *rpc.ConversationMessagesRequest) (*rpc.ConversationMessagesResponse, error) {
+	user := s.getUser(ctx)
+
+	_, err := s.repos.Conversation.Conversation(ctx, user.ID, r.ConversationId)
+	if err != nil {
+		if merry.Is(err, repo.ErrConversationNotFound) {
+			return nil, twirp.NotFoundError(err.Error())
+		}
+		return nil, err
+	}
+
+	var from time.Time
+	if r.From != "" {
+		from, err = common.RPCStringToTime(r.From)
+		if err != nil {
+			return nil, twirp.InvalidArgumentError("from", err.Error())
+		}
+	}
+
+	messages, err := s.repos.Conversation.Messages(ctx, r.ConversationId, user.ID, r.DeviceId, from, int(r.Count))
+	if err != nil {
+		return nil, err
+	}
+
+	rpcMessages := make([]*rpc.ConversationMessage, len(messages))
+	for i, msg := range messages {
+		rpcMessages[i], err = s.conversationMessageToRPC(ctx, msg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &rpc.ConversationMessagesResponse{
+		Messages: rpcMessages,
+	}, nil
+}
+
+func (s *conversationService) MarkRead(ctx context.Context, r *rpc.ConversationMarkReadRequest) (*rpc.Empty, error) {
+	user := s.getUser(ctx)
+
+	_, err := s.repos.Conversation.Conversation(ctx, user.ID, r.ConversationId)
+	if err != nil {
+		if merry.Is(err, repo.ErrConversationNotFound) {
+			return nil, twirp.NotFoundError(err.Error())
+		}
+		return nil, err
+	}
+
+	err = s.repos.Conversation.MarkRead(ctx, user.ID, r.ConversationId, common.CurrentTimestamp())
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.Empty{}, nil
+}
+
+func (s *conversationService) conversationsToRPC(ctx context.Context, conversations []repo.Conversation) ([]*rpc.Conversation, error) {
+	conversationIDs := make([]string, len(conversations))
+	for i, conversation := range conversations {
+		conversationIDs[i] = conversation.ID
+	}
+
+	members, err := s.repos.Conversation.ConversationsMembers(ctx, conversationIDs)
+	if err != nil {
+		return nil, err
+	}
+	lastMessages, err := s.repos.Conversation.LastMessages(ctx, conversationIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcConversations := make([]*rpc.Conversation, len(conversations))
+	for i, conversation := range conversations {
+		rpcMembers := make([]*rpc.User, len(members[conversation.ID]))
+		for j, member := range members[conversation.ID] {
+			rpcMembers[j] = &rpc.User{
+				Id:   member.UserID,
+				Name: member.UserName,
+			}
+		}
+
+		rpcConversations[i] = &rpc.Conversation{
+			Id:          conversation.ID,
+			Members:     rpcMembers,
+			CreatedBy:   conversation.CreatedBy,
+			CreatedAt:   common.TimeToRPCString(conversation.CreatedAt),
+			UpdatedAt:   common.TimeToRPCString(conversation.UpdatedAt),
+			UnreadCount: int32(conversation.UnreadCount),
+		}
+
+		if lastMessage, ok := lastMessages[conversation.ID]; ok {
+			rpcConversations[i].LastMessage, err = s.conversationMessageToRPC(ctx, lastMessage)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return rpcConversations, nil
+}
+
+func (s *conversationService) conversationMessageToRPC(ctx context.Context, msg repo.ConversationMessage) (*rpc.ConversationMessage, error) {
+	attachmentLink, err := s.createBlobLink(ctx, msg.AttachmentID)
+	if err != nil {
+		return nil, err
+	}
+	attachmentThumbnailLink, err := s.createBlobLink(ctx, msg.AttachmentThumbnailID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rpc.ConversationMessage{
+		Id:                  msg.ID,
+		ConversationId:      msg.ConversationID,
+		UserId:              msg.UserID,
+		UserName:            msg.UserName,
+		Text:                msg.Text,
+		Attachment:          attachmentLink,
+		AttachmentType:      msg.AttachmentType,
+		AttachmentThumbnail: attachmentThumbnailLink,
+		MediaExpired:        msg.MediaExpired,
+		CreatedAt:           common.TimeToRPCString(msg.CreatedAt),
+		Encrypted:           msg.Encrypted,
+		SenderDeviceId:      msg.SenderDeviceID,
+		Ciphertext:          msg.Ciphertext,
+	}, nil
+}
@@ -0,0 +1,164 @@
+package services_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/mreider/koto/backend/messagehub/repo"
+	"github.com/mreider/koto/backend/messagehub/rpc"
+	"github.com/mreider/koto/backend/messagehub/services"
+	"github.com/mreider/koto/backend/token"
+)
+
+const hubAddress = "http://localhost:12002"
+
+type sentNotification struct {
+	userIDs     []string
+	text        string
+	messageType string
+	data        map[string]interface{}
+}
+
+type notificationSender struct {
+	mu   sync.Mutex
+	sent []sentNotification
+}
+
+func (s *notificationSender) Run(context.Context) {}
+
+func (s *notificationSender) SendNotification(_ context.Context, userIDs []string, text, messageType string, data map[string]interface{}) {
+	if len(userIDs) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, sentNotification{userIDs: userIDs, text: text, messageType: messageType, data: data})
+}
+
+func (s *notificationSender) EnqueueNotification(ctx context.Context, _ *sqlx.Tx, userIDs []string, text, messageType string, data map[string]interface{}) error {
+	s.SendNotification(ctx, userIDs, text, messageType, data)
+	return nil
+}
+
+func (s *notificationSender) Sent() []sentNotification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]sentNotification(nil), s.sent...)
+}
+
+func TestConversationService(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	repos := repo.Repos{
+		Conversation: repo.NewConversations(te.DB),
+		User:         repo.NewUsers(te.DB),
+		Blob:         repo.NewBlobs(te.DB),
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	tokenGenerator := token.NewGenerator(privateKey)
+	tokenParser := token.NewParser(func() *rsa.PublicKey { return &privateKey.PublicKey })
+	sender := &notificationSender{}
+	base := services.NewBase(repos, tokenParser, tokenGenerator, nil, hubAddress, nil, sender, nil)
+	s := services.NewConversation(base)
+
+	user1Ctx := context.WithValue(te.Ctx, services.ContextUserKey, services.User{ID: "1", Name: "user1"})
+	user2Ctx := context.WithValue(te.Ctx, services.ContextUserKey, services.User{ID: "2", Name: "user2"})
+	user3Ctx := context.WithValue(te.Ctx, services.ContextUserKey, services.User{ID: "3", Name: "user3"})
+
+	conversationToken := func(userID, userName, hub string, members map[string]interface{}) string {
+		tok, err := tokenGenerator.Generate(userID, userName, "conversation", time.Now().Add(time.Hour), map[string]interface{}{
+			"hub":     hub,
+			"members": members,
+		})
+		require.Nil(t, err)
+		return tok
+	}
+
+	members := map[string]interface{}{"1": "user1", "2": "user2"}
+	_, err = s.Create(user1Ctx, &rpc.ConversationCreateRequest{Token: conversationToken("2", "user2", hubAddress, members)})
+	assert.Equal(t, twirp.InvalidArgument, err.(twirp.Error).Code(), "the token is issued to another user")
+	_, err = s.Create(user1Ctx, &rpc.ConversationCreateRequest{Token: conversationToken("1", "user1", "http://other-hub", members)})
+	assert.Equal(t, twirp.InvalidArgument, err.(twirp.Error).Code(), "the token is issued for another hub")
+	_, err = s.Create(user1Ctx, &rpc.ConversationCreateRequest{Token: "invalid"})
+	assert.Equal(t, twirp.InvalidArgument, err.(twirp.Error).Code())
+
+	createResp, err := s.Create(user1Ctx, &rpc.ConversationCreateRequest{Token: conversationToken("1", "user1", hubAddress, members)})
+	require.Nil(t, err)
+	conversationID := createResp.Conversation.Id
+	require.Len(t, createResp.Conversation.Members, 2)
+	assert.Equal(t, "1", createResp.Conversation.Members[0].Id)
+	assert.Equal(t, "2", createResp.Conversation.Members[1].Id)
+
+	createResp, err = s.Create(user2Ctx, &rpc.ConversationCreateRequest{Token: conversationToken("2", "user2", hubAddress, members)})
+	require.Nil(t, err)
+	assert.Equal(t, conversationID, createResp.Conversation.Id, "the members have one conversation")
+
+	_, err = s.Send(user3Ctx, &rpc.ConversationSendRequest{ConversationId: conversationID, Text: "hi"})
+	assert.Equal(t, twirp.NotFound, err.(twirp.Error).Code(), "only members send messages")
+	_, err = s.Messages(user3Ctx, &rpc.ConversationMessagesRequest{ConversationId: conversationID})
+	assert.Equal(t, twirp.NotFound, err.(twirp.Error).Code(), "only members read messages")
+	_, err = s.MarkRead(user3Ctx, &rpc.ConversationMarkReadRequest{ConversationId: conversationID})
+	assert.Equal(t, twirp.NotFound, err.(twirp.Error).Code())
+	conversationsResp, err := s.Conversations(user3Ctx, &rpc.Empty{})
+	require.Nil(t, err)
+	assert.Empty(t, conversationsResp.Conversations)
+
+	_, err = s.Send(user1Ctx, &rpc.ConversationSendRequest{
+		ConversationId: conversationID,
+		SenderDeviceId: "phone",
+		Envelopes:      []*rpc.ConversationEnvelope{{UserId: "3", DeviceId: "phone", Ciphertext: "secret"}},
+	})
+	assert.Equal(t, twirp.InvalidArgument, err.(twirp.Error).Code(), "envelopes are addressed to members only")
+
+	texts := []string{"one", "two", "three", "four", "five"}
+	for i, text := range texts {
+		// pagination is by created_at, keep the messages apart
+		time.Sleep(time.Millisecond)
+		_, err = s.Send(user1Ctx, &rpc.ConversationSendRequest{ConversationId: conversationID, Text: text})
+		require.Nil(t, err, i)
+	}
+	sent := sender.Sent()
+	require.Len(t, sent, len(texts))
+	assert.Equal(t, []string{"2"}, sent[0].userIDs, "the sender isn't notified")
+
+	conversationsResp, err = s.Conversations(user2Ctx, &rpc.Empty{})
+	require.Nil(t, err)
+	require.Len(t, conversationsResp.Conversations, 1)
+	assert.Equal(t, int32(len(texts)), conversationsResp.UnreadCount)
+	assert.Equal(t, "five", conversationsResp.Conversations[0].LastMessage.Text)
+
+	var pages [][]string
+	from := ""
+	for {
+		messagesResp, err := s.Messages(user2Ctx, &rpc.ConversationMessagesRequest{ConversationId: conversationID, From: from, Count: 2})
+		require.Nil(t, err)
+		if len(messagesResp.Messages) == 0 {
+			break
+		}
+		var page []string
+		for _, msg := range messagesResp.Messages {
+			page = append(page, msg.Text)
+		}
+		pages = append(pages, page)
+		from = messagesResp.Messages[len(messagesResp.Messages)-1].CreatedAt
+	}
+	assert.Equal(t, [][]string{{"five", "four"}, {"three", "two"}, {"one"}}, pages)
+
+	_, err = s.MarkRead(user2Ctx, &rpc.ConversationMarkReadRequest{ConversationId: conversationID})
+	require.Nil(t, err)
+	conversationsResp, err = s.Conversations(user2Ctx, &rpc.Empty{})
+	require.Nil(t, err)
+	assert.Equal(t, int32(0), conversationsResp.UnreadCount)
+}
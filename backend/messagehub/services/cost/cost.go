@@ -0,0 +1,175 @@
+package cost
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ansel1/merry"
+
+	"github.com/mreider/koto/backend/messagehub/config"
+)
+
+const (
+	bytesPerGB     = 1 << 30
+	daysPerMonth   = 30
+	dayLayout      = "2006-01-02"
+	storageSection = "storage"
+)
+
+var ProjectionDays = []int{30, 90, 365}
+
+type MediaUsage struct {
+	MediaType string
+	Bytes     int64
+	Blobs     int
+}
+
+type DailyVolume struct {
+	Day   time.Time
+	Bytes int64
+	Count int
+}
+
+type Projection struct {
+	Days        int
+	StoredBytes int64
+}
+
+type ProviderCost struct {
+	Provider       string
+	MonthlyStorage float64
+	MonthlyEgress  float64
+	MonthlyTotal   float64
+	// ProjectedMonthlyTotal is the monthly total after the longest projection period.
+	ProjectedMonthlyTotal float64
+}
+
+type Input struct {
+	Stored           []MediaUsage
+	UnaccountedBlobs int
+	Uploads          []DailyVolume
+	Egress           []DailyVolume
+	Days             int
+}
+
+type Report struct {
+	Stored           []MediaUsage
+	StoredBytes      int64
+	Uploads          []DailyVolume
+	Egress           []DailyVolume
+	Days             int
+	AvgDailyUploadGB float64
+	AvgDailyEgressGB float64
+	Projections      []Projection
+	Costs            []ProviderCost
+	// UnaccountedBlobs are attachments whose sizes aren't recorded, they are missing in the stored bytes.
+	UnaccountedBlobs int
+}
+
+// Build estimates costs from the current storage and the average daily volumes over in.Days.
+// Growth is projected linearly from the average daily upload volume.
+func Build(in Input, prices []config.Price) Report {
+	report := Report{
+		Stored:           in.Stored,
+		UnaccountedBlobs: in.UnaccountedBlobs,
+		Uploads:          in.Uploads,
+		Egress:           in.Egress,
+		Days:             in.Days,
+	}
+	sort.SliceStable(report.Stored, func(i, j int) bool {
+		return report.Stored[i].Bytes > report.Stored[j].Bytes
+	})
+	for _, item := range in.Stored {
+		report.StoredBytes += item.Bytes
+	}
+
+	days := in.Days
+	if days < 1 {
+		days = 1
+	}
+	avgDailyUpload := float64(totalBytes(in.Uploads)) / float64(days)
+	avgDailyEgress := float64(totalBytes(in.Egress)) / float64(days)
+	report.AvgDailyUploadGB = avgDailyUpload / bytesPerGB
+	report.AvgDailyEgressGB = avgDailyEgress / bytesPerGB
+
+	for _, projectionDays := range ProjectionDays {
+		report.Projections = append(report.Projections, Projection{
+			Days:        projectionDays,
+			StoredBytes: report.StoredBytes + int64(math.Round(avgDailyUpload*float64(projectionDays))),
+		})
+	}
+
+	storedGB := float64(report.StoredBytes) / bytesPerGB
+	projectedGB := storedGB
+	if len(report.Projections) > 0 {
+		projectedGB = float64(report.Projections[len(report.Projections)-1].StoredBytes) / bytesPerGB
+	}
+	monthlyEgressGB := report.AvgDailyEgressGB * daysPerMonth
+	for _, price := range prices {
+		monthlyEgress := round(monthlyEgressGB * price.EgressPerGB)
+		item := ProviderCost{
+			Provider:       price.Provider,
+			MonthlyStorage: round(storedGB * price.StoragePerGB),
+			MonthlyEgress:  monthlyEgress,
+		}
+		item.MonthlyTotal = round(item.MonthlyStorage + item.MonthlyEgress)
+		item.ProjectedMonthlyTotal = round(projectedGB*price.StoragePerGB + monthlyEgress)
+		report.Costs = append(report.Costs, item)
+	}
+	return report
+}
+
+// WriteCSV writes the report as "section,item,value" rows.
+func WriteCSV(w io.Writer, report Report) error {
+	cw := csv.NewWriter(w)
+	write := func(section, item, value string) {
+		_ = cw.Write([]string{section, item, value})
+	}
+
+	write("section", "item", "value")
+	write(storageSection, "total_bytes", strconv.FormatInt(report.StoredBytes, 10))
+	for _, item := range report.Stored {
+		write(storageSection, item.MediaType+"_bytes", strconv.FormatInt(item.Bytes, 10))
+		write(storageSection, item.MediaType+"_blobs", strconv.Itoa(item.Blobs))
+	}
+	write(storageSection, "unaccounted_blobs", strconv.Itoa(report.UnaccountedBlobs))
+	for _, item := range report.Uploads {
+		write("upload", item.Day.Format(dayLayout), strconv.FormatInt(item.Bytes, 10))
+	}
+	for _, item := range report.Egress {
+		write("egress", item.Day.Format(dayLayout), strconv.FormatInt(item.Bytes, 10))
+	}
+	write("average", "daily_upload_gb", formatFloat(report.AvgDailyUploadGB))
+	write("average", "daily_egress_gb", formatFloat(report.AvgDailyEgressGB))
+	for _, item := range report.Projections {
+		write("projection", strconv.Itoa(item.Days)+"d_bytes", strconv.FormatInt(item.StoredBytes, 10))
+	}
+	for _, item := range report.Costs {
+		write("cost", item.Provider+"_monthly_storage", formatFloat(item.MonthlyStorage))
+		write("cost", item.Provider+"_monthly_egress", formatFloat(item.MonthlyEgress))
+		write("cost", item.Provider+"_monthly_total", formatFloat(item.MonthlyTotal))
+		write("cost", item.Provider+"_projected_monthly_total", formatFloat(item.ProjectedMonthlyTotal))
+	}
+	cw.Flush()
+	return merry.Wrap(cw.Error())
+}
+
+func totalBytes(items []DailyVolume) int64 {
+	var total int64
+	for _, item := range items {
+		total += item.Bytes
+	}
+	return total
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
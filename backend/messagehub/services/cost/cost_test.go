@@ -0,0 +1,55 @@
+package cost_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/messagehub/config"
+	"github.com/mreider/koto/backend/messagehub/services/cost"
+)
+
+const gb = 1 << 30
+
+func TestBuild(t *testing.T) {
+	day := time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)
+	report := cost.Build(cost.Input{
+		Stored: []cost.MediaUsage{
+			{MediaType: "image", Bytes: 2 * gb, Blobs: 20},
+			{MediaType: "video", Bytes: 8 * gb, Blobs: 2},
+		},
+		UnaccountedBlobs: 3,
+		Uploads:          []cost.DailyVolume{{Day: day, Bytes: 5 * gb}, {Day: day.AddDate(0, 0, 1), Bytes: 5 * gb}},
+		Egress:           []cost.DailyVolume{{Day: day, Bytes: 10 * gb}},
+		Days:             10,
+	}, []config.Price{{Provider: "test", StoragePerGB: 0.02, EgressPerGB: 0.01}})
+
+	assert.Equal(t, int64(10*gb), report.StoredBytes)
+	assert.Equal(t, "video", report.Stored[0].MediaType)
+	assert.Equal(t, 1.0, report.AvgDailyUploadGB)
+	assert.Equal(t, 1.0, report.AvgDailyEgressGB)
+	assert.Equal(t, []cost.Projection{
+		{Days: 30, StoredBytes: 40 * gb},
+		{Days: 90, StoredBytes: 100 * gb},
+		{Days: 365, StoredBytes: 375 * gb},
+	}, report.Projections)
+	assert.Equal(t, []cost.ProviderCost{{
+		Provider:              "test",
+		MonthlyStorage:        0.2,
+		MonthlyEgress:         0.3,
+		MonthlyTotal:          0.5,
+		ProjectedMonthlyTotal: 7.8,
+	}}, report.Costs)
+
+	var buf bytes.Buffer
+	require.NoError(t, cost.WriteCSV(&buf, report))
+	csv := buf.String()
+	assert.True(t, strings.HasPrefix(csv, "section,item,value\n"))
+	assert.Contains(t, csv, "upload,2020-07-01,5368709120\n")
+	assert.Contains(t, csv, "cost,test_monthly_total,0.5\n")
+	assert.Contains(t, csv, "storage,unaccounted_blobs,3\n")
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/messagehub/config"
+	"github.com/mreider/koto/backend/messagehub/repo"
+	"github.com/mreider/koto/backend/messagehub/services/cost"
+)
+
+const (
+	defaultCostReportDays = 30
+	maxCostReportDays     = 365
+)
+
+type CostReporter struct {
+	repos  repo.Repos
+	prices []config.Price
+}
+
+func NewCostReporter(repos repo.Repos, prices []config.Price) *CostReporter {
+	return &CostReporter{
+		repos:  repos,
+		prices: prices,
+	}
+}
+
+// Report builds the cost report from the last days (30 by default).
+func (r *CostReporter) Report(ctx context.Context, days int) (cost.Report, error) {
+	if days <= 0 {
+		days = defaultCostReportDays
+	}
+	if days > maxCostReportDays {
+		days = maxCostReportDays
+	}
+	since := common.CurrentTimestamp().Truncate(time.Hour*24).AddDate(0, 0, -days+1)
+
+	mediaTypes, err := r.repos.Blob.UsageByMediaType(ctx)
+	if err != nil {
+		return cost.Report{}, err
+	}
+	unaccountedBlobs, err := r.repos.Blob.UnaccountedBlobCount(ctx)
+	if err != nil {
+		return cost.Report{}, err
+	}
+	uploads, err := r.repos.Blob.DailyUploads(ctx, since)
+	if err != nil {
+		return cost.Report{}, err
+	}
+	egress, err := r.repos.Blob.DailyEgress(ctx, since)
+	if err != nil {
+		return cost.Report{}, err
+	}
+
+	in := cost.Input{
+		Stored:           make([]cost.MediaUsage, len(mediaTypes)),
+		UnaccountedBlobs: unaccountedBlobs,
+		Uploads:          dailyVolumesToCost(uploads),
+		Egress:           dailyVolumesToCost(egress),
+		Days:             days,
+	}
+	for i, item := range mediaTypes {
+		in.Stored[i] = cost.MediaUsage{
+			MediaType: item.MediaType,
+			Bytes:     item.Size,
+			Blobs:     item.BlobCount,
+		}
+	}
+	return cost.Build(in, r.prices), nil
+}
+
+func dailyVolumesToCost(volumes []repo.DailyVolume) []cost.DailyVolume {
+	result := make([]cost.DailyVolume, len(volumes))
+	for i, item := range volumes {
+		result[i] = cost.DailyVolume{
+			Day:   item.Day,
+			Bytes: item.Size,
+			Count: item.Count,
+		}
+	}
+	return result
+}
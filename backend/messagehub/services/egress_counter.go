@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/messagehub/repo"
+)
+
+const (
+	egressFlushInterval = time.Minute
+)
+
+// EgressCounter estimates download traffic by counting generated blob links.
+// Every link is counted as one full download, so the estimate is an upper bound:
+// links are also generated for posts that are never opened, and downloads from S3 can't be counted by the hub.
+type EgressCounter struct {
+	repos repo.Repos
+
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func NewEgressCounter(repos repo.Repos) *EgressCounter {
+	return &EgressCounter{
+		repos:  repos,
+		counts: make(map[string]int),
+	}
+}
+
+func (c *EgressCounter) Count(blobID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[blobID]++
+}
+
+func (c *EgressCounter) Flush(ctx context.Context) {
+	ticker := time.NewTicker(egressFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.flush(context.Background())
+			return
+		case <-ticker.C:
+			c.flush(ctx)
+		}
+	}
+}
+
+func (c *EgressCounter) flush(ctx context.Context) {
+	c.mu.Lock()
+	counts := c.counts
+	c.counts = make(map[string]int)
+	c.mu.Unlock()
+
+	if len(counts) == 0 {
+		return
+	}
+
+	blobIDs := make([]string, 0, len(counts))
+	for blobID := range counts {
+		blobIDs = append(blobIDs, blobID)
+	}
+	sizes, err := c.repos.Blob.BlobSizes(ctx, blobIDs)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("can't load blob sizes")
+		c.restore(counts)
+		return
+	}
+
+	var links int
+	var size int64
+	for blobID, count := range counts {
+		links += count
+		size += sizes[blobID] * int64(count)
+	}
+	day := common.CurrentTimestamp().Truncate(time.Hour * 24)
+	err = c.repos.Blob.AddEgress(ctx, day, links, size)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("can't save blob egress")
+		c.restore(counts)
+	}
+}
+
+// restore keeps the counts that aren't saved for the next flush.
+func (c *EgressCounter) restore(counts map[string]int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for blobID, count := range counts {
+		c.counts[blobID] += count
+	}
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mreider/koto/backend/messagehub/repo"
+)
+
+type egressBlobRepo struct {
+	repo.BlobRepo
+	failures int
+	links    int
+	size     int64
+}
+
+func (r *egressBlobRepo) BlobSizes(_ context.Context, blobIDs []string) (map[string]int64, error) {
+	sizes := make(map[string]int64, len(blobIDs))
+	for _, blobID := range blobIDs {
+		sizes[blobID] = 10
+	}
+	return sizes, nil
+}
+
+func (r *egressBlobRepo) AddEgress(_ context.Context, _ time.Time, links int, size int64) error {
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("database is unavailable")
+	}
+	r.links += links
+	r.size += size
+	return nil
+}
+
+func TestEgressCounter_KeepsCountsOnFailure(t *testing.T) {
+	blobs := &egressBlobRepo{failures: 1}
+	c := NewEgressCounter(repo.Repos{Blob: blobs})
+
+	c.Count("a")
+	c.Count("a")
+	c.flush(context.Background())
+	assert.Equal(t, 0, blobs.links)
+
+	c.Count("b")
+	c.flush(context.Background())
+	assert.Equal(t, 3, blobs.links, "the counts of the failed flush are saved with the next one")
+	assert.Equal(t, int64(30), blobs.size)
+
+	c.flush(context.Background())
+	assert.Equal(t, 3, blobs.links)
+}
@@ -0,0 +1,89 @@
+package event
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	icalTimeLayout = "20060102T150405Z"
+	icalLineLength = 75
+)
+
+type CalendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	StartsAt    time.Time
+	EndsAt      time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func Calendar(name string, events []CalendarEvent) []byte {
+	var buf bytes.Buffer
+	writeLine(&buf, "BEGIN:VCALENDAR")
+	writeLine(&buf, "VERSION:2.0")
+	writeLine(&buf, "PRODID:-//Koto//Message Hub//EN")
+	writeLine(&buf, "CALSCALE:GREGORIAN")
+	writeLine(&buf, "METHOD:PUBLISH")
+	if name != "" {
+		writeLine(&buf, "X-WR-CALNAME:"+escapeText(name))
+	}
+	for _, event := range events {
+		writeLine(&buf, "BEGIN:VEVENT")
+		writeLine(&buf, "UID:"+escapeText(event.UID))
+		writeLine(&buf, "DTSTAMP:"+formatTime(event.UpdatedAt))
+		writeLine(&buf, "CREATED:"+formatTime(event.CreatedAt))
+		writeLine(&buf, "LAST-MODIFIED:"+formatTime(event.UpdatedAt))
+		writeLine(&buf, "DTSTART:"+formatTime(event.StartsAt))
+		if !event.EndsAt.IsZero() {
+			writeLine(&buf, "DTEND:"+formatTime(event.EndsAt))
+		}
+		writeLine(&buf, "SUMMARY:"+escapeText(event.Summary))
+		if event.Description != "" {
+			writeLine(&buf, "DESCRIPTION:"+escapeText(event.Description))
+		}
+		if event.Location != "" {
+			writeLine(&buf, "LOCATION:"+escapeText(event.Location))
+		}
+		writeLine(&buf, "END:VEVENT")
+	}
+	writeLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(icalTimeLayout)
+}
+
+func escapeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// writeLine folds content lines longer than 75 octets without splitting UTF-8 characters.
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := icalLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icalLineLength - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,50 @@
+package event_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mreider/koto/backend/messagehub/services/event"
+)
+
+func TestCalendar(t *testing.T) {
+	startsAt := time.Date(2020, 7, 1, 18, 30, 0, 0, time.UTC)
+	calendar := string(event.Calendar("Koto", []event.CalendarEvent{
+		{
+			UID:         "1@localhost",
+			Summary:     "Birthday, party; again",
+			Description: "line 1\nline 2",
+			Location:    "Home",
+			StartsAt:    startsAt,
+			EndsAt:      startsAt.Add(time.Hour * 3),
+			CreatedAt:   startsAt,
+			UpdatedAt:   startsAt,
+		},
+	}))
+
+	assert.True(t, strings.HasPrefix(calendar, "BEGIN:VCALENDAR\r\n"))
+	assert.True(t, strings.HasSuffix(calendar, "END:VCALENDAR\r\n"))
+	assert.Contains(t, calendar, "\r\nDTSTART:20200701T183000Z\r\n")
+	assert.Contains(t, calendar, "\r\nDTEND:20200701T213000Z\r\n")
+	assert.Contains(t, calendar, "\r\nSUMMARY:Birthday\\, party\\; again\r\n")
+	assert.Contains(t, calendar, "\r\nDESCRIPTION:line 1\\nline 2\r\n")
+}
+
+func TestCalendar_LongLines(t *testing.T) {
+	calendar := string(event.Calendar("", []event.CalendarEvent{
+		{
+			UID:      "1@localhost",
+			Summary:  strings.Repeat("день рождения ", 20),
+			StartsAt: time.Now(),
+		},
+	}))
+
+	for _, line := range strings.Split(strings.TrimSuffix(calendar, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+	}
+	unfolded := strings.ReplaceAll(calendar, "\r\n ", "")
+	assert.Contains(t, unfolded, "SUMMARY:"+strings.Repeat("день рождения ", 20)+"\r\n")
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/messagehub/repo"
+)
+
+const (
+	eventReminderInterval = time.Minute
+)
+
+type EventReminder struct {
+	repos              repo.Repos
+	notificationSender NotificationSender
+	leadTime           time.Duration
+}
+
+func NewEventReminder(repos repo.Repos, notificationSender NotificationSender, leadTime time.Duration) *EventReminder {
+	return &EventReminder{
+		repos:              repos,
+		notificationSender: notificationSender,
+		leadTime:           leadTime,
+	}
+}
+
+func (r *EventReminder) Remind(ctx context.Context) {
+	ticker := time.NewTicker(eventReminderInterval)
+	defer ticker.Stop()
+
+	r.remind(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.remind(ctx)
+		}
+	}
+}
+
+func (r *EventReminder) remind(ctx context.Context) {
+	now := common.CurrentTimestamp()
+	events, err := r.repos.Event.DueReminders(ctx, now, now.Add(r.leadTime))
+	if err != nil {
+		logging.FromContext(ctx).Error(err)
+		return
+	}
+
+	for _, event := range events {
+		reminded, err := r.repos.Event.MarkReminded(ctx, event.MessageID, now)
+		if err != nil {
+			logging.FromContext(ctx).Error(err)
+			continue
+		}
+		if !reminded {
+			continue
+		}
+
+		userIDs := []string{event.UserID}
+		for _, rsvp := range event.RSVPs {
+			if rsvp.UserID != event.UserID {
+				userIDs = append(userIDs, rsvp.UserID)
+			}
+		}
+		r.notificationSender.SendNotification(ctx, userIDs, event.Title+" starts soon", "event/reminder", map[string]interface{}{
+			"user_id":    event.UserID,
+			"message_id": event.MessageID,
+			"starts_at":  common.TimeToRPCString(event.StartsAt),
+		})
+	}
+}
@@ -2,9 +2,10 @@ package services
 
 import (
 	"context"
-	"log"
 	"sync"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/mreider/koto/backend/common"
 	"github.com/mreider/koto/backend/messagehub/rpc"
 )
@@ -34,7 +35,7 @@ func (s *infoService) Version(context.Context, *rpc.Empty) (*rpc.InfoVersionResp
 	s.dockerOnce.Do(func() {
 		container, err := common.CurrentContainer(context.Background())
 		if err != nil {
-			log.Println("can't get docker info:", err)
+			logrus.WithError(err).Error("can't get docker info")
 		}
 		if container != nil {
 			s.dockerCreated = container.ImageCreated()
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/twitchtv/twirp"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/messagehub/repo"
+)
+
+const (
+	postLimitPeriod    = time.Hour * 24
+	commentLimitPeriod = time.Hour
+	uploadLimitPeriod  = time.Hour * 24
+)
+
+// PostingLimits are per-user limits, 0 means unlimited.
+type PostingLimits struct {
+	PostsPerDay           int
+	CommentsPerHour       int
+	AttachmentBytesPerDay int64
+}
+
+type LimitUsage struct {
+	Posts           int
+	Comments        int
+	AttachmentBytes int64
+}
+
+type Limiter struct {
+	repos  repo.Repos
+	limits PostingLimits
+}
+
+func NewLimiter(repos repo.Repos, limits PostingLimits) *Limiter {
+	return &Limiter{
+		repos:  repos,
+		limits: limits,
+	}
+}
+
+func (l *Limiter) Limits() PostingLimits {
+	return l.limits
+}
+
+func (l *Limiter) Usage(ctx context.Context, userID string) (LimitUsage, error) {
+	now := common.CurrentTimestamp()
+	posts, err := l.repos.Limit.PostCount(ctx, userID, now.Add(-postLimitPeriod))
+	if err != nil {
+		return LimitUsage{}, err
+	}
+	comments, err := l.repos.Limit.CommentCount(ctx, userID, now.Add(-commentLimitPeriod))
+	if err != nil {
+		return LimitUsage{}, err
+	}
+	attachmentBytes, err := l.repos.Limit.UploadedSize(ctx, userID, now.Add(-uploadLimitPeriod))
+	if err != nil {
+		return LimitUsage{}, err
+	}
+	return LimitUsage{
+		Posts:           posts,
+		Comments:        comments,
+		AttachmentBytes: attachmentBytes,
+	}, nil
+}
+
+// CheckPost is called in the transaction that adds the post. The user is locked until the transaction ends,
+// so parallel posts can't exceed the limit together.
+func (l *Limiter) CheckPost(ctx context.Context, tx *sqlx.Tx, userID string) error {
+	if l.limits.PostsPerDay <= 0 {
+		return nil
+	}
+	err := l.repos.Limit.LockUser(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+	count, err := l.repos.Limit.PostCount(ctx, userID, common.CurrentTimestamp().Add(-postLimitPeriod))
+	if err != nil {
+		return err
+	}
+	if count >= l.limits.PostsPerDay {
+		return limitError("daily post limit reached", l.limits.PostsPerDay, postLimitPeriod)
+	}
+	return nil
+}
+
+// CheckComment is called in the transaction that adds the comment, like CheckPost.
+func (l *Limiter) CheckComment(ctx context.Context, tx *sqlx.Tx, userID string) error {
+	if l.limits.CommentsPerHour <= 0 {
+		return nil
+	}
+	err := l.repos.Limit.LockUser(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+	count, err := l.repos.Limit.CommentCount(ctx, userID, common.CurrentTimestamp().Add(-commentLimitPeriod))
+	if err != nil {
+		return err
+	}
+	if count >= l.limits.CommentsPerHour {
+		return limitError("hourly comment limit reached", l.limits.CommentsPerHour, commentLimitPeriod)
+	}
+	return nil
+}
+
+// RemainingUploadBytes returns how many attachment bytes the user can upload today, or 0 if it's unlimited.
+func (l *Limiter) RemainingUploadBytes(ctx context.Context, userID string) (int64, error) {
+	if l.limits.AttachmentBytesPerDay <= 0 {
+		return 0, nil
+	}
+	size, err := l.repos.Limit.UploadedSize(ctx, userID, common.CurrentTimestamp().Add(-uploadLimitPeriod))
+	if err != nil {
+		return 0, err
+	}
+	remaining := l.limits.AttachmentBytesPerDay - size
+	if remaining <= 0 {
+		return 0, limitError("daily attachment limit reached", int(l.limits.AttachmentBytesPerDay), uploadLimitPeriod)
+	}
+	return remaining, nil
+}
+
+// checkHubPostLimit enforces the post limit the hub admin set on the user hub: only users closer to the admin
+// than post_limit friendship steps can post. The user hub puts both values into the post-message token.
+func checkHubPostLimit(claims map[string]interface{}) error {
+	postLimit, _ := claims["post_limit"].(float64)
+	if postLimit <= 0 {
+		return nil
+	}
+	distance, ok := claims["distance"].(float64)
+	if !ok || distance >= postLimit {
+		return twirp.NewError(twirp.PermissionDenied, "the hub doesn't accept posts from this user")
+	}
+	return nil
+}
+
+func limitError(msg string, limit int, period time.Duration) error {
+	return twirp.NewError(twirp.ResourceExhausted, msg).
+		WithMeta("limit", strconv.Itoa(limit)).
+		WithMeta("period_seconds", strconv.Itoa(int(period.Seconds())))
+}
@@ -0,0 +1,135 @@
+package services_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/messagehub/repo"
+	"github.com/mreider/koto/backend/messagehub/rpc"
+	"github.com/mreider/koto/backend/messagehub/services"
+	"github.com/mreider/koto/backend/token"
+)
+
+func TestLimiter(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	repos := repo.Repos{
+		DB:      te.DB,
+		Message: repo.NewMessages(te.DB),
+		Poll:    repo.NewPolls(te.DB),
+		Event:   repo.NewEvents(te.DB),
+		User:    repo.NewUsers(te.DB),
+		Blob:    repo.NewBlobs(te.DB),
+		Limit:   repo.NewLimits(te.DB),
+	}
+	for _, id := range []string{"1", "2"} {
+		require.Nil(t, repos.User.AddUser(te.Ctx, id, "user"+id))
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	tokenGenerator := token.NewGenerator(privateKey)
+	tokenParser := token.NewParser(func() *rsa.PublicKey { return &privateKey.PublicKey })
+	sender := &notificationSender{}
+	base := services.NewBase(repos, tokenParser, tokenGenerator, nil, hubAddress, nil, sender, nil)
+	limiter := services.NewLimiter(repos, services.PostingLimits{PostsPerDay: 3, CommentsPerHour: 2})
+	s := services.NewMessage(base, nil, services.NewReactionNotifier(sender, 0), 3, limiter)
+
+	user1Ctx := context.WithValue(te.Ctx, services.ContextUserKey, services.User{ID: "1", Name: "user1"})
+	user2Ctx := context.WithValue(te.Ctx, services.ContextUserKey, services.User{ID: "2", Name: "user2"})
+	generate := func(userID, scope string, claims map[string]interface{}) string {
+		claims["hub"] = hubAddress
+		tok, err := tokenGenerator.Generate(userID, "user"+userID, scope, time.Now().Add(time.Hour), claims)
+		require.Nil(t, err)
+		return tok
+	}
+	post := func(ctx context.Context, userID string, claims map[string]interface{}) (*rpc.MessagePostResponse, error) {
+		claims["friends"] = []string{}
+		return s.Post(ctx, &rpc.MessagePostRequest{Token: generate(userID, "post-message", claims), Text: "post"})
+	}
+	comment := func(messageID string) error {
+		_, err := s.PostComment(user1Ctx, &rpc.MessagePostCommentRequest{
+			Token:     generate("1", "get-messages", map[string]interface{}{"users": []string{"1"}}),
+			MessageId: messageID,
+			Text:      "comment",
+		})
+		return err
+	}
+	addOld := func(id, parentID string, age time.Duration) {
+		createdAt := common.CurrentTimestamp().Add(-age)
+		require.Nil(t, common.RunInTransaction(te.Ctx, te.DB, func(tx *sqlx.Tx) error {
+			return repos.Message.AddMessage(te.Ctx, tx, parentID, repo.Message{
+				ID: id, UserID: "1", UserName: "user1", Text: id, CreatedAt: createdAt, UpdatedAt: createdAt,
+				PublishedAt: sql.NullTime{Time: createdAt, Valid: true},
+			})
+		}))
+	}
+
+	// posts of the previous day don't count
+	addOld("yesterday", "", time.Hour*25)
+	addOld("old-comment", "yesterday", time.Hour*2)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 5)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = post(user1Ctx, "1", map[string]interface{}{})
+		}(i)
+	}
+	wg.Wait()
+	var posted int
+	for _, err := range errs {
+		if err == nil {
+			posted++
+			continue
+		}
+		assert.Equal(t, twirp.ResourceExhausted, err.(twirp.Error).Code())
+		assert.Equal(t, "3", err.(twirp.Error).Meta("limit"))
+		assert.Equal(t, "86400", err.(twirp.Error).Meta("period_seconds"))
+	}
+	assert.Equal(t, 3, posted, "parallel posts don't exceed the limit together")
+
+	resp, err := s.PostingLimits(user1Ctx, &rpc.Empty{})
+	require.Nil(t, err)
+	assert.Equal(t, int32(0), resp.PostsRemaining)
+	assert.Equal(t, int32(2), resp.CommentsRemaining, "comments of the previous hour don't count")
+	assert.Equal(t, int64(-1), resp.AttachmentBytesRemaining)
+
+	require.Nil(t, comment("yesterday"))
+	require.Nil(t, comment("yesterday"))
+	err = comment("yesterday")
+	require.NotNil(t, err)
+	assert.Equal(t, twirp.ResourceExhausted, err.(twirp.Error).Code())
+	assert.Equal(t, "3600", err.(twirp.Error).Meta("period_seconds"))
+
+	resp, err = s.PostingLimits(user2Ctx, &rpc.Empty{})
+	require.Nil(t, err)
+	assert.Equal(t, int32(3), resp.PostsRemaining)
+
+	// the user hub puts the hub post limit and the friendship distance to the hub admin into the token
+	_, err = post(user2Ctx, "2", map[string]interface{}{"post_limit": 2, "distance": 2})
+	require.NotNil(t, err)
+	assert.Equal(t, twirp.PermissionDenied, err.(twirp.Error).Code())
+	_, err = post(user2Ctx, "2", map[string]interface{}{"post_limit": 2, "distance": 1})
+	require.Nil(t, err)
+	_, err = post(user2Ctx, "2", map[string]interface{}{"post_limit": 0, "distance": 5})
+	require.Nil(t, err)
+
+	resp, err = s.PostingLimits(user2Ctx, &rpc.Empty{})
+	require.Nil(t, err)
+	assert.Equal(t, int32(1), resp.PostsRemaining)
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/messagehub/repo"
+)
+
+const (
+	publishInterval = time.Second * 10
+)
+
+type MessagePublisher struct {
+	repos              repo.Repos
+	notificationSender NotificationSender
+	userHub            UserHubClient
+}
+
+func NewMessagePublisher(repos repo.Repos, notificationSender NotificationSender, userHub UserHubClient) *MessagePublisher {
+	return &MessagePublisher{
+		repos:              repos,
+		notificationSender: notificationSender,
+		userHub:            userHub,
+	}
+}
+
+func (p *MessagePublisher) Publish(ctx context.Context) {
+	ticker := time.NewTicker(publishInterval)
+	defer ticker.Stop()
+
+	p.publish(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.publish(ctx)
+		}
+	}
+}
+
+func (p *MessagePublisher) publish(ctx context.Context) {
+	now := common.CurrentTimestamp()
+	scheduledMessages, err := p.repos.Message.DueScheduledMessages(ctx, now)
+	if err != nil {
+		logging.FromContext(ctx).Error(err)
+		return
+	}
+
+	for _, item := range scheduledMessages {
+		err = p.publishMessage(ctx, item, now)
+		if err != nil {
+			logging.FromContext(ctx).Error(err)
+		}
+	}
+}
+
+func (p *MessagePublisher) publishMessage(ctx context.Context, item repo.ScheduledMessage, now time.Time) error {
+	msg, err := p.repos.Message.Message(ctx, "", item.MessageID)
+	if err != nil {
+		return err
+	}
+
+	friendIDs, err := p.scheduledMessageFriends(ctx, msg.UserID, item, now)
+	if err != nil {
+		if !merry.Is(err, ErrUserNotFound) {
+			return merry.Prepend(err, "can't load friends for the scheduled message")
+		}
+		// the author has moved to another hub, so they have to post it again
+		err = p.repos.Message.MoveToDrafts(ctx, msg.UserID, msg.ID)
+		if err != nil {
+			return err
+		}
+		p.notificationSender.SendNotification(ctx, []string{msg.UserID}, "Your scheduled message wasn't published and was moved to drafts", "message/schedule-expired", map[string]interface{}{
+			"message_id": msg.ID,
+		})
+		return nil
+	}
+
+	return common.RunInTransaction(ctx, p.repos.DB, func(tx *sqlx.Tx) error {
+		published, err := p.repos.Message.PublishMessage(ctx, tx, msg.ID, now)
+		if err != nil || !published {
+			return err
+		}
+		err = enqueuePostNotifications(ctx, tx, p.notificationSender, p.repos.User, msg, friendIDs)
+		if err != nil {
+			return merry.Prepend(err, "can't send notifications for the scheduled message")
+		}
+		return nil
+	})
+}
+
+// scheduledMessageFriends returns the friends of the post-message token the message was scheduled with
+// while the token is valid. After it expires, the friends are confirmed by the user hub,
+// so the message is published only to the users who are still friends of the author.
+func (p *MessagePublisher) scheduledMessageFriends(ctx context.Context, userID string, item repo.ScheduledMessage, now time.Time) ([]string, error) {
+	if item.TokenExpiresAt.Valid && now.Before(item.TokenExpiresAt.Time) {
+		return item.FriendIDs, nil
+	}
+
+	currentFriendIDs, err := p.userHub.Friends(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !item.TokenExpiresAt.Valid {
+		return currentFriendIDs, nil
+	}
+
+	isCurrentFriend := make(map[string]bool, len(currentFriendIDs))
+	for _, friendID := range currentFriendIDs {
+		isCurrentFriend[friendID] = true
+	}
+	friendIDs := make([]string, 0, len(item.FriendIDs))
+	for _, friendID := range item.FriendIDs {
+		if isCurrentFriend[friendID] {
+			friendIDs = append(friendIDs, friendID)
+		}
+	}
+	return friendIDs, nil
+}
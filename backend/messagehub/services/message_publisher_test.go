@@ -0,0 +1,150 @@
+package services_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/messagehub/repo"
+	"github.com/mreider/koto/backend/messagehub/rpc"
+	"github.com/mreider/koto/backend/messagehub/services"
+	"github.com/mreider/koto/backend/token"
+)
+
+type userHub map[string][]string
+
+func (h userHub) Friends(_ context.Context, userID string) ([]string, error) {
+	friendIDs, ok := h[userID]
+	if !ok {
+		return nil, services.ErrUserNotFound.Here()
+	}
+	return friendIDs, nil
+}
+
+func TestMessageService_DraftsAndSchedule(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	repos := repo.Repos{
+		DB:      te.DB,
+		Message: repo.NewMessages(te.DB),
+		Poll:    repo.NewPolls(te.DB),
+		Event:   repo.NewEvents(te.DB),
+		User:    repo.NewUsers(te.DB),
+		Blob:    repo.NewBlobs(te.DB),
+		Limit:   repo.NewLimits(te.DB),
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	tokenGenerator := token.NewGenerator(privateKey)
+	tokenParser := token.NewParser(func() *rsa.PublicKey { return &privateKey.PublicKey })
+	sender := &notificationSender{}
+	base := services.NewBase(repos, tokenParser, tokenGenerator, nil, hubAddress, nil, sender, nil)
+	s := services.NewMessage(base, nil, services.NewReactionNotifier(sender, 0), 3, services.NewLimiter(repos, services.PostingLimits{}))
+	publisher := services.NewMessagePublisher(repos, sender, userHub{"1": {"2", "3"}})
+
+	user1Ctx := context.WithValue(te.Ctx, services.ContextUserKey, services.User{ID: "1", Name: "user1"})
+	user2Ctx := context.WithValue(te.Ctx, services.ContextUserKey, services.User{ID: "2", Name: "user2"})
+	user4Ctx := context.WithValue(te.Ctx, services.ContextUserKey, services.User{ID: "4", Name: "user4"})
+
+	postTokenWithExp := func(userID, userName string, exp time.Time, friendIDs ...string) string {
+		tok, err := tokenGenerator.Generate(userID, userName, "post-message", exp, map[string]interface{}{
+			"hub":     hubAddress,
+			"friends": friendIDs,
+		})
+		require.Nil(t, err)
+		return tok
+	}
+	postToken := func(userID, userName string) string {
+		return postTokenWithExp(userID, userName, time.Now().Add(time.Hour), "2")
+	}
+	publish := func() {
+		ctx, cancel := context.WithCancel(te.Ctx)
+		cancel()
+		publisher.Publish(ctx)
+	}
+
+	draftResp, err := s.SaveDraft(user1Ctx, &rpc.MessageSaveDraftRequest{Text: "draft"})
+	require.Nil(t, err)
+	draftID := draftResp.Draft.Id
+	assert.True(t, draftResp.Draft.IsDraft)
+
+	_, err = s.SaveDraft(user2Ctx, &rpc.MessageSaveDraftRequest{DraftId: draftID, Text: "stolen"})
+	assert.Equal(t, twirp.NotFound, err.(twirp.Error).Code(), "only the author edits a draft")
+
+	// the schedule may be later than the post-message token expiration
+	publishAt := common.TimeToRPCString(time.Now().Add(time.Hour * 24))
+	postResp, err := s.Post(user1Ctx, &rpc.MessagePostRequest{Token: postToken("1", "user1"), DraftId: draftID, Text: "scheduled", PublishAt: publishAt})
+	require.Nil(t, err)
+	assert.Equal(t, draftID, postResp.Message.Id)
+	assert.Equal(t, publishAt, postResp.Message.PublishAt)
+	assert.False(t, postResp.Message.IsDraft)
+
+	draftsResp, err := s.Drafts(user1Ctx, &rpc.Empty{})
+	require.Nil(t, err)
+	require.Len(t, draftsResp.Drafts, 1)
+	assert.Equal(t, "scheduled", draftsResp.Drafts[0].Text)
+	assert.Equal(t, publishAt, draftsResp.Drafts[0].PublishAt)
+
+	draftResp, err = s.SaveDraft(user1Ctx, &rpc.MessageSaveDraftRequest{DraftId: draftID, Text: "unscheduled"})
+	require.Nil(t, err)
+	assert.True(t, draftResp.Draft.IsDraft, "saving a scheduled message cancels the schedule")
+	assert.Empty(t, draftResp.Draft.PublishAt)
+
+	publishAt = common.TimeToRPCString(time.Now().Add(time.Millisecond * 100))
+	_, err = s.Post(user1Ctx, &rpc.MessagePostRequest{Token: postToken("1", "user1"), DraftId: draftID, Text: "scheduled", PublishAt: publishAt})
+	require.Nil(t, err)
+	shortTokenExp := time.Now().Add(time.Second)
+	laterPublishAt := common.TimeToRPCString(time.Now().Add(time.Millisecond * 500))
+	expiredPostResp, err := s.Post(user1Ctx, &rpc.MessagePostRequest{Token: postTokenWithExp("1", "user1", shortTokenExp, "2", "5"), Text: "expired token", PublishAt: laterPublishAt})
+	require.Nil(t, err)
+	_, err = s.Post(user4Ctx, &rpc.MessagePostRequest{Token: postTokenWithExp("4", "user4", shortTokenExp, "1"), Text: "moved away", PublishAt: laterPublishAt})
+	require.Nil(t, err)
+	assert.Empty(t, sender.Sent(), "scheduled messages aren't announced")
+
+	time.Sleep(time.Millisecond * 200)
+	publish()
+
+	msg, err := repos.Message.Message(te.Ctx, "1", draftID)
+	require.Nil(t, err)
+	assert.True(t, msg.PublishedAt.Valid)
+	assert.False(t, msg.IsDraft)
+
+	sent := sender.Sent()
+	require.Len(t, sent, 1)
+	assert.Equal(t, "message/post", sent[0].messageType)
+	assert.Equal(t, []string{"2"}, sent[0].userIDs, "the friends of a valid token are used")
+	assert.Equal(t, draftID, sent[0].data["message_id"])
+
+	time.Sleep(time.Until(shortTokenExp.Add(time.Second)))
+	publish()
+
+	draftsResp, err = s.Drafts(user1Ctx, &rpc.Empty{})
+	require.Nil(t, err)
+	assert.Empty(t, draftsResp.Drafts)
+	draftsResp, err = s.Drafts(user4Ctx, &rpc.Empty{})
+	require.Nil(t, err)
+	require.Len(t, draftsResp.Drafts, 1, "the friends of a user who left the hub are unknown")
+	assert.True(t, draftsResp.Drafts[0].IsDraft)
+
+	sent = sender.Sent()
+	require.Len(t, sent, 3)
+	byType := make(map[string]sentNotification)
+	for _, n := range sent[1:] {
+		byType[n.messageType] = n
+	}
+	assert.Equal(t, []string{"2"}, byType["message/post"].userIDs, "the friends of an expired token are confirmed by the user hub")
+	assert.Equal(t, expiredPostResp.Message.Id, byType["message/post"].data["message_id"])
+	assert.Equal(t, []string{"4"}, byType["message/schedule-expired"].userIDs)
+
+	publish()
+	assert.Len(t, sender.Sent(), 3, "a message is published once")
+}
@@ -3,18 +3,22 @@ package services
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"image/jpeg"
-	"log"
+	"net/url"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/ansel1/merry"
 	"github.com/gofrs/uuid"
 	"github.com/h2non/filetype"
+	"github.com/jmoiron/sqlx"
 	"github.com/twitchtv/twirp"
 
 	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
 	"github.com/mreider/koto/backend/messagehub/repo"
 	"github.com/mreider/koto/backend/messagehub/rpc"
 	"github.com/mreider/koto/backend/messagehub/services/message"
@@ -22,16 +26,25 @@ import (
 )
 
 const (
-	fileTypeBufSize = 8192
+	fileTypeBufSize           = 8192
+	calendarFeedTokenDuration = time.Hour * 24 * 365 * 10
 )
 
 type messageService struct {
 	*BaseService
+	reactions        []string
+	reactionNotifier ReactionNotifier
+	maxCommentDepth  int
+	limiter          *Limiter
 }
 
-func NewMessage(base *BaseService) rpc.MessageService {
+func NewMessage(base *BaseService, reactions []string, reactionNotifier ReactionNotifier, maxCommentDepth int, limiter *Limiter) rpc.MessageService {
 	return &messageService{
-		BaseService: base,
+		BaseService:      base,
+		reactions:        reactions,
+		reactionNotifier: reactionNotifier,
+		maxCommentDepth:  maxCommentDepth,
+		limiter:          limiter,
 	}
 }
 
@@ -51,48 +64,337 @@ func (s *messageService) Post(ctx context.Context, r *rpc.MessagePostRequest) (*
 		return nil, twirp.NewError(twirp.InvalidArgument, "invalid token")
 	}
 
+	err = checkHubPostLimit(claims)
+	if err != nil {
+		return nil, err
+	}
+
 	rawFriendIDs := claims["friends"].([]interface{})
 	friends := make([]string, len(rawFriendIDs))
 	for i, rawID := range rawFriendIDs {
 		friends[i] = rawID.(string)
 	}
 
-	messageID, err := uuid.NewV4()
+	now := common.CurrentTimestamp()
+	var publishAt time.Time
+	if r.PublishAt != "" {
+		publishAt, err = common.RPCStringToTime(r.PublishAt)
+		if err != nil {
+			return nil, twirp.InvalidArgumentError("publish_at", err.Error())
+		}
+	}
+	scheduled := publishAt.After(now)
+
+	var poll repo.Poll
+	if r.Poll != nil {
+		publishTime := now
+		if scheduled {
+			publishTime = publishAt
+		}
+		poll, err = newPoll(r.Poll, publishTime)
+		if err != nil {
+			return nil, err
+		}
+		if r.DraftId != "" {
+			_, err = s.repos.Poll.Poll(ctx, user.ID, r.DraftId)
+			if err == nil {
+				return nil, twirp.InvalidArgumentError("poll", "the draft already has a poll")
+			}
+			if !merry.Is(err, repo.ErrPollNotFound) {
+				return nil, err
+			}
+		}
+	}
+
+	var event repo.Event
+	if r.Event != nil {
+		event, err = newEvent(r.Event)
+		if err != nil {
+			return nil, err
+		}
+		if r.DraftId != "" {
+			_, err = s.repos.Event.Event(ctx, user.ID, r.DraftId)
+			if err == nil {
+				return nil, twirp.InvalidArgumentError("event", "the draft already has an event")
+			}
+			if !merry.Is(err, repo.ErrEventNotFound) {
+				return nil, err
+			}
+		}
+	}
+
+	var msg repo.Message
+	if r.DraftId == "" {
+		msg, err = s.newMessage(ctx, user, r.Text, r.AttachmentId)
+	} else {
+		msg, err = s.saveDraft(ctx, user, r.DraftId, r.Text, r.AttachmentId)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	attachmentThumbnailID, attachmentType, err := s.processAttachment(ctx, r.AttachmentId)
+	// the message is published or scheduled together with its poll, event and notifications
+	err = common.RunInTransaction(ctx, s.repos.DB, func(tx *sqlx.Tx) error {
+		err := s.limiter.CheckPost(ctx, tx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		if r.DraftId == "" {
+			if !scheduled {
+				msg.PublishedAt = sql.NullTime{Time: msg.CreatedAt, Valid: true}
+			}
+			err := s.repos.Message.AddMessage(ctx, tx, "", msg)
+			if err != nil {
+				return err
+			}
+		}
+
+		if scheduled {
+			schedule := repo.ScheduledMessage{
+				MessageID: msg.ID,
+				PublishAt: publishAt,
+				FriendIDs: friends,
+			}
+			if exp, ok := claims["exp"].(float64); ok {
+				schedule.TokenExpiresAt = sql.NullTime{Time: time.Unix(int64(exp), 0), Valid: true}
+			}
+			err := s.repos.Message.ScheduleMessage(ctx, tx, user.ID, schedule)
+			if err != nil {
+				return err
+			}
+		} else if r.DraftId != "" {
+			_, err := s.repos.Message.PublishMessage(ctx, tx, msg.ID, now)
+			if err != nil {
+				return err
+			}
+		}
+
+		if r.Poll != nil {
+			poll.MessageID = msg.ID
+			poll.CreatedAt = now
+			err := s.repos.Poll.AddPoll(ctx, tx, poll)
+			if err != nil {
+				return err
+			}
+		}
+
+		if r.Event != nil {
+			event.MessageID = msg.ID
+			event.CreatedAt = now
+			err := s.repos.Event.AddEvent(ctx, tx, event)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !scheduled {
+			return enqueuePostNotifications(ctx, tx, s.notificationSender, s.repos.User, msg, friends)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err = s.repos.Message.Message(ctx, user.ID, msg.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcMessage, err := s.messageToRPC(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	err = s.setPolls(ctx, user.ID, map[string]*rpc.Message{msg.ID: rpcMessage})
+	if err != nil {
+		return nil, err
+	}
+	err = s.setEvents(ctx, user, map[string]*rpc.Message{msg.ID: rpcMessage})
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.MessagePostResponse{
+		Message: rpcMessage,
+	}, nil
+}
+
+func (s *messageService) SaveDraft(ctx context.Context, r *rpc.MessageSaveDraftRequest) (*rpc.MessageSaveDraftResponse, error) {
+	user := s.getUser(ctx)
+
+	msg, err := s.saveDraft(ctx, user, r.DraftId, r.Text, r.AttachmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	// saving a scheduled message cancels the schedule
+	if msg.PublishAt.Valid {
+		err = s.repos.Message.MoveToDrafts(ctx, user.ID, msg.ID)
+		if err != nil {
+			return nil, err
+		}
+		msg.PublishAt = sql.NullTime{}
+		msg.IsDraft = true
+	}
+
+	rpcMessage, err := s.messageToRPC(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.MessageSaveDraftResponse{
+		Draft: rpcMessage,
+	}, nil
+}
+
+func (s *messageService) Drafts(ctx context.Context, _ *rpc.Empty) (*rpc.MessageDraftsResponse, error) {
+	user := s.getUser(ctx)
+
+	drafts, err := s.repos.Message.Drafts(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcDrafts := make([]*rpc.Message, len(drafts))
+	rpcDraftMap := make(map[string]*rpc.Message, len(drafts))
+	for i, draft := range drafts {
+		rpcDrafts[i], err = s.messageToRPC(ctx, draft)
+		if err != nil {
+			return nil, err
+		}
+		rpcDraftMap[draft.ID] = rpcDrafts[i]
+	}
+
+	err = s.setPolls(ctx, user.ID, rpcDraftMap)
+	if err != nil {
+		return nil, err
+	}
+	err = s.setEvents(ctx, user, rpcDraftMap)
 	if err != nil {
 		return nil, err
 	}
+	return &rpc.MessageDraftsResponse{
+		Drafts: rpcDrafts,
+	}, nil
+}
+
+func (s *messageService) newMessage(ctx context.Context, user User, text, attachmentID string) (repo.Message, error) {
+	messageID, err := uuid.NewV4()
+	if err != nil {
+		return repo.Message{}, err
+	}
+
+	attachmentThumbnailID, attachmentType, err := s.processAttachment(ctx, attachmentID)
+	if err != nil {
+		return repo.Message{}, err
+	}
 
 	now := common.CurrentTimestamp()
-	msg := repo.Message{
+	return repo.Message{
 		ID:                    messageID.String(),
-		UserID:                claims["id"].(string),
-		UserName:              claims["name"].(string),
-		Text:                  r.Text,
-		AttachmentID:          r.AttachmentId,
+		UserID:                user.ID,
+		UserName:              user.Name,
+		Text:                  text,
+		AttachmentID:          attachmentID,
 		AttachmentType:        attachmentType,
 		AttachmentThumbnailID: attachmentThumbnailID,
 		CreatedAt:             now,
 		UpdatedAt:             now,
+	}, nil
+}
+
+func (s *messageService) saveDraft(ctx context.Context, user User, draftID, text, attachmentID string) (repo.Message, error) {
+	if draftID == "" {
+		msg, err := s.newMessage(ctx, user, text, attachmentID)
+		if err != nil {
+			return repo.Message{}, err
+		}
+		msg.IsDraft = true
+		err = common.RunInTransaction(ctx, s.repos.DB, func(tx *sqlx.Tx) error {
+			return s.repos.Message.AddMessage(ctx, tx, "", msg)
+		})
+		if err != nil {
+			return repo.Message{}, err
+		}
+		return msg, nil
+	}
+
+	msg, err := s.repos.Message.Message(ctx, user.ID, draftID)
+	if err != nil {
+		if merry.Is(err, repo.ErrMessageNotFound) {
+			return repo.Message{}, twirp.NotFoundError("draft not found")
+		}
+		return repo.Message{}, err
+	}
+	if msg.UserID != user.ID || msg.PublishedAt.Valid {
+		return repo.Message{}, twirp.NotFoundError("draft not found")
+	}
+
+	now := common.CurrentTimestamp()
+	if text != msg.Text {
+		err = s.repos.Message.EditMessageText(ctx, user.ID, msg.ID, text, now)
+		if err != nil {
+			return repo.Message{}, err
+		}
+	}
+	if attachmentID != msg.AttachmentID {
+		attachmentThumbnailID, attachmentType, err := s.processAttachment(ctx, attachmentID)
+		if err != nil {
+			return repo.Message{}, err
+		}
+		err = s.repos.Message.EditMessageAttachment(ctx, user.ID, msg.ID, attachmentID, attachmentType, attachmentThumbnailID, now)
+		if err != nil {
+			return repo.Message{}, err
+		}
+	}
+
+	return s.repos.Message.Message(ctx, user.ID, msg.ID)
+}
+
+func (s *messageService) messageToRPC(ctx context.Context, msg repo.Message) (*rpc.Message, error) {
+	attachmentLink, err := s.createBlobLink(ctx, msg.AttachmentID)
+	if err != nil {
+		return nil, err
 	}
-	err = s.repos.Message.AddMessage("", msg)
+
+	attachmentThumbnailLink, err := s.createBlobLink(ctx, msg.AttachmentThumbnailID)
 	if err != nil {
 		return nil, err
 	}
 
-	s.notificationSender.SendNotification(friends, msg.UserName+" posted a new message", "message/post", map[string]interface{}{
+	rpcMessage := &rpc.Message{
+		Id:                  msg.ID,
+		UserId:              msg.UserID,
+		UserName:            msg.UserName,
+		Text:                msg.Text,
+		Attachment:          attachmentLink,
+		AttachmentType:      msg.AttachmentType,
+		AttachmentThumbnail: attachmentThumbnailLink,
+		MediaExpired:        msg.MediaExpired,
+		CreatedAt:           common.TimeToRPCString(msg.CreatedAt),
+		UpdatedAt:           common.TimeToRPCString(msg.UpdatedAt),
+		Likes:               int32(msg.Likes),
+		LikedByMe:           msg.LikedByMe,
+		IsDraft:             msg.IsDraft,
+	}
+	if msg.PublishAt.Valid {
+		rpcMessage.PublishAt = common.TimeToRPCString(msg.PublishAt.Time)
+	}
+	return rpcMessage, nil
+}
+
+func enqueuePostNotifications(ctx context.Context, tx *sqlx.Tx, notificationSender NotificationSender, userRepo repo.UserRepo, msg repo.Message, friendIDs []string) error {
+	err := notificationSender.EnqueueNotification(ctx, tx, friendIDs, msg.UserName+" posted a new message", "message/post", map[string]interface{}{
 		"user_id":    msg.UserID,
 		"message_id": msg.ID,
 	})
+	if err != nil {
+		return err
+	}
 
 	userTags := message.FindUserTags(msg.Text)
-	users, err := s.repos.User.FindUsersByName(userTags)
+	users, err := userRepo.FindUsersByName(ctx, userTags)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	notifyUsers := make([]string, 0, len(users))
 	for _, u := range users {
@@ -100,36 +402,10 @@ func (s *messageService) Post(ctx context.Context, r *rpc.MessagePostRequest) (*
 			notifyUsers = append(notifyUsers, u.ID)
 		}
 	}
-	s.notificationSender.SendNotification(notifyUsers, msg.UserName+" tagged you in a message", "message/tag", map[string]interface{}{
+	return notificationSender.EnqueueNotification(ctx, tx, notifyUsers, msg.UserName+" tagged you in a message", "message/tag", map[string]interface{}{
 		"user_id":    msg.UserID,
 		"message_id": msg.ID,
 	})
-
-	attachmentLink, err := s.createBlobLink(ctx, msg.AttachmentID)
-	if err != nil {
-		return nil, err
-	}
-
-	attachmentThumbnailLink, err := s.createBlobLink(ctx, msg.AttachmentThumbnailID)
-	if err != nil {
-		return nil, err
-	}
-
-	return &rpc.MessagePostResponse{
-		Message: &rpc.Message{
-			Id:                  msg.ID,
-			UserId:              msg.UserID,
-			UserName:            msg.UserName,
-			Text:                msg.Text,
-			Attachment:          attachmentLink,
-			AttachmentType:      attachmentType,
-			AttachmentThumbnail: attachmentThumbnailLink,
-			CreatedAt:           common.TimeToRPCString(msg.CreatedAt),
-			UpdatedAt:           common.TimeToRPCString(msg.UpdatedAt),
-			Likes:               int32(msg.Likes),
-			LikedByMe:           msg.LikedByMe,
-		},
-	}, nil
 }
 
 func (s *messageService) Messages(ctx context.Context, r *rpc.MessageMessagesRequest) (*rpc.MessageMessagesResponse, error) {
@@ -162,7 +438,7 @@ func (s *messageService) Messages(ctx context.Context, r *rpc.MessageMessagesReq
 		}
 	}
 
-	messages, err := s.repos.Message.Messages(user.ID, userIDs, from, int(r.Count))
+	messages, err := s.repos.Message.Messages(ctx, user.ID, userIDs, from, int(r.Count))
 	if err != nil {
 		return nil, err
 	}
@@ -189,6 +465,7 @@ func (s *messageService) Messages(ctx context.Context, r *rpc.MessageMessagesReq
 			Attachment:          attachmentLink,
 			AttachmentType:      msg.AttachmentType,
 			AttachmentThumbnail: attachmentThumbnailLink,
+			MediaExpired:        msg.MediaExpired,
 			CreatedAt:           common.TimeToRPCString(msg.CreatedAt),
 			UpdatedAt:           common.TimeToRPCString(msg.UpdatedAt),
 			Likes:               int32(msg.Likes),
@@ -197,26 +474,11 @@ func (s *messageService) Messages(ctx context.Context, r *rpc.MessageMessagesReq
 		rpcMessageMap[msg.ID] = rpcMessages[i]
 	}
 
-	allLikes, err := s.repos.Message.MessagesLikes(messageIDs)
-	if err != nil {
-		return nil, err
-	}
-	for msgID, likes := range allLikes {
-		rpcLikes := make([]*rpc.MessageLike, len(likes))
-		for i, like := range likes {
-			rpcLikes[i] = &rpc.MessageLike{
-				UserId:   like.UserID,
-				UserName: like.UserName,
-				LikedAt:  common.TimeToRPCString(like.CreatedAt),
-			}
-		}
-		rpcMessageMap[msgID].LikedBy = rpcLikes
-	}
-
-	comments, err := s.repos.Message.Comments(user.ID, messageIDs)
+	comments, err := s.repos.Message.Comments(ctx, user.ID, messageIDs)
 	if err != nil {
 		return nil, err
 	}
+	rpcCommentMap := make(map[string]*rpc.Message)
 	for messageID, messageComments := range comments {
 		rpcComments := make([]*rpc.Message, len(messageComments))
 		for i, comment := range messageComments {
@@ -237,15 +499,32 @@ func (s *messageService) Messages(ctx context.Context, r *rpc.MessageMessagesReq
 				Attachment:          attachmentLink,
 				AttachmentType:      comment.AttachmentType,
 				AttachmentThumbnail: attachmentThumbnailLink,
+				MediaExpired:        comment.MediaExpired,
 				CreatedAt:           common.TimeToRPCString(comment.CreatedAt),
 				UpdatedAt:           common.TimeToRPCString(comment.UpdatedAt),
 				Likes:               int32(comment.Likes),
 				LikedByMe:           comment.LikedByMe,
+				ReplyToId:           comment.ReplyToID.String,
+				Depth:               int32(comment.Depth),
 			}
+			rpcCommentMap[comment.ID] = rpcComments[i]
 		}
 		rpcMessageMap[messageID].Comments = rpcComments
 	}
 
+	err = s.setReactions(ctx, user.ID, rpcMessageMap, rpcCommentMap)
+	if err != nil {
+		return nil, err
+	}
+	err = s.setPolls(ctx, user.ID, rpcMessageMap)
+	if err != nil {
+		return nil, err
+	}
+	err = s.setEvents(ctx, user, rpcMessageMap)
+	if err != nil {
+		return nil, err
+	}
+
 	return &rpc.MessageMessagesResponse{
 		Messages: rpcMessages,
 	}, nil
@@ -273,7 +552,7 @@ func (s *messageService) Message(ctx context.Context, r *rpc.MessageMessageReque
 		userIDs[rawUserID.(string)] = true
 	}
 
-	msg, err := s.repos.Message.Message(user.ID, r.MessageId)
+	msg, err := s.repos.Message.Message(ctx, user.ID, r.MessageId)
 	if err != nil {
 		if merry.Is(err, repo.ErrMessageNotFound) {
 			return nil, twirp.NotFoundError("message not found")
@@ -281,7 +560,7 @@ func (s *messageService) Message(ctx context.Context, r *rpc.MessageMessageReque
 		return nil, err
 	}
 
-	if !userIDs[msg.UserID] {
+	if !userIDs[msg.UserID] || (!msg.PublishedAt.Valid && msg.UserID != user.ID) {
 		return nil, twirp.NotFoundError("message not found")
 	}
 
@@ -302,32 +581,18 @@ func (s *messageService) Message(ctx context.Context, r *rpc.MessageMessageReque
 		Attachment:          attachmentLink,
 		AttachmentType:      msg.AttachmentType,
 		AttachmentThumbnail: attachmentThumbnailLink,
+		MediaExpired:        msg.MediaExpired,
 		CreatedAt:           common.TimeToRPCString(msg.CreatedAt),
 		UpdatedAt:           common.TimeToRPCString(msg.UpdatedAt),
 		Likes:               int32(msg.Likes),
 		LikedByMe:           msg.LikedByMe,
 	}
 
-	allLikes, err := s.repos.Message.MessagesLikes([]string{msg.ID})
-	if err != nil {
-		return nil, err
-	}
-	for _, likes := range allLikes {
-		rpcLikes := make([]*rpc.MessageLike, len(likes))
-		for i, like := range likes {
-			rpcLikes[i] = &rpc.MessageLike{
-				UserId:   like.UserID,
-				UserName: like.UserName,
-				LikedAt:  common.TimeToRPCString(like.CreatedAt),
-			}
-		}
-		rpcMessage.LikedBy = rpcLikes
-	}
-
-	comments, err := s.repos.Message.Comments(user.ID, []string{msg.ID})
+	comments, err := s.repos.Message.Comments(ctx, user.ID, []string{msg.ID})
 	if err != nil {
 		return nil, err
 	}
+	rpcCommentMap := make(map[string]*rpc.Message)
 	for _, messageComments := range comments {
 		rpcComments := make([]*rpc.Message, len(messageComments))
 		for i, comment := range messageComments {
@@ -348,15 +613,32 @@ func (s *messageService) Message(ctx context.Context, r *rpc.MessageMessageReque
 				Attachment:          attachmentLink,
 				AttachmentType:      comment.AttachmentType,
 				AttachmentThumbnail: attachmentThumbnailLink,
+				MediaExpired:        comment.MediaExpired,
 				CreatedAt:           common.TimeToRPCString(comment.CreatedAt),
 				UpdatedAt:           common.TimeToRPCString(comment.UpdatedAt),
 				Likes:               int32(comment.Likes),
 				LikedByMe:           comment.LikedByMe,
+				ReplyToId:           comment.ReplyToID.String,
+				Depth:               int32(comment.Depth),
 			}
+			rpcCommentMap[comment.ID] = rpcComments[i]
 		}
 		rpcMessage.Comments = rpcComments
 	}
 
+	err = s.setReactions(ctx, user.ID, map[string]*rpc.Message{msg.ID: rpcMessage}, rpcCommentMap)
+	if err != nil {
+		return nil, err
+	}
+	err = s.setPolls(ctx, user.ID, map[string]*rpc.Message{msg.ID: rpcMessage})
+	if err != nil {
+		return nil, err
+	}
+	err = s.setEvents(ctx, user, map[string]*rpc.Message{msg.ID: rpcMessage})
+	if err != nil {
+		return nil, err
+	}
+
 	return &rpc.MessageMessageResponse{
 		Message: rpcMessage,
 	}, nil
@@ -366,7 +648,7 @@ func (s *messageService) Edit(ctx context.Context, r *rpc.MessageEditRequest) (*
 	user := s.getUser(ctx)
 	now := common.CurrentTimestamp()
 	if r.TextChanged {
-		err := s.repos.Message.EditMessageText(user.ID, r.MessageId, r.Text, now)
+		err := s.repos.Message.EditMessageText(ctx, user.ID, r.MessageId, r.Text, now)
 		if err != nil {
 			if merry.Is(err, repo.ErrMessageNotFound) {
 				return nil, twirp.NotFoundError(err.Error())
@@ -380,7 +662,7 @@ func (s *messageService) Edit(ctx context.Context, r *rpc.MessageEditRequest) (*
 			return nil, err
 		}
 
-		err = s.repos.Message.EditMessageAttachment(user.ID, r.MessageId, r.AttachmentId, attachmentType, attachmentThumbnailID, now)
+		err = s.repos.Message.EditMessageAttachment(ctx, user.ID, r.MessageId, r.AttachmentId, attachmentType, attachmentThumbnailID, now)
 		if err != nil {
 			if merry.Is(err, repo.ErrMessageNotFound) {
 				return nil, twirp.NotFoundError(err.Error())
@@ -389,7 +671,7 @@ func (s *messageService) Edit(ctx context.Context, r *rpc.MessageEditRequest) (*
 		}
 	}
 
-	msg, err := s.repos.Message.Message(user.ID, r.MessageId)
+	msg, err := s.repos.Message.Message(ctx, user.ID, r.MessageId)
 	if err != nil {
 		if merry.Is(err, repo.ErrMessageNotFound) {
 			return nil, twirp.NotFoundError(err.Error())
@@ -415,6 +697,7 @@ func (s *messageService) Edit(ctx context.Context, r *rpc.MessageEditRequest) (*
 			Attachment:          attachmentLink,
 			AttachmentType:      msg.AttachmentType,
 			AttachmentThumbnail: attachmentThumbnailLink,
+			MediaExpired:        msg.MediaExpired,
 			CreatedAt:           common.TimeToRPCString(msg.CreatedAt),
 			UpdatedAt:           common.TimeToRPCString(msg.UpdatedAt),
 			Likes:               int32(msg.Likes),
@@ -426,7 +709,7 @@ func (s *messageService) Edit(ctx context.Context, r *rpc.MessageEditRequest) (*
 func (s *messageService) Delete(ctx context.Context, r *rpc.MessageDeleteRequest) (_ *rpc.Empty, err error) {
 	user := s.getUser(ctx)
 
-	err = s.repos.Message.DeleteMessage(user.ID, r.MessageId)
+	err = s.repos.Message.DeleteMessage(ctx, user.ID, r.MessageId)
 	if err != nil {
 		if merry.Is(err, repo.ErrMessageNotFound) {
 			return nil, twirp.NotFoundError(err.Error())
@@ -452,7 +735,7 @@ func (s *messageService) PostComment(ctx context.Context, r *rpc.MessagePostComm
 		return nil, twirp.NewError(twirp.InvalidArgument, "invalid token")
 	}
 
-	msg, err := s.repos.Message.Message(user.ID, r.MessageId)
+	msg, err := s.repos.Message.Message(ctx, user.ID, r.MessageId)
 	if err != nil {
 		if merry.Is(err, repo.ErrMessageNotFound) {
 			return nil, twirp.NotFoundError(err.Error())
@@ -474,6 +757,32 @@ func (s *messageService) PostComment(ctx context.Context, r *rpc.MessagePostComm
 		return nil, twirp.NotFoundError(repo.ErrMessageNotFound.Error())
 	}
 
+	if !msg.PublishedAt.Valid {
+		return nil, twirp.NotFoundError(repo.ErrMessageNotFound.Error())
+	}
+
+	if msg.ParentID.Valid {
+		return nil, twirp.InvalidArgumentError("message_id", "should be a post, use reply_to_id to reply to a comment")
+	}
+
+	var replyTo repo.Message
+	if r.ReplyToId != "" {
+		replyTo, err = s.repos.Message.Message(ctx, user.ID, r.ReplyToId)
+		if err != nil {
+			if merry.Is(err, repo.ErrMessageNotFound) {
+				return nil, twirp.NotFoundError("comment not found")
+			}
+			return nil, err
+		}
+		if replyTo.ParentID.String != msg.ID {
+			return nil, twirp.NotFoundError("comment not found")
+		}
+	}
+	depth, ok := replyDepth(replyTo, s.maxCommentDepth)
+	if !ok {
+		return nil, twirp.InvalidArgumentError("reply_to_id", "maximum reply depth is reached")
+	}
+
 	commentID, err := uuid.NewV4()
 	if err != nil {
 		return nil, err
@@ -495,37 +804,67 @@ func (s *messageService) PostComment(ctx context.Context, r *rpc.MessagePostComm
 		AttachmentThumbnailID: attachmentThumbnailID,
 		CreatedAt:             now,
 		UpdatedAt:             now,
+		PublishedAt:           sql.NullTime{Time: now, Valid: true},
 	}
-	err = s.repos.Message.AddMessage(r.MessageId, comment)
+	if replyTo.ID != "" {
+		comment.ReplyToID = sql.NullString{String: replyTo.ID, Valid: true}
+		comment.Depth = depth
+	}
+
+	userTags := message.FindUserTags(comment.Text)
+	users, err := s.repos.User.FindUsersByName(ctx, userTags)
 	if err != nil {
 		return nil, err
 	}
-
-	if user.ID != msg.UserID {
-		s.notificationSender.SendNotification([]string{msg.UserID}, user.Name+" posted a new comment", "comment/post", map[string]interface{}{
-			"user_id":    user.ID,
-			"message_id": msg.ID,
-			"comment_id": comment.ID,
-		})
-	}
-
-	userTags := message.FindUserTags(comment.Text)
-	users, err := s.repos.User.FindUsersByName(userTags)
-	if err != nil {
-		return nil, err
-	}
-
 	notifyUsers := make([]string, 0, len(users))
 	for _, u := range users {
 		if u.ID != comment.UserID {
 			notifyUsers = append(notifyUsers, u.ID)
 		}
 	}
-	s.notificationSender.SendNotification(notifyUsers, comment.UserName+" tagged you in a comment", "comment/tag", map[string]interface{}{
-		"user_id":    comment.UserID,
-		"message_id": msg.ID,
-		"comment_id": comment.ID,
+
+	err = common.RunInTransaction(ctx, s.repos.DB, func(tx *sqlx.Tx) error {
+		err := s.limiter.CheckComment(ctx, tx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		err = s.repos.Message.AddMessage(ctx, tx, r.MessageId, comment)
+		if err != nil {
+			return err
+		}
+
+		if replyTo.ID != "" && user.ID != replyTo.UserID {
+			err = s.notificationSender.EnqueueNotification(ctx, tx, []string{replyTo.UserID}, user.Name+" replied to your comment", "comment/reply", map[string]interface{}{
+				"user_id":     user.ID,
+				"message_id":  msg.ID,
+				"comment_id":  comment.ID,
+				"reply_to_id": replyTo.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if user.ID != msg.UserID && replyTo.UserID != msg.UserID {
+			err = s.notificationSender.EnqueueNotification(ctx, tx, []string{msg.UserID}, user.Name+" posted a new comment", "comment/post", map[string]interface{}{
+				"user_id":    user.ID,
+				"user_name":  user.Name,
+				"message_id": msg.ID,
+				"comment_id": comment.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return s.notificationSender.EnqueueNotification(ctx, tx, notifyUsers, comment.UserName+" tagged you in a comment", "comment/tag", map[string]interface{}{
+			"user_id":    comment.UserID,
+			"message_id": msg.ID,
+			"comment_id": comment.ID,
+		})
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	attachmentLink, err := s.createBlobLink(ctx, comment.AttachmentID)
 	if err != nil {
@@ -550,6 +889,8 @@ func (s *messageService) PostComment(ctx context.Context, r *rpc.MessagePostComm
 			UpdatedAt:           common.TimeToRPCString(comment.UpdatedAt),
 			Likes:               int32(comment.Likes),
 			LikedByMe:           comment.LikedByMe,
+			ReplyToId:           comment.ReplyToID.String,
+			Depth:               int32(comment.Depth),
 		},
 	}, nil
 }
@@ -558,7 +899,7 @@ func (s *messageService) EditComment(ctx context.Context, r *rpc.MessageEditComm
 	user := s.getUser(ctx)
 	now := common.CurrentTimestamp()
 	if r.TextChanged {
-		err := s.repos.Message.EditMessageText(user.ID, r.CommentId, r.Text, now)
+		err := s.repos.Message.EditMessageText(ctx, user.ID, r.CommentId, r.Text, now)
 		if err != nil {
 			if merry.Is(err, repo.ErrMessageNotFound) {
 				return nil, twirp.NotFoundError("comment not found")
@@ -572,7 +913,7 @@ func (s *messageService) EditComment(ctx context.Context, r *rpc.MessageEditComm
 			return nil, err
 		}
 
-		err = s.repos.Message.EditMessageAttachment(user.ID, r.CommentId, r.AttachmentId, attachmentType, attachmentThumbnailID, now)
+		err = s.repos.Message.EditMessageAttachment(ctx, user.ID, r.CommentId, r.AttachmentId, attachmentType, attachmentThumbnailID, now)
 		if err != nil {
 			if merry.Is(err, repo.ErrMessageNotFound) {
 				return nil, twirp.NotFoundError(err.Error())
@@ -581,7 +922,7 @@ func (s *messageService) EditComment(ctx context.Context, r *rpc.MessageEditComm
 		}
 	}
 
-	comment, err := s.repos.Message.Message(user.ID, r.CommentId)
+	comment, err := s.repos.Message.Message(ctx, user.ID, r.CommentId)
 	if err != nil {
 		if merry.Is(err, repo.ErrMessageNotFound) {
 			return nil, twirp.NotFoundError("comment not found")
@@ -608,17 +949,20 @@ func (s *messageService) EditComment(ctx context.Context, r *rpc.MessageEditComm
 			Attachment:          attachmentLink,
 			AttachmentType:      comment.AttachmentType,
 			AttachmentThumbnail: attachmentThumbnailLink,
+			MediaExpired:        comment.MediaExpired,
 			CreatedAt:           common.TimeToRPCString(comment.CreatedAt),
 			UpdatedAt:           common.TimeToRPCString(comment.UpdatedAt),
 			Likes:               int32(comment.Likes),
 			LikedByMe:           comment.LikedByMe,
+			ReplyToId:           comment.ReplyToID.String,
+			Depth:               int32(comment.Depth),
 		},
 	}, nil
 }
 
 func (s *messageService) DeleteComment(ctx context.Context, r *rpc.MessageDeleteCommentRequest) (_ *rpc.Empty, err error) {
 	user := s.getUser(ctx)
-	err = s.repos.Message.DeleteMessage(user.ID, r.CommentId)
+	err = s.repos.Message.DeleteMessage(ctx, user.ID, r.CommentId)
 	if err != nil {
 		if merry.Is(err, repo.ErrMessageNotFound) {
 			return nil, twirp.NotFoundError("comment not found")
@@ -628,12 +972,12 @@ func (s *messageService) DeleteComment(ctx context.Context, r *rpc.MessageDelete
 	return &rpc.Empty{}, nil
 }
 
-func (s *messageService) getAttachmentType(ctx context.Context, attachmentID string) (string, error) {
+func (s *BaseService) getAttachmentType(ctx context.Context, attachmentID string) (string, error) {
 	if attachmentID == "" {
 		return "", nil
 	}
 
-	buf, err := s.s3Storage.ReadN(ctx, attachmentID, fileTypeBufSize)
+	buf, err := s.blobStorage.ReadN(ctx, attachmentID, fileTypeBufSize)
 	if err != nil {
 		return "", merry.Wrap(err)
 	}
@@ -644,7 +988,7 @@ func (s *messageService) getAttachmentType(ctx context.Context, attachmentID str
 	return t.MIME.Value, nil
 }
 
-func (s *messageService) getAttachmentThumbnailID(ctx context.Context, attachmentID, attachmentType string) (string, error) {
+func (s *BaseService) getAttachmentThumbnailID(ctx context.Context, attachmentID, attachmentType string) (string, error) {
 	if strings.HasPrefix(attachmentType, "image/") {
 		return attachmentID, nil
 	}
@@ -657,7 +1001,7 @@ func (s *messageService) getAttachmentThumbnailID(ctx context.Context, attachmen
 	if err != nil {
 		return "", merry.Wrap(err)
 	}
-	thumbnail, err := common.VideoThumbnail(link)
+	thumbnail, err := common.VideoThumbnail(ctx, link)
 	if err != nil {
 		return "", merry.Wrap(err)
 	}
@@ -667,17 +1011,68 @@ func (s *messageService) getAttachmentThumbnailID(ctx context.Context, attachmen
 
 	ext := filepath.Ext(attachmentID)
 	attachmentThumbnailID := strings.TrimSuffix(attachmentID, ext) + "-thumbnail.jpg"
-	err = s.s3Storage.PutObject(ctx, attachmentThumbnailID, thumbnail, "image/jpeg")
+	err = s.blobStorage.PutObject(ctx, attachmentThumbnailID, thumbnail, "image/jpeg")
 	if err != nil {
 		return "", err
 	}
 	return attachmentThumbnailID, nil
 }
 
+// RegenerateThumbnails creates thumbnails of existing attachments, e.g. of videos uploaded while ffmpeg was missing.
+// Unless all is set, only attachments without a thumbnail are processed. Failed attachments are logged and skipped.
+func (s *BaseService) RegenerateThumbnails(ctx context.Context, all bool) (updated, failed int, err error) {
+	attachments, err := s.repos.Maintenance.MediaAttachments(ctx, !all)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, attachment := range attachments {
+		if ctx.Err() != nil {
+			return updated, failed, merry.Wrap(ctx.Err())
+		}
+
+		log := logging.FromContext(ctx).WithField("attachment_id", attachment.AttachmentID)
+		attachmentThumbnailID, err := s.getAttachmentThumbnailID(ctx, attachment.AttachmentID, attachment.AttachmentType)
+		if err != nil {
+			log.WithError(err).Error("can't create thumbnail")
+			failed++
+			continue
+		}
+		if attachmentThumbnailID == "" {
+			log.Warn("video has no frames for a thumbnail")
+			failed++
+			continue
+		}
+		if attachmentThumbnailID != attachment.AttachmentID {
+			err = s.recordBlob(ctx, attachment.UserID, attachmentThumbnailID)
+			if err != nil {
+				return updated, failed, err
+			}
+		}
+		err = s.repos.Maintenance.SetAttachmentThumbnail(ctx, attachment, attachmentThumbnailID)
+		if err != nil {
+			return updated, failed, err
+		}
+		updated++
+	}
+	return updated, failed, nil
+}
+
+// replyDepth returns the depth of a new comment, replyTo is empty for a top-level comment.
+func replyDepth(replyTo repo.Message, maxDepth int) (depth int, ok bool) {
+	if replyTo.ID == "" {
+		return 0, true
+	}
+	if replyTo.Depth >= maxDepth {
+		return 0, false
+	}
+	return replyTo.Depth + 1, true
+}
+
 func (s *messageService) LikeMessage(ctx context.Context, r *rpc.MessageLikeMessageRequest) (*rpc.MessageLikeMessageResponse, error) {
 	user := s.getUser(ctx)
 
-	msg, err := s.repos.Message.Message(user.ID, r.MessageId)
+	msg, err := s.repos.Message.Message(ctx, user.ID, r.MessageId)
 	if err != nil {
 		if !merry.Is(err, repo.ErrMessageNotFound) {
 			return nil, err
@@ -691,14 +1086,17 @@ func (s *messageService) LikeMessage(ctx context.Context, r *rpc.MessageLikeMess
 		return nil, twirp.InvalidArgumentError("message_id", "is not a message")
 	}
 
-	newLikeCount, err := s.repos.Message.LikeMessage(user.ID, msg.ID)
+	if !msg.PublishedAt.Valid {
+		return &rpc.MessageLikeMessageResponse{
+			Likes: -1,
+		}, nil
+	}
+
+	newLikeCount, err := s.repos.Message.LikeMessage(ctx, user.ID, msg.ID)
 	if err != nil {
 		return nil, err
 	}
-	s.notificationSender.SendNotification([]string{msg.UserID}, user.Name+" liked your post", "message/like", map[string]interface{}{
-		"user_id":    user.ID,
-		"message_id": msg.ID,
-	})
+	s.reactionNotifier.Notify(msg.UserID, msg.ID, "", user, repo.LikeReaction)
 	return &rpc.MessageLikeMessageResponse{
 		Likes: int32(newLikeCount),
 	}, nil
@@ -707,7 +1105,7 @@ func (s *messageService) LikeMessage(ctx context.Context, r *rpc.MessageLikeMess
 func (s *messageService) LikeComment(ctx context.Context, r *rpc.MessageLikeCommentRequest) (*rpc.MessageLikeCommentResponse, error) {
 	user := s.getUser(ctx)
 
-	comment, err := s.repos.Message.Message(user.ID, r.CommentId)
+	comment, err := s.repos.Message.Message(ctx, user.ID, r.CommentId)
 	if err != nil {
 		if !merry.Is(err, repo.ErrMessageNotFound) {
 			return nil, err
@@ -721,57 +1119,57 @@ func (s *messageService) LikeComment(ctx context.Context, r *rpc.MessageLikeComm
 		return nil, twirp.InvalidArgumentError("comment_id", "is not a comment")
 	}
 
-	newLikeCount, err := s.repos.Message.LikeMessage(user.ID, comment.ID)
+	newLikeCount, err := s.repos.Message.LikeMessage(ctx, user.ID, comment.ID)
 	if err != nil {
 		return nil, err
 	}
-	s.notificationSender.SendNotification([]string{comment.UserID}, user.Name+" liked your comment", "comment/like", map[string]interface{}{
-		"user_id":    user.ID,
-		"message_id": comment.ParentID.String,
-		"comment_id": comment.ID,
-	})
+	s.reactionNotifier.Notify(comment.UserID, comment.ParentID.String, comment.ID, user, repo.LikeReaction)
 	return &rpc.MessageLikeCommentResponse{
 		Likes: int32(newLikeCount),
 	}, nil
 }
 
-func (s *messageService) MessageLikes(_ context.Context, r *rpc.MessageMessageLikesRequest) (*rpc.MessageMessageLikesResponse, error) {
-	likes, err := s.repos.Message.MessageLikes(r.MessageId)
+func (s *messageService) MessageLikes(ctx context.Context, r *rpc.MessageMessageLikesRequest) (*rpc.MessageMessageLikesResponse, error) {
+	reactions, err := s.repos.Message.MessageReactions(ctx, r.MessageId)
 	if err != nil {
 		return nil, err
 	}
-	rpcLikes := make([]*rpc.MessageLike, len(likes))
-	for i, like := range likes {
-		rpcLikes[i] = &rpc.MessageLike{
-			UserId:   like.UserID,
-			UserName: like.UserName,
-			LikedAt:  common.TimeToRPCString(like.CreatedAt),
-		}
-	}
 	return &rpc.MessageMessageLikesResponse{
-		Likes: rpcLikes,
+		Likes: likesToRPC(reactions),
 	}, nil
 }
 
-func (s *messageService) CommentLikes(_ context.Context, r *rpc.MessageCommentLikesRequest) (*rpc.MessageCommentLikesResponse, error) {
-	likes, err := s.repos.Message.MessageLikes(r.CommentId)
+func (s *messageService) CommentLikes(ctx context.Context, r *rpc.MessageCommentLikesRequest) (*rpc.MessageCommentLikesResponse, error) {
+	reactions, err := s.repos.Message.MessageReactions(ctx, r.CommentId)
 	if err != nil {
 		return nil, err
 	}
-	rpcLikes := make([]*rpc.MessageLike, len(likes))
-	for i, like := range likes {
-		rpcLikes[i] = &rpc.MessageLike{
-			UserId:   like.UserID,
-			UserName: like.UserName,
-			LikedAt:  common.TimeToRPCString(like.CreatedAt),
-		}
-	}
 	return &rpc.MessageCommentLikesResponse{
-		Likes: rpcLikes,
+		Likes: likesToRPC(reactions),
 	}, nil
 }
 
-func (s *messageService) processAttachment(ctx context.Context, attachmentID string) (attachmentThumbnailID, attachmentType string, err error) {
+func (s *BaseService) processAttachment(ctx context.Context, attachmentID string) (attachmentThumbnailID, attachmentType string, err error) {
+	attachmentThumbnailID, attachmentType, err = s.inspectAttachment(ctx, attachmentID)
+	if err != nil {
+		return "", "", err
+	}
+
+	user := s.getUser(ctx)
+	err = s.recordBlob(ctx, user.ID, attachmentID)
+	if err != nil {
+		return "", "", err
+	}
+	if attachmentThumbnailID != attachmentID {
+		err = s.recordBlob(ctx, user.ID, attachmentThumbnailID)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	return attachmentThumbnailID, attachmentType, nil
+}
+
+func (s *BaseService) inspectAttachment(ctx context.Context, attachmentID string) (attachmentThumbnailID, attachmentType string, err error) {
 	attachmentType, err = s.getAttachmentType(ctx, attachmentID)
 	if err != nil {
 		return "", "", err
@@ -787,9 +1185,9 @@ func (s *messageService) processAttachment(ctx context.Context, attachmentID str
 	}
 
 	var buf bytes.Buffer
-	err = s.s3Storage.Read(ctx, attachmentID, &buf)
+	err = s.blobStorage.Read(ctx, attachmentID, &buf)
 	if err != nil {
-		log.Println("can't read attachment:", err)
+		logging.FromContext(ctx).WithError(err).Error("can't read attachment")
 		return attachmentThumbnailID, attachmentType, nil
 	}
 
@@ -800,7 +1198,7 @@ func (s *messageService) processAttachment(ctx context.Context, attachmentID str
 	if img, err := common.DecodeImageAndFixOrientation(bytes.NewReader(buf.Bytes()), orientation); err == nil {
 		buf.Reset()
 		if err := jpeg.Encode(&buf, img, nil); err == nil {
-			_ = s.s3Storage.PutObject(ctx, attachmentID, buf.Bytes(), attachmentType)
+			_ = s.blobStorage.PutObject(ctx, attachmentID, buf.Bytes(), attachmentType)
 		}
 	}
 	return attachmentThumbnailID, attachmentType, nil
@@ -808,7 +1206,7 @@ func (s *messageService) processAttachment(ctx context.Context, attachmentID str
 
 func (s *messageService) SetMessageVisibility(ctx context.Context, r *rpc.MessageSetMessageVisibilityRequest) (*rpc.Empty, error) {
 	user := s.getUser(ctx)
-	err := s.repos.Message.SetMessageVisibility(user.ID, r.MessageId, r.Visibility)
+	err := s.repos.Message.SetMessageVisibility(ctx, user.ID, r.MessageId, r.Visibility)
 	if err != nil {
 		return nil, err
 	}
@@ -817,9 +1215,552 @@ func (s *messageService) SetMessageVisibility(ctx context.Context, r *rpc.Messag
 
 func (s *messageService) SetCommentVisibility(ctx context.Context, r *rpc.MessageSetCommentVisibilityRequest) (*rpc.Empty, error) {
 	user := s.getUser(ctx)
-	err := s.repos.Message.SetMessageVisibility(user.ID, r.CommentId, r.Visibility)
+	err := s.repos.Message.SetMessageVisibility(ctx, user.ID, r.CommentId, r.Visibility)
 	if err != nil {
 		return nil, err
 	}
 	return &rpc.Empty{}, nil
 }
+
+func (s *messageService) AvailableReactions(context.Context, *rpc.Empty) (*rpc.MessageAvailableReactionsResponse, error) {
+	return &rpc.MessageAvailableReactionsResponse{
+		Reactions: s.reactions,
+	}, nil
+}
+
+func (s *messageService) AddReaction(ctx context.Context, r *rpc.MessageAddReactionRequest) (*rpc.MessageReactionResponse, error) {
+	user := s.getUser(ctx)
+
+	if !s.isReactionAllowed(r.Reaction) {
+		return nil, twirp.InvalidArgumentError("reaction", "is not supported")
+	}
+
+	msg, err := s.repos.Message.Message(ctx, user.ID, r.MessageId)
+	if err != nil {
+		if merry.Is(err, repo.ErrMessageNotFound) {
+			return nil, twirp.NotFoundError(err.Error())
+		}
+		return nil, err
+	}
+	if !msg.PublishedAt.Valid {
+		return nil, twirp.NotFoundError(repo.ErrMessageNotFound.Error())
+	}
+
+	err = s.repos.Message.AddReaction(ctx, user.ID, msg.ID, r.Reaction)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.ParentID.Valid {
+		s.reactionNotifier.Notify(msg.UserID, msg.ParentID.String, msg.ID, user, r.Reaction)
+	} else {
+		s.reactionNotifier.Notify(msg.UserID, msg.ID, "", user, r.Reaction)
+	}
+
+	return s.reactionResponse(ctx, user.ID, msg.ID)
+}
+
+func (s *messageService) RemoveReaction(ctx context.Context, r *rpc.MessageRemoveReactionRequest) (*rpc.MessageReactionResponse, error) {
+	user := s.getUser(ctx)
+
+	err := s.repos.Message.RemoveReaction(ctx, user.ID, r.MessageId, r.Reaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reactionResponse(ctx, user.ID, r.MessageId)
+}
+
+func (s *messageService) MessageReactions(ctx context.Context, r *rpc.MessageMessageReactionsRequest) (*rpc.MessageMessageReactionsResponse, error) {
+	reactions, err := s.repos.Message.MessageReactions(ctx, r.MessageId)
+	if err != nil {
+		return nil, err
+	}
+	rpcReactions := make([]*rpc.MessageLike, len(reactions))
+	for i, reaction := range reactions {
+		rpcReactions[i] = reactionToRPC(reaction)
+	}
+	return &rpc.MessageMessageReactionsResponse{
+		Reactions: rpcReactions,
+	}, nil
+}
+
+func (s *messageService) isReactionAllowed(reaction string) bool {
+	return containsString(s.reactions, reaction)
+}
+
+func (s *messageService) reactionResponse(ctx context.Context, userID, messageID string) (*rpc.MessageReactionResponse, error) {
+	reactions, err := s.repos.Message.MessageReactions(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	counts, myReactions := reactionSummary(userID, reactions)
+	return &rpc.MessageReactionResponse{
+		Reactions:   counts,
+		MyReactions: myReactions,
+	}, nil
+}
+
+func (s *messageService) setReactions(ctx context.Context, userID string, rpcMessages, rpcComments map[string]*rpc.Message) error {
+	messageIDs := make([]string, 0, len(rpcMessages)+len(rpcComments))
+	for id := range rpcMessages {
+		messageIDs = append(messageIDs, id)
+	}
+	for id := range rpcComments {
+		messageIDs = append(messageIDs, id)
+	}
+
+	allReactions, err := s.repos.Message.MessagesReactions(ctx, messageIDs)
+	if err != nil {
+		return err
+	}
+	for messageID, reactions := range allReactions {
+		rpcMessage, ok := rpcMessages[messageID]
+		if !ok {
+			rpcMessage = rpcComments[messageID]
+		}
+		rpcMessage.LikedBy = likesToRPC(reactions)
+		rpcMessage.Reactions, rpcMessage.MyReactions = reactionSummary(userID, reactions)
+	}
+	return nil
+}
+
+func reactionSummary(userID string, reactions []repo.MessageReaction) (counts []*rpc.MessageReactionCount, myReactions []string) {
+	countMap := make(map[string]*rpc.MessageReactionCount)
+	for _, reaction := range reactions {
+		count, ok := countMap[reaction.Reaction]
+		if !ok {
+			count = &rpc.MessageReactionCount{Reaction: reaction.Reaction}
+			countMap[reaction.Reaction] = count
+			counts = append(counts, count)
+		}
+		count.Count++
+		if reaction.UserID == userID {
+			myReactions = append(myReactions, reaction.Reaction)
+		}
+	}
+	return counts, myReactions
+}
+
+func likesToRPC(reactions []repo.MessageReaction) []*rpc.MessageLike {
+	rpcLikes := make([]*rpc.MessageLike, 0, len(reactions))
+	for _, reaction := range reactions {
+		if reaction.Reaction == repo.LikeReaction {
+			rpcLikes = append(rpcLikes, reactionToRPC(reaction))
+		}
+	}
+	return rpcLikes
+}
+
+func reactionToRPC(reaction repo.MessageReaction) *rpc.MessageLike {
+	return &rpc.MessageLike{
+		UserId:   reaction.UserID,
+		UserName: reaction.UserName,
+		LikedAt:  common.TimeToRPCString(reaction.CreatedAt),
+		Reaction: reaction.Reaction,
+	}
+}
+
+func (s *messageService) Vote(ctx context.Context, r *rpc.MessageVoteRequest) (*rpc.MessageVoteResponse, error) {
+	user := s.getUser(ctx)
+
+	msg, err := s.visibleMessage(ctx, user, r.Token, r.MessageId)
+	if err != nil {
+		return nil, err
+	}
+
+	poll, err := s.repos.Poll.Poll(ctx, user.ID, msg.ID)
+	if err != nil {
+		if merry.Is(err, repo.ErrPollNotFound) {
+			return nil, twirp.NotFoundError(err.Error())
+		}
+		return nil, err
+	}
+
+	if isPollClosed(poll, common.CurrentTimestamp()) {
+		return nil, twirp.NewError(twirp.FailedPrecondition, "poll is closed")
+	}
+	if len(r.OptionIds) > 1 && !poll.MultipleChoice {
+		return nil, twirp.InvalidArgumentError("option_ids", "only one option can be chosen")
+	}
+
+	optionIDs := make([]string, 0, len(r.OptionIds))
+	for _, optionID := range r.OptionIds {
+		if !containsString(optionIDs, optionID) {
+			optionIDs = append(optionIDs, optionID)
+		}
+	}
+
+	err = s.repos.Poll.Vote(ctx, user.ID, msg.ID, optionIDs)
+	if err != nil {
+		if merry.Is(err, repo.ErrPollOptionInvalid) {
+			return nil, twirp.InvalidArgumentError("option_ids", err.Error())
+		}
+		return nil, err
+	}
+
+	poll, err = s.repos.Poll.Poll(ctx, user.ID, msg.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.MessageVoteResponse{
+		Poll: pollToRPC(poll),
+	}, nil
+}
+
+func (s *messageService) Rsvp(ctx context.Context, r *rpc.MessageRsvpRequest) (*rpc.MessageRsvpResponse, error) {
+	user := s.getUser(ctx)
+
+	if r.Status != repo.RSVPYes && r.Status != repo.RSVPNo && r.Status != repo.RSVPMaybe {
+		return nil, twirp.InvalidArgumentError("status", "should be yes, no or maybe")
+	}
+
+	msg, err := s.visibleMessage(ctx, user, r.Token, r.MessageId)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.repos.Event.Event(ctx, user.ID, msg.ID)
+	if err != nil {
+		if merry.Is(err, repo.ErrEventNotFound) {
+			return nil, twirp.NotFoundError(err.Error())
+		}
+		return nil, err
+	}
+
+	endsAt := event.StartsAt
+	if event.EndsAt.Valid {
+		endsAt = event.EndsAt.Time
+	}
+	if endsAt.Before(common.CurrentTimestamp()) {
+		return nil, twirp.NewError(twirp.FailedPrecondition, "event is over")
+	}
+
+	err = s.repos.Event.SetRSVP(ctx, user.ID, msg.ID, r.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.UserID != user.ID && r.Status != event.MyRSVP {
+		s.notificationSender.SendNotification(ctx, []string{msg.UserID}, user.Name+" replied "+r.Status+" to your event", "event/rsvp", map[string]interface{}{
+			"user_id":    user.ID,
+			"user_name":  user.Name,
+			"message_id": msg.ID,
+			"status":     r.Status,
+		})
+	}
+
+	event, err = s.repos.Event.Event(ctx, user.ID, msg.ID)
+	if err != nil {
+		return nil, err
+	}
+	icsToken, err := s.eventCalendarToken(user, []string{msg.ID})
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.MessageRsvpResponse{
+		Event: s.eventToRPC(event, icsToken),
+	}, nil
+}
+
+func (s *messageService) CalendarFeed(ctx context.Context, _ *rpc.Empty) (*rpc.MessageCalendarFeedResponse, error) {
+	user := s.getUser(ctx)
+
+	version, err := s.repos.Event.CalendarFeedVersion(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	link, err := s.calendarFeedLink(user, version)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.MessageCalendarFeedResponse{
+		Link: link,
+	}, nil
+}
+
+// RotateCalendarFeed replaces the calendar feed link, the old links stop working.
+func (s *messageService) RotateCalendarFeed(ctx context.Context, _ *rpc.Empty) (*rpc.MessageCalendarFeedResponse, error) {
+	user := s.getUser(ctx)
+
+	version, err := s.repos.Event.RotateCalendarFeed(ctx, user.ID, common.CurrentTimestamp())
+	if err != nil {
+		return nil, err
+	}
+	link, err := s.calendarFeedLink(user, version)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.MessageCalendarFeedResponse{
+		Link: link,
+	}, nil
+}
+
+// calendarFeedLink is valid while the version of the calendar feed of the user is the same.
+func (s *messageService) calendarFeedLink(user User, version int) (string, error) {
+	feedToken, err := s.tokenGenerator.Generate(user.ID, user.Name, "calendar-feed",
+		common.CurrentTimestamp().Add(calendarFeedTokenDuration),
+		map[string]interface{}{
+			"version": version,
+		})
+	if err != nil {
+		return "", merry.Wrap(err)
+	}
+	return s.externalAddress + "/calendar/feed.ics?token=" + url.QueryEscape(feedToken), nil
+}
+
+// visibleMessage returns a published message whose author is listed in the get-messages token.
+func (s *messageService) visibleMessage(ctx context.Context, user User, rawToken, messageID string) (repo.Message, error) {
+	_, claims, err := s.tokenParser.Parse(rawToken, "get-messages")
+	if err != nil {
+		if merry.Is(err, token.ErrInvalidToken) {
+			return repo.Message{}, twirp.NewError(twirp.InvalidArgument, "invalid token")
+		}
+		return repo.Message{}, err
+	}
+
+	if user.ID != claims["id"].(string) ||
+		strings.TrimSuffix(s.externalAddress, "/") != strings.TrimSuffix(claims["hub"].(string), "/") {
+		return repo.Message{}, twirp.NewError(twirp.InvalidArgument, "invalid token")
+	}
+
+	msg, err := s.repos.Message.Message(ctx, user.ID, messageID)
+	if err != nil {
+		if merry.Is(err, repo.ErrMessageNotFound) {
+			return repo.Message{}, twirp.NotFoundError(err.Error())
+		}
+		return repo.Message{}, err
+	}
+
+	rawUserIDs := claims["users"].([]interface{})
+	found := false
+	for _, rawUserID := range rawUserIDs {
+		if rawUserID.(string) == msg.UserID {
+			found = true
+			break
+		}
+	}
+	if !found || !msg.PublishedAt.Valid {
+		return repo.Message{}, twirp.NotFoundError(repo.ErrMessageNotFound.Error())
+	}
+	return msg, nil
+}
+
+func (s *messageService) setPolls(ctx context.Context, userID string, rpcMessages map[string]*rpc.Message) error {
+	messageIDs := make([]string, 0, len(rpcMessages))
+	for messageID := range rpcMessages {
+		messageIDs = append(messageIDs, messageID)
+	}
+
+	polls, err := s.repos.Poll.Polls(ctx, userID, messageIDs)
+	if err != nil {
+		return err
+	}
+	for messageID, poll := range polls {
+		rpcMessages[messageID].Poll = pollToRPC(poll)
+	}
+	return nil
+}
+
+func newPoll(r *rpc.MessagePostPoll, publishAt time.Time) (repo.Poll, error) {
+	options := make([]repo.PollOption, 0, len(r.Options))
+	for _, text := range r.Options {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return repo.Poll{}, twirp.InvalidArgumentError("poll.options", "shouldn't be empty")
+		}
+		optionID, err := uuid.NewV4()
+		if err != nil {
+			return repo.Poll{}, err
+		}
+		options = append(options, repo.PollOption{
+			ID:   optionID.String(),
+			Text: text,
+		})
+	}
+	if len(options) < 2 {
+		return repo.Poll{}, twirp.InvalidArgumentError("poll.options", "should contain at least two options")
+	}
+
+	poll := repo.Poll{
+		MultipleChoice: r.MultipleChoice,
+		Anonymous:      r.Anonymous,
+		Options:        options,
+	}
+	if r.ClosesAt != "" {
+		closesAt, err := common.RPCStringToTime(r.ClosesAt)
+		if err != nil {
+			return repo.Poll{}, twirp.InvalidArgumentError("poll.closes_at", err.Error())
+		}
+		if !closesAt.After(publishAt) {
+			return repo.Poll{}, twirp.InvalidArgumentError("poll.closes_at", "should be after the publish time")
+		}
+		poll.ClosesAt = sql.NullTime{Time: closesAt, Valid: true}
+	}
+	return poll, nil
+}
+
+func isPollClosed(poll repo.Poll, now time.Time) bool {
+	return poll.ClosedAt.Valid || (poll.ClosesAt.Valid && !poll.ClosesAt.Time.After(now))
+}
+
+func pollToRPC(poll repo.Poll) *rpc.Poll {
+	rpcOptions := make([]*rpc.PollOption, len(poll.Options))
+	for i, option := range poll.Options {
+		rpcVoters := make([]*rpc.User, len(option.Voters))
+		for j, voter := range option.Voters {
+			rpcVoters[j] = &rpc.User{
+				Id:   voter.UserID,
+				Name: voter.UserName,
+			}
+		}
+		rpcOptions[i] = &rpc.PollOption{
+			Id:        option.ID,
+			Text:      option.Text,
+			Votes:     int32(option.Votes),
+			VotedByMe: option.VotedByMe,
+			Voters:    rpcVoters,
+		}
+	}
+
+	rpcPoll := &rpc.Poll{
+		Options:        rpcOptions,
+		MultipleChoice: poll.MultipleChoice,
+		Anonymous:      poll.Anonymous,
+		IsClosed:       isPollClosed(poll, common.CurrentTimestamp()),
+		TotalVoters:    int32(poll.TotalVoters),
+	}
+	if poll.ClosesAt.Valid {
+		rpcPoll.ClosesAt = common.TimeToRPCString(poll.ClosesAt.Time)
+	}
+	return rpcPoll
+}
+
+func (s *messageService) setEvents(ctx context.Context, user User, rpcMessages map[string]*rpc.Message) error {
+	messageIDs := make([]string, 0, len(rpcMessages))
+	for messageID := range rpcMessages {
+		messageIDs = append(messageIDs, messageID)
+	}
+
+	events, err := s.repos.Event.Events(ctx, user.ID, messageIDs)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	eventIDs := make([]string, 0, len(events))
+	for messageID := range events {
+		eventIDs = append(eventIDs, messageID)
+	}
+	icsToken, err := s.eventCalendarToken(user, eventIDs)
+	if err != nil {
+		return err
+	}
+	for messageID, event := range events {
+		rpcMessages[messageID].Event = s.eventToRPC(event, icsToken)
+	}
+	return nil
+}
+
+// eventCalendarToken is shared by the .ics links of the events listed in one response.
+func (s *messageService) eventCalendarToken(user User, messageIDs []string) (string, error) {
+	sort.Strings(messageIDs)
+	return s.tokenGenerator.Generate(user.ID, user.Name, "event-calendar",
+		common.CurrentTimestamp().Add(time.Hour*24),
+		map[string]interface{}{
+			"message_ids": messageIDs,
+		})
+}
+
+func (s *messageService) eventToRPC(event repo.Event, icsToken string) *rpc.Event {
+	rpcRSVPs := make([]*rpc.EventRsvp, len(event.RSVPs))
+	for i, rsvp := range event.RSVPs {
+		rpcRSVPs[i] = &rpc.EventRsvp{
+			UserId:   rsvp.UserID,
+			UserName: rsvp.UserName,
+			Status:   rsvp.Status,
+		}
+	}
+
+	return &rpc.Event{
+		Title:       event.Title,
+		StartsAt:    common.TimeToRPCString(event.StartsAt),
+		EndsAt:      common.NullTimeToRPCString(event.EndsAt),
+		Location:    event.Location,
+		Description: event.Description,
+		MyRsvp:      event.MyRSVP,
+		Rsvps:       rpcRSVPs,
+		IcsLink:     s.externalAddress + "/calendar/event/" + event.MessageID + ".ics?token=" + url.QueryEscape(icsToken),
+	}
+}
+
+func newEvent(r *rpc.MessagePostEvent) (repo.Event, error) {
+	title := strings.TrimSpace(r.Title)
+	if title == "" {
+		return repo.Event{}, twirp.InvalidArgumentError("event.title", "is empty")
+	}
+	if r.StartsAt == "" {
+		return repo.Event{}, twirp.InvalidArgumentError("event.starts_at", "is empty")
+	}
+	startsAt, err := common.RPCStringToTime(r.StartsAt)
+	if err != nil {
+		return repo.Event{}, twirp.InvalidArgumentError("event.starts_at", err.Error())
+	}
+
+	event := repo.Event{
+		Title:       title,
+		StartsAt:    startsAt,
+		Location:    strings.TrimSpace(r.Location),
+		Description: r.Description,
+	}
+	if r.EndsAt != "" {
+		endsAt, err := common.RPCStringToTime(r.EndsAt)
+		if err != nil {
+			return repo.Event{}, twirp.InvalidArgumentError("event.ends_at", err.Error())
+		}
+		if !endsAt.After(startsAt) {
+			return repo.Event{}, twirp.InvalidArgumentError("event.ends_at", "should be after starts_at")
+		}
+		event.EndsAt = sql.NullTime{Time: endsAt, Valid: true}
+	}
+	return event, nil
+}
+
+func (s *messageService) SetDeleteExpiredPosts(ctx context.Context, r *rpc.MessageSetDeleteExpiredPostsRequest) (*rpc.Empty, error) {
+	user := s.getUser(ctx)
+	err := s.repos.Retention.SetDeleteExpiredPosts(ctx, user.ID, r.Enabled)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.Empty{}, nil
+}
+
+func (s *messageService) PostingLimits(ctx context.Context, _ *rpc.Empty) (*rpc.MessagePostingLimitsResponse, error) {
+	user := s.getUser(ctx)
+	usage, err := s.limiter.Usage(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	limits := s.limiter.Limits()
+	return &rpc.MessagePostingLimitsResponse{
+		PostsPerDay:              int32(limits.PostsPerDay),
+		PostsRemaining:           int32(remaining(int64(limits.PostsPerDay), int64(usage.Posts))),
+		CommentsPerHour:          int32(limits.CommentsPerHour),
+		CommentsRemaining:        int32(remaining(int64(limits.CommentsPerHour), int64(usage.Comments))),
+		AttachmentBytesPerDay:    limits.AttachmentBytesPerDay,
+		AttachmentBytesRemaining: remaining(limits.AttachmentBytesPerDay, usage.AttachmentBytes),
+	}, nil
+}
+
+// remaining returns -1 for unlimited values.
+func remaining(limit, used int64) int64 {
+	if limit <= 0 {
+		return -1
+	}
+	if used >= limit {
+		return 0
+	}
+	return limit - used
+}
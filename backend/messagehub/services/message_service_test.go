@@ -0,0 +1,76 @@
+package services
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/messagehub/repo"
+	"github.com/mreider/koto/backend/messagehub/rpc"
+)
+
+func TestReplyDepth(t *testing.T) {
+	tests := []struct {
+		replyTo repo.Message
+		depth   int
+		ok      bool
+	}{
+		{repo.Message{}, 0, true},
+		{repo.Message{ID: "1", Depth: 0}, 1, true},
+		{repo.Message{ID: "1", Depth: 2}, 3, true},
+		{repo.Message{ID: "1", Depth: 3}, 0, false},
+		{repo.Message{ID: "1", Depth: 4}, 0, false},
+	}
+	for _, test := range tests {
+		depth, ok := replyDepth(test.replyTo, 3)
+		assert.Equal(t, test.ok, ok)
+		assert.Equal(t, test.depth, depth)
+	}
+}
+
+func TestNewPoll(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		poll  *rpc.MessagePostPoll
+		valid bool
+	}{
+		{&rpc.MessagePostPoll{Options: []string{"yes", "no"}}, true},
+		{&rpc.MessagePostPoll{Options: []string{"yes", "no"}, ClosesAt: common.TimeToRPCString(now.Add(time.Hour))}, true},
+		{&rpc.MessagePostPoll{Options: []string{"yes"}}, false},
+		{&rpc.MessagePostPoll{Options: []string{"yes", " "}}, false},
+		{&rpc.MessagePostPoll{Options: []string{"yes", "no"}, ClosesAt: common.TimeToRPCString(now.Add(-time.Hour))}, false},
+		{&rpc.MessagePostPoll{Options: []string{"yes", "no"}, ClosesAt: "tomorrow"}, false},
+	}
+	for i, test := range tests {
+		poll, err := newPoll(test.poll, now)
+		if !test.valid {
+			assert.NotNil(t, err, i)
+			continue
+		}
+		require.Nil(t, err, i)
+		assert.Len(t, poll.Options, len(test.poll.Options))
+		assert.Equal(t, test.poll.ClosesAt != "", poll.ClosesAt.Valid)
+	}
+}
+
+func TestIsPollClosed(t *testing.T) {
+	now := time.Now()
+	at := func(t time.Time) sql.NullTime { return sql.NullTime{Time: t, Valid: true} }
+	tests := []struct {
+		poll   repo.Poll
+		closed bool
+	}{
+		{repo.Poll{}, false},
+		{repo.Poll{ClosesAt: at(now.Add(time.Minute))}, false},
+		{repo.Poll{ClosesAt: at(now)}, true},
+		{repo.Poll{ClosesAt: at(now.Add(-time.Minute))}, true},
+		{repo.Poll{ClosesAt: at(now.Add(time.Minute)), ClosedAt: at(now)}, true},
+	}
+	for i, test := range tests {
+		assert.Equal(t, test.closed, isPollClosed(test.poll, now), i)
+	}
+}
@@ -2,120 +2,299 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/common/metrics"
+	"github.com/mreider/koto/backend/common/tracing"
+	"github.com/mreider/koto/backend/messagehub/repo"
 	"github.com/mreider/koto/backend/token"
 )
 
+const (
+	outboxDeliveryInterval = time.Second * 5
+	outboxCleanInterval    = time.Hour
+	outboxBatchSize        = 100
+	outboxMaxBackoff       = time.Hour
+	outboxMaxAttempts      = 30
+	outboxClaimDuration    = time.Minute * 5
+	outboxDeliveredTTL     = time.Hour * 24 * 7
+	outboxStaleAge         = time.Hour * 24 * 3
+)
+
+var errNotificationsRejected = merry.New("notifications are rejected by the user hub")
+
 type NotificationSender interface {
-	Start()
-	SendNotification(userIDs []string, text, messageType string, data map[string]interface{})
+	Run(ctx context.Context)
+	SendNotification(ctx context.Context, userIDs []string, text, messageType string, data map[string]interface{})
+	// EnqueueNotification adds the notification to the outbox in tx, so it's sent only if tx is committed.
+	EnqueueNotification(ctx context.Context, tx *sqlx.Tx, userIDs []string, text, messageType string, data map[string]interface{}) error
 }
 
 type notificationSender struct {
+	db               *sqlx.DB
 	notificationRepo common.NotificationRepo
+	outboxRepo       repo.NotificationOutboxRepo
 	externalAddress  string
 	userHubEndpoint  string
 	tokenGenerator   token.Generator
 	userHubClient    *http.Client
-	notifications    chan notification
+	wakeUp           chan struct{}
 }
 
 type notification struct {
+	ID          string                 `json:"id,omitempty"`
 	UserIDs     []string               `json:"users"`
 	Text        string                 `json:"text"`
 	MessageType string                 `json:"message_type"`
 	Data        map[string]interface{} `json:"data"`
 }
 
-func NewNotificationSender(notificationRepo common.NotificationRepo, externalAddress, userHubEndpoint string,
+func NewNotificationSender(db *sqlx.DB, notificationRepo common.NotificationRepo, outboxRepo repo.NotificationOutboxRepo, externalAddress, userHubEndpoint string,
 	tokenGenerator token.Generator) NotificationSender {
 	return &notificationSender{
+		db:               db,
 		notificationRepo: notificationRepo,
+		outboxRepo:       outboxRepo,
 		externalAddress:  externalAddress,
 		userHubEndpoint:  userHubEndpoint,
 		tokenGenerator:   tokenGenerator,
 		userHubClient: &http.Client{
-			Timeout: time.Second * 30,
+			Transport: tracing.Transport(nil),
+			Timeout:   time.Second * 30,
 		},
-		notifications: make(chan notification, 10000),
+		wakeUp: make(chan struct{}, 1),
+	}
+}
+
+// SendNotification puts the notification into the outbox, which is delivered to the user hub in the background.
+// The notification is stored for the in-app recipients returned by the user hub.
+func (n *notificationSender) SendNotification(ctx context.Context, userIDs []string, text, messageType string, data map[string]interface{}) {
+	err := common.RunInTransaction(ctx, n.db, func(tx *sqlx.Tx) error {
+		return n.EnqueueNotification(ctx, tx, userIDs, text, messageType, data)
+	})
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("can't add notification to outbox")
 	}
 }
 
-func (n *notificationSender) SendNotification(userIDs []string, text, messageType string, data map[string]interface{}) {
+func (n *notificationSender) EnqueueNotification(ctx context.Context, tx *sqlx.Tx, userIDs []string, text, messageType string, data map[string]interface{}) error {
 	if len(userIDs) == 0 {
-		return
+		return nil
 	}
 
-	n.notifications <- notification{
+	err := n.outboxRepo.Enqueue(ctx, tx, notification{
 		UserIDs:     userIDs,
 		Text:        text,
 		MessageType: messageType,
 		Data:        data,
+	})
+	if err != nil {
+		return err
+	}
+
+	// if tx isn't committed yet, the notification is delivered on the next tick
+	select {
+	case n.wakeUp <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run delivers the outbox until ctx is done, then makes a last delivery attempt.
+func (n *notificationSender) Run(ctx context.Context) {
+	metrics.GaugeFunc("koto_notification_outbox_depth", "Undelivered notifications in the outbox.", func() (float64, error) {
+		stats, err := n.outboxRepo.Stats(context.Background(), common.CurrentTimestamp().Add(-outboxStaleAge))
+		return float64(stats.Depth), err
+	})
+	metrics.GaugeFunc("koto_notification_outbox_oldest_age_seconds", "Age of the oldest undelivered notification.", func() (float64, error) {
+		stats, err := n.outboxRepo.Stats(context.Background(), common.CurrentTimestamp().Add(-outboxStaleAge))
+		if err != nil || !stats.Oldest.Valid {
+			return 0, err
+		}
+		return common.CurrentTimestamp().Sub(stats.Oldest.Time).Seconds(), nil
+	})
+	metrics.GaugeFunc("koto_notification_outbox_dead", "Notifications moved to the dead letter after failed attempts.", func() (float64, error) {
+		stats, err := n.outboxRepo.Stats(context.Background(), common.CurrentTimestamp().Add(-outboxStaleAge))
+		return float64(stats.Dead), err
+	})
+
+	ticker := time.NewTicker(outboxDeliveryInterval)
+	defer ticker.Stop()
+	cleanTicker := time.NewTicker(outboxCleanInterval)
+	defer cleanTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			n.deliver(context.Background())
+			return
+		case <-cleanTicker.C:
+			n.cleanOutbox(ctx)
+			continue
+		case <-ticker.C:
+		case <-n.wakeUp:
+			// wait a bit to send notifications in batches
+			time.Sleep(time.Second)
+		}
+		n.deliver(ctx)
+	}
+}
+
+func (n *notificationSender) deliver(ctx context.Context) {
+	for {
+		now := common.CurrentTimestamp()
+		items, err := n.outboxRepo.ClaimDueItems(ctx, now, outboxBatchSize, outboxClaimDuration)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("can't load notification outbox")
+			return
+		}
+		if len(items) == 0 {
+			return
+		}
+
+		notifications := make([]notification, 0, len(items))
+		for _, item := range items {
+			var ntf notification
+			err = json.Unmarshal(item.Payload, &ntf)
+			if err != nil {
+				logging.FromContext(ctx).WithError(err).Error("can't decode outbox item")
+				// it can't be sent at all, so it's moved to the dead letter right away
+				n.markFailed(ctx, item.ID, now, err, 0)
+				continue
+			}
+			ntf.ID = item.ID
+			notifications = append(notifications, ntf)
+		}
+
+		delivered := n.deliverNotifications(ctx, notifications, now)
+		err = n.outboxRepo.MarkDelivered(ctx, delivered, common.CurrentTimestamp())
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("can't update notification outbox")
+			return
+		}
+		if len(items) < outboxBatchSize {
+			return
+		}
 	}
 }
 
-func (n *notificationSender) Start() {
-	go func() {
-		var pendingNotifications []notification
-		ticker := time.NewTicker(time.Second * 10)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case ntf := <-n.notifications:
-				err := n.notificationRepo.AddNotifications(ntf.UserIDs, ntf.Text, ntf.MessageType, ntf.Data)
-				if err != nil {
-					log.Println("can't add notification to database:", err)
-				}
-
-				pendingNotifications = append(pendingNotifications, ntf)
-				if len(pendingNotifications) >= 100 {
-					n.sendNotifications(pendingNotifications)
-					pendingNotifications = pendingNotifications[:0]
-				}
-			case <-ticker.C:
-				if len(pendingNotifications) > 0 {
-					n.sendNotifications(pendingNotifications)
-					pendingNotifications = pendingNotifications[:0]
-				}
+// deliverNotifications returns the ids of the delivered notifications.
+// If the user hub rejects a batch, its notifications are sent one by one, so one bad notification doesn't hold back the others.
+func (n *notificationSender) deliverNotifications(ctx context.Context, notifications []notification, now time.Time) []string {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	inAppRecipients, err := n.sendNotifications(ctx, notifications)
+	if err != nil {
+		if len(notifications) > 1 && merry.Is(err, errNotificationsRejected) {
+			var delivered []string
+			for _, ntf := range notifications {
+				delivered = append(delivered, n.deliverNotifications(ctx, []notification{ntf}, now)...)
 			}
+			return delivered
+		}
+		logging.FromContext(ctx).WithError(err).Error("can't deliver notifications")
+		for _, ntf := range notifications {
+			n.markFailed(ctx, ntf.ID, now, err, outboxMaxAttempts)
+		}
+		return nil
+	}
+
+	ids := make([]string, len(notifications))
+	for i, ntf := range notifications {
+		ids[i] = ntf.ID
+		userIDs := inAppRecipients[ntf.ID]
+		if len(userIDs) == 0 {
+			continue
+		}
+		err = n.notificationRepo.AddNotifications(ctx, userIDs, ntf.Text, ntf.MessageType, ntf.Data)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("can't add notification to database")
+		}
+	}
+	return ids
+}
+
+func (n *notificationSender) markFailed(ctx context.Context, id string, now time.Time, sendErr error, maxAttempts int) {
+	err := n.outboxRepo.MarkFailed(ctx, id, now, sendErr.Error(), outboxMaxBackoff, maxAttempts)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("can't update notification outbox")
+	}
+}
+
+func (n *notificationSender) cleanOutbox(ctx context.Context) {
+	now := common.CurrentTimestamp()
+	err := n.outboxRepo.DeleteDelivered(ctx, now.Add(-outboxDeliveredTTL))
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("can't clean notification outbox")
+	}
+	// undelivered notifications are kept until the user hub accepts them
+	stats, err := n.outboxRepo.Stats(ctx, now.Add(-outboxStaleAge))
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("can't load notification outbox stats")
+	} else {
+		if stats.Stale > 0 {
+			logging.FromContext(ctx).Errorf("%d notifications are undelivered for more than %s", stats.Stale, outboxStaleAge)
+		}
+		if stats.Dead > 0 {
+			logging.FromContext(ctx).Errorf("%d notifications are in the dead letter", stats.Dead)
 		}
-	}()
+	}
 }
 
-func (n *notificationSender) sendNotifications(notifications []notification) {
+// sendNotifications returns the in-app recipients by notification id.
+func (n *notificationSender) sendNotifications(ctx context.Context, notifications []notification) (_ map[string][]string, err error) {
+	ctx, span := tracing.Start(ctx, "notifications.send", attribute.Int("notifications.count", len(notifications)))
+	defer func() { tracing.End(span, err) }()
+
 	claims := map[string]interface{}{
 		"notifications": notifications,
 	}
 	notificationsToken, err := n.tokenGenerator.Generate(n.externalAddress, "", "notifications",
 		time.Now().Add(time.Minute*1), claims)
 	if err != nil {
-		log.Println("can't generate notifications token:", err)
-		return
+		return nil, merry.Prepend(err, "can't generate notifications token")
 	}
 
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, n.userHubEndpoint,
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.userHubEndpoint,
 		strings.NewReader(fmt.Sprintf(`{"node": "%s", "notifications_token": "%s"}`, n.externalAddress, notificationsToken)))
 	if err != nil {
-		log.Println("can't generate notifications request:", err)
-		return
+		return nil, merry.Wrap(err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := n.userHubClient.Do(req)
 	if err != nil {
-		log.Println("can't post notifications request:", err)
-		return
+		return nil, merry.Wrap(err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 	if resp.StatusCode != http.StatusOK {
-		log.Println("notifications response - unexpected status:", resp.Status)
-		return
+		return nil, merry.Appendf(errNotificationsRejected, "unexpected status: %s", resp.Status)
+	}
+
+	var body struct {
+		InAppRecipients map[string]struct {
+			UserIDs []string `json:"user_ids"`
+		} `json:"in_app_recipients"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	inAppRecipients := make(map[string][]string, len(body.InAppRecipients))
+	for id, recipients := range body.InAppRecipients {
+		inAppRecipients[id] = recipients.UserIDs
 	}
+	return inAppRecipients, nil
 }
@@ -0,0 +1,178 @@
+package services_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/messagehub/repo"
+	"github.com/mreider/koto/backend/messagehub/services"
+	"github.com/mreider/koto/backend/token"
+)
+
+func TestNotificationSender_InAppRecipients(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	notifications := common.NewNotifications(te.DB)
+	outbox := repo.NewNotificationOutbox(te.DB)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	tokenParser := token.NewParser(func() *rsa.PublicKey { return &privateKey.PublicKey })
+
+	// the user hub keeps only the first user of every notification in the app
+	userHub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			NotificationsToken string `json:"notifications_token"`
+		}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		_, claims, err := tokenParser.Parse(req.NotificationsToken, "notifications")
+		require.Nil(t, err)
+
+		inAppRecipients := make(map[string]interface{})
+		for _, rawNotification := range claims["notifications"].([]interface{}) {
+			ntf := rawNotification.(map[string]interface{})
+			inAppRecipients[ntf["id"].(string)] = map[string]interface{}{
+				"user_ids": ntf["users"].([]interface{})[:1],
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"in_app_recipients": inAppRecipients,
+		})
+	}))
+	defer userHub.Close()
+
+	sender := services.NewNotificationSender(te.DB, notifications, outbox, hubAddress, userHub.URL, token.NewGenerator(privateKey))
+	sender.SendNotification(te.Ctx, []string{"1", "2"}, "user3 liked your post", "message/like", map[string]interface{}{
+		"message_id": "message-1",
+		"user_id":    "3",
+	})
+	sender.SendNotification(te.Ctx, []string{"2"}, "user3 commented on your post", "comment/post", map[string]interface{}{
+		"message_id": "message-2",
+		"user_id":    "3",
+	})
+
+	user1Notifications, err := notifications.Notifications(te.Ctx, "1", "", 10)
+	require.Nil(t, err)
+	assert.Empty(t, user1Notifications, "notifications are stored on delivery")
+
+	ctx, cancel := context.WithCancel(te.Ctx)
+	cancel()
+	sender.Run(ctx)
+
+	user1Notifications, err = notifications.Notifications(te.Ctx, "1", "", 10)
+	require.Nil(t, err)
+	require.Len(t, user1Notifications, 1)
+	assert.Equal(t, "message/like", user1Notifications[0].Type)
+
+	user2Notifications, err := notifications.Notifications(te.Ctx, "2", "", 10)
+	require.Nil(t, err)
+	require.Len(t, user2Notifications, 1)
+	assert.Equal(t, "comment/post", user2Notifications[0].Type, "user2 isn't an in-app recipient of the like")
+
+	stats, err := outbox.Stats(te.Ctx, time.Now())
+	require.Nil(t, err)
+	assert.Equal(t, 0, stats.Depth)
+}
+
+func TestNotificationSender_Transaction(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	outbox := repo.NewNotificationOutbox(te.DB)
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	sender := services.NewNotificationSender(te.DB, common.NewNotifications(te.DB), outbox, hubAddress, "http://localhost:1", token.NewGenerator(privateKey))
+
+	errRollback := errors.New("rollback")
+	err = common.RunInTransaction(te.Ctx, te.DB, func(tx *sqlx.Tx) error {
+		err := sender.EnqueueNotification(te.Ctx, tx, []string{"1"}, "rolled back", "message/post", nil)
+		require.Nil(t, err)
+		return errRollback
+	})
+	assert.Equal(t, errRollback, err)
+	stats, err := outbox.Stats(te.Ctx, time.Now())
+	require.Nil(t, err)
+	assert.Equal(t, 0, stats.Depth, "the notification is rolled back with the write")
+
+	err = common.RunInTransaction(te.Ctx, te.DB, func(tx *sqlx.Tx) error {
+		return sender.EnqueueNotification(te.Ctx, tx, []string{"1"}, "committed", "message/post", nil)
+	})
+	require.Nil(t, err)
+
+	// the user hub is unavailable, the notification is kept however old it is
+	ctx, cancel := context.WithCancel(te.Ctx)
+	cancel()
+	sender.Run(ctx)
+	stats, err = outbox.Stats(te.Ctx, time.Now().Add(time.Hour*24*30))
+	require.Nil(t, err)
+	assert.Equal(t, 1, stats.Depth)
+	assert.Equal(t, 1, stats.Stale)
+}
+
+func TestNotificationSender_RejectedNotification(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	notifications := common.NewNotifications(te.DB)
+	outbox := repo.NewNotificationOutbox(te.DB)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	tokenParser := token.NewParser(func() *rsa.PublicKey { return &privateKey.PublicKey })
+
+	// the user hub rejects every batch with the "bad" notification
+	userHub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			NotificationsToken string `json:"notifications_token"`
+		}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		_, claims, err := tokenParser.Parse(req.NotificationsToken, "notifications")
+		require.Nil(t, err)
+
+		inAppRecipients := make(map[string]interface{})
+		for _, rawNotification := range claims["notifications"].([]interface{}) {
+			ntf := rawNotification.(map[string]interface{})
+			if ntf["text"] == "bad" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			inAppRecipients[ntf["id"].(string)] = map[string]interface{}{
+				"user_ids": ntf["users"],
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"in_app_recipients": inAppRecipients,
+		})
+	}))
+	defer userHub.Close()
+
+	sender := services.NewNotificationSender(te.DB, notifications, outbox, hubAddress, userHub.URL, token.NewGenerator(privateKey))
+	for _, text := range []string{"good", "bad", "also good"} {
+		sender.SendNotification(te.Ctx, []string{"1"}, text, "message/post", nil)
+	}
+
+	ctx, cancel := context.WithCancel(te.Ctx)
+	cancel()
+	sender.Run(ctx)
+
+	user1Notifications, err := notifications.Notifications(te.Ctx, "1", "", 10)
+	require.Nil(t, err)
+	assert.Len(t, user1Notifications, 2, "the rejected notification doesn't hold back the others")
+
+	stats, err := outbox.Stats(te.Ctx, time.Now())
+	require.Nil(t, err)
+	assert.Equal(t, 1, stats.Depth)
+}
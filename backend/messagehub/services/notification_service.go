@@ -3,10 +3,18 @@ package services
 import (
 	"context"
 
+	"github.com/ansel1/merry"
+	"github.com/twitchtv/twirp"
+
 	"github.com/mreider/koto/backend/common"
 	"github.com/mreider/koto/backend/messagehub/rpc"
 )
 
+const (
+	defaultNotificationCount = 50
+	maxNotificationCount     = 200
+)
+
 type notificationService struct {
 	*BaseService
 }
@@ -19,7 +27,7 @@ func NewNotification(base *BaseService) rpc.NotificationService {
 
 func (s *notificationService) Count(ctx context.Context, _ *rpc.Empty) (*rpc.NotificationCountResponse, error) {
 	user := s.getUser(ctx)
-	total, unread, err := s.repos.Notification.Counts(user.ID)
+	total, unread, err := s.repos.Notification.Counts(ctx, user.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -29,10 +37,20 @@ func (s *notificationService) Count(ctx context.Context, _ *rpc.Empty) (*rpc.Not
 	}, nil
 }
 
-func (s *notificationService) Notifications(ctx context.Context, _ *rpc.Empty) (*rpc.NotificationNotificationsResponse, error) {
+func (s *notificationService) Notifications(ctx context.Context, r *rpc.NotificationNotificationsRequest) (*rpc.NotificationNotificationsResponse, error) {
+	count := int(r.Count)
+	if count <= 0 {
+		count = defaultNotificationCount
+	} else if count > maxNotificationCount {
+		count = maxNotificationCount
+	}
+
 	user := s.getUser(ctx)
-	notifications, err := s.repos.Notification.Notifications(user.ID)
+	notifications, err := s.repos.Notification.Notifications(ctx, user.ID, r.BeforeId, count)
 	if err != nil {
+		if merry.Is(err, common.ErrNotificationNotFound) {
+			return nil, twirp.NotFoundError(err.Error())
+		}
 		return nil, err
 	}
 	rpcNotifications := make([]*rpc.Notification, len(notifications))
@@ -43,6 +61,7 @@ func (s *notificationService) Notifications(ctx context.Context, _ *rpc.Empty) (
 			Type:      notification.Type,
 			Data:      notification.Data.String(),
 			CreatedAt: common.TimeToRPCString(notification.CreatedAt),
+			UpdatedAt: common.TimeToRPCString(notification.UpdatedAt),
 			ReadAt:    common.NullTimeToRPCString(notification.ReadAt),
 		}
 	}
@@ -54,7 +73,12 @@ func (s *notificationService) Notifications(ctx context.Context, _ *rpc.Empty) (
 
 func (s *notificationService) Clean(ctx context.Context, r *rpc.NotificationCleanRequest) (*rpc.Empty, error) {
 	user := s.getUser(ctx)
-	err := s.repos.Notification.Clean(user.ID, r.LastKnownId)
+	var err error
+	if len(r.NotificationIds) > 0 {
+		err = s.repos.Notification.DeleteNotifications(ctx, user.ID, r.NotificationIds)
+	} else {
+		err = s.repos.Notification.Clean(ctx, user.ID, r.LastKnownId)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +87,12 @@ func (s *notificationService) Clean(ctx context.Context, r *rpc.NotificationClea
 
 func (s *notificationService) MarkRead(ctx context.Context, r *rpc.NotificationMarkReadRequest) (*rpc.Empty, error) {
 	user := s.getUser(ctx)
-	err := s.repos.Notification.MarkRead(user.ID, r.LastKnownId)
+	var err error
+	if len(r.NotificationIds) > 0 {
+		err = s.repos.Notification.MarkNotificationsRead(ctx, user.ID, r.NotificationIds)
+	} else {
+		err = s.repos.Notification.MarkRead(ctx, user.ID, r.LastKnownId)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/messagehub/repo"
+)
+
+const (
+	pollCloseInterval = time.Second * 30
+)
+
+type PollCloser struct {
+	repos              repo.Repos
+	notificationSender NotificationSender
+}
+
+func NewPollCloser(repos repo.Repos, notificationSender NotificationSender) *PollCloser {
+	return &PollCloser{
+		repos:              repos,
+		notificationSender: notificationSender,
+	}
+}
+
+func (c *PollCloser) Close(ctx context.Context) {
+	ticker := time.NewTicker(pollCloseInterval)
+	defer ticker.Stop()
+
+	c.close(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.close(ctx)
+		}
+	}
+}
+
+func (c *PollCloser) close(ctx context.Context) {
+	now := common.CurrentTimestamp()
+	polls, err := c.repos.Poll.DuePolls(ctx, now)
+	if err != nil {
+		logging.FromContext(ctx).Error(err)
+		return
+	}
+
+	for _, poll := range polls {
+		err = c.closePoll(ctx, poll, now)
+		if err != nil {
+			logging.FromContext(ctx).Error(err)
+		}
+	}
+}
+
+func (c *PollCloser) closePoll(ctx context.Context, poll repo.Poll, now time.Time) error {
+	msg, err := c.repos.Message.Message(ctx, "", poll.MessageID)
+	if err != nil {
+		return err
+	}
+
+	closed, err := c.repos.Poll.ClosePoll(ctx, poll.MessageID, now)
+	if err != nil {
+		return err
+	}
+	if !closed {
+		return nil
+	}
+
+	c.notificationSender.SendNotification(ctx, []string{msg.UserID}, "Your poll is closed", "message/poll-closed", map[string]interface{}{
+		"message_id": msg.ID,
+	})
+	return nil
+}
@@ -0,0 +1,136 @@
+package services_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/messagehub/repo"
+	"github.com/mreider/koto/backend/messagehub/rpc"
+	"github.com/mreider/koto/backend/messagehub/services"
+	"github.com/mreider/koto/backend/token"
+)
+
+func TestMessageService_Polls(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	repos := repo.Repos{
+		DB:      te.DB,
+		Message: repo.NewMessages(te.DB),
+		Poll:    repo.NewPolls(te.DB),
+		Event:   repo.NewEvents(te.DB),
+		User:    repo.NewUsers(te.DB),
+		Blob:    repo.NewBlobs(te.DB),
+		Limit:   repo.NewLimits(te.DB),
+	}
+	for _, id := range []string{"1", "2", "3"} {
+		require.Nil(t, repos.User.AddUser(te.Ctx, id, "user"+id))
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	tokenGenerator := token.NewGenerator(privateKey)
+	tokenParser := token.NewParser(func() *rsa.PublicKey { return &privateKey.PublicKey })
+	sender := &notificationSender{}
+	base := services.NewBase(repos, tokenParser, tokenGenerator, nil, hubAddress, nil, sender, nil)
+	s := services.NewMessage(base, nil, services.NewReactionNotifier(sender, 0), 3, services.NewLimiter(repos, services.PostingLimits{}))
+	pollCloser := services.NewPollCloser(repos, sender)
+
+	user1Ctx := context.WithValue(te.Ctx, services.ContextUserKey, services.User{ID: "1", Name: "user1"})
+	user2Ctx := context.WithValue(te.Ctx, services.ContextUserKey, services.User{ID: "2", Name: "user2"})
+	user3Ctx := context.WithValue(te.Ctx, services.ContextUserKey, services.User{ID: "3", Name: "user3"})
+
+	generate := func(userID, scope string, claims map[string]interface{}) string {
+		claims["hub"] = hubAddress
+		tok, err := tokenGenerator.Generate(userID, "user"+userID, scope, time.Now().Add(time.Hour), claims)
+		require.Nil(t, err)
+		return tok
+	}
+	getMessagesToken := func(userID string) string {
+		return generate(userID, "get-messages", map[string]interface{}{"users": []string{"1", userID}})
+	}
+	vote := func(ctx context.Context, userID, messageID string, optionIDs ...string) (*rpc.Poll, error) {
+		resp, err := s.Vote(ctx, &rpc.MessageVoteRequest{Token: getMessagesToken(userID), MessageId: messageID, OptionIds: optionIDs})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Poll, nil
+	}
+
+	_, err = s.Post(user1Ctx, &rpc.MessagePostRequest{
+		Token: generate("1", "post-message", map[string]interface{}{"friends": []string{"2", "3"}}),
+		Text:  "invalid poll",
+		Poll:  &rpc.MessagePostPoll{Options: []string{"only one"}},
+	})
+	assert.Equal(t, twirp.InvalidArgument, err.(twirp.Error).Code())
+	messages, err := repos.Message.Messages(te.Ctx, "1", []string{"1"}, time.Time{}, 0)
+	require.Nil(t, err)
+	assert.Empty(t, messages, "a message with an invalid poll isn't posted")
+
+	postResp, err := s.Post(user1Ctx, &rpc.MessagePostRequest{
+		Token: generate("1", "post-message", map[string]interface{}{"friends": []string{"2", "3"}}),
+		Text:  "poll",
+		Poll: &rpc.MessagePostPoll{
+			Options:  []string{"yes", "no"},
+			ClosesAt: common.TimeToRPCString(time.Now().Add(time.Millisecond * 500)),
+		},
+	})
+	require.Nil(t, err)
+	messageID := postResp.Message.Id
+	require.NotNil(t, postResp.Message.Poll)
+	require.Len(t, postResp.Message.Poll.Options, 2)
+	yes, no := postResp.Message.Poll.Options[0].Id, postResp.Message.Poll.Options[1].Id
+
+	_, err = vote(user2Ctx, "2", messageID, yes, no)
+	assert.Equal(t, twirp.InvalidArgument, err.(twirp.Error).Code(), "the poll is single choice")
+	_, err = vote(user2Ctx, "2", messageID, "unknown")
+	assert.Equal(t, twirp.InvalidArgument, err.(twirp.Error).Code())
+
+	poll, err := vote(user2Ctx, "2", messageID, yes)
+	require.Nil(t, err)
+	poll, err = vote(user2Ctx, "2", messageID, no)
+	require.Nil(t, err)
+	assert.Equal(t, int32(0), poll.Options[0].Votes, "a new vote replaces the old one")
+	assert.Equal(t, int32(1), poll.Options[1].Votes)
+	assert.True(t, poll.Options[1].VotedByMe)
+
+	poll, err = vote(user3Ctx, "3", messageID, no)
+	require.Nil(t, err)
+	assert.Equal(t, int32(2), poll.TotalVoters)
+	require.Len(t, poll.Options[1].Voters, 2)
+	assert.Equal(t, "2", poll.Options[1].Voters[0].Id)
+	assert.False(t, poll.IsClosed)
+
+	ctx, cancel := context.WithCancel(te.Ctx)
+	cancel()
+	pollCloser.Close(ctx)
+	assert.Empty(t, sender.Sent(), "the poll isn't due yet")
+
+	time.Sleep(time.Millisecond * 600)
+	pollCloser.Close(ctx)
+	pollCloser.Close(ctx)
+
+	closed := 0
+	for _, n := range sender.Sent() {
+		if n.messageType == "message/poll-closed" {
+			closed++
+			assert.Equal(t, []string{"1"}, n.userIDs)
+			assert.Equal(t, messageID, n.data["message_id"])
+		}
+	}
+	assert.Equal(t, 1, closed, "the author is notified once")
+
+	_, err = vote(user3Ctx, "3", messageID, yes)
+	assert.Equal(t, twirp.FailedPrecondition, err.(twirp.Error).Code())
+	savedPoll, err := repos.Poll.Poll(te.Ctx, "1", messageID)
+	require.Nil(t, err)
+	assert.True(t, savedPoll.ClosedAt.Valid)
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mreider/koto/backend/messagehub/repo"
+)
+
+type ReactionNotifier interface {
+	Run(ctx context.Context)
+	Notify(ownerID, messageID, commentID string, user User, reaction string)
+}
+
+type reactionNotifier struct {
+	notificationSender NotificationSender
+	delay              time.Duration
+	pending            map[string]*pendingReactions
+	pendingMu          sync.Mutex
+}
+
+type pendingReactions struct {
+	ownerID   string
+	messageID string
+	commentID string
+	userIDs   []string
+	userNames []string
+	reactions []string
+	createdAt time.Time
+}
+
+func NewReactionNotifier(notificationSender NotificationSender, delay time.Duration) ReactionNotifier {
+	return &reactionNotifier{
+		notificationSender: notificationSender,
+		delay:              delay,
+		pending:            make(map[string]*pendingReactions),
+	}
+}
+
+func (n *reactionNotifier) Notify(ownerID, messageID, commentID string, user User, reaction string) {
+	if ownerID == user.ID {
+		return
+	}
+
+	n.pendingMu.Lock()
+	defer n.pendingMu.Unlock()
+
+	key := ownerID + "/" + messageID + "/" + commentID
+	item, ok := n.pending[key]
+	if !ok {
+		item = &pendingReactions{
+			ownerID:   ownerID,
+			messageID: messageID,
+			commentID: commentID,
+			createdAt: time.Now(),
+		}
+		n.pending[key] = item
+	}
+	if !containsString(item.userIDs, user.ID) {
+		item.userIDs = append(item.userIDs, user.ID)
+		item.userNames = append(item.userNames, user.Name)
+	}
+	if !containsString(item.reactions, reaction) {
+		item.reactions = append(item.reactions, reaction)
+	}
+
+	if n.delay <= 0 {
+		delete(n.pending, key)
+		n.send(item)
+	}
+}
+
+// Run sends the delayed notifications until ctx is done, then sends the pending ones right away.
+func (n *reactionNotifier) Run(ctx context.Context) {
+	if n.delay <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(n.delay / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			n.flush(time.Now())
+			return
+		case <-ticker.C:
+			n.flush(time.Now().Add(-n.delay))
+		}
+	}
+}
+
+func (n *reactionNotifier) flush(createdBefore time.Time) {
+	n.pendingMu.Lock()
+	var ready []*pendingReactions
+	for key, item := range n.pending {
+		if !item.createdAt.After(createdBefore) {
+			ready = append(ready, item)
+			delete(n.pending, key)
+		}
+	}
+	n.pendingMu.Unlock()
+
+	for _, item := range ready {
+		n.send(item)
+	}
+}
+
+func (n *reactionNotifier) send(item *pendingReactions) {
+	target, notificationType := "post", "message"
+	if item.commentID != "" {
+		target, notificationType = "comment", "comment"
+	}
+
+	text := reactionNotificationText(item.userNames, item.reactions, target)
+	if len(item.reactions) == 1 && item.reactions[0] == repo.LikeReaction {
+		notificationType += "/like"
+	} else {
+		notificationType += "/reaction"
+	}
+
+	data := map[string]interface{}{
+		"user_id":    item.userIDs[0],
+		"user_name":  item.userNames[0],
+		"user_ids":   item.userIDs,
+		"user_names": item.userNames,
+		"message_id": item.messageID,
+		"reactions":  item.reactions,
+	}
+	if item.commentID != "" {
+		data["comment_id"] = item.commentID
+	}
+	n.notificationSender.SendNotification(context.Background(), []string{item.ownerID}, text, notificationType, data)
+}
+
+func reactionNotificationText(userNames, reactions []string, target string) string {
+	var who string
+	switch len(userNames) {
+	case 1:
+		who = userNames[0]
+	case 2:
+		who = userNames[0] + " and " + userNames[1]
+	default:
+		who = userNames[0] + " and " + strconv.Itoa(len(userNames)-1) + " others"
+	}
+
+	switch {
+	case len(reactions) == 1 && reactions[0] == repo.LikeReaction:
+		return who + " liked your " + target
+	case len(reactions) == 1:
+		return who + " reacted " + reactions[0] + " to your " + target
+	default:
+		return who + " reacted to your " + target
+	}
+}
+
+func containsString(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/messagehub/repo"
+)
+
+type sentNotification struct {
+	userIDs     []string
+	text        string
+	messageType string
+	data        map[string]interface{}
+}
+
+type fakeNotificationSender struct {
+	mu   sync.Mutex
+	sent []sentNotification
+}
+
+func (s *fakeNotificationSender) Run(context.Context) {}
+
+func (s *fakeNotificationSender) SendNotification(_ context.Context, userIDs []string, text, messageType string, data map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, sentNotification{userIDs: userIDs, text: text, messageType: messageType, data: data})
+}
+
+func (s *fakeNotificationSender) EnqueueNotification(ctx context.Context, _ *sqlx.Tx, userIDs []string, text, messageType string, data map[string]interface{}) error {
+	s.SendNotification(ctx, userIDs, text, messageType, data)
+	return nil
+}
+
+func (s *fakeNotificationSender) Sent() []sentNotification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]sentNotification(nil), s.sent...)
+}
+
+func TestReactionNotificationText(t *testing.T) {
+	tests := []struct {
+		userNames []string
+		reactions []string
+		target    string
+		expected  string
+	}{
+		{[]string{"ann"}, []string{repo.LikeReaction}, "post", "ann liked your post"},
+		{[]string{"ann"}, []string{"🎉"}, "comment", "ann reacted 🎉 to your comment"},
+		{[]string{"ann", "bob"}, []string{repo.LikeReaction}, "post", "ann and bob liked your post"},
+		{[]string{"ann", "bob", "cid"}, []string{"🎉"}, "post", "ann and 2 others reacted 🎉 to your post"},
+		{[]string{"ann"}, []string{repo.LikeReaction, "🎉"}, "post", "ann reacted to your post"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, reactionNotificationText(test.userNames, test.reactions, test.target))
+	}
+}
+
+func TestReactionNotifier_Coalesce(t *testing.T) {
+	sender := &fakeNotificationSender{}
+	n := NewReactionNotifier(sender, time.Minute).(*reactionNotifier)
+
+	ann := User{ID: "1", Name: "ann"}
+	bob := User{ID: "2", Name: "bob"}
+	owner := User{ID: "3", Name: "owner"}
+	n.Notify(owner.ID, "m1", "", ann, repo.LikeReaction)
+	n.Notify(owner.ID, "m1", "", ann, repo.LikeReaction)
+	n.Notify(owner.ID, "m1", "", bob, "🎉")
+	n.Notify(owner.ID, "m1", "c1", ann, repo.LikeReaction)
+	n.Notify(owner.ID, "m1", "", owner, "🎉")
+
+	n.flush(time.Now().Add(-time.Minute))
+	assert.Empty(t, sender.Sent(), "nothing is older than the delay")
+
+	n.flush(time.Now())
+	sent := sender.Sent()
+	require.Len(t, sent, 2)
+	byType := make(map[string]sentNotification)
+	for _, s := range sent {
+		assert.Equal(t, []string{owner.ID}, s.userIDs)
+		byType[s.messageType] = s
+	}
+
+	post := byType["message/reaction"]
+	assert.Equal(t, "ann and bob reacted to your post", post.text)
+	assert.Equal(t, []string{"1", "2"}, post.data["user_ids"])
+	assert.Equal(t, []string{repo.LikeReaction, "🎉"}, post.data["reactions"])
+	assert.NotContains(t, post.data, "comment_id")
+
+	comment := byType["comment/like"]
+	assert.Equal(t, "ann liked your comment", comment.text)
+	assert.Equal(t, "c1", comment.data["comment_id"])
+
+	n.flush(time.Now())
+	assert.Len(t, sender.Sent(), 2, "sent items are removed")
+}
+
+func TestReactionNotifier_NoDelay(t *testing.T) {
+	sender := &fakeNotificationSender{}
+	n := NewReactionNotifier(sender, 0)
+
+	n.Notify("3", "m1", "", User{ID: "1", Name: "ann"}, repo.LikeReaction)
+	n.Notify("3", "m1", "", User{ID: "2", Name: "bob"}, repo.LikeReaction)
+
+	sent := sender.Sent()
+	require.Len(t, sent, 2)
+	assert.Equal(t, "ann liked your post", sent[0].text)
+	assert.Equal(t, "bob liked your post", sent[1].text)
+}
+
+func TestReactionNotifier_RunFlushesOnShutdown(t *testing.T) {
+	sender := &fakeNotificationSender{}
+	n := NewReactionNotifier(sender, time.Hour)
+	n.Notify("3", "m1", "", User{ID: "1", Name: "ann"}, repo.LikeReaction)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		n.Run(ctx)
+		close(done)
+	}()
+	cancel()
+	<-done
+
+	require.Len(t, sender.Sent(), 1)
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/ansel1/merry"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/messagehub/config"
+	"github.com/mreider/koto/backend/messagehub/repo"
+)
+
+const (
+	retentionInterval  = time.Hour
+	retentionBatchSize = 500
+)
+
+type RetentionItem struct {
+	MessageID      string
+	UserID         string
+	AttachmentType string
+	CreatedAt      time.Time
+	Size           int64
+	DeletePost     bool
+	Conversation   bool
+}
+
+// RetentionEngine expires attachments of posts, comments and conversation messages by the configured policy.
+// Posts of users who opted in are deleted as a whole. In dry-run mode it only logs what would be removed.
+type RetentionEngine struct {
+	repos  repo.Repos
+	policy config.RetentionPolicy
+	dryRun bool
+}
+
+func NewRetentionEngine(repos repo.Repos, policy config.RetentionPolicy, dryRun bool) *RetentionEngine {
+	return &RetentionEngine{
+		repos:  repos,
+		policy: policy,
+		dryRun: dryRun,
+	}
+}
+
+func (e *RetentionEngine) Run(ctx context.Context) {
+	if len(e.policy) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(retentionInterval)
+	defer ticker.Stop()
+
+	e.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.run(ctx)
+		}
+	}
+}
+
+func (e *RetentionEngine) run(ctx context.Context) {
+	err := e.Expire(ctx, common.CurrentTimestamp())
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("can't plan retention")
+	}
+}
+
+// Plan returns the attachments and posts that are expired at now.
+func (e *RetentionEngine) Plan(ctx context.Context, now time.Time) ([]RetentionItem, error) {
+	if len(e.policy) == 0 {
+		return nil, nil
+	}
+
+	var items []RetentionItem
+	before := now.Add(-e.policy.MinAge())
+	var afterCreatedAt time.Time
+	var afterID string
+	for {
+		candidates, err := e.repos.Retention.Candidates(ctx, before, afterCreatedAt, afterID, retentionBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range candidates {
+			if !e.policy.Expired(candidate.AttachmentType, candidate.CreatedAt, now) {
+				continue
+			}
+			items = append(items, RetentionItem{
+				MessageID:      candidate.MessageID,
+				UserID:         candidate.UserID,
+				AttachmentType: candidate.AttachmentType,
+				CreatedAt:      candidate.CreatedAt,
+				Size:           candidate.Size,
+				DeletePost:     candidate.DeleteExpiredPosts && !candidate.ParentID.Valid && !candidate.Conversation,
+				Conversation:   candidate.Conversation,
+			})
+		}
+		if len(candidates) < retentionBatchSize {
+			return items, nil
+		}
+		last := candidates[len(candidates)-1]
+		afterCreatedAt, afterID = last.CreatedAt, last.MessageID
+	}
+}
+
+// Expire removes the attachments and posts that are expired at now. In dry-run mode it only logs them.
+func (e *RetentionEngine) Expire(ctx context.Context, now time.Time) error {
+	items, err := e.Plan(ctx, now)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	var size int64
+	var posts int
+	for _, item := range items {
+		size += item.Size
+		if item.DeletePost {
+			posts++
+		}
+	}
+	if e.dryRun {
+		logging.FromContext(ctx).Infof("retention (dry run): would expire %d attachments (%d bytes), including %d whole posts", len(items), size, posts)
+		return nil
+	}
+
+	for _, item := range items {
+		switch {
+		case item.DeletePost:
+			err = e.repos.Message.DeleteMessage(ctx, item.UserID, item.MessageID)
+			if merry.Is(err, repo.ErrMessageNotFound) {
+				err = nil
+			}
+		case item.Conversation:
+			err = e.repos.Retention.ExpireConversationMedia(ctx, item.MessageID)
+		default:
+			err = e.repos.Retention.ExpireMedia(ctx, item.MessageID)
+		}
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("can't apply retention")
+		}
+	}
+	logging.FromContext(ctx).Infof("retention: expired %d attachments (%d bytes), including %d whole posts", len(items), size, posts)
+	return nil
+}
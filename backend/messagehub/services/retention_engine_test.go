@@ -0,0 +1,106 @@
+package services_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/messagehub/config"
+	"github.com/mreider/koto/backend/messagehub/repo"
+	"github.com/mreider/koto/backend/messagehub/services"
+)
+
+func TestRetentionEngine(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	repos := repo.Repos{
+		DB:           te.DB,
+		Message:      repo.NewMessages(te.DB),
+		Conversation: repo.NewConversations(te.DB),
+		User:         repo.NewUsers(te.DB),
+		Blob:         repo.NewBlobs(te.DB),
+		Retention:    repo.NewRetention(te.DB),
+	}
+	for _, id := range []string{"1", "2"} {
+		require.Nil(t, repos.User.AddUser(te.Ctx, id, "user"+id))
+	}
+	require.Nil(t, repos.Retention.SetDeleteExpiredPosts(te.Ctx, "1", true))
+
+	createdAt := common.CurrentTimestamp().Add(-time.Hour * 24 * 3)
+	addPost := func(id, userID, attachmentType string) {
+		require.Nil(t, common.RunInTransaction(te.Ctx, te.DB, func(tx *sqlx.Tx) error {
+			return repos.Message.AddMessage(te.Ctx, tx, "", repo.Message{
+				ID: id, UserID: userID, UserName: "user" + userID, Text: id,
+				AttachmentID: id + ".blob", AttachmentType: attachmentType,
+				CreatedAt: createdAt, UpdatedAt: createdAt,
+				PublishedAt: sql.NullTime{Time: createdAt, Valid: true},
+			})
+		}))
+	}
+	addPost("post1", "1", "video/mp4")
+	addPost("post2", "2", "video/mp4")
+	addPost("post3", "2", "image/jpeg")
+
+	conversation, err := repos.Conversation.AddConversation(te.Ctx, repo.Conversation{
+		ID: "conversation", MemberKey: "1,2", CreatedBy: "1", CreatedAt: createdAt, UpdatedAt: createdAt,
+	}, []string{"1", "2"})
+	require.Nil(t, err)
+	require.Nil(t, common.RunInTransaction(te.Ctx, te.DB, func(tx *sqlx.Tx) error {
+		return repos.Conversation.AddMessage(te.Ctx, tx, repo.ConversationMessage{
+			ID: "chat", ConversationID: conversation.ID, UserID: "1", UserName: "user1",
+			AttachmentID: "chat.blob", AttachmentType: "video/webm", CreatedAt: createdAt,
+		}, nil)
+	}))
+
+	policy, err := config.ParseRetentionPolicy("video:1,image:30")
+	require.Nil(t, err)
+	now := common.CurrentTimestamp()
+
+	pendingDeletes := func() []string {
+		var blobIDs []string
+		require.Nil(t, te.DB.Select(&blobIDs, "select blob_id from blob_pending_deletes order by blob_id"))
+		return blobIDs
+	}
+
+	items, err := services.NewRetentionEngine(repos, policy, true).Plan(te.Ctx, now)
+	require.Nil(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, []string{"post1", "post2", "chat"}, []string{items[0].MessageID, items[1].MessageID, items[2].MessageID})
+	assert.True(t, items[0].DeletePost)
+	assert.False(t, items[1].DeletePost)
+	assert.True(t, items[2].Conversation)
+	assert.False(t, items[2].DeletePost, "conversation messages are never deleted as a whole")
+
+	require.Nil(t, services.NewRetentionEngine(repos, policy, true).Expire(te.Ctx, now))
+	assert.Empty(t, pendingDeletes(), "a dry run doesn't change anything")
+	post, err := repos.Message.Message(te.Ctx, "1", "post1")
+	require.Nil(t, err)
+	assert.Equal(t, "post1.blob", post.AttachmentID)
+
+	require.Nil(t, services.NewRetentionEngine(repos, policy, false).Expire(te.Ctx, now))
+	assert.Equal(t, []string{"chat.blob", "post1.blob", "post2.blob"}, pendingDeletes())
+
+	_, err = repos.Message.Message(te.Ctx, "1", "post1")
+	assert.True(t, merry.Is(err, repo.ErrMessageNotFound), "the post of a user who opted in is deleted")
+
+	post, err = repos.Message.Message(te.Ctx, "2", "post2")
+	require.Nil(t, err)
+	assert.Empty(t, post.AttachmentID)
+	assert.True(t, post.MediaExpired)
+
+	post, err = repos.Message.Message(te.Ctx, "2", "post3")
+	require.Nil(t, err)
+	assert.Equal(t, "post3.blob", post.AttachmentID, "images are kept longer")
+
+	messages, err := repos.Conversation.LastMessages(te.Ctx, []string{conversation.ID})
+	require.Nil(t, err)
+	assert.Empty(t, messages[conversation.ID].AttachmentID)
+	assert.True(t, messages[conversation.ID].MediaExpired)
+}
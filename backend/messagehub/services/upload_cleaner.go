@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/messagehub/repo"
+)
+
+const (
+	uploadCleanInterval  = time.Hour
+	uploadReservationTTL = time.Hour * 24
+)
+
+// UploadCleaner removes uploads that weren't attached to a message within a day and releases their quota.
+type UploadCleaner struct {
+	repos repo.Repos
+}
+
+func NewUploadCleaner(repos repo.Repos) *UploadCleaner {
+	return &UploadCleaner{
+		repos: repos,
+	}
+}
+
+func (c *UploadCleaner) Clean(ctx context.Context) {
+	ticker := time.NewTicker(uploadCleanInterval)
+	defer ticker.Stop()
+
+	for {
+		expired, err := c.repos.Blob.ExpireReservations(ctx, common.CurrentTimestamp().Add(-uploadReservationTTL))
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("can't expire uploads")
+		} else if expired > 0 {
+			logging.FromContext(ctx).Infof("expired %d unattached uploads", expired)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
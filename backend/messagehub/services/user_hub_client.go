@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ansel1/merry"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/tracing"
+	"github.com/mreider/koto/backend/token"
+)
+
+var ErrUserNotFound = common.ErrNotFound.WithMessage("user not found")
+
+type UserHubClient interface {
+	// Friends returns the current friends of a user of this hub.
+	Friends(ctx context.Context, userID string) ([]string, error)
+}
+
+type userHubClient struct {
+	externalAddress string
+	userHubAddress  string
+	tokenGenerator  token.Generator
+	client          *http.Client
+}
+
+func NewUserHubClient(externalAddress, userHubAddress string, tokenGenerator token.Generator) UserHubClient {
+	return &userHubClient{
+		externalAddress: externalAddress,
+		userHubAddress:  strings.TrimSuffix(userHubAddress, "/"),
+		tokenGenerator:  tokenGenerator,
+		client: &http.Client{
+			Transport: tracing.Transport(nil),
+			Timeout:   time.Second * 30,
+		},
+	}
+}
+
+func (c *userHubClient) Friends(ctx context.Context, userID string) ([]string, error) {
+	friendsToken, err := c.tokenGenerator.Generate(c.externalAddress, "", "user-friends", time.Now().Add(time.Minute), map[string]interface{}{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, merry.Prepend(err, "can't generate user friends token")
+	}
+
+	var resp struct {
+		FriendIDs []string `json:"friend_ids"`
+	}
+	err = c.call(ctx, "UserFriends", map[string]string{
+		"node":  c.externalAddress,
+		"token": friendsToken,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.FriendIDs, nil
+}
+
+func (c *userHubClient) call(ctx context.Context, method string, request, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf("%s/rpc.MessageHubNotificationService/%s", c.userHubAddress, method), strings.NewReader(string(body)))
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return ErrUserNotFound.Here()
+	default:
+		return merry.Errorf("unexpected status: %s", resp.Status)
+	}
+	return merry.Wrap(json.NewDecoder(resp.Body).Decode(response))
+}
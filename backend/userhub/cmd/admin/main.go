@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ansel1/merry"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mreider/koto/backend/admin"
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/userhub/bcrypt"
+	"github.com/mreider/koto/backend/userhub/config"
+	"github.com/mreider/koto/backend/userhub/migrate"
+	"github.com/mreider/koto/backend/userhub/repo"
+	"github.com/mreider/koto/backend/userhub/services"
+)
+
+const usage = `Usage: user-hub-admin [-config path] command [arguments]
+
+User hub commands:
+  user create -name name -email email [-confirm]    the password is read from stdin
+  user confirm id|name
+  user admin [-revoke] id|name
+  hub list
+  hub approve [-skip-verify] id|address
+  hub disable id|address
+`
+
+func main() {
+	admin.Main(usage, func(configPath string) (*admin.Hub, error) {
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			return nil, err
+		}
+		return &admin.Hub{
+			DB:              cfg.DB,
+			S3:              cfg.S3,
+			Blob:            cfg.Blob,
+			ExternalAddress: cfg.ExternalAddress,
+			PrivateKeyPath:  cfg.PrivateKeyPath,
+			Log:             cfg.Log,
+			Migrations:      migrate.Migrations(),
+			Migrate:         migrate.Migrate,
+			ReferencedBlobs: func(ctx context.Context, db *sqlx.DB) (map[string]int64, error) {
+				blobIDs, err := repo.NewUsers(db).AvatarBlobs(ctx)
+				if err != nil {
+					return nil, err
+				}
+				referenced := make(map[string]int64, len(blobIDs))
+				for _, blobID := range blobIDs {
+					referenced[blobID] = -1
+				}
+				return referenced, nil
+			},
+			Commands: map[string]admin.Command{
+				"user": userCommand,
+				"hub":  hubCommand,
+			},
+		}, nil
+	})
+}
+
+func userCommand(ctx context.Context, h *admin.Hub, args []string) error {
+	if len(args) == 0 {
+		return admin.ErrUsage.Here()
+	}
+
+	db, err := h.OpenDB()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	users := repo.NewUsers(db)
+
+	switch args[0] {
+	case "create":
+		fs := flag.NewFlagSet("user create", flag.ContinueOnError)
+		name := fs.String("name", "", "user name")
+		email := fs.String("email", "", "user email")
+		confirm := fs.Bool("confirm", false, "confirm the user right away, the CLI doesn't send confirmation emails")
+		err := admin.ParseFlags(fs, args[1:])
+		if err != nil {
+			return err
+		}
+		if *name == "" || *email == "" || fs.NArg() != 0 {
+			return admin.ErrUsage.Here()
+		}
+		return createUser(ctx, users, strings.TrimSpace(*name), *email, *confirm)
+	case "confirm":
+		if len(args) != 2 {
+			return admin.ErrUsage.Here()
+		}
+		user, err := findUser(ctx, users, args[1])
+		if err != nil {
+			return err
+		}
+		ok, err := users.ConfirmUser(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			logrus.Infof("%s is already confirmed", user.Name)
+			return nil
+		}
+		logrus.Infof("confirmed %s", user.Name)
+		return nil
+	case "admin":
+		fs := flag.NewFlagSet("user admin", flag.ContinueOnError)
+		revoke := fs.Bool("revoke", false, "revoke admin rights")
+		err := admin.ParseFlags(fs, args[1:])
+		if err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return admin.ErrUsage.Here()
+		}
+		user, err := findUser(ctx, users, fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		err = users.SetAdmin(ctx, user.ID, !*revoke)
+		if err != nil {
+			return err
+		}
+		if *revoke {
+			logrus.Infof("revoked admin rights of %s, admins listed in the hub config keep them", user.Name)
+		} else {
+			logrus.Infof("granted admin rights to %s", user.Name)
+		}
+		return nil
+	default:
+		return admin.ErrUsage.Here()
+	}
+}
+
+func createUser(ctx context.Context, users repo.UserRepo, name, email string, confirm bool) error {
+	if !services.IsValidUserName(name) {
+		return merry.Errorf("user name '%s' is invalid", name)
+	}
+	user, err := users.FindUserByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if user != nil {
+		return merry.Errorf("user %s already exists", name)
+	}
+
+	_, _ = fmt.Fprint(os.Stderr, "Password: ")
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && password == "" {
+		return merry.Prepend(err, "can't read password")
+	}
+	password = strings.TrimRight(password, "\r\n")
+	if password == "" {
+		return merry.New("password shouldn't be empty")
+	}
+	passwordHash, err := bcrypt.NewPasswordHash().GenerateHash(password)
+	if err != nil {
+		return err
+	}
+
+	userID, err := uuid.NewV4()
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	err = users.AddUser(ctx, userID.String(), name, email, passwordHash)
+	if err != nil {
+		return err
+	}
+	if confirm {
+		_, err = users.ConfirmUser(ctx, userID.String())
+		if err != nil {
+			return err
+		}
+	}
+	logrus.Infof("created user %s with id %s", name, userID.String())
+	return nil
+}
+
+func findUser(ctx context.Context, users repo.UserRepo, idOrName string) (*repo.User, error) {
+	user, err := users.FindUserByIDOrName(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, merry.Errorf("user %s not found", idOrName)
+	}
+	return user, nil
+}
+
+func hubCommand(ctx context.Context, h *admin.Hub, args []string) error {
+	if len(args) == 0 {
+		return admin.ErrUsage.Here()
+	}
+
+	db, err := h.OpenDB()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	hubs := repo.NewMessageHubs(db)
+
+	switch args[0] {
+	case "list":
+		if len(args) != 1 {
+			return admin.ErrUsage.Here()
+		}
+		return listHubs(ctx, hubs)
+	case "approve":
+		fs := flag.NewFlagSet("hub approve", flag.ContinueOnError)
+		skipVerify := fs.Bool("skip-verify", false, "don't check that the hub answers at its address")
+		err := admin.ParseFlags(fs, args[1:])
+		if err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return admin.ErrUsage.Here()
+		}
+		hub, err := findHub(ctx, hubs, fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		if !*skipVerify {
+			err = services.VerifyHubAddress(ctx, hub.Address)
+			if err != nil {
+				return merry.Prepend(err, "can't verify hub")
+			}
+		}
+		err = hubs.ApproveHub(ctx, hub.ID)
+		if err != nil {
+			return err
+		}
+		logrus.Infof("approved hub %s", hub.Address)
+		return nil
+	case "disable":
+		if len(args) != 2 {
+			return admin.ErrUsage.Here()
+		}
+		hub, err := findHub(ctx, hubs, args[1])
+		if err != nil {
+			return err
+		}
+		err = hubs.DisableHub(ctx, hub.ID)
+		if err != nil {
+			return err
+		}
+		logrus.Infof("disabled hub %s", hub.Address)
+		return nil
+	default:
+		return admin.ErrUsage.Here()
+	}
+}
+
+func listHubs(ctx context.Context, hubs repo.MessageHubRepo) error {
+	allHubs, err := hubs.AllHubs(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tADDRESS\tADMIN\tSTATUS\tCREATED AT")
+	for _, hub := range allHubs {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", hub.ID, hub.Address, hub.AdminName, hubStatus(hub), common.TimeToRPCString(hub.CreatedAt))
+	}
+	return w.Flush()
+}
+
+func hubStatus(hub repo.MessageHub) string {
+	switch {
+	case hub.DisabledAt.Valid:
+		return "disabled"
+	case hub.ApprovedAt.Valid:
+		return "approved"
+	default:
+		return "pending"
+	}
+}
+
+func findHub(ctx context.Context, hubs repo.MessageHubRepo, idOrAddress string) (*repo.MessageHub, error) {
+	allHubs, err := hubs.AllHubs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range allHubs {
+		if allHubs[i].ID == idOrAddress || allHubs[i].Address == common.CleanPublicURL(idOrAddress) {
+			return &allHubs[i], nil
+		}
+	}
+	return nil, merry.Errorf("hub %s not found", idOrAddress)
+}
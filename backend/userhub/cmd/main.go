@@ -10,8 +10,10 @@ import (
 
 	"github.com/ansel1/merry"
 	"github.com/rakyll/statik/fs"
+	"github.com/sirupsen/logrus"
 
 	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/metrics"
 	_ "github.com/mreider/koto/backend/statik"
 	"github.com/mreider/koto/backend/token"
 	"github.com/mreider/koto/backend/userhub"
@@ -28,30 +30,40 @@ func main() {
 		log.Fatalln(err)
 	}
 
-	err = common.CreateDatabaseIfNotExist(cfg.DB)
+	err = cfg.Log.Setup()
 	if err != nil {
 		log.Fatalln(err)
 	}
 
-	db, n, err := common.OpenDatabase(cfg.DB, migrate.Migrate)
+	shutdownTracing, err := cfg.Tracing.Setup(context.Background(), "koto-user-hub")
 	if err != nil {
-		log.Fatalln(err)
+		logrus.Fatal(err)
 	}
-	log.Printf("Applied %d migrations to %s\n", n, cfg.DB.DBName)
 
-	s3Storage, err := cfg.S3.CreateStorage()
+	err = common.CreateDatabaseIfNotExist(cfg.DB)
 	if err != nil {
-		log.Fatalln(err)
+		logrus.Fatal(err)
+	}
+
+	db, n, err := common.OpenDatabase(cfg.DB, migrate.Migrate)
+	if err != nil {
+		logrus.Fatal(err)
 	}
+	logrus.Infof("applied %d migrations to %s", n, cfg.DB.DBName)
 
 	err = common.GenerateRSAKey(cfg.PrivateKeyPath)
 	if err != nil {
-		log.Fatalln(err)
+		logrus.Fatal(err)
 	}
 
 	privateKey, publicKey, publicKeyPEM, err := common.RSAKeysFromPrivateKeyFile(cfg.PrivateKeyPath)
 	if err != nil {
-		log.Fatalln(err)
+		logrus.Fatal(err)
+	}
+
+	blobStorage, err := common.CreateBlobStorage(cfg.S3, cfg.Blob, cfg.ExternalAddress, privateKey)
+	if err != nil {
+		logrus.Fatal(err)
 	}
 
 	tokenGenerator := token.NewGenerator(privateKey)
@@ -60,26 +72,33 @@ func main() {
 	})
 
 	repos := repo.Repos{
-		User:         repo.NewUsers(db),
-		Invite:       repo.NewInvites(db),
-		Friend:       repo.NewFriends(db),
-		MessageHubs:  repo.NewMessageHubs(db),
-		Notification: common.NewNotifications(db),
-		FCMToken:     repo.NewFCMToken(db),
+		User:                repo.NewUsers(db),
+		Invite:              repo.NewInvites(db),
+		Friend:              repo.NewFriends(db),
+		MessageHubs:         repo.NewMessageHubs(db),
+		Notification:        common.NewNotifications(db),
+		FCMToken:            repo.NewFCMToken(db),
+		DeviceKey:           repo.NewDeviceKeys(db),
+		Birthday:            repo.NewBirthdays(db),
+		Digest:              repo.NewDigests(db),
+		NotificationSetting: repo.NewNotificationSettings(db),
+		WebPushSubscription: repo.NewWebPushSubscriptions(db),
+		HubNotification:     repo.NewHubNotifications(db),
 	}
 
-	s3Cleaner := common.NewS3Cleaner(db, s3Storage)
-	go s3Cleaner.Clean(context.Background())
+	metrics.RegisterDBStats(metrics.Default, db.DB)
 
 	staticFS, err := fs.New()
 	if err != nil {
-		log.Fatalln(err)
+		logrus.Fatal(err)
 	}
 
-	server := userhub.NewServer(cfg, string(publicKeyPEM), repos, tokenGenerator, tokenParser, s3Storage, staticFS)
-	err = server.Run()
+	server := userhub.NewServer(cfg, db, string(publicKeyPEM), repos, tokenGenerator, tokenParser, blobStorage, staticFS)
+	err = server.Run(common.ShutdownContext())
+	_ = db.Close()
+	_ = shutdownTracing(context.Background())
 	if err != nil {
-		log.Fatalln(err)
+		logrus.Fatal(err)
 	}
 }
 
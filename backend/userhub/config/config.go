@@ -8,25 +8,46 @@ import (
 	"github.com/jinzhu/configor"
 
 	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/common/tracing"
 )
 
 type Config struct {
-	ListenAddress        string `yaml:"address" default:":12001" env:"KOTO_LISTEN_ADDRESS"`
-	PrivateKeyPath       string `yaml:"private_key_path" default:"user_hub.rsa" env:"KOTO_PRIVATE_KEY"`
-	Admins               string `yaml:"admins" env:"KOTO_ADMINS"`
-	TokenDurationSeconds int    `yaml:"token_duration" default:"3600" env:"KOTO_TOKEN_DURATION"`
-	FrontendAddress      string `yaml:"frontend" default:"http://localhost:3000" env:"KOTO_FRONTEND_ADDRESS"`
-	TestMode             bool   `yaml:"test_mode" default:"false" env:"KOTO_TEST_MODE"`
-	AdminFriendship      string `yaml:"admin_friendship" default:"" env:"KOTO_ADMIN_FRIENDSHIP"`
-	FirebaseToken        string `yaml:"firebase_token" default:"" env:"KOTO_FIREBASE_TOKEN"`
-
-	DB   common.DatabaseConfig `yaml:"db"`
-	S3   common.S3Config       `yaml:"s3"`
-	SMTP common.SMTPConfig     `yaml:"smtp"`
+	ListenAddress             string `yaml:"address" default:":12001" env:"KOTO_LISTEN_ADDRESS"`
+	ExternalAddress           string `yaml:"external_address" default:"http://localhost:12001" env:"KOTO_EXTERNAL_ADDRESS"`
+	PrivateKeyPath            string `yaml:"private_key_path" default:"user_hub.rsa" env:"KOTO_PRIVATE_KEY"`
+	Admins                    string `yaml:"admins" env:"KOTO_ADMINS"`
+	TokenDurationSeconds      int    `yaml:"token_duration" default:"3600" env:"KOTO_TOKEN_DURATION"`
+	FrontendAddress           string `yaml:"frontend" default:"http://localhost:3000" env:"KOTO_FRONTEND_ADDRESS"`
+	TestMode                  bool   `yaml:"test_mode" default:"false" env:"KOTO_TEST_MODE"`
+	AdminFriendship           string `yaml:"admin_friendship" default:"" env:"KOTO_ADMIN_FRIENDSHIP"`
+	FirebaseToken             string `yaml:"firebase_token" default:"" env:"KOTO_FIREBASE_TOKEN"`
+	VAPIDPrivateKey           string `yaml:"vapid_private_key" default:"" env:"KOTO_VAPID_PRIVATE_KEY"`
+	VAPIDSubject              string `yaml:"vapid_subject" default:"" env:"KOTO_VAPID_SUBJECT"`
+	MetricsToken              string `yaml:"metrics_token" default:"" env:"KOTO_METRICS_TOKEN"`
+	NotificationRetentionDays int    `yaml:"notification_retention_days" default:"90" env:"KOTO_NOTIFICATION_RETENTION_DAYS"`
+	ShutdownTimeoutSeconds    int    `yaml:"shutdown_timeout" default:"30" env:"KOTO_SHUTDOWN_TIMEOUT"`
+	ShutdownDrainDelaySeconds int    `yaml:"shutdown_drain_delay" default:"5" env:"KOTO_SHUTDOWN_DRAIN_DELAY"`
+
+	DB      common.DatabaseConfig  `yaml:"db"`
+	S3      common.S3Config        `yaml:"s3"`
+	Blob    common.FSStorageConfig `yaml:"blob"`
+	Log     logging.Config         `yaml:"log"`
+	Tracing tracing.Config         `yaml:"tracing"`
+	SMTP    common.SMTPConfig      `yaml:"smtp"`
+	APNs    APNsConfig             `yaml:"apns"`
 
 	adminList []string
 }
 
+type APNsConfig struct {
+	KeyPath string `yaml:"key_path" env:"KOTO_APNS_KEY_PATH"`
+	KeyID   string `yaml:"key_id" env:"KOTO_APNS_KEY_ID"`
+	TeamID  string `yaml:"team_id" env:"KOTO_APNS_TEAM_ID"`
+	Topic   string `yaml:"topic" env:"KOTO_APNS_TOPIC"`
+	Sandbox bool   `yaml:"sandbox" default:"false" env:"KOTO_APNS_SANDBOX"`
+}
+
 func Load(cfgPath string) (Config, error) {
 	cfgPaths := make([]string, 0, 1)
 	if cfgPath != "" {
@@ -44,6 +65,10 @@ func Load(cfgPath string) (Config, error) {
 	}
 
 	cfg.FrontendAddress = common.CleanPublicURL(cfg.FrontendAddress)
+	cfg.ExternalAddress = common.CleanPublicURL(cfg.ExternalAddress)
+	if cfg.VAPIDSubject == "" {
+		cfg.VAPIDSubject = cfg.FrontendAddress
+	}
 
 	return cfg, nil
 }
@@ -64,3 +89,15 @@ func (cfg Config) IsAdmin(userName string) bool {
 func (cfg Config) AdminList() []string {
 	return cfg.adminList
 }
+
+func (cfg Config) NotificationRetention() time.Duration {
+	return time.Duration(cfg.NotificationRetentionDays) * time.Hour * 24
+}
+
+func (cfg Config) ShutdownTimeout() time.Duration {
+	return time.Duration(cfg.ShutdownTimeoutSeconds) * time.Second
+}
+
+func (cfg Config) ShutdownDrainDelay() time.Duration {
+	return time.Duration(cfg.ShutdownDrainDelaySeconds) * time.Second
+}
@@ -0,0 +1,40 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002o() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002o",
+		Up: []string{
+			`
+create table device_keys
+(
+	user_id text not null constraint device_keys_users_id_fk references users,
+	device_id text not null,
+	identity_key text not null,
+	signed_prekey_id int not null,
+	signed_prekey text not null,
+	signed_prekey_signature text not null,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null,
+	constraint device_keys_pk primary key (user_id, device_id)
+);
+`,
+			`
+create table device_prekeys
+(
+	user_id text not null,
+	device_id text not null,
+	key_id int not null,
+	public_key text not null,
+	created_at timestamp with time zone not null,
+	constraint device_prekeys_pk primary key (user_id, device_id, key_id),
+	constraint device_prekeys_device_keys_fk foreign key (user_id, device_id) references device_keys (user_id, device_id)
+);
+`,
+		},
+		Down: []string{},
+	}
+}
@@ -0,0 +1,28 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002p() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002p",
+		Up: []string{
+			`
+alter table users add birthday date;
+alter table users add hide_birthday_year boolean default false not null;
+alter table users add time_zone text default '' not null;
+`,
+			`
+create table birthday_digests
+(
+	user_id text not null constraint birthday_digests_users_id_fk references users,
+	day date not null,
+	created_at timestamp with time zone not null,
+	constraint birthday_digests_pk primary key (user_id, day)
+);
+`,
+		},
+		Down: []string{},
+	}
+}
@@ -0,0 +1,27 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002q() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002q",
+		Up: []string{
+			`
+alter table users add digest_frequency text default 'off' not null;
+`,
+			`
+create table email_digests
+(
+	user_id text not null constraint email_digests_users_id_fk references users,
+	period_start date not null,
+	created_at timestamp with time zone not null,
+	sent_at timestamp with time zone,
+	constraint email_digests_pk primary key (user_id, period_start)
+);
+`,
+		},
+		Down: []string{},
+	}
+}
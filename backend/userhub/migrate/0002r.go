@@ -0,0 +1,36 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002r() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002r",
+		Up: []string{
+			`
+create table notification_settings
+(
+	user_id text not null constraint notification_settings_users_id_fk references users,
+	type text not null,
+	in_app boolean not null,
+	push boolean not null,
+	email boolean not null,
+	updated_at timestamp with time zone not null,
+	constraint notification_settings_pk primary key (user_id, type)
+);
+`,
+			`
+create table notification_mutes
+(
+	user_id text not null constraint notification_mutes_users_id_fk references users,
+	kind text not null,
+	target_id text not null,
+	created_at timestamp with time zone not null,
+	constraint notification_mutes_pk primary key (user_id, kind, target_id)
+);
+`,
+		},
+		Down: []string{},
+	}
+}
@@ -0,0 +1,30 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002t() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002t",
+		Up: []string{
+			`
+create table web_push_subscriptions
+(
+	user_id text not null constraint web_push_subscriptions_users_id_fk references users,
+	endpoint text not null,
+	p256dh text not null,
+	auth text not null,
+	device_id text not null,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null,
+	constraint web_push_subscriptions_pk primary key (user_id, endpoint)
+);
+`,
+			`
+create index web_push_subscriptions_endpoint_index on web_push_subscriptions (endpoint);
+`,
+		},
+		Down: []string{},
+	}
+}
@@ -0,0 +1,18 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002u() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002u",
+		Up: []string{
+			`
+alter table fcm_tokens add failure_count integer default 0 not null;
+alter table fcm_tokens add last_failure_at timestamp with time zone;
+`,
+		},
+		Down: []string{},
+	}
+}
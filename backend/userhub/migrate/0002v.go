@@ -0,0 +1,26 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002v() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002v",
+		Up: []string{
+			`
+create table hub_notification_keys
+(
+	node text not null,
+	id text not null,
+	created_at timestamp with time zone not null,
+	constraint hub_notification_keys_pk primary key (node, id)
+);
+`,
+			`
+create index hub_notification_keys_created_at_index on hub_notification_keys (created_at);
+`,
+		},
+		Down: []string{},
+	}
+}
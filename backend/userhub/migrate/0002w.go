@@ -0,0 +1,17 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002w() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002w",
+		Up: []string{
+			`
+alter table users add is_admin boolean default false not null;
+`,
+		},
+		Down: []string{},
+	}
+}
@@ -0,0 +1,20 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002x() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002x",
+		Up: []string{
+			`
+alter table email_digests add claimed_at timestamp with time zone;
+update email_digests set claimed_at = created_at;
+alter table email_digests alter column claimed_at set not null;
+alter table email_digests add attempts integer default 1 not null;
+`,
+		},
+		Down: []string{},
+	}
+}
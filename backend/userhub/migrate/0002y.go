@@ -0,0 +1,20 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002y() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002y",
+		Up: []string{
+			`
+alter table hub_notification_keys add notification jsonb;
+alter table hub_notification_keys add sent_at timestamp with time zone;
+update hub_notification_keys set sent_at = created_at;
+create index hub_notification_keys_unsent_index on hub_notification_keys (created_at) where sent_at is null;
+`,
+		},
+		Down: []string{},
+	}
+}
@@ -0,0 +1,17 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0002z() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0002z",
+		Up: []string{
+			`
+alter table email_digests alter column claimed_at drop not null;
+`,
+		},
+		Down: []string{},
+	}
+}
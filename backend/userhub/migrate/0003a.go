@@ -0,0 +1,20 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0003a() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0003a",
+		Up: []string{
+			`
+alter table notifications add updated_at timestamp with time zone;
+update notifications set updated_at = created_at;
+alter table notifications alter column updated_at set not null;
+create index notifications_user_id_updated_at_index on notifications (user_id, updated_at);
+`,
+		},
+		Down: []string{},
+	}
+}
@@ -0,0 +1,21 @@
+package migrate
+
+import (
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func migration0003b() *migrate.Migration {
+	return &migrate.Migration{
+		Id: "0003b",
+		Up: []string{
+			`
+alter table fcm_tokens add provider text;
+update fcm_tokens set provider = case when os in ('apns', 'unifiedpush') then os else 'fcm' end;
+alter table fcm_tokens alter column provider set not null;
+alter table fcm_tokens add p256dh text default '' not null;
+alter table fcm_tokens add auth text default '' not null;
+`,
+		},
+		Down: []string{},
+	}
+}
@@ -6,8 +6,9 @@ import (
 	migrate "github.com/rubenv/sql-migrate"
 )
 
-func Migrate(db *sqlx.DB, dialect string) (n int, err error) {
-	migrationSource := &migrate.MemoryMigrationSource{
+// Migrations returns the migrations of the hub database in the order they are applied.
+func Migrations() migrate.MigrationSource {
+	return &migrate.MemoryMigrationSource{
 		Migrations: []*migrate.Migration{
 			migration0002a(),
 			migration0002b(),
@@ -23,10 +24,26 @@ func Migrate(db *sqlx.DB, dialect string) (n int, err error) {
 			migration0002l(),
 			migration0002m(),
 			migration0002n(),
+			migration0002o(),
+			migration0002p(),
+			migration0002q(),
+			migration0002r(),
+			migration0002s(),
+			migration0002t(),
+			migration0002u(),
+			migration0002v(),
+			migration0002w(),
+			migration0002x(),
+			migration0002y(),
+			migration0002z(),
+			migration0003a(),
+			migration0003b(),
 		},
 	}
+}
 
-	n, err = migrate.Exec(db.DB, dialect, migrationSource, migrate.Up)
+func Migrate(db *sqlx.DB, dialect string) (n int, err error) {
+	n, err = migrate.Exec(db.DB, dialect, Migrations(), migrate.Up)
 	if err != nil {
 		return 0, merry.Wrap(err)
 	}
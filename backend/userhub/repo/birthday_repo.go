@@ -0,0 +1,91 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+)
+
+type FriendBirthday struct {
+	UserID           string       `db:"user_id"`
+	UserName         string       `db:"user_name"`
+	UserEmail        string       `db:"user_email"`
+	UserTimeZone     string       `db:"user_time_zone"`
+	FriendID         string       `db:"friend_id"`
+	FriendName       string       `db:"friend_name"`
+	Birthday         sql.NullTime `db:"birthday"`
+	HideBirthdayYear bool         `db:"hide_birthday_year"`
+}
+
+type BirthdayRepo interface {
+	FriendBirthdays(ctx context.Context, monthDays []string) ([]FriendBirthday, error)
+	ClaimDigest(ctx context.Context, userID string, day, now time.Time) (bool, error)
+	ReleaseDigest(ctx context.Context, userID string, day time.Time) error
+}
+
+type birthdayRepo struct {
+	db *sqlx.DB
+}
+
+func NewBirthdays(db *sqlx.DB) BirthdayRepo {
+	return &birthdayRepo{
+		db: db,
+	}
+}
+
+// FriendBirthdays returns users whose friends have birthdays on the given days formatted as MM-DD.
+func (r *birthdayRepo) FriendBirthdays(ctx context.Context, monthDays []string) ([]FriendBirthday, error) {
+	if len(monthDays) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(`
+		select u.id user_id, u.name user_name, u.email user_email, u.time_zone user_time_zone,
+		       b.id friend_id, b.name friend_name, b.birthday, b.hide_birthday_year
+		from friends f
+			inner join users u on u.id = f.user_id
+			inner join users b on b.id = f.friend_id
+		where b.birthday is not null and to_char(b.birthday, 'MM-DD') in (?)
+		order by u.id, b.name`, monthDays)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	var birthdays []FriendBirthday
+	err = r.db.SelectContext(ctx, &birthdays, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return birthdays, nil
+}
+
+// ClaimDigest reserves the birthday digest of the day, so it is sent once.
+func (r *birthdayRepo) ClaimDigest(ctx context.Context, userID string, day, now time.Time) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `
+		insert into birthday_digests(user_id, day, created_at)
+		values ($1, $2, $3)
+		on conflict (user_id, day) do nothing`,
+		userID, day.Format("2006-01-02"), now)
+	if err != nil {
+		return false, merry.Wrap(err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, merry.Wrap(err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// ReleaseDigest lets the digest which wasn't delivered be claimed again.
+func (r *birthdayRepo) ReleaseDigest(ctx context.Context, userID string, day time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		delete from birthday_digests
+		where user_id = $1 and day = $2`,
+		userID, day.Format("2006-01-02"))
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+func TestBirthdayRepo_ClaimDigest(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	users := NewUsers(te.DB)
+	birthdays := NewBirthdays(te.DB)
+	require.Nil(t, users.AddUser(te.Ctx, "1", "user1", "user1@mail.org", "password1-hash"))
+
+	now := common.CurrentTimestamp()
+	day1 := time.Date(2021, time.May, 3, 9, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	claim := func(day time.Time) bool {
+		claimed, err := birthdays.ClaimDigest(te.Ctx, "1", day, now)
+		require.Nil(t, err)
+		return claimed
+	}
+
+	assert.True(t, claim(day1))
+	assert.False(t, claim(day1), "the digest is claimed")
+	assert.True(t, claim(day2))
+
+	require.Nil(t, birthdays.ReleaseDigest(te.Ctx, "1", day1))
+	assert.True(t, claim(day1), "a released digest is claimed again")
+	assert.False(t, claim(day2))
+}
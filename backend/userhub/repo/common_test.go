@@ -0,0 +1,10 @@
+package repo
+
+import (
+	"github.com/mreider/koto/backend/common/dbtest"
+	"github.com/mreider/koto/backend/userhub/migrate"
+)
+
+func NewTestEnvironment() *dbtest.TestEnvironment {
+	return dbtest.NewTestEnvironment("userhub_repo", migrate.Migrate)
+}
@@ -0,0 +1,180 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+type DeviceKey struct {
+	UserID                string    `db:"user_id"`
+	DeviceID              string    `db:"device_id"`
+	IdentityKey           string    `db:"identity_key"`
+	SignedPrekeyID        int       `db:"signed_prekey_id"`
+	SignedPrekey          string    `db:"signed_prekey"`
+	SignedPrekeySignature string    `db:"signed_prekey_signature"`
+	CreatedAt             time.Time `db:"created_at"`
+	UpdatedAt             time.Time `db:"updated_at"`
+}
+
+type DevicePrekey struct {
+	KeyID     int    `db:"key_id"`
+	PublicKey string `db:"public_key"`
+}
+
+type DeviceKeyRepo interface {
+	SetDeviceKey(ctx context.Context, key DeviceKey) error
+	AddPrekeys(ctx context.Context, userID, deviceID string, prekeys []DevicePrekey) error
+	PrekeyCount(ctx context.Context, userID, deviceID string) (int, error)
+	DeviceKeys(ctx context.Context, userIDs []string) ([]DeviceKey, error)
+	ClaimPrekey(ctx context.Context, userID, deviceID string) (*DevicePrekey, error)
+	RemoveDevice(ctx context.Context, userID, deviceID string) error
+}
+
+type deviceKeyRepo struct {
+	db *sqlx.DB
+}
+
+func NewDeviceKeys(db *sqlx.DB) DeviceKeyRepo {
+	return &deviceKeyRepo{
+		db: db,
+	}
+}
+
+func (r *deviceKeyRepo) SetDeviceKey(ctx context.Context, key DeviceKey) error {
+	return common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		var identityKey string
+		err := tx.GetContext(ctx, &identityKey, `
+			select identity_key
+			from device_keys
+			where user_id = $1 and device_id = $2`,
+			key.UserID, key.DeviceID)
+		if err != nil && !merry.Is(err, sql.ErrNoRows) {
+			return merry.Wrap(err)
+		}
+
+		// prekeys were generated for the previous identity and can't be used anymore
+		if identityKey != "" && identityKey != key.IdentityKey {
+			_, err = tx.ExecContext(ctx, `
+				delete from device_prekeys
+				where user_id = $1 and device_id = $2`,
+				key.UserID, key.DeviceID)
+			if err != nil {
+				return merry.Wrap(err)
+			}
+		}
+
+		now := common.CurrentTimestamp()
+		_, err = tx.ExecContext(ctx, `
+			insert into device_keys(user_id, device_id, identity_key, signed_prekey_id, signed_prekey, signed_prekey_signature, created_at, updated_at)
+			values ($1, $2, $3, $4, $5, $6, $7, $7)
+			on conflict (user_id, device_id) do update
+				set identity_key = $3, signed_prekey_id = $4, signed_prekey = $5, signed_prekey_signature = $6, updated_at = $7`,
+			key.UserID, key.DeviceID, key.IdentityKey, key.SignedPrekeyID, key.SignedPrekey, key.SignedPrekeySignature, now)
+		if err != nil {
+			return merry.Wrap(err)
+		}
+		return nil
+	})
+}
+
+func (r *deviceKeyRepo) AddPrekeys(ctx context.Context, userID, deviceID string, prekeys []DevicePrekey) error {
+	return common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		now := common.CurrentTimestamp()
+		for _, prekey := range prekeys {
+			_, err := tx.ExecContext(ctx, `
+				insert into device_prekeys(user_id, device_id, key_id, public_key, created_at)
+				values ($1, $2, $3, $4, $5)
+				on conflict (user_id, device_id, key_id) do update set public_key = $4, created_at = $5`,
+				userID, deviceID, prekey.KeyID, prekey.PublicKey, now)
+			if err != nil {
+				return merry.Wrap(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *deviceKeyRepo) PrekeyCount(ctx context.Context, userID, deviceID string) (int, error) {
+	var count int
+	err := r.db.GetContext(ctx, &count, `
+		select count(*)
+		from device_prekeys
+		where user_id = $1 and device_id = $2`,
+		userID, deviceID)
+	if err != nil {
+		return 0, merry.Wrap(err)
+	}
+	return count, nil
+}
+
+func (r *deviceKeyRepo) DeviceKeys(ctx context.Context, userIDs []string) ([]DeviceKey, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(`
+		select user_id, device_id, identity_key, signed_prekey_id, signed_prekey, signed_prekey_signature, created_at, updated_at
+		from device_keys
+		where user_id in (?)
+		order by user_id, device_id`, userIDs)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	query = r.db.Rebind(query)
+
+	var keys []DeviceKey
+	err = r.db.SelectContext(ctx, &keys, query, args...)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return keys, nil
+}
+
+func (r *deviceKeyRepo) ClaimPrekey(ctx context.Context, userID, deviceID string) (*DevicePrekey, error) {
+	var prekey DevicePrekey
+	err := r.db.GetContext(ctx, &prekey, `
+		delete from device_prekeys
+		where (user_id, device_id, key_id) = (
+			select user_id, device_id, key_id
+			from device_prekeys
+			where user_id = $1 and device_id = $2
+			order by key_id
+			limit 1
+			for update skip locked)
+		returning key_id, public_key`,
+		userID, deviceID)
+	if err != nil {
+		if merry.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, merry.Wrap(err)
+	}
+	return &prekey, nil
+}
+
+func (r *deviceKeyRepo) RemoveDevice(ctx context.Context, userID, deviceID string) error {
+	return common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			delete from device_prekeys
+			where user_id = $1 and device_id = $2`,
+			userID, deviceID)
+		if err != nil {
+			return merry.Wrap(err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			delete from device_keys
+			where user_id = $1 and device_id = $2`,
+			userID, deviceID)
+		if err != nil {
+			return merry.Wrap(err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,115 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+const (
+	DigestOff    = "off"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+
+	digestMaxAttempts = 3
+)
+
+type DigestRepo interface {
+	DigestRecipients(ctx context.Context) ([]User, error)
+	ClaimDigest(ctx context.Context, userID string, periodStart, now time.Time) (bool, error)
+	ReleaseDigest(ctx context.Context, userID string, periodStart time.Time) error
+	MarkDigestSent(ctx context.Context, userID string, periodStart, now time.Time) error
+	UnreadNotifications(ctx context.Context, userID string, since time.Time, count int) ([]common.Notification, error)
+}
+
+type digestRepo struct {
+	db *sqlx.DB
+}
+
+func NewDigests(db *sqlx.DB) DigestRepo {
+	return &digestRepo{
+		db: db,
+	}
+}
+
+func (r *digestRepo) DigestRecipients(ctx context.Context) ([]User, error) {
+	var users []User
+	err := r.db.SelectContext(ctx, &users, `
+		select id, name, email, time_zone, digest_frequency
+		from users
+		where digest_frequency in ($1, $2) and email <> '' and confirmed_at is not null
+		order by id`,
+		DigestDaily, DigestWeekly)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return users, nil
+}
+
+// ClaimDigest reserves the digest for the period, so it isn't sent twice even if the process restarts.
+// A claimed digest isn't claimed again until it is released, a digest is released up to digestMaxAttempts times.
+func (r *digestRepo) ClaimDigest(ctx context.Context, userID string, periodStart, now time.Time) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `
+		insert into email_digests(user_id, period_start, created_at, claimed_at)
+		values ($1, $2, $3, $3)
+		on conflict (user_id, period_start) do update
+		set claimed_at = $3, attempts = email_digests.attempts + 1
+		where email_digests.sent_at is null and email_digests.claimed_at is null and email_digests.attempts < $4`,
+		userID, periodStart.Format("2006-01-02"), now, digestMaxAttempts)
+	if err != nil {
+		return false, merry.Wrap(err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, merry.Wrap(err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// ReleaseDigest lets the digest which wasn't delivered be claimed again.
+func (r *digestRepo) ReleaseDigest(ctx context.Context, userID string, periodStart time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		update email_digests
+		set claimed_at = null
+		where user_id = $1 and period_start = $2 and sent_at is null`,
+		userID, periodStart.Format("2006-01-02"))
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+func (r *digestRepo) MarkDigestSent(ctx context.Context, userID string, periodStart, now time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		update email_digests
+		set sent_at = $1
+		where user_id = $2 and period_start = $3`,
+		now, userID, periodStart.Format("2006-01-02"))
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+func (r *digestRepo) UnreadNotifications(ctx context.Context, userID string, since time.Time, count int) ([]common.Notification, error) {
+	var notifications []common.Notification
+	err := r.db.SelectContext(ctx, &notifications, `
+		select n.id, n.user_id, n.text, n.type, n.data, n.created_at, n.updated_at, n.read_at
+		from notifications n
+		where n.user_id = $1 and n.read_at is null and n.updated_at >= $2
+		  and not exists(
+			select *
+			from notification_settings s
+			where s.user_id = n.user_id and s.type = n.type and not s.email)
+		order by n.updated_at desc
+		limit $3`,
+		userID, since, count)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return notifications, nil
+}
@@ -0,0 +1,46 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+func TestDigestRepo_ClaimDigest(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	users := NewUsers(te.DB)
+	digests := NewDigests(te.DB)
+	require.Nil(t, users.AddUser(te.Ctx, "1", "user1", "user1@mail.org", "password1-hash"))
+
+	now := common.CurrentTimestamp()
+	day1 := time.Date(2021, time.May, 3, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	claim := func(periodStart time.Time, now time.Time) bool {
+		claimed, err := digests.ClaimDigest(te.Ctx, "1", periodStart, now)
+		require.Nil(t, err)
+		return claimed
+	}
+
+	assert.True(t, claim(day1, now))
+	assert.False(t, claim(day1, now), "the digest is claimed")
+	assert.False(t, claim(day1, now.Add(time.Hour*24)), "the claim doesn't time out")
+
+	require.Nil(t, digests.ReleaseDigest(te.Ctx, "1", day1))
+	assert.True(t, claim(day1, now), "a released digest is retried")
+	require.Nil(t, digests.ReleaseDigest(te.Ctx, "1", day1))
+	assert.True(t, claim(day1, now))
+	require.Nil(t, digests.ReleaseDigest(te.Ctx, "1", day1))
+	assert.False(t, claim(day1, now), "the digest is attempted 3 times at most")
+
+	assert.True(t, claim(day2, now))
+	require.Nil(t, digests.MarkDigestSent(te.Ctx, "1", day2, now))
+	require.Nil(t, digests.ReleaseDigest(te.Ctx, "1", day2))
+	assert.False(t, claim(day2, now.Add(time.Hour)), "a sent digest isn't claimed again")
+}
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"time"
 
 	"github.com/ansel1/merry"
@@ -13,12 +14,19 @@ type FCMToken struct {
 	UserID    string    `db:"user_id"`
 	Token     string    `db:"token"`
 	DeviceID  string    `db:"device_id"`
+	OS        string    `db:"os"`
+	Provider  string    `db:"provider"`
+	P256dh    string    `db:"p256dh"`
+	Auth      string    `db:"auth"`
 	CreatedAt time.Time `db:"created_at"`
 }
 
 type FCMTokenRepo interface {
-	AddToken(userID, token, deviceID, os string) error
-	UsersTokens(userIDs []string) ([]string, error)
+	AddToken(ctx context.Context, userID string, token FCMToken) error
+	UsersTokens(ctx context.Context, userIDs []string) ([]FCMToken, error)
+	TokenSucceeded(ctx context.Context, token string) error
+	TokenFailed(ctx context.Context, token string, maxFailures int) error
+	DeleteToken(ctx context.Context, token string) error
 }
 
 type fcmTokenRepo struct {
@@ -31,33 +39,66 @@ func NewFCMToken(db *sqlx.DB) FCMTokenRepo {
 	}
 }
 
-func (r *fcmTokenRepo) AddToken(userID, token, deviceID, os string) error {
-	_, err := r.db.Exec(`
-		insert into fcm_tokens(user_id, token, device_id, os, created_at, updated_at)
-		values($1, $2, $3, $4, $5, $5)
-		on conflict (user_id, token) do update set device_id = $3, os = $4, updated_at = $5, deleted_at = null;`,
-		userID, token, deviceID, os, common.CurrentTimestamp())
+func (r *fcmTokenRepo) AddToken(ctx context.Context, userID string, token FCMToken) error {
+	_, err := r.db.ExecContext(ctx, `
+		insert into fcm_tokens(user_id, token, device_id, os, provider, p256dh, auth, created_at, updated_at)
+		values($1, $2, $3, $4, $5, $6, $7, $8, $8)
+		on conflict (user_id, token) do update
+		set device_id = $3, os = $4, provider = $5, p256dh = $6, auth = $7, updated_at = $8, deleted_at = null, failure_count = 0;`,
+		userID, token.Token, token.DeviceID, token.OS, token.Provider, token.P256dh, token.Auth, common.CurrentTimestamp())
 	return merry.Wrap(err)
 }
 
-func (r *fcmTokenRepo) UsersTokens(userIDs []string) ([]string, error) {
+func (r *fcmTokenRepo) UsersTokens(ctx context.Context, userIDs []string) ([]FCMToken, error) {
 	if len(userIDs) == 0 {
 		return nil, nil
 	}
 
 	query, args, err := sqlx.In(`
-		select distinct token
+		select distinct on (token) user_id, token, device_id, os, provider, p256dh, auth, created_at
 		from fcm_tokens
-		where user_id in (?) and deleted_at is null;`, userIDs)
+		where user_id in (?) and deleted_at is null
+		order by token, updated_at desc;`, userIDs)
 	if err != nil {
 		return nil, merry.Wrap(err)
 	}
 	query = r.db.Rebind(query)
 
-	var tokens []string
-	err = r.db.Select(&tokens, query, args...)
+	var tokens []FCMToken
+	err = r.db.SelectContext(ctx, &tokens, query, args...)
 	if err != nil {
 		return nil, merry.Wrap(err)
 	}
 	return tokens, nil
 }
+
+func (r *fcmTokenRepo) TokenSucceeded(ctx context.Context, token string) error {
+	_, err := r.db.ExecContext(ctx, `
+		update fcm_tokens
+		set failure_count = 0
+		where token = $1 and failure_count > 0;`,
+		token)
+	return merry.Wrap(err)
+}
+
+// TokenFailed counts a delivery failure and soft-deletes the token after maxFailures failures in a row.
+func (r *fcmTokenRepo) TokenFailed(ctx context.Context, token string, maxFailures int) error {
+	now := common.CurrentTimestamp()
+	_, err := r.db.ExecContext(ctx, `
+		update fcm_tokens
+		set failure_count = failure_count + 1,
+		    last_failure_at = $1,
+		    deleted_at = case when failure_count + 1 >= $2 then $1 else deleted_at end
+		where token = $3 and deleted_at is null;`,
+		now, maxFailures, token)
+	return merry.Wrap(err)
+}
+
+func (r *fcmTokenRepo) DeleteToken(ctx context.Context, token string) error {
+	_, err := r.db.ExecContext(ctx, `
+		update fcm_tokens
+		set deleted_at = $1
+		where token = $2 and deleted_at is null;`,
+		common.CurrentTimestamp(), token)
+	return merry.Wrap(err)
+}
@@ -1,15 +1,17 @@
 package repo
 
 import (
+	"context"
+
 	"github.com/ansel1/merry"
 	"github.com/jmoiron/sqlx"
 )
 
 type FriendRepo interface {
-	Friends(user User) ([]User, error)
-	FriendsWithSubFriends(user User) (map[User][]User, error)
-	FriendsOfFriends(user User) (map[User][]User, error)
-	AreFriends(user1, user2 User) (bool, error)
+	Friends(ctx context.Context, user User) ([]User, error)
+	FriendsWithSubFriends(ctx context.Context, user User) (map[User][]User, error)
+	FriendsOfFriends(ctx context.Context, user User) (map[User][]User, error)
+	AreFriends(ctx context.Context, user1, user2 User) (bool, error)
 }
 
 type friendRepo struct {
@@ -22,9 +24,9 @@ func NewFriends(db *sqlx.DB) FriendRepo {
 	}
 }
 
-func (r *friendRepo) Friends(user User) ([]User, error) {
+func (r *friendRepo) Friends(ctx context.Context, user User) ([]User, error) {
 	var friends []User
-	err := r.db.Select(&friends, `
+	err := r.db.SelectContext(ctx, &friends, `
 		select id, name, avatar_thumbnail_id
 		from users
 		where id in (
@@ -38,7 +40,7 @@ func (r *friendRepo) Friends(user User) ([]User, error) {
 	return friends, nil
 }
 
-func (r *friendRepo) FriendsWithSubFriends(user User) (map[User][]User, error) {
+func (r *friendRepo) FriendsWithSubFriends(ctx context.Context, user User) (map[User][]User, error) {
 	result := make(map[User][]User)
 
 	var items []struct {
@@ -49,7 +51,7 @@ func (r *friendRepo) FriendsWithSubFriends(user User) (map[User][]User, error) {
 		FriendName     string `db:"friend_name"`
 		FriendAvatarID string `db:"friend_avatar_id"`
 	}
-	err := r.db.Select(&items, `
+	err := r.db.SelectContext(ctx, &items, `
 		select f.user_id user_id, uu.name user_name, uu.avatar_thumbnail_id user_avatar_id,
 			f.friend_id friend_id, uf.name friend_name, uf.avatar_thumbnail_id friend_avatar_id
 		from friends f
@@ -69,7 +71,7 @@ func (r *friendRepo) FriendsWithSubFriends(user User) (map[User][]User, error) {
 	return result, nil
 }
 
-func (r *friendRepo) FriendsOfFriends(user User) (map[User][]User, error) {
+func (r *friendRepo) FriendsOfFriends(ctx context.Context, user User) (map[User][]User, error) {
 	result := make(map[User][]User)
 
 	var items []struct {
@@ -80,7 +82,7 @@ func (r *friendRepo) FriendsOfFriends(user User) (map[User][]User, error) {
 		FriendName     string `db:"friend_name"`
 		FriendAvatarID string `db:"friend_avatar_id"`
 	}
-	err := r.db.Select(&items, `
+	err := r.db.SelectContext(ctx, &items, `
 		select f.friend_id user_id, uf.name user_name, uf.avatar_thumbnail_id user_avatar_id,
 		       f.user_id friend_id, uu.name friend_name, uu.avatar_thumbnail_id friend_avatar_id 
 		from friends f
@@ -102,9 +104,9 @@ func (r *friendRepo) FriendsOfFriends(user User) (map[User][]User, error) {
 	return result, nil
 }
 
-func (r *friendRepo) AreFriends(user1, user2 User) (bool, error) {
+func (r *friendRepo) AreFriends(ctx context.Context, user1, user2 User) (bool, error) {
 	var areFriends bool
-	err := r.db.Get(&areFriends, `
+	err := r.db.GetContext(ctx, &areFriends, `
 		select case when exists(select * from friends where user_id = $1 and friend_id = $2) then true else false end
 		`, user1.ID, user2.ID)
 	if err != nil {
@@ -0,0 +1,112 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/types"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+const (
+	hubNotificationKeyTTL = time.Hour * 24 * 7
+)
+
+type HubNotification struct {
+	Node         string         `db:"node"`
+	ID           string         `db:"id"`
+	Notification types.JSONText `db:"notification"`
+}
+
+type HubNotificationRepo interface {
+	ClaimNotifications(ctx context.Context, node string, notifications []HubNotification) (map[string]bool, error)
+	UnsentNotifications(ctx context.Context, count int) ([]HubNotification, error)
+	MarkNotificationsSent(ctx context.Context, notifications []HubNotification, sentAt time.Time) error
+}
+
+type hubNotificationRepo struct {
+	db *sqlx.DB
+}
+
+func NewHubNotifications(db *sqlx.DB) HubNotificationRepo {
+	return &hubNotificationRepo{
+		db: db,
+	}
+}
+
+// ClaimNotifications records the notifications posted by a message hub by their idempotency keys
+// and returns the keys that haven't been seen before. The recorded notifications are sent by the notification sender.
+func (r *hubNotificationRepo) ClaimNotifications(ctx context.Context, node string, notifications []HubNotification) (map[string]bool, error) {
+	claimed := make(map[string]bool, len(notifications))
+	if len(notifications) == 0 {
+		return claimed, nil
+	}
+
+	now := common.CurrentTimestamp()
+	err := common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			delete from hub_notification_keys
+			where created_at < $1 and sent_at is not null`,
+			now.Add(-hubNotificationKeyTTL))
+		if err != nil {
+			return merry.Wrap(err)
+		}
+
+		for _, notification := range notifications {
+			res, err := tx.ExecContext(ctx, `
+				insert into hub_notification_keys(node, id, notification, created_at)
+				values ($1, $2, $3, $4)
+				on conflict (node, id) do nothing`,
+				node, notification.ID, notification.Notification, now)
+			if err != nil {
+				return merry.Wrap(err)
+			}
+			rowsAffected, err := res.RowsAffected()
+			if err != nil {
+				return merry.Wrap(err)
+			}
+			if rowsAffected > 0 {
+				claimed[notification.ID] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+func (r *hubNotificationRepo) UnsentNotifications(ctx context.Context, count int) ([]HubNotification, error) {
+	var notifications []HubNotification
+	err := r.db.SelectContext(ctx, &notifications, `
+		select node, id, notification
+		from hub_notification_keys
+		where sent_at is null
+		order by created_at, id
+		limit $1`,
+		count)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return notifications, nil
+}
+
+func (r *hubNotificationRepo) MarkNotificationsSent(ctx context.Context, notifications []HubNotification, sentAt time.Time) error {
+	return common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		for _, notification := range notifications {
+			_, err := tx.ExecContext(ctx, `
+				update hub_notification_keys
+				set sent_at = $1
+				where node = $2 and id = $3`,
+				sentAt, notification.Node, notification.ID)
+			if err != nil {
+				return merry.Wrap(err)
+			}
+		}
+		return nil
+	})
+}
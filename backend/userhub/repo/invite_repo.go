@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
@@ -30,13 +31,13 @@ type Invite struct {
 }
 
 type InviteRepo interface {
-	AddInvite(userID, friendID string) error
-	AddInviteByEmail(userID, friendEmail string) error
-	AcceptInvite(inviterID, friendID string, autoAccepted bool) error
-	RejectInvite(inviterID, friendID string) error
-	InvitesFromMe(user User) ([]Invite, error)
-	InvitesForMe(user User) ([]Invite, error)
-	InviteStatuses(user User) (map[string]string, error)
+	AddInvite(ctx context.Context, userID, friendID string) error
+	AddInviteByEmail(ctx context.Context, userID, friendEmail string) error
+	AcceptInvite(ctx context.Context, inviterID, friendID string, autoAccepted bool) error
+	RejectInvite(ctx context.Context, inviterID, friendID string) error
+	InvitesFromMe(ctx context.Context, user User) ([]Invite, error)
+	InvitesForMe(ctx context.Context, user User) ([]Invite, error)
+	InviteStatuses(ctx context.Context, user User) (map[string]string, error)
 }
 
 type inviteRepo struct {
@@ -49,8 +50,8 @@ func NewInvites(db *sqlx.DB) InviteRepo {
 	}
 }
 
-func (r *inviteRepo) AddInvite(inviterID, friendID string) error {
-	_, err := r.db.Exec(`
+func (r *inviteRepo) AddInvite(ctx context.Context, inviterID, friendID string) error {
+	_, err := r.db.ExecContext(ctx, `
 		insert into invites(user_id, friend_id, friend_email, created_at)
 		select $1, $2, '', $3
 		where not exists(select * from invites where user_id = $1 and friend_id = $2 and rejected_at is null)`,
@@ -58,8 +59,8 @@ func (r *inviteRepo) AddInvite(inviterID, friendID string) error {
 	return merry.Wrap(err)
 }
 
-func (r *inviteRepo) AddInviteByEmail(inviterID, friendEmail string) error {
-	_, err := r.db.Exec(`
+func (r *inviteRepo) AddInviteByEmail(ctx context.Context, inviterID, friendEmail string) error {
+	_, err := r.db.ExecContext(ctx, `
 		insert into invites(user_id, friend_email, created_at)
 		select $1, $2, $3
 		where not exists(select * from invites where user_id = $1 and friend_email = $2 and rejected_at is null)`,
@@ -67,9 +68,9 @@ func (r *inviteRepo) AddInviteByEmail(inviterID, friendEmail string) error {
 	return merry.Wrap(err)
 }
 
-func (r *inviteRepo) AcceptInvite(inviterID, friendID string, autoAccepted bool) error {
-	return common.RunInTransaction(r.db, func(tx *sqlx.Tx) error {
-		res, err := tx.Exec(`
+func (r *inviteRepo) AcceptInvite(ctx context.Context, inviterID, friendID string, autoAccepted bool) error {
+	return common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		res, err := tx.ExecContext(ctx, `
 		update invites
 		set accepted_at = $1, auto_accepted = $2
 		where user_id = $3 and friend_id = $4 and rejected_at is null`,
@@ -85,7 +86,7 @@ func (r *inviteRepo) AcceptInvite(inviterID, friendID string, autoAccepted bool)
 			return ErrInviteNotFound.Here()
 		}
 
-		_, err = tx.Exec(`
+		_, err = tx.ExecContext(ctx, `
 			insert into friends(user_id, friend_id)
 			select $1, $2
 			where not exists(select * from friends where user_id = $1 and friend_id = $2)`,
@@ -94,7 +95,7 @@ func (r *inviteRepo) AcceptInvite(inviterID, friendID string, autoAccepted bool)
 			return merry.Wrap(err)
 		}
 
-		_, err = tx.Exec(`
+		_, err = tx.ExecContext(ctx, `
 			insert into friends(user_id, friend_id)
 			select $1, $2
 			where not exists(select * from friends where user_id = $1 and friend_id = $2)`,
@@ -107,9 +108,9 @@ func (r *inviteRepo) AcceptInvite(inviterID, friendID string, autoAccepted bool)
 	})
 }
 
-func (r *inviteRepo) RejectInvite(inviterID, friendID string) error {
-	return common.RunInTransaction(r.db, func(tx *sqlx.Tx) error {
-		res, err := tx.Exec(`
+func (r *inviteRepo) RejectInvite(ctx context.Context, inviterID, friendID string) error {
+	return common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		res, err := tx.ExecContext(ctx, `
 		update invites
 		set rejected_at = $1, accepted_at = null
 		where user_id = $2 and friend_id = $3 and rejected_at is null`,
@@ -125,7 +126,7 @@ func (r *inviteRepo) RejectInvite(inviterID, friendID string) error {
 			return ErrInviteNotFound.Here()
 		}
 
-		_, err = tx.Exec(`
+		_, err = tx.ExecContext(ctx, `
 			delete from friends
 			where (user_id = $1 and friend_id = $2) or (user_id = $2 and friend_id = $1)`,
 			inviterID, friendID)
@@ -137,9 +138,9 @@ func (r *inviteRepo) RejectInvite(inviterID, friendID string) error {
 	})
 }
 
-func (r *inviteRepo) InvitesFromMe(user User) ([]Invite, error) {
+func (r *inviteRepo) InvitesFromMe(ctx context.Context, user User) ([]Invite, error) {
 	var invites []Invite
-	err := r.db.Select(&invites, `
+	err := r.db.SelectContext(ctx, &invites, `
 		select i.id, i.user_id, coalesce(u.id, '') friend_id, coalesce(u.name, '') friend_name, coalesce(u.email, i.friend_email) friend_email,
 		       coalesce(u.avatar_thumbnail_id, '') friend_avatar_id,
 		       i.created_at, i.accepted_at, i.rejected_at
@@ -154,9 +155,9 @@ func (r *inviteRepo) InvitesFromMe(user User) ([]Invite, error) {
 	return invites, nil
 }
 
-func (r *inviteRepo) InvitesForMe(user User) ([]Invite, error) {
+func (r *inviteRepo) InvitesForMe(ctx context.Context, user User) ([]Invite, error) {
 	var invites []Invite
-	err := r.db.Select(&invites, `
+	err := r.db.SelectContext(ctx, &invites, `
 		select i.id, i.user_id, u.name user_name, u.email user_email, u.avatar_thumbnail_id user_avatar_id,
 		       i.created_at, i.accepted_at, i.rejected_at
 		from invites i
@@ -170,13 +171,13 @@ func (r *inviteRepo) InvitesForMe(user User) ([]Invite, error) {
 	return invites, nil
 }
 
-func (r *inviteRepo) InviteStatuses(user User) (map[string]string, error) {
+func (r *inviteRepo) InviteStatuses(ctx context.Context, user User) (map[string]string, error) {
 	var items []struct {
 		UserID string `db:"user_id"`
 		Status string `db:"status"`
 	}
 
-	err := r.db.Select(&items, `
+	err := r.db.SelectContext(ctx, &items, `
 with t as (
     select u.id user_id,
            i.accepted_at,
@@ -204,7 +205,7 @@ where rn = 1;
 		result[item.UserID] = item.Status
 	}
 
-	err = r.db.Select(&items, `
+	err = r.db.SelectContext(ctx, &items, `
 with t as (
     select i.user_id,
            i.accepted_at,
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"database/sql"
 	"sort"
 	"time"
@@ -32,17 +33,19 @@ type ConnectedMessageHub struct {
 }
 
 type MessageHubRepo interface {
-	HubExists(address string) (bool, error)
-	AddHub(address, details string, hubAdmin User, postLimit int) (string, error)
-	AllHubs() ([]MessageHub, error)
-	Hubs(user User) ([]MessageHub, error)
-	Hub(hubID string) (*MessageHub, error)
-	ApproveHub(hubID string) error
-	RemoveHub(hubID string) error
-	ConnectedHubs(user User) ([]ConnectedMessageHub, error)
-	SetHubPostLimit(hubAdminID, hubID string, postLimit int) error
-	AssignUserToHub(userID, hubID string) error
-	UserHubs(userIDs []string) (map[string][]string, error)
+	HubExists(ctx context.Context, address string) (bool, error)
+	IsHubActive(ctx context.Context, address string) (bool, error)
+	AddHub(ctx context.Context, address, details string, hubAdmin User, postLimit int) (string, error)
+	AllHubs(ctx context.Context) ([]MessageHub, error)
+	Hubs(ctx context.Context, user User) ([]MessageHub, error)
+	Hub(ctx context.Context, hubID string) (*MessageHub, error)
+	ApproveHub(ctx context.Context, hubID string) error
+	DisableHub(ctx context.Context, hubID string) error
+	RemoveHub(ctx context.Context, hubID string) error
+	ConnectedHubs(ctx context.Context, user User) ([]ConnectedMessageHub, error)
+	SetHubPostLimit(ctx context.Context, hubAdminID, hubID string, postLimit int) error
+	AssignUserToHub(ctx context.Context, userID, hubID string) error
+	UserHubs(ctx context.Context, userIDs []string) (map[string][]string, error)
 }
 
 type messageHubRepo struct {
@@ -59,9 +62,9 @@ func NewMessageHubs(db *sqlx.DB) MessageHubRepo {
 	}
 }
 
-func (r *messageHubRepo) HubExists(address string) (bool, error) {
+func (r *messageHubRepo) HubExists(ctx context.Context, address string) (bool, error) {
 	var hubID string
-	err := r.db.Get(&hubID, `select id from message_hubs where address = $1`,
+	err := r.db.GetContext(ctx, &hubID, `select id from message_hubs where address = $1`,
 		address)
 	if err != nil {
 		if merry.Is(err, sql.ErrNoRows) {
@@ -72,7 +75,22 @@ func (r *messageHubRepo) HubExists(address string) (bool, error) {
 	return true, nil
 }
 
-func (r *messageHubRepo) AddHub(address, details string, hubAdmin User, postLimit int) (string, error) {
+// IsHubActive reports whether the hub is approved and isn't disabled.
+func (r *messageHubRepo) IsHubActive(ctx context.Context, address string) (bool, error) {
+	var isActive bool
+	err := r.db.GetContext(ctx, &isActive, `
+		select exists(
+			select 1
+			from message_hubs
+			where rtrim(address, '/') = rtrim($1, '/') and approved_at is not null and disabled_at is null)`,
+		address)
+	if err != nil {
+		return false, merry.Wrap(err)
+	}
+	return isActive, nil
+}
+
+func (r *messageHubRepo) AddHub(ctx context.Context, address, details string, hubAdmin User, postLimit int) (string, error) {
 	hubID, err := uuid.NewV4()
 	if err != nil {
 		return "", merry.Wrap(err)
@@ -82,7 +100,7 @@ func (r *messageHubRepo) AddHub(address, details string, hubAdmin User, postLimi
 		postLimit = 0
 	}
 
-	_, err = r.db.Exec(`
+	_, err = r.db.ExecContext(ctx, `
 		insert into message_hubs(id, address, admin_id, created_at, details, post_limit) 
 		VALUES ($1, $2, $3, $4, $5, $6)`,
 		hubID.String(), address, hubAdmin.ID, common.CurrentTimestamp(), details, postLimit)
@@ -92,9 +110,9 @@ func (r *messageHubRepo) AddHub(address, details string, hubAdmin User, postLimi
 	return hubID.String(), nil
 }
 
-func (r *messageHubRepo) AllHubs() ([]MessageHub, error) {
+func (r *messageHubRepo) AllHubs(ctx context.Context) ([]MessageHub, error) {
 	var hubs []MessageHub
-	err := r.db.Select(&hubs, `
+	err := r.db.SelectContext(ctx, &hubs, `
 			select h.id, h.address, h.admin_id, h.created_at, h.approved_at, h.disabled_at, h.details,
 				   u.name admin_name, u.avatar_thumbnail_id admin_avatar_id, post_limit
 			from message_hubs h
@@ -105,9 +123,9 @@ func (r *messageHubRepo) AllHubs() ([]MessageHub, error) {
 	return hubs, merry.Wrap(err)
 }
 
-func (r *messageHubRepo) Hubs(user User) ([]MessageHub, error) {
+func (r *messageHubRepo) Hubs(ctx context.Context, user User) ([]MessageHub, error) {
 	var hubs []MessageHub
-	err := r.db.Select(&hubs, `
+	err := r.db.SelectContext(ctx, &hubs, `
 		select h.id, h.address, h.admin_id, h.created_at, h.approved_at, h.disabled_at, h.details,
 				   u.name admin_name, u.avatar_thumbnail_id admin_avatar_id, post_limit
 		from message_hubs h
@@ -119,9 +137,9 @@ func (r *messageHubRepo) Hubs(user User) ([]MessageHub, error) {
 	return hubs, merry.Wrap(err)
 }
 
-func (r *messageHubRepo) Hub(hubID string) (*MessageHub, error) {
+func (r *messageHubRepo) Hub(ctx context.Context, hubID string) (*MessageHub, error) {
 	var hub MessageHub
-	err := r.db.Get(&hub, `
+	err := r.db.GetContext(ctx, &hub, `
 		select id, address, admin_id, created_at, approved_at, disabled_at, details, post_limit
 		from message_hubs
 		where id = $1`, hubID)
@@ -136,18 +154,27 @@ func (r *messageHubRepo) Hub(hubID string) (*MessageHub, error) {
 	return &hub, nil
 }
 
-func (r *messageHubRepo) ApproveHub(hubID string) error {
-	_, err := r.db.Exec(`
+func (r *messageHubRepo) ApproveHub(ctx context.Context, hubID string) error {
+	_, err := r.db.ExecContext(ctx, `
 		update message_hubs
-		set approved_at = $1
+		set approved_at = $1, disabled_at = null
 		where id = $2`,
 		common.CurrentTimestamp(), hubID)
 	return merry.Wrap(err)
 }
 
-func (r *messageHubRepo) RemoveHub(hubID string) error {
-	return common.RunInTransaction(r.db, func(tx *sqlx.Tx) error {
-		_, err := tx.Exec(`
+func (r *messageHubRepo) DisableHub(ctx context.Context, hubID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		update message_hubs
+		set disabled_at = $1
+		where id = $2 and disabled_at is null`,
+		common.CurrentTimestamp(), hubID)
+	return merry.Wrap(err)
+}
+
+func (r *messageHubRepo) RemoveHub(ctx context.Context, hubID string) error {
+	return common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(ctx, `
 			delete from user_message_hubs
 			where hub_id = $1`,
 			hubID)
@@ -155,7 +182,7 @@ func (r *messageHubRepo) RemoveHub(hubID string) error {
 			return merry.Wrap(err)
 		}
 
-		_, err = tx.Exec(`
+		_, err = tx.ExecContext(ctx, `
 			delete from message_hubs
 			where id = $1`,
 			hubID)
@@ -163,7 +190,7 @@ func (r *messageHubRepo) RemoveHub(hubID string) error {
 	})
 }
 
-func (r *messageHubRepo) ConnectedHubs(user User) (connectedHubs []ConnectedMessageHub, err error) {
+func (r *messageHubRepo) ConnectedHubs(ctx context.Context, user User) (connectedHubs []ConnectedMessageHub, err error) {
 	type friend struct {
 		MinDistance int
 		Count       int
@@ -189,7 +216,7 @@ func (r *messageHubRepo) ConnectedHubs(user User) (connectedHubs []ConnectedMess
 			return nil, merry.Wrap(err)
 		}
 		query = r.db.Rebind(query)
-		err = r.db.Select(&nextPairs, query, args...)
+		err = r.db.SelectContext(ctx, &nextPairs, query, args...)
 		if err != nil {
 			return nil, merry.Wrap(err)
 		}
@@ -213,7 +240,7 @@ func (r *messageHubRepo) ConnectedHubs(user User) (connectedHubs []ConnectedMess
 	}
 
 	var hubs []MessageHub
-	err = r.db.Select(&hubs, `
+	err = r.db.SelectContext(ctx, &hubs, `
 		select id, address, admin_id, created_at, approved_at, disabled_at, details, post_limit
 		from message_hubs
 		where approved_at is not null and disabled_at is null`)
@@ -236,12 +263,12 @@ func (r *messageHubRepo) ConnectedHubs(user User) (connectedHubs []ConnectedMess
 	return connectedHubs, nil
 }
 
-func (r *messageHubRepo) SetHubPostLimit(hubAdminID, hubID string, postLimit int) error {
+func (r *messageHubRepo) SetHubPostLimit(ctx context.Context, hubAdminID, hubID string, postLimit int) error {
 	if postLimit < 0 {
 		postLimit = 1
 	}
 
-	_, err := r.db.Exec(`
+	_, err := r.db.ExecContext(ctx, `
 		update message_hubs
 		set post_limit = $1
 		where id = $2 and admin_id = $3`,
@@ -249,9 +276,9 @@ func (r *messageHubRepo) SetHubPostLimit(hubAdminID, hubID string, postLimit int
 	return merry.Wrap(err)
 }
 
-func (r *messageHubRepo) AssignUserToHub(userID, hubID string) error {
+func (r *messageHubRepo) AssignUserToHub(ctx context.Context, userID, hubID string) error {
 	now := common.CurrentTimestamp()
-	_, err := r.db.Exec(`
+	_, err := r.db.ExecContext(ctx, `
 			insert into user_message_hubs(user_id, hub_id, created_at, updated_at)
 			values($1, $2, $3, $4)
 			on conflict (user_id, hub_id) do update set updated_at = $4;`,
@@ -262,7 +289,7 @@ func (r *messageHubRepo) AssignUserToHub(userID, hubID string) error {
 	return nil
 }
 
-func (r *messageHubRepo) UserHubs(userIDs []string) (map[string][]string, error) {
+func (r *messageHubRepo) UserHubs(ctx context.Context, userIDs []string) (map[string][]string, error) {
 	if len(userIDs) == 0 {
 		return nil, nil
 	}
@@ -280,7 +307,7 @@ func (r *messageHubRepo) UserHubs(userIDs []string) (map[string][]string, error)
 		UserID     string `db:"user_id"`
 		HubAddress string `db:"hub_address"`
 	}
-	err = r.db.Select(&hubs, query, args...)
+	err = r.db.SelectContext(ctx, &hubs, query, args...)
 	if err != nil {
 		return nil, merry.Wrap(err)
 	}
@@ -0,0 +1,164 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+const (
+	MuteThread = "thread"
+	MuteUser   = "user"
+)
+
+type NotificationSetting struct {
+	Type      string    `json:"type" db:"type"`
+	InApp     bool      `json:"in_app" db:"in_app"`
+	Push      bool      `json:"push" db:"push"`
+	Email     bool      `json:"email" db:"email"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type NotificationMute struct {
+	Kind      string    `json:"kind" db:"kind"`
+	TargetID  string    `json:"target_id" db:"target_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type NotificationChannels struct {
+	UserID string `db:"user_id"`
+	InApp  bool   `db:"in_app"`
+	Push   bool   `db:"push"`
+	Email  bool   `db:"email"`
+}
+
+type NotificationSettingRepo interface {
+	Settings(ctx context.Context, userID string) ([]NotificationSetting, error)
+	SetSetting(ctx context.Context, userID string, setting NotificationSetting) error
+	RemoveSetting(ctx context.Context, userID, notificationType string) error
+	Mutes(ctx context.Context, userID string) ([]NotificationMute, error)
+	Mute(ctx context.Context, userID, kind, targetID string) error
+	Unmute(ctx context.Context, userID, kind, targetID string) error
+	Channels(ctx context.Context, userIDs []string, notificationType string, threadIDs []string, actorID string) ([]NotificationChannels, error)
+}
+
+type notificationSettingRepo struct {
+	db *sqlx.DB
+}
+
+func NewNotificationSettings(db *sqlx.DB) NotificationSettingRepo {
+	return &notificationSettingRepo{
+		db: db,
+	}
+}
+
+func (r *notificationSettingRepo) Settings(ctx context.Context, userID string) ([]NotificationSetting, error) {
+	var settings []NotificationSetting
+	err := r.db.SelectContext(ctx, &settings, `
+		select type, in_app, push, email, updated_at
+		from notification_settings
+		where user_id = $1
+		order by type`,
+		userID)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return settings, nil
+}
+
+func (r *notificationSettingRepo) SetSetting(ctx context.Context, userID string, setting NotificationSetting) error {
+	_, err := r.db.ExecContext(ctx, `
+		insert into notification_settings(user_id, type, in_app, push, email, updated_at)
+		values ($1, $2, $3, $4, $5, $6)
+		on conflict (user_id, type) do update set in_app = $3, push = $4, email = $5, updated_at = $6`,
+		userID, setting.Type, setting.InApp, setting.Push, setting.Email, common.CurrentTimestamp())
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+func (r *notificationSettingRepo) RemoveSetting(ctx context.Context, userID, notificationType string) error {
+	_, err := r.db.ExecContext(ctx, `
+		delete from notification_settings
+		where user_id = $1 and type = $2`,
+		userID, notificationType)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+func (r *notificationSettingRepo) Mutes(ctx context.Context, userID string) ([]NotificationMute, error) {
+	var mutes []NotificationMute
+	err := r.db.SelectContext(ctx, &mutes, `
+		select kind, target_id, created_at
+		from notification_mutes
+		where user_id = $1
+		order by created_at desc`,
+		userID)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return mutes, nil
+}
+
+func (r *notificationSettingRepo) Mute(ctx context.Context, userID, kind, targetID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		insert into notification_mutes(user_id, kind, target_id, created_at)
+		values ($1, $2, $3, $4)
+		on conflict (user_id, kind, target_id) do nothing`,
+		userID, kind, targetID, common.CurrentTimestamp())
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+func (r *notificationSettingRepo) Unmute(ctx context.Context, userID, kind, targetID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		delete from notification_mutes
+		where user_id = $1 and kind = $2 and target_id = $3`,
+		userID, kind, targetID)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	return nil
+}
+
+// Channels returns the enabled channels for the users who haven't muted the thread or the actor.
+// Types without settings are enabled on all channels.
+func (r *notificationSettingRepo) Channels(ctx context.Context, userIDs []string, notificationType string, threadIDs []string, actorID string) ([]NotificationChannels, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	if len(threadIDs) == 0 {
+		threadIDs = []string{""}
+	}
+
+	query, args, err := sqlx.In(`
+		select u.id user_id,
+		       coalesce(s.in_app, true) in_app, coalesce(s.push, true) push, coalesce(s.email, true) email
+		from users u
+			left join notification_settings s on s.user_id = u.id and s.type = ?
+		where u.id in (?)
+		  and not exists(
+			select *
+			from notification_mutes m
+			where m.user_id = u.id
+			  and ((m.kind = ? and m.target_id in (?)) or (m.kind = ? and m.target_id = ?)))`,
+		notificationType, userIDs, MuteThread, threadIDs, MuteUser, actorID)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	var channels []NotificationChannels
+	err = r.db.SelectContext(ctx, &channels, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return channels, nil
+}
@@ -0,0 +1,102 @@
+package repo
+
+import (
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationSettingRepo_Channels(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	users := NewUsers(te.DB)
+	settings := NewNotificationSettings(te.DB)
+	for i := 1; i <= 6; i++ {
+		id := strconv.Itoa(i)
+		require.Nil(t, users.AddUser(te.Ctx, id, "user"+id, "user"+id+"@mail.org", "password"+id+"-hash"))
+	}
+	require.Nil(t, settings.SetSetting(te.Ctx, "2", NotificationSetting{Type: "message/like", InApp: false, Push: true, Email: true}))
+	require.Nil(t, settings.SetSetting(te.Ctx, "3", NotificationSetting{Type: "message/like", InApp: true, Push: false, Email: false}))
+	require.Nil(t, settings.Mute(te.Ctx, "4", MuteThread, "message-1"))
+	require.Nil(t, settings.Mute(te.Ctx, "5", MuteUser, "actor-1"))
+	require.Nil(t, settings.SetSetting(te.Ctx, "6", NotificationSetting{Type: "comment/post", InApp: false, Push: false, Email: false}))
+
+	allUsers := []string{"1", "2", "3", "4", "5", "6", "unknown"}
+	all := func(userID string) NotificationChannels {
+		return NotificationChannels{UserID: userID, InApp: true, Push: true, Email: true}
+	}
+
+	tests := []struct {
+		name             string
+		notificationType string
+		threadIDs        []string
+		actorID          string
+		want             []NotificationChannels
+	}{
+		{
+			name:             "settings and mutes",
+			notificationType: "message/like",
+			threadIDs:        []string{"message-1"},
+			actorID:          "actor-1",
+			want: []NotificationChannels{
+				all("1"),
+				{UserID: "2", InApp: false, Push: true, Email: true},
+				{UserID: "3", InApp: true, Push: false, Email: false},
+				all("6"),
+			},
+		},
+		{
+			name:             "muted thread among several threads",
+			notificationType: "message/like",
+			threadIDs:        []string{"conversation-1", "message-1"},
+			actorID:          "actor-2",
+			want: []NotificationChannels{
+				all("1"),
+				{UserID: "2", InApp: false, Push: true, Email: true},
+				{UserID: "3", InApp: true, Push: false, Email: false},
+				all("5"),
+				all("6"),
+			},
+		},
+		{
+			name:             "other thread and actor",
+			notificationType: "message/like",
+			threadIDs:        []string{"message-2"},
+			actorID:          "actor-2",
+			want: []NotificationChannels{
+				all("1"),
+				{UserID: "2", InApp: false, Push: true, Email: true},
+				{UserID: "3", InApp: true, Push: false, Email: false},
+				all("4"),
+				all("5"),
+				all("6"),
+			},
+		},
+		{
+			name:             "type without settings",
+			notificationType: "comment/like",
+			actorID:          "actor-1",
+			want:             []NotificationChannels{all("1"), all("2"), all("3"), all("4"), all("6")},
+		},
+		{
+			name:             "all channels disabled",
+			notificationType: "comment/post",
+			want: []NotificationChannels{
+				all("1"), all("2"), all("3"), all("4"), all("5"),
+				{UserID: "6", InApp: false, Push: false, Email: false},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			channels, err := settings.Channels(te.Ctx, allUsers, test.notificationType, test.threadIDs, test.actorID)
+			require.Nil(t, err)
+			sort.Slice(channels, func(i, j int) bool { return channels[i].UserID < channels[j].UserID })
+			assert.Equal(t, test.want, channels)
+		})
+	}
+}
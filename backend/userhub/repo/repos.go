@@ -5,10 +5,16 @@ import (
 )
 
 type Repos struct {
-	User         UserRepo
-	Invite       InviteRepo
-	Friend       FriendRepo
-	MessageHubs  MessageHubRepo
-	Notification common.NotificationRepo
-	FCMToken     FCMTokenRepo
+	User                UserRepo
+	Invite              InviteRepo
+	Friend              FriendRepo
+	MessageHubs         MessageHubRepo
+	Notification        common.NotificationRepo
+	FCMToken            FCMTokenRepo
+	DeviceKey           DeviceKeyRepo
+	Birthday            BirthdayRepo
+	Digest              DigestRepo
+	NotificationSetting NotificationSettingRepo
+	WebPushSubscription WebPushSubscriptionRepo
+	HubNotification     HubNotificationRepo
 }
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"database/sql"
 	"strings"
 	"time"
@@ -21,20 +22,30 @@ type User struct {
 	CreatedAt         time.Time    `json:"created_at,omitempty" db:"created_at"`
 	UpdatedAt         time.Time    `json:"updated_at,omitempty" db:"updated_at"`
 	ConfirmedAt       sql.NullTime `json:"confirmed_at,omitempty" db:"confirmed_at"`
+	Birthday          sql.NullTime `json:"birthday,omitempty" db:"birthday"`
+	HideBirthdayYear  bool         `json:"hide_birthday_year,omitempty" db:"hide_birthday_year"`
+	TimeZone          string       `json:"time_zone,omitempty" db:"time_zone"`
+	DigestFrequency   string       `json:"digest_frequency,omitempty" db:"digest_frequency"`
+	IsAdmin           bool         `json:"is_admin,omitempty" db:"is_admin"`
 }
 
 type UserRepo interface {
-	FindUserByIDOrName(value string) (*User, error)
-	FindUserByID(id string) (*User, error)
-	FindUsersByEmail(email string) ([]User, error)
-	FindUserByName(name string) (*User, error)
-	AddUser(id, name, email, passwordHash string) error
-	UserCount() (int, error)
-	SetAvatar(userID, avatarOriginalID, avatarThumbnailID string) error
-	SetEmail(userID, email string) error
-	SetPassword(userID, passwordHash string) error
-	FindUsers(ids []string) ([]User, error)
-	ConfirmUser(userID string) (bool, error)
+	FindUserByIDOrName(ctx context.Context, value string) (*User, error)
+	FindUserByID(ctx context.Context, id string) (*User, error)
+	FindUsersByEmail(ctx context.Context, email string) ([]User, error)
+	FindUserByName(ctx context.Context, name string) (*User, error)
+	AddUser(ctx context.Context, id, name, email, passwordHash string) error
+	UserCount(ctx context.Context) (int, error)
+	SetAvatar(ctx context.Context, userID, avatarOriginalID, avatarThumbnailID string) error
+	SetEmail(ctx context.Context, userID, email string) error
+	SetPassword(ctx context.Context, userID, passwordHash string) error
+	SetBirthday(ctx context.Context, userID string, birthday sql.NullTime, hideYear bool) error
+	SetTimeZone(ctx context.Context, userID, timeZone string) error
+	SetDigestFrequency(ctx context.Context, userID, frequency string) error
+	FindUsers(ctx context.Context, ids []string) ([]User, error)
+	ConfirmUser(ctx context.Context, userID string) (bool, error)
+	SetAdmin(ctx context.Context, userID string, isAdmin bool) error
+	AvatarBlobs(ctx context.Context) ([]string, error)
 }
 
 type userRepo struct {
@@ -47,9 +58,9 @@ func NewUsers(db *sqlx.DB) UserRepo {
 	}
 }
 
-func (r *userRepo) FindUserByIDOrName(value string) (*User, error) {
+func (r *userRepo) FindUserByIDOrName(ctx context.Context, value string) (*User, error) {
 	var user User
-	err := r.db.Get(&user, `
+	err := r.db.GetContext(ctx, &user, `
 		select id, name, email, password_hash, avatar_original_id, avatar_thumbnail_id, created_at, updated_at
 		from users
 		where id = $1 or lower(name) = $2`,
@@ -63,10 +74,11 @@ func (r *userRepo) FindUserByIDOrName(value string) (*User, error) {
 	return &user, nil
 }
 
-func (r *userRepo) FindUserByID(id string) (*User, error) {
+func (r *userRepo) FindUserByID(ctx context.Context, id string) (*User, error) {
 	var user User
-	err := r.db.Get(&user, `
-		select id, name, email, password_hash, avatar_original_id, avatar_thumbnail_id, created_at, updated_at, confirmed_at
+	err := r.db.GetContext(ctx, &user, `
+		select id, name, email, password_hash, avatar_original_id, avatar_thumbnail_id, created_at, updated_at, confirmed_at,
+		       birthday, hide_birthday_year, time_zone, digest_frequency, is_admin
 		from users
 		where id = $1`, id)
 	if err != nil {
@@ -78,9 +90,9 @@ func (r *userRepo) FindUserByID(id string) (*User, error) {
 	return &user, nil
 }
 
-func (r *userRepo) FindUsersByEmail(email string) ([]User, error) {
+func (r *userRepo) FindUsersByEmail(ctx context.Context, email string) ([]User, error) {
 	var users []User
-	err := r.db.Select(&users, `
+	err := r.db.SelectContext(ctx, &users, `
 		select id, name, email, password_hash, avatar_original_id, avatar_thumbnail_id, created_at, updated_at, confirmed_at
 		from users
 		where email = $1`, email)
@@ -90,9 +102,9 @@ func (r *userRepo) FindUsersByEmail(email string) ([]User, error) {
 	return users, nil
 }
 
-func (r *userRepo) FindUserByName(name string) (*User, error) {
+func (r *userRepo) FindUserByName(ctx context.Context, name string) (*User, error) {
 	var user User
-	err := r.db.Get(&user, `
+	err := r.db.GetContext(ctx, &user, `
 		select id, name, email, password_hash, avatar_original_id, avatar_thumbnail_id, created_at, updated_at, confirmed_at
 		from users
 		where lower(name) = $1`,
@@ -106,9 +118,9 @@ func (r *userRepo) FindUserByName(name string) (*User, error) {
 	return &user, nil
 }
 
-func (r *userRepo) AddUser(id, name, email, passwordHash string) error {
-	return common.RunInTransaction(r.db, func(tx *sqlx.Tx) error {
-		_, err := tx.Exec(`
+func (r *userRepo) AddUser(ctx context.Context, id, name, email, passwordHash string) error {
+	return common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(ctx, `
 			insert into users(id, name, email, password_hash, created_at, updated_at)
 			values($1, $2, $3, $4, $5, $5)`,
 			id, name, email, passwordHash, common.CurrentTimestamp())
@@ -116,7 +128,7 @@ func (r *userRepo) AddUser(id, name, email, passwordHash string) error {
 			return merry.Wrap(err)
 		}
 		if email != "" {
-			_, err = tx.Exec(`
+			_, err = tx.ExecContext(ctx, `
 			update invites
 			set friend_id = $1
 			where friend_id is null and friend_email = $2`,
@@ -126,22 +138,22 @@ func (r *userRepo) AddUser(id, name, email, passwordHash string) error {
 	})
 }
 
-func (r *userRepo) UserCount() (int, error) {
+func (r *userRepo) UserCount(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.Get(&count, "select count(*) from users;")
+	err := r.db.GetContext(ctx, &count, "select count(*) from users;")
 	return count, merry.Wrap(err)
 }
 
-func (r *userRepo) SetAvatar(userID, avatarOriginalID, avatarThumbnailID string) error {
-	return common.RunInTransaction(r.db, func(tx *sqlx.Tx) error {
+func (r *userRepo) SetAvatar(ctx context.Context, userID, avatarOriginalID, avatarThumbnailID string) error {
+	return common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
 		var user User
-		err := tx.Get(&user, "select avatar_original_id, avatar_thumbnail_id from users where id = $1", userID)
+		err := tx.GetContext(ctx, &user, "select avatar_original_id, avatar_thumbnail_id from users where id = $1", userID)
 		if err != nil {
 			return merry.Wrap(err)
 		}
 		now := common.CurrentTimestamp()
 		if user.AvatarOriginalID != "" && user.AvatarOriginalID != avatarOriginalID {
-			_, err = tx.Exec(`
+			_, err = tx.ExecContext(ctx, `
 				insert into blob_pending_deletes(blob_id, deleted_at)
 				values ($1, $2)`,
 				user.AvatarOriginalID, now)
@@ -150,7 +162,7 @@ func (r *userRepo) SetAvatar(userID, avatarOriginalID, avatarThumbnailID string)
 			}
 		}
 		if user.AvatarThumbnailID != "" && user.AvatarThumbnailID != avatarThumbnailID {
-			_, err = tx.Exec(`
+			_, err = tx.ExecContext(ctx, `
 				insert into blob_pending_deletes(blob_id, deleted_at)
 				values ($1, $2)`,
 				user.AvatarThumbnailID, now)
@@ -159,7 +171,7 @@ func (r *userRepo) SetAvatar(userID, avatarOriginalID, avatarThumbnailID string)
 			}
 		}
 
-		_, err = tx.Exec(`
+		_, err = tx.ExecContext(ctx, `
 			update users
 			set avatar_original_id = $1, avatar_thumbnail_id = $2, updated_at = $3
 			where id = $4;`,
@@ -168,25 +180,64 @@ func (r *userRepo) SetAvatar(userID, avatarOriginalID, avatarThumbnailID string)
 	})
 }
 
-func (r *userRepo) SetEmail(userID, email string) error {
-	_, err := r.db.Exec(`
+func (r *userRepo) SetEmail(ctx context.Context, userID, email string) error {
+	return common.RunInTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			update users
+			set email = $1, updated_at = $2
+			where id = $3;`,
+			email, common.CurrentTimestamp(), userID)
+		if err != nil {
+			return merry.Wrap(err)
+		}
+		if email != "" {
+			_, err = tx.ExecContext(ctx, `
+			update invites
+			set friend_id = $1
+			where friend_id is null and friend_email = $2 and user_id <> $1`,
+				userID, email)
+		}
+		return merry.Wrap(err)
+	})
+}
+
+func (r *userRepo) SetPassword(ctx context.Context, userID, passwordHash string) error {
+	_, err := r.db.ExecContext(ctx, `
 		update users
-		set email = $1, updated_at = $2
+		set password_hash = $1, updated_at = $2
 		where id = $3;`,
-		email, common.CurrentTimestamp(), userID)
+		passwordHash, common.CurrentTimestamp(), userID)
 	return merry.Wrap(err)
 }
 
-func (r *userRepo) SetPassword(userID, passwordHash string) error {
-	_, err := r.db.Exec(`
+func (r *userRepo) SetBirthday(ctx context.Context, userID string, birthday sql.NullTime, hideYear bool) error {
+	_, err := r.db.ExecContext(ctx, `
 		update users
-		set password_hash = $1, updated_at = $2
+		set birthday = $1, hide_birthday_year = $2, updated_at = $3
+		where id = $4;`,
+		birthday, hideYear, common.CurrentTimestamp(), userID)
+	return merry.Wrap(err)
+}
+
+func (r *userRepo) SetTimeZone(ctx context.Context, userID, timeZone string) error {
+	_, err := r.db.ExecContext(ctx, `
+		update users
+		set time_zone = $1, updated_at = $2
 		where id = $3;`,
-		passwordHash, common.CurrentTimestamp(), userID)
+		timeZone, common.CurrentTimestamp(), userID)
 	return merry.Wrap(err)
 }
 
-func (r *userRepo) FindUsers(ids []string) ([]User, error) {
+func (r *userRepo) SetDigestFrequency(ctx context.Context, userID, frequency string) error {
+	_, err := r.db.ExecContext(ctx, `
+		update users
+		set digest_frequency = $1, updated_at = $2
+		where id = $3;`,
+		frequency, common.CurrentTimestamp(), userID)
+	return merry.Wrap(err)
+}
+
+func (r *userRepo) FindUsers(ctx context.Context, ids []string) ([]User, error) {
 	if len(ids) == 0 {
 		return nil, nil
 	}
@@ -200,15 +251,15 @@ func (r *userRepo) FindUsers(ids []string) ([]User, error) {
 	}
 	query = r.db.Rebind(query)
 	var users []User
-	err = r.db.Select(&users, query, args...)
+	err = r.db.SelectContext(ctx, &users, query, args...)
 	if err != nil {
 		return nil, merry.Wrap(err)
 	}
 	return users, nil
 }
 
-func (r *userRepo) ConfirmUser(userID string) (ok bool, err error) {
-	res, err := r.db.Exec(`
+func (r *userRepo) ConfirmUser(ctx context.Context, userID string) (ok bool, err error) {
+	res, err := r.db.ExecContext(ctx, `
 		update users
 		set confirmed_at = $1
 		where id = $2 and confirmed_at is null`,
@@ -222,3 +273,24 @@ func (r *userRepo) ConfirmUser(userID string) (ok bool, err error) {
 	}
 	return rowsAffected == 1, nil
 }
+
+func (r *userRepo) SetAdmin(ctx context.Context, userID string, isAdmin bool) error {
+	_, err := r.db.ExecContext(ctx, `
+		update users
+		set is_admin = $1, updated_at = $2
+		where id = $3;`,
+		isAdmin, common.CurrentTimestamp(), userID)
+	return merry.Wrap(err)
+}
+
+func (r *userRepo) AvatarBlobs(ctx context.Context) ([]string, error) {
+	var blobIDs []string
+	err := r.db.SelectContext(ctx, &blobIDs, `
+		select avatar_original_id from users where avatar_original_id <> ''
+		union
+		select avatar_thumbnail_id from users where avatar_thumbnail_id <> ''`)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return blobIDs, nil
+}
@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+type WebPushSubscription struct {
+	UserID    string    `db:"user_id"`
+	Endpoint  string    `db:"endpoint"`
+	P256dh    string    `db:"p256dh"`
+	Auth      string    `db:"auth"`
+	DeviceID  string    `db:"device_id"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type WebPushSubscriptionRepo interface {
+	AddSubscription(ctx context.Context, subscription WebPushSubscription) error
+	UsersSubscriptions(ctx context.Context, userIDs []string) ([]WebPushSubscription, error)
+	RemoveSubscription(ctx context.Context, endpoint string) error
+}
+
+type webPushSubscriptionRepo struct {
+	db *sqlx.DB
+}
+
+func NewWebPushSubscriptions(db *sqlx.DB) WebPushSubscriptionRepo {
+	return &webPushSubscriptionRepo{
+		db: db,
+	}
+}
+
+func (r *webPushSubscriptionRepo) AddSubscription(ctx context.Context, subscription WebPushSubscription) error {
+	_, err := r.db.ExecContext(ctx, `
+		insert into web_push_subscriptions(user_id, endpoint, p256dh, auth, device_id, created_at, updated_at)
+		values ($1, $2, $3, $4, $5, $6, $6)
+		on conflict (user_id, endpoint) do update set p256dh = $3, auth = $4, device_id = $5, updated_at = $6`,
+		subscription.UserID, subscription.Endpoint, subscription.P256dh, subscription.Auth, subscription.DeviceID, common.CurrentTimestamp())
+	return merry.Wrap(err)
+}
+
+func (r *webPushSubscriptionRepo) UsersSubscriptions(ctx context.Context, userIDs []string) ([]WebPushSubscription, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(`
+		select user_id, endpoint, p256dh, auth, device_id, created_at, updated_at
+		from web_push_subscriptions
+		where user_id in (?)`, userIDs)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+
+	var subscriptions []WebPushSubscription
+	err = r.db.SelectContext(ctx, &subscriptions, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return subscriptions, nil
+}
+
+func (r *webPushSubscriptionRepo) RemoveSubscription(ctx context.Context, endpoint string) error {
+	_, err := r.db.ExecContext(ctx, `
+		delete from web_push_subscriptions
+		where endpoint = $1`,
+		endpoint)
+	return merry.Wrap(err)
+}
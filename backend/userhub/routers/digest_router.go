@@ -0,0 +1,75 @@
+package routers
+
+import (
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi"
+
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/token"
+	"github.com/mreider/koto/backend/userhub/repo"
+)
+
+const (
+	unsubscribedPage = `<!DOCTYPE html><html><body><p>You have been unsubscribed from KOTO digest emails.</p></body></html>`
+)
+
+// unsubscribePage doesn't unsubscribe by itself, so link scanners that follow the link don't change anything.
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html><html><body>
+<form method="post" action="?token={{.}}">
+<p>Unsubscribe from KOTO digest emails?</p>
+<button type="submit">Unsubscribe</button>
+</form>
+</body></html>`))
+
+func Digest(userRepo repo.UserRepo, tokenParser token.Parser) http.Handler {
+	h := &digestRouter{
+		userRepo:    userRepo,
+		tokenParser: tokenParser,
+	}
+	r := chi.NewRouter()
+	r.Get("/unsubscribe", h.ConfirmUnsubscribe)
+	r.Post("/unsubscribe", h.Unsubscribe)
+	return r
+}
+
+type digestRouter struct {
+	userRepo    repo.UserRepo
+	tokenParser token.Parser
+}
+
+func (dr *digestRouter) ConfirmUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	unsubscribeToken := r.URL.Query().Get("token")
+	_, _, err := dr.tokenParser.Parse(unsubscribeToken, "digest-unsubscribe")
+	if err != nil {
+		http.Error(w, "invalid token", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = unsubscribePage.Execute(w, url.QueryEscape(unsubscribeToken))
+	if err != nil {
+		logging.FromContext(r.Context()).WithError(err).Error("can't render unsubscribe page")
+	}
+}
+
+// Unsubscribe also handles one-click unsubscribe requests of mail clients (RFC 8058).
+func (dr *digestRouter) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	_, claims, err := dr.tokenParser.Parse(r.URL.Query().Get("token"), "digest-unsubscribe")
+	if err != nil {
+		http.Error(w, "invalid token", http.StatusBadRequest)
+		return
+	}
+
+	err = dr.userRepo.SetDigestFrequency(r.Context(), claims["id"].(string), repo.DigestOff)
+	if err != nil {
+		logging.FromContext(r.Context()).WithError(err).Error("can't unsubscribe from digest")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(unsubscribedPage))
+}
@@ -0,0 +1,75 @@
+package routers_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/token"
+	"github.com/mreider/koto/backend/userhub/repo"
+	"github.com/mreider/koto/backend/userhub/routers"
+)
+
+type userRepo struct {
+	repo.UserRepo
+	digestFrequencies map[string]string
+}
+
+func (r *userRepo) SetDigestFrequency(_ context.Context, userID, frequency string) error {
+	r.digestFrequencies[userID] = frequency
+	return nil
+}
+
+func TestDigest_Unsubscribe(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	tokenGenerator := token.NewGenerator(privateKey)
+	tokenParser := token.NewParser(func() *rsa.PublicKey { return &privateKey.PublicKey })
+
+	users := &userRepo{digestFrequencies: map[string]string{}}
+	h := routers.Digest(users, tokenParser)
+
+	unsubscribeToken, err := tokenGenerator.Generate("1", "user1", "digest-unsubscribe", time.Now().Add(time.Hour), nil)
+	require.Nil(t, err)
+	authToken, err := tokenGenerator.Generate("1", "user1", "auth", time.Now().Add(time.Hour), nil)
+	require.Nil(t, err)
+
+	serve := func(method, unsubscribeToken string) *httptest.ResponseRecorder {
+		var r *http.Request
+		if method == http.MethodPost {
+			r = httptest.NewRequest(method, "/unsubscribe?token="+url.QueryEscape(unsubscribeToken), strings.NewReader("List-Unsubscribe=One-Click"))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		} else {
+			r = httptest.NewRequest(method, "/unsubscribe?token="+url.QueryEscape(unsubscribeToken), nil)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve(http.MethodGet, unsubscribeToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `<form method="post"`)
+	assert.Contains(t, w.Body.String(), unsubscribeToken)
+	assert.Empty(t, users.digestFrequencies, "GET doesn't unsubscribe")
+
+	w = serve(http.MethodGet, authToken)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = serve(http.MethodPost, authToken)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Empty(t, users.digestFrequencies)
+
+	w = serve(http.MethodPost, unsubscribeToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, map[string]string{"1": repo.DigestOff}, users.digestFrequencies)
+}
@@ -3,22 +3,23 @@ package routers
 import (
 	"bytes"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"sync"
 	"time"
 
 	"github.com/go-chi/chi"
+	"github.com/sirupsen/logrus"
 
 	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
 	"github.com/mreider/koto/backend/userhub/repo"
 )
 
-func Image(userRepo repo.UserRepo, s3Storage *common.S3Storage, staticFS http.FileSystem) http.Handler {
+func Image(userRepo repo.UserRepo, blobStorage common.BlobStorage, staticFS http.FileSystem) http.Handler {
 	h := &imageRouter{
-		userRepo:  userRepo,
-		s3Storage: s3Storage,
-		staticFS:  staticFS,
+		userRepo:    userRepo,
+		blobStorage: blobStorage,
+		staticFS:    staticFS,
 	}
 	r := chi.NewRouter()
 	r.Get("/avatar/{userID}", h.UserAvatar)
@@ -26,9 +27,9 @@ func Image(userRepo repo.UserRepo, s3Storage *common.S3Storage, staticFS http.Fi
 }
 
 type imageRouter struct {
-	userRepo  repo.UserRepo
-	s3Storage *common.S3Storage
-	staticFS  http.FileSystem
+	userRepo    repo.UserRepo
+	blobStorage common.BlobStorage
+	staticFS    http.FileSystem
 
 	noAvatarOnce    sync.Once
 	noAvatarImage   []byte
@@ -42,9 +43,9 @@ func (ir *imageRouter) UserAvatar(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := ir.userRepo.FindUserByID(userID)
+	user, err := ir.userRepo.FindUserByID(r.Context(), userID)
 	if err != nil {
-		log.Println("can't find user: ", err)
+		logging.FromContext(r.Context()).WithError(err).Error("can't find user")
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -59,9 +60,9 @@ func (ir *imageRouter) UserAvatar(w http.ResponseWriter, r *http.Request) {
 		http.ServeContent(w, r, "no-avatar.png", ir.noAvatarModTime, bytes.NewReader(ir.noAvatarImage))
 		return
 	}
-	link, err := ir.s3Storage.CreateLink(r.Context(), user.AvatarThumbnailID, time.Hour*24)
+	link, err := ir.blobStorage.CreateLink(r.Context(), user.AvatarThumbnailID, time.Hour*24)
 	if err != nil {
-		log.Println("can't create s3 link: ", err)
+		logging.FromContext(r.Context()).WithError(err).Error("can't create blob link")
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -74,20 +75,20 @@ func (ir *imageRouter) loadNoAvatarImage() {
 	ir.noAvatarOnce.Do(func() {
 		f, err := ir.staticFS.Open("/no-avatar.png")
 		if err != nil {
-			log.Println("can't open no-avatar.png:", err)
+			logrus.WithError(err).Error("can't open no-avatar.png")
 			return
 		}
 		defer func() { _ = f.Close() }()
 		content, err := ioutil.ReadAll(f)
 		if err != nil {
-			log.Println("can't read no-avatar.png:", err)
+			logrus.WithError(err).Error("can't read no-avatar.png")
 			return
 		}
 		ir.noAvatarImage = content
 
 		stat, err := f.Stat()
 		if err != nil {
-			log.Println("can't get stat for no-avatar.png:", err)
+			logrus.WithError(err).Error("can't get stat for no-avatar.png")
 			return
 		}
 		ir.noAvatarModTime = stat.ModTime()
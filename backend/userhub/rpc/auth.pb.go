@@ -348,7 +348,7 @@ var file_auth_proto_rawDesc = []byte{
 	0x65, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
 	0x72, 0x65, 0x73, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x65,
 	0x77, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x6e, 0x65, 0x77, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x32, 0xf0, 0x02,
+	0x52, 0x0b, 0x6e, 0x65, 0x77, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x32, 0xe5, 0x03,
 	0x0a, 0x0b, 0x41, 0x75, 0x74, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x30, 0x0a,
 	0x08, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x18, 0x2e, 0x72, 0x70, 0x63, 0x2e,
 	0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
@@ -372,8 +372,15 @@ var file_auth_proto_rawDesc = []byte{
 	0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x20,
 	0x0a, 0x06, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45,
 	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x42, 0x08, 0x5a, 0x06, 0x2e, 0x2e, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x12, 0x39, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x45, 0x6d, 0x61, 0x69, 0x6c,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x17, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x75, 0x74,
+	0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x38, 0x0a, 0x11, 0x52,
+	0x65, 0x76, 0x65, 0x72, 0x74, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x17, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x08, 0x5a, 0x06, 0x2e, 0x2e, 0x2f, 0x72, 0x70, 0x63, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -405,15 +412,19 @@ var file_auth_proto_depIdxs = []int32{
 	3, // 4: rpc.AuthService.SendResetPasswordLink:input_type -> rpc.AuthSendResetPasswordLinkRequest
 	4, // 5: rpc.AuthService.ResetPassword:input_type -> rpc.AuthResetPasswordRequest
 	5, // 6: rpc.AuthService.Logout:input_type -> rpc.Empty
-	5, // 7: rpc.AuthService.Register:output_type -> rpc.Empty
-	5, // 8: rpc.AuthService.Login:output_type -> rpc.Empty
-	5, // 9: rpc.AuthService.Confirm:output_type -> rpc.Empty
-	5, // 10: rpc.AuthService.SendConfirmLink:output_type -> rpc.Empty
-	5, // 11: rpc.AuthService.SendResetPasswordLink:output_type -> rpc.Empty
-	5, // 12: rpc.AuthService.ResetPassword:output_type -> rpc.Empty
-	5, // 13: rpc.AuthService.Logout:output_type -> rpc.Empty
-	7, // [7:14] is the sub-list for method output_type
-	0, // [0:7] is the sub-list for method input_type
+	2, // 7: rpc.AuthService.ConfirmEmailChange:input_type -> rpc.AuthConfirmRequest
+	2, // 8: rpc.AuthService.RevertEmailChange:input_type -> rpc.AuthConfirmRequest
+	5, // 9: rpc.AuthService.Register:output_type -> rpc.Empty
+	5, // 10: rpc.AuthService.Login:output_type -> rpc.Empty
+	5, // 11: rpc.AuthService.Confirm:output_type -> rpc.Empty
+	5, // 12: rpc.AuthService.SendConfirmLink:output_type -> rpc.Empty
+	5, // 13: rpc.AuthService.SendResetPasswordLink:output_type -> rpc.Empty
+	5, // 14: rpc.AuthService.ResetPassword:output_type -> rpc.Empty
+	5, // 15: rpc.AuthService.Logout:output_type -> rpc.Empty
+	5, // 16: rpc.AuthService.ConfirmEmailChange:output_type -> rpc.Empty
+	5, // 17: rpc.AuthService.RevertEmailChange:output_type -> rpc.Empty
+	9, // [9:18] is the sub-list for method output_type
+	0, // [0:9] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
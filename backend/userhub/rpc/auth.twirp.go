@@ -10,8 +10,8 @@ It is generated from these files:
 	blob.proto
 	info.proto
 	invite.proto
-	messagehub_notification.proto
 	messagehub.proto
+	messagehub_notification.proto
 	model.proto
 	notification.proto
 	token.proto
@@ -55,6 +55,10 @@ type AuthService interface {
 	ResetPassword(context.Context, *AuthResetPasswordRequest) (*Empty, error)
 
 	Logout(context.Context, *Empty) (*Empty, error)
+
+	ConfirmEmailChange(context.Context, *AuthConfirmRequest) (*Empty, error)
+
+	RevertEmailChange(context.Context, *AuthConfirmRequest) (*Empty, error)
 }
 
 // ===========================
@@ -63,7 +67,7 @@ type AuthService interface {
 
 type authServiceProtobufClient struct {
 	client HTTPClient
-	urls   [7]string
+	urls   [9]string
 	opts   twirp.ClientOptions
 }
 
@@ -80,7 +84,7 @@ func NewAuthServiceProtobufClient(addr string, client HTTPClient, opts ...twirp.
 	}
 
 	prefix := urlBase(addr) + AuthServicePathPrefix
-	urls := [7]string{
+	urls := [9]string{
 		prefix + "Register",
 		prefix + "Login",
 		prefix + "Confirm",
@@ -88,6 +92,8 @@ func NewAuthServiceProtobufClient(addr string, client HTTPClient, opts ...twirp.
 		prefix + "SendResetPasswordLink",
 		prefix + "ResetPassword",
 		prefix + "Logout",
+		prefix + "ConfirmEmailChange",
+		prefix + "RevertEmailChange",
 	}
 
 	return &authServiceProtobufClient{
@@ -237,13 +243,53 @@ func (c *authServiceProtobufClient) Logout(ctx context.Context, in *Empty) (*Emp
 	return out, nil
 }
 
+func (c *authServiceProtobufClient) ConfirmEmailChange(ctx context.Context, in *AuthConfirmRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "AuthService")
+	ctx = ctxsetters.WithMethodName(ctx, "ConfirmEmailChange")
+	out := new(Empty)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[7], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *authServiceProtobufClient) RevertEmailChange(ctx context.Context, in *AuthConfirmRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "AuthService")
+	ctx = ctxsetters.WithMethodName(ctx, "RevertEmailChange")
+	out := new(Empty)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[8], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // =======================
 // AuthService JSON Client
 // =======================
 
 type authServiceJSONClient struct {
 	client HTTPClient
-	urls   [7]string
+	urls   [9]string
 	opts   twirp.ClientOptions
 }
 
@@ -260,7 +306,7 @@ func NewAuthServiceJSONClient(addr string, client HTTPClient, opts ...twirp.Clie
 	}
 
 	prefix := urlBase(addr) + AuthServicePathPrefix
-	urls := [7]string{
+	urls := [9]string{
 		prefix + "Register",
 		prefix + "Login",
 		prefix + "Confirm",
@@ -268,6 +314,8 @@ func NewAuthServiceJSONClient(addr string, client HTTPClient, opts ...twirp.Clie
 		prefix + "SendResetPasswordLink",
 		prefix + "ResetPassword",
 		prefix + "Logout",
+		prefix + "ConfirmEmailChange",
+		prefix + "RevertEmailChange",
 	}
 
 	return &authServiceJSONClient{
@@ -417,6 +465,46 @@ func (c *authServiceJSONClient) Logout(ctx context.Context, in *Empty) (*Empty,
 	return out, nil
 }
 
+func (c *authServiceJSONClient) ConfirmEmailChange(ctx context.Context, in *AuthConfirmRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "AuthService")
+	ctx = ctxsetters.WithMethodName(ctx, "ConfirmEmailChange")
+	out := new(Empty)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[7], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *authServiceJSONClient) RevertEmailChange(ctx context.Context, in *AuthConfirmRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "AuthService")
+	ctx = ctxsetters.WithMethodName(ctx, "RevertEmailChange")
+	out := new(Empty)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[8], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ==========================
 // AuthService Server Handler
 // ==========================
@@ -486,6 +574,12 @@ func (s *authServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Reques
 	case "/rpc.AuthService/Logout":
 		s.serveLogout(ctx, resp, req)
 		return
+	case "/rpc.AuthService/ConfirmEmailChange":
+		s.serveConfirmEmailChange(ctx, resp, req)
+		return
+	case "/rpc.AuthService/RevertEmailChange":
+		s.serveRevertEmailChange(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		err = badRouteError(msg, req.Method, req.URL.Path)
@@ -1397,6 +1491,264 @@ func (s *authServiceServer) serveLogoutProtobuf(ctx context.Context, resp http.R
 	callResponseSent(ctx, s.hooks)
 }
 
+func (s *authServiceServer) serveConfirmEmailChange(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveConfirmEmailChangeJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveConfirmEmailChangeProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *authServiceServer) serveConfirmEmailChangeJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ConfirmEmailChange")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(AuthConfirmRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.AuthService.ConfirmEmailChange(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling ConfirmEmailChange. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *authServiceServer) serveConfirmEmailChangeProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ConfirmEmailChange")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(AuthConfirmRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.AuthService.ConfirmEmailChange(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling ConfirmEmailChange. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *authServiceServer) serveRevertEmailChange(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveRevertEmailChangeJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveRevertEmailChangeProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *authServiceServer) serveRevertEmailChangeJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RevertEmailChange")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(AuthConfirmRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.AuthService.RevertEmailChange(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling RevertEmailChange. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *authServiceServer) serveRevertEmailChangeProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RevertEmailChange")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(AuthConfirmRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.AuthService.RevertEmailChange(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling RevertEmailChange. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
 func (s *authServiceServer) ServiceDescriptor() ([]byte, int) {
 	return twirpFileDescriptor0, 0
 }
@@ -1925,30 +2277,31 @@ func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error)
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 392 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0xcf, 0x4b, 0xe3, 0x40,
-	0x14, 0xc7, 0xe9, 0xcf, 0xcd, 0xbe, 0xec, 0xb2, 0xcb, 0x6c, 0xcb, 0x86, 0xc2, 0xb2, 0x35, 0x20,
-	0x68, 0x0f, 0x51, 0xf4, 0xe6, 0x4d, 0xc5, 0x8b, 0x44, 0x90, 0xe8, 0xc9, 0x83, 0x21, 0x4d, 0x9f,
-	0xed, 0xd0, 0xce, 0x4c, 0x9c, 0x4c, 0x5a, 0xbc, 0xf8, 0x37, 0xfb, 0x27, 0x48, 0x66, 0x9a, 0x90,
-	0xd4, 0x5a, 0xf0, 0x96, 0xf7, 0xde, 0x37, 0xef, 0xfb, 0xcd, 0xe7, 0x11, 0x80, 0x28, 0x53, 0x33,
-	0x2f, 0x91, 0x42, 0x09, 0xd2, 0x92, 0x49, 0x3c, 0xb0, 0x99, 0x98, 0xe0, 0xc2, 0x74, 0xdc, 0x57,
-	0xf8, 0x73, 0x9e, 0xa9, 0x59, 0x80, 0x53, 0x9a, 0x2a, 0x94, 0x01, 0x3e, 0x67, 0x98, 0x2a, 0x42,
-	0xa0, 0xcd, 0x23, 0x86, 0x4e, 0x63, 0xd8, 0x38, 0xf8, 0x1e, 0xe8, 0x67, 0xd2, 0x83, 0x0e, 0xb2,
-	0x88, 0x2e, 0x9c, 0xa6, 0x6e, 0x9a, 0x82, 0x0c, 0xc0, 0x4a, 0xa2, 0x34, 0x5d, 0x09, 0x39, 0x71,
-	0x5a, 0x7a, 0x50, 0xd6, 0x64, 0x0f, 0x7e, 0x50, 0xbe, 0xa4, 0x0a, 0x43, 0x25, 0xe6, 0xc8, 0x9d,
-	0xb6, 0x9e, 0xdb, 0xa6, 0x77, 0x9f, 0xb7, 0xdc, 0x18, 0x7e, 0xe7, 0xfe, 0xbe, 0x98, 0x52, 0xbe,
-	0xcb, 0xbc, 0x6a, 0xd3, 0xdc, 0xb0, 0xf9, 0x0f, 0xb6, 0x44, 0x86, 0x6c, 0x8c, 0x32, 0x64, 0xa8,
-	0x53, 0x58, 0x01, 0x14, 0xad, 0x1b, 0x74, 0x47, 0x40, 0x72, 0x93, 0x4b, 0xc1, 0x9f, 0xa8, 0x64,
-	0x85, 0x4d, 0x0f, 0x3a, 0x26, 0x96, 0xf1, 0x31, 0x85, 0xeb, 0xc3, 0x30, 0xd7, 0xde, 0x21, 0x9f,
-	0x04, 0x98, 0xa2, 0xba, 0x5d, 0xbb, 0xf8, 0x94, 0xcf, 0xbf, 0x4c, 0xc7, 0x7d, 0x04, 0xc7, 0xe0,
-	0xad, 0x6c, 0x2a, 0xb6, 0xe8, 0xd8, 0x29, 0xaa, 0xb0, 0x9a, 0x02, 0x74, 0x4b, 0xb3, 0xc9, 0xf1,
-	0x71, 0x5c, 0x85, 0x1b, 0xdf, 0x6d, 0x73, 0x5c, 0x15, 0xab, 0x4e, 0xde, 0x9a, 0x60, 0x9b, 0xb8,
-	0x72, 0x49, 0x63, 0x24, 0xc7, 0x60, 0x15, 0xa7, 0x24, 0x8e, 0x27, 0x93, 0xd8, 0xdb, 0x72, 0xdd,
-	0x01, 0xe8, 0xc9, 0x15, 0x4b, 0xd4, 0x0b, 0x19, 0x41, 0x47, 0xc3, 0x27, 0xfd, 0x52, 0x5e, 0x3d,
-	0x46, 0x4d, 0xeb, 0xc1, 0xb7, 0x35, 0x43, 0xf2, 0xb7, 0x54, 0xd7, 0xa9, 0xd6, 0xf4, 0x87, 0xf0,
-	0x2b, 0xe7, 0xb8, 0x56, 0xe4, 0x04, 0x49, 0x65, 0x5c, 0x93, 0x5e, 0x43, 0x7f, 0x2b, 0x72, 0xb2,
-	0x5f, 0x1a, 0xed, 0x3a, 0x49, 0x6d, 0xd7, 0x19, 0xfc, 0xac, 0xe9, 0xc8, 0xbf, 0x0a, 0x89, 0x8f,
-	0x87, 0xa8, 0xbd, 0x3b, 0x84, 0xae, 0x2f, 0xa6, 0x22, 0x53, 0x9f, 0x25, 0xbd, 0xb0, 0x1e, 0xba,
-	0x9e, 0x77, 0x24, 0x93, 0x78, 0xdc, 0xd5, 0xbf, 0xd0, 0xe9, 0x7b, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0xcb, 0x0b, 0xc5, 0xca, 0x62, 0x03, 0x00, 0x00,
+	// 413 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0x41, 0x8f, 0xd3, 0x30,
+	0x10, 0x85, 0xb5, 0xdb, 0x6d, 0x09, 0x13, 0x10, 0x30, 0xec, 0x8a, 0xa8, 0x12, 0xa2, 0x44, 0x42,
+	0x82, 0x3d, 0x04, 0x04, 0x17, 0xe0, 0x06, 0xab, 0xbd, 0xa0, 0x20, 0xa1, 0xc0, 0x89, 0x03, 0x51,
+	0x9a, 0x0e, 0xa9, 0xd5, 0xda, 0x0e, 0x8e, 0xd3, 0x8a, 0x0b, 0xff, 0x96, 0xff, 0x81, 0x6c, 0x27,
+	0x51, 0x52, 0x4a, 0x45, 0x6f, 0xf1, 0xcc, 0xf3, 0xbc, 0xf1, 0xf7, 0x14, 0x80, 0xac, 0xd6, 0xcb,
+	0xa8, 0x54, 0x52, 0x4b, 0x1c, 0xa9, 0x32, 0x9f, 0xfa, 0x5c, 0x2e, 0x68, 0xed, 0x2a, 0xe1, 0x2f,
+	0xb8, 0xff, 0xae, 0xd6, 0xcb, 0x84, 0x0a, 0x56, 0x69, 0x52, 0x09, 0xfd, 0xa8, 0xa9, 0xd2, 0x88,
+	0x70, 0x26, 0x32, 0x4e, 0xc1, 0xc9, 0xec, 0xe4, 0xe9, 0xcd, 0xc4, 0x7e, 0xe3, 0x39, 0x8c, 0x89,
+	0x67, 0x6c, 0x1d, 0x9c, 0xda, 0xa2, 0x3b, 0xe0, 0x14, 0xbc, 0x32, 0xab, 0xaa, 0xad, 0x54, 0x8b,
+	0x60, 0x64, 0x1b, 0xdd, 0x19, 0x1f, 0xc3, 0x2d, 0x26, 0x36, 0x4c, 0x53, 0xaa, 0xe5, 0x8a, 0x44,
+	0x70, 0x66, 0xfb, 0xbe, 0xab, 0x7d, 0x31, 0xa5, 0x30, 0x87, 0xbb, 0xc6, 0x3f, 0x96, 0x05, 0x13,
+	0x87, 0xcc, 0xfb, 0x36, 0xa7, 0x3b, 0x36, 0x8f, 0xc0, 0x57, 0xc4, 0x89, 0xcf, 0x49, 0xa5, 0x9c,
+	0xec, 0x16, 0x5e, 0x02, 0x6d, 0xe9, 0x23, 0x85, 0x97, 0x80, 0xc6, 0xe4, 0x4a, 0x8a, 0xef, 0x4c,
+	0xf1, 0xd6, 0xe6, 0x1c, 0xc6, 0x6e, 0x2d, 0xe7, 0xe3, 0x0e, 0x61, 0x0c, 0x33, 0xa3, 0xfd, 0x4c,
+	0x62, 0x91, 0x50, 0x45, 0xfa, 0x53, 0xe3, 0x12, 0x33, 0xb1, 0x3a, 0x9a, 0x4e, 0xf8, 0x0d, 0x02,
+	0x87, 0xb7, 0x37, 0xa9, 0x9d, 0x62, 0xd7, 0xae, 0x48, 0xa7, 0xfd, 0x2d, 0xc0, 0x96, 0x2c, 0x1b,
+	0x83, 0x4f, 0xd0, 0x36, 0xdd, 0x79, 0xb7, 0x2f, 0x68, 0xdb, 0x8e, 0x7a, 0xf9, 0x7b, 0x04, 0xbe,
+	0x5b, 0x57, 0x6d, 0x58, 0x4e, 0xf8, 0x02, 0xbc, 0x36, 0x4a, 0x0c, 0x22, 0x55, 0xe6, 0xd1, 0x9e,
+	0x74, 0xa7, 0x60, 0x3b, 0xd7, 0xbc, 0xd4, 0x3f, 0xf1, 0x12, 0xc6, 0x16, 0x3e, 0x5e, 0x74, 0xf2,
+	0x7e, 0x18, 0x03, 0x6d, 0x04, 0x37, 0x1a, 0x86, 0xf8, 0xa0, 0x53, 0x0f, 0xa9, 0x0e, 0xf4, 0xcf,
+	0xe0, 0x8e, 0xe1, 0xd8, 0x28, 0x0c, 0x41, 0xec, 0xb5, 0x07, 0xd2, 0x0f, 0x70, 0xb1, 0x17, 0x39,
+	0x3e, 0xe9, 0x8c, 0x0e, 0x45, 0x32, 0x98, 0xf5, 0x16, 0x6e, 0x0f, 0x74, 0xf8, 0xb0, 0x47, 0xe2,
+	0xef, 0x20, 0x06, 0x77, 0x67, 0x30, 0x89, 0x65, 0x21, 0x6b, 0xfd, 0xcf, 0x4d, 0xdf, 0x00, 0x36,
+	0x0f, 0xba, 0x36, 0x11, 0x5f, 0x2d, 0x33, 0x51, 0xd0, 0xff, 0xf1, 0x78, 0x0d, 0xf7, 0x12, 0xda,
+	0x90, 0xd2, 0xc7, 0xde, 0x7c, 0xef, 0x7d, 0x9d, 0x44, 0xd1, 0x73, 0x55, 0xe6, 0xf3, 0x89, 0xfd,
+	0x6f, 0x5f, 0xfd, 0x19, 0x00, 0x39, 0x3f, 0x60, 0x9e, 0xd7, 0x03, 0x00, 0x00,
 }
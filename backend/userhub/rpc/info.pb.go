@@ -119,6 +119,53 @@ func (x *InfoVersionResponse) GetDockerUpdated() string {
 	return ""
 }
 
+type InfoWebPushPublicKeyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PublicKey string `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+}
+
+func (x *InfoWebPushPublicKeyResponse) Reset() {
+	*x = InfoWebPushPublicKeyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_info_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InfoWebPushPublicKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InfoWebPushPublicKeyResponse) ProtoMessage() {}
+
+func (x *InfoWebPushPublicKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_info_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InfoWebPushPublicKeyResponse.ProtoReflect.Descriptor instead.
+func (*InfoWebPushPublicKeyResponse) Descriptor() ([]byte, []int) {
+	return file_info_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *InfoWebPushPublicKeyResponse) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
 var File_info_proto protoreflect.FileDescriptor
 
 var file_info_proto_rawDesc = []byte{
@@ -131,15 +178,23 @@ var file_info_proto_rawDesc = []byte{
 	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a,
 	0x0e, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x64, 0x32, 0x73, 0x0a, 0x0b, 0x49, 0x6e, 0x66, 0x6f, 0x53, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x65, 0x64, 0x22, 0x3d, 0x0a, 0x1c, 0x49, 0x6e, 0x66, 0x6f, 0x57, 0x65, 0x62, 0x50,
+	0x75, 0x73, 0x68, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x4b, 0x65, 0x79, 0x32, 0xb6, 0x01, 0x0a, 0x0b, 0x49, 0x6e, 0x66, 0x6f, 0x53, 0x65, 0x72, 0x76,
 	0x69, 0x63, 0x65, 0x12, 0x33, 0x0a, 0x09, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79,
 	0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a, 0x2e, 0x72,
 	0x70, 0x63, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79,
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73,
 	0x69, 0x6f, 0x6e, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
 	0x18, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x08, 0x5a, 0x06, 0x2e, 0x2e, 0x2f,
-	0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x10, 0x57, 0x65, 0x62,
+	0x50, 0x75, 0x73, 0x68, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x0a, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x21, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x49, 0x6e, 0x66, 0x6f, 0x57, 0x65, 0x62, 0x50, 0x75, 0x73, 0x68, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x08, 0x5a, 0x06,
+	0x2e, 0x2e, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -154,19 +209,22 @@ func file_info_proto_rawDescGZIP() []byte {
 	return file_info_proto_rawDescData
 }
 
-var file_info_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_info_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_info_proto_goTypes = []interface{}{
-	(*InfoPublicKeyResponse)(nil), // 0: rpc.InfoPublicKeyResponse
-	(*InfoVersionResponse)(nil),   // 1: rpc.InfoVersionResponse
-	(*Empty)(nil),                 // 2: rpc.Empty
+	(*InfoPublicKeyResponse)(nil),        // 0: rpc.InfoPublicKeyResponse
+	(*InfoVersionResponse)(nil),          // 1: rpc.InfoVersionResponse
+	(*InfoWebPushPublicKeyResponse)(nil), // 2: rpc.InfoWebPushPublicKeyResponse
+	(*Empty)(nil),                        // 3: rpc.Empty
 }
 var file_info_proto_depIdxs = []int32{
-	2, // 0: rpc.InfoService.PublicKey:input_type -> rpc.Empty
-	2, // 1: rpc.InfoService.Version:input_type -> rpc.Empty
-	0, // 2: rpc.InfoService.PublicKey:output_type -> rpc.InfoPublicKeyResponse
-	1, // 3: rpc.InfoService.Version:output_type -> rpc.InfoVersionResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
+	3, // 0: rpc.InfoService.PublicKey:input_type -> rpc.Empty
+	3, // 1: rpc.InfoService.Version:input_type -> rpc.Empty
+	3, // 2: rpc.InfoService.WebPushPublicKey:input_type -> rpc.Empty
+	0, // 3: rpc.InfoService.PublicKey:output_type -> rpc.InfoPublicKeyResponse
+	1, // 4: rpc.InfoService.Version:output_type -> rpc.InfoVersionResponse
+	2, // 5: rpc.InfoService.WebPushPublicKey:output_type -> rpc.InfoWebPushPublicKeyResponse
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -203,6 +261,18 @@ func file_info_proto_init() {
 				return nil
 			}
 		}
+		file_info_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InfoWebPushPublicKeyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -210,7 +280,7 @@ func file_info_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_info_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,7 +21,9 @@ import ctxsetters "github.com/twitchtv/twirp/ctxsetters"
 // =======================================
 
 type MessageHubNotificationService interface {
-	PostNotifications(context.Context, *MessageHubNotificationPostNotificationsRequest) (*Empty, error)
+	PostNotifications(context.Context, *MessageHubNotificationPostNotificationsRequest) (*MessageHubNotificationPostNotificationsResponse, error)
+
+	UserFriends(context.Context, *MessageHubNotificationUserFriendsRequest) (*MessageHubNotificationUserFriendsResponse, error)
 }
 
 // =============================================
@@ -30,7 +32,7 @@ type MessageHubNotificationService interface {
 
 type messageHubNotificationServiceProtobufClient struct {
 	client HTTPClient
-	urls   [1]string
+	urls   [2]string
 	opts   twirp.ClientOptions
 }
 
@@ -47,8 +49,9 @@ func NewMessageHubNotificationServiceProtobufClient(addr string, client HTTPClie
 	}
 
 	prefix := urlBase(addr) + MessageHubNotificationServicePathPrefix
-	urls := [1]string{
+	urls := [2]string{
 		prefix + "PostNotifications",
+		prefix + "UserFriends",
 	}
 
 	return &messageHubNotificationServiceProtobufClient{
@@ -58,11 +61,11 @@ func NewMessageHubNotificationServiceProtobufClient(addr string, client HTTPClie
 	}
 }
 
-func (c *messageHubNotificationServiceProtobufClient) PostNotifications(ctx context.Context, in *MessageHubNotificationPostNotificationsRequest) (*Empty, error) {
+func (c *messageHubNotificationServiceProtobufClient) PostNotifications(ctx context.Context, in *MessageHubNotificationPostNotificationsRequest) (*MessageHubNotificationPostNotificationsResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "rpc")
 	ctx = ctxsetters.WithServiceName(ctx, "MessageHubNotificationService")
 	ctx = ctxsetters.WithMethodName(ctx, "PostNotifications")
-	out := new(Empty)
+	out := new(MessageHubNotificationPostNotificationsResponse)
 	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
@@ -78,13 +81,33 @@ func (c *messageHubNotificationServiceProtobufClient) PostNotifications(ctx cont
 	return out, nil
 }
 
+func (c *messageHubNotificationServiceProtobufClient) UserFriends(ctx context.Context, in *MessageHubNotificationUserFriendsRequest) (*MessageHubNotificationUserFriendsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageHubNotificationService")
+	ctx = ctxsetters.WithMethodName(ctx, "UserFriends")
+	out := new(MessageHubNotificationUserFriendsResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // =========================================
 // MessageHubNotificationService JSON Client
 // =========================================
 
 type messageHubNotificationServiceJSONClient struct {
 	client HTTPClient
-	urls   [1]string
+	urls   [2]string
 	opts   twirp.ClientOptions
 }
 
@@ -101,8 +124,9 @@ func NewMessageHubNotificationServiceJSONClient(addr string, client HTTPClient,
 	}
 
 	prefix := urlBase(addr) + MessageHubNotificationServicePathPrefix
-	urls := [1]string{
+	urls := [2]string{
 		prefix + "PostNotifications",
+		prefix + "UserFriends",
 	}
 
 	return &messageHubNotificationServiceJSONClient{
@@ -112,11 +136,11 @@ func NewMessageHubNotificationServiceJSONClient(addr string, client HTTPClient,
 	}
 }
 
-func (c *messageHubNotificationServiceJSONClient) PostNotifications(ctx context.Context, in *MessageHubNotificationPostNotificationsRequest) (*Empty, error) {
+func (c *messageHubNotificationServiceJSONClient) PostNotifications(ctx context.Context, in *MessageHubNotificationPostNotificationsRequest) (*MessageHubNotificationPostNotificationsResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "rpc")
 	ctx = ctxsetters.WithServiceName(ctx, "MessageHubNotificationService")
 	ctx = ctxsetters.WithMethodName(ctx, "PostNotifications")
-	out := new(Empty)
+	out := new(MessageHubNotificationPostNotificationsResponse)
 	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
@@ -132,6 +156,26 @@ func (c *messageHubNotificationServiceJSONClient) PostNotifications(ctx context.
 	return out, nil
 }
 
+func (c *messageHubNotificationServiceJSONClient) UserFriends(ctx context.Context, in *MessageHubNotificationUserFriendsRequest) (*MessageHubNotificationUserFriendsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "MessageHubNotificationService")
+	ctx = ctxsetters.WithMethodName(ctx, "UserFriends")
+	out := new(MessageHubNotificationUserFriendsResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ============================================
 // MessageHubNotificationService Server Handler
 // ============================================
@@ -183,6 +227,9 @@ func (s *messageHubNotificationServiceServer) ServeHTTP(resp http.ResponseWriter
 	case "/rpc.MessageHubNotificationService/PostNotifications":
 		s.servePostNotifications(ctx, resp, req)
 		return
+	case "/rpc.MessageHubNotificationService/UserFriends":
+		s.serveUserFriends(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		err = badRouteError(msg, req.Method, req.URL.Path)
@@ -226,7 +273,7 @@ func (s *messageHubNotificationServiceServer) servePostNotificationsJSON(ctx con
 	}
 
 	// Call service method
-	var respContent *Empty
+	var respContent *MessageHubNotificationPostNotificationsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = s.MessageHubNotificationService.PostNotifications(ctx, reqContent)
@@ -237,7 +284,7 @@ func (s *messageHubNotificationServiceServer) servePostNotificationsJSON(ctx con
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling PostNotifications. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageHubNotificationPostNotificationsResponse and nil error while calling PostNotifications. nil responses are not supported"))
 		return
 	}
 
@@ -285,7 +332,7 @@ func (s *messageHubNotificationServiceServer) servePostNotificationsProtobuf(ctx
 	}
 
 	// Call service method
-	var respContent *Empty
+	var respContent *MessageHubNotificationPostNotificationsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = s.MessageHubNotificationService.PostNotifications(ctx, reqContent)
@@ -296,7 +343,136 @@ func (s *messageHubNotificationServiceServer) servePostNotificationsProtobuf(ctx
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling PostNotifications. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageHubNotificationPostNotificationsResponse and nil error while calling PostNotifications. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageHubNotificationServiceServer) serveUserFriends(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveUserFriendsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveUserFriendsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *messageHubNotificationServiceServer) serveUserFriendsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UserFriends")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(MessageHubNotificationUserFriendsRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageHubNotificationUserFriendsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageHubNotificationService.UserFriends(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageHubNotificationUserFriendsResponse and nil error while calling UserFriends. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *messageHubNotificationServiceServer) serveUserFriendsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UserFriends")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(MessageHubNotificationUserFriendsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *MessageHubNotificationUserFriendsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.MessageHubNotificationService.UserFriends(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *MessageHubNotificationUserFriendsResponse and nil error while calling UserFriends. nil responses are not supported"))
 		return
 	}
 
@@ -333,17 +509,29 @@ func (s *messageHubNotificationServiceServer) PathPrefix() string {
 }
 
 var twirpFileDescriptor4 = []byte{
-	// 188 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0xcd, 0x4d, 0x2d, 0x2e,
-	0x4e, 0x4c, 0x4f, 0xcd, 0x28, 0x4d, 0x8a, 0xcf, 0xcb, 0x2f, 0xc9, 0x4c, 0xcb, 0x4c, 0x4e, 0x2c,
-	0xc9, 0xcc, 0xcf, 0xd3, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2e, 0x2a, 0x48, 0x96, 0xe2,
-	0xce, 0xcd, 0x4f, 0x49, 0xcd, 0x81, 0x88, 0x28, 0x95, 0x72, 0xe9, 0xf9, 0x42, 0xb4, 0x78, 0x94,
-	0x26, 0xf9, 0x21, 0xe9, 0x08, 0xc8, 0x2f, 0x2e, 0x41, 0xe6, 0x17, 0x07, 0xa5, 0x16, 0x96, 0xa6,
-	0x16, 0x97, 0x08, 0x09, 0x71, 0xb1, 0xe4, 0xe5, 0xa7, 0xa4, 0x4a, 0x30, 0x2a, 0x30, 0x6a, 0x70,
-	0x06, 0x81, 0xd9, 0x42, 0xfa, 0x5c, 0xc2, 0xc8, 0xb6, 0x15, 0xc7, 0x97, 0xe4, 0x67, 0xa7, 0xe6,
-	0x49, 0x30, 0x81, 0x95, 0x08, 0xa1, 0x48, 0x85, 0x80, 0x64, 0x8c, 0x4a, 0xb9, 0x64, 0xb1, 0x5b,
-	0x1b, 0x9c, 0x5a, 0x54, 0x96, 0x99, 0x9c, 0x2a, 0x14, 0xc2, 0x25, 0x88, 0xe1, 0x02, 0x21, 0x63,
-	0xbd, 0xa2, 0x82, 0x64, 0x12, 0xdd, 0x2b, 0xc5, 0x05, 0xd6, 0xe4, 0x9a, 0x5b, 0x50, 0x52, 0xe9,
-	0xc4, 0x11, 0xc5, 0xa6, 0xa7, 0xa7, 0x5f, 0x54, 0x90, 0x9c, 0xc4, 0x06, 0xf6, 0xbe, 0x31, 0x20,
-	0x00, 0x00, 0xff, 0xff, 0xf4, 0xf4, 0x24, 0x30, 0x31, 0x01, 0x00, 0x00,
+	// 379 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0x41, 0x6b, 0xe2, 0x40,
+	0x14, 0x26, 0x71, 0x75, 0xf5, 0xe5, 0xb0, 0xeb, 0xac, 0x07, 0x37, 0x20, 0x48, 0x60, 0xc1, 0x3d,
+	0xec, 0x08, 0xba, 0x87, 0x65, 0x97, 0x3d, 0xb4, 0xd0, 0xd2, 0x14, 0x5a, 0x4a, 0x6a, 0x2f, 0xbd,
+	0x84, 0x98, 0x3c, 0xdb, 0x41, 0x9d, 0x99, 0xce, 0x4c, 0x04, 0x2f, 0xfd, 0x07, 0x3d, 0xf5, 0x0f,
+	0x17, 0x13, 0xa9, 0x11, 0x83, 0x68, 0x6f, 0xf3, 0xde, 0x7c, 0xef, 0xfb, 0xde, 0x7c, 0x1f, 0x03,
+	0x9d, 0x39, 0x6a, 0x1d, 0x3d, 0xe0, 0x63, 0x3a, 0x0e, 0xb9, 0x30, 0x6c, 0xc2, 0xe2, 0xc8, 0x30,
+	0xc1, 0xa9, 0x54, 0xc2, 0x08, 0x52, 0x51, 0x32, 0x76, 0x9d, 0xb9, 0x48, 0x70, 0x96, 0x77, 0xbc,
+	0x14, 0xe8, 0x55, 0x3e, 0x72, 0x91, 0x8e, 0xaf, 0x0b, 0x13, 0x37, 0x42, 0x9b, 0x62, 0xad, 0x03,
+	0x7c, 0x4a, 0x51, 0x1b, 0x42, 0xe0, 0x13, 0x17, 0x09, 0xb6, 0xad, 0xae, 0xd5, 0x6b, 0x04, 0xd9,
+	0x99, 0xf4, 0xe1, 0x5b, 0x51, 0x4d, 0x87, 0x46, 0x4c, 0x91, 0xb7, 0xed, 0x0c, 0x42, 0xb6, 0xae,
+	0x46, 0xab, 0x1b, 0xef, 0x3f, 0x74, 0xcb, 0x65, 0x03, 0x8c, 0x99, 0x64, 0xc8, 0x8d, 0x26, 0xdf,
+	0xa1, 0x9e, 0x6a, 0x54, 0x21, 0x4b, 0x74, 0xdb, 0xea, 0x56, 0x7a, 0x8d, 0xe0, 0xf3, 0xaa, 0xf6,
+	0x13, 0xed, 0xbd, 0xda, 0xd0, 0x3f, 0x78, 0x6d, 0x2d, 0x05, 0xd7, 0x48, 0x52, 0x68, 0x32, 0x1e,
+	0x46, 0x52, 0x86, 0xea, 0x5d, 0x23, 0xe3, 0x75, 0x06, 0x3e, 0x55, 0x32, 0xa6, 0x47, 0x12, 0x52,
+	0x9f, 0x9f, 0x48, 0xb9, 0xd9, 0xf7, 0x8c, 0x1b, 0xb5, 0x0c, 0xbe, 0xb0, 0xed, 0xae, 0xcb, 0xa0,
+	0x55, 0x06, 0x24, 0x5f, 0xa1, 0x32, 0xc5, 0xe5, 0xda, 0xc5, 0xd5, 0x91, 0xfc, 0x83, 0xea, 0x22,
+	0x9a, 0xa5, 0x98, 0xd9, 0xe6, 0x0c, 0x7e, 0xec, 0x59, 0x6a, 0x43, 0x16, 0xe4, 0x33, 0x7f, 0xed,
+	0x3f, 0x96, 0x37, 0x82, 0x5e, 0x39, 0xfc, 0x4e, 0xa3, 0x3a, 0x57, 0x0c, 0x79, 0xb2, 0x37, 0xc5,
+	0x16, 0x54, 0x8b, 0xb9, 0xe5, 0x85, 0x77, 0x09, 0x3f, 0x0f, 0x60, 0x5d, 0x9b, 0xdc, 0x01, 0x98,
+	0x64, 0xad, 0x42, 0x6a, 0x8d, 0xbc, 0xe3, 0x27, 0x7a, 0xf0, 0x62, 0x43, 0xa7, 0x9c, 0xec, 0x16,
+	0xd5, 0x82, 0xc5, 0x48, 0x9e, 0xa1, 0xb9, 0xe3, 0x38, 0x19, 0x1e, 0x97, 0x4f, 0xf6, 0x42, 0xf7,
+	0xf7, 0x47, 0x42, 0x25, 0x33, 0x70, 0x0a, 0xef, 0x22, 0xbf, 0xf6, 0x90, 0xec, 0xba, 0xea, 0xd2,
+	0x43, 0xe1, 0xb9, 0xda, 0x69, 0xfd, 0xbe, 0x46, 0x69, 0x5f, 0xc9, 0x78, 0x5c, 0xcb, 0xbe, 0xe3,
+	0xf0, 0x6d, 0x00, 0x32, 0x50, 0xdd, 0xbb, 0xc1, 0x03, 0x00, 0x00,
 }
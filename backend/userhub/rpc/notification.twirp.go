@@ -23,11 +23,19 @@ import ctxsetters "github.com/twitchtv/twirp/ctxsetters"
 type NotificationService interface {
 	Count(context.Context, *Empty) (*NotificationCountResponse, error)
 
-	Notifications(context.Context, *Empty) (*NotificationNotificationsResponse, error)
+	Notifications(context.Context, *NotificationNotificationsRequest) (*NotificationNotificationsResponse, error)
 
 	Clean(context.Context, *NotificationCleanRequest) (*Empty, error)
 
 	MarkRead(context.Context, *NotificationMarkReadRequest) (*Empty, error)
+
+	Settings(context.Context, *Empty) (*NotificationSettingsResponse, error)
+
+	SetSetting(context.Context, *NotificationSetSettingRequest) (*Empty, error)
+
+	Mute(context.Context, *NotificationMuteRequest) (*Empty, error)
+
+	Unmute(context.Context, *NotificationUnmuteRequest) (*Empty, error)
 }
 
 // ===================================
@@ -36,7 +44,7 @@ type NotificationService interface {
 
 type notificationServiceProtobufClient struct {
 	client HTTPClient
-	urls   [4]string
+	urls   [8]string
 	opts   twirp.ClientOptions
 }
 
@@ -53,11 +61,15 @@ func NewNotificationServiceProtobufClient(addr string, client HTTPClient, opts .
 	}
 
 	prefix := urlBase(addr) + NotificationServicePathPrefix
-	urls := [4]string{
+	urls := [8]string{
 		prefix + "Count",
 		prefix + "Notifications",
 		prefix + "Clean",
 		prefix + "MarkRead",
+		prefix + "Settings",
+		prefix + "SetSetting",
+		prefix + "Mute",
+		prefix + "Unmute",
 	}
 
 	return &notificationServiceProtobufClient{
@@ -87,7 +99,7 @@ func (c *notificationServiceProtobufClient) Count(ctx context.Context, in *Empty
 	return out, nil
 }
 
-func (c *notificationServiceProtobufClient) Notifications(ctx context.Context, in *Empty) (*NotificationNotificationsResponse, error) {
+func (c *notificationServiceProtobufClient) Notifications(ctx context.Context, in *NotificationNotificationsRequest) (*NotificationNotificationsResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "rpc")
 	ctx = ctxsetters.WithServiceName(ctx, "NotificationService")
 	ctx = ctxsetters.WithMethodName(ctx, "Notifications")
@@ -147,13 +159,93 @@ func (c *notificationServiceProtobufClient) MarkRead(ctx context.Context, in *No
 	return out, nil
 }
 
+func (c *notificationServiceProtobufClient) Settings(ctx context.Context, in *Empty) (*NotificationSettingsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "NotificationService")
+	ctx = ctxsetters.WithMethodName(ctx, "Settings")
+	out := new(NotificationSettingsResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *notificationServiceProtobufClient) SetSetting(ctx context.Context, in *NotificationSetSettingRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "NotificationService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetSetting")
+	out := new(Empty)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *notificationServiceProtobufClient) Mute(ctx context.Context, in *NotificationMuteRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "NotificationService")
+	ctx = ctxsetters.WithMethodName(ctx, "Mute")
+	out := new(Empty)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[6], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *notificationServiceProtobufClient) Unmute(ctx context.Context, in *NotificationUnmuteRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "NotificationService")
+	ctx = ctxsetters.WithMethodName(ctx, "Unmute")
+	out := new(Empty)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[7], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ===============================
 // NotificationService JSON Client
 // ===============================
 
 type notificationServiceJSONClient struct {
 	client HTTPClient
-	urls   [4]string
+	urls   [8]string
 	opts   twirp.ClientOptions
 }
 
@@ -170,11 +262,15 @@ func NewNotificationServiceJSONClient(addr string, client HTTPClient, opts ...tw
 	}
 
 	prefix := urlBase(addr) + NotificationServicePathPrefix
-	urls := [4]string{
+	urls := [8]string{
 		prefix + "Count",
 		prefix + "Notifications",
 		prefix + "Clean",
 		prefix + "MarkRead",
+		prefix + "Settings",
+		prefix + "SetSetting",
+		prefix + "Mute",
+		prefix + "Unmute",
 	}
 
 	return &notificationServiceJSONClient{
@@ -204,7 +300,7 @@ func (c *notificationServiceJSONClient) Count(ctx context.Context, in *Empty) (*
 	return out, nil
 }
 
-func (c *notificationServiceJSONClient) Notifications(ctx context.Context, in *Empty) (*NotificationNotificationsResponse, error) {
+func (c *notificationServiceJSONClient) Notifications(ctx context.Context, in *NotificationNotificationsRequest) (*NotificationNotificationsResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "rpc")
 	ctx = ctxsetters.WithServiceName(ctx, "NotificationService")
 	ctx = ctxsetters.WithMethodName(ctx, "Notifications")
@@ -264,6 +360,86 @@ func (c *notificationServiceJSONClient) MarkRead(ctx context.Context, in *Notifi
 	return out, nil
 }
 
+func (c *notificationServiceJSONClient) Settings(ctx context.Context, in *Empty) (*NotificationSettingsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "NotificationService")
+	ctx = ctxsetters.WithMethodName(ctx, "Settings")
+	out := new(NotificationSettingsResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *notificationServiceJSONClient) SetSetting(ctx context.Context, in *NotificationSetSettingRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "NotificationService")
+	ctx = ctxsetters.WithMethodName(ctx, "SetSetting")
+	out := new(Empty)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *notificationServiceJSONClient) Mute(ctx context.Context, in *NotificationMuteRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "NotificationService")
+	ctx = ctxsetters.WithMethodName(ctx, "Mute")
+	out := new(Empty)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[6], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *notificationServiceJSONClient) Unmute(ctx context.Context, in *NotificationUnmuteRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "NotificationService")
+	ctx = ctxsetters.WithMethodName(ctx, "Unmute")
+	out := new(Empty)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[7], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ==================================
 // NotificationService Server Handler
 // ==================================
@@ -324,6 +500,18 @@ func (s *notificationServiceServer) ServeHTTP(resp http.ResponseWriter, req *htt
 	case "/rpc.NotificationService/MarkRead":
 		s.serveMarkRead(ctx, resp, req)
 		return
+	case "/rpc.NotificationService/Settings":
+		s.serveSettings(ctx, resp, req)
+		return
+	case "/rpc.NotificationService/SetSetting":
+		s.serveSetSetting(ctx, resp, req)
+		return
+	case "/rpc.NotificationService/Mute":
+		s.serveMute(ctx, resp, req)
+		return
+	case "/rpc.NotificationService/Unmute":
+		s.serveUnmute(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		err = badRouteError(msg, req.Method, req.URL.Path)
@@ -488,7 +676,7 @@ func (s *notificationServiceServer) serveNotificationsJSON(ctx context.Context,
 		return
 	}
 
-	reqContent := new(Empty)
+	reqContent := new(NotificationNotificationsRequest)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
@@ -548,7 +736,7 @@ func (s *notificationServiceServer) serveNotificationsProtobuf(ctx context.Conte
 		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
 		return
 	}
-	reqContent := new(Empty)
+	reqContent := new(NotificationNotificationsRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
@@ -848,6 +1036,522 @@ func (s *notificationServiceServer) serveMarkReadProtobuf(ctx context.Context, r
 	callResponseSent(ctx, s.hooks)
 }
 
+func (s *notificationServiceServer) serveSettings(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveSettingsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveSettingsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *notificationServiceServer) serveSettingsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Settings")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(Empty)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *NotificationSettingsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.NotificationService.Settings(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *NotificationSettingsResponse and nil error while calling Settings. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *notificationServiceServer) serveSettingsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Settings")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(Empty)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *NotificationSettingsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.NotificationService.Settings(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *NotificationSettingsResponse and nil error while calling Settings. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *notificationServiceServer) serveSetSetting(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveSetSettingJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveSetSettingProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *notificationServiceServer) serveSetSettingJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetSetting")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(NotificationSetSettingRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.NotificationService.SetSetting(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling SetSetting. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *notificationServiceServer) serveSetSettingProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetSetting")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(NotificationSetSettingRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.NotificationService.SetSetting(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling SetSetting. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *notificationServiceServer) serveMute(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveMuteJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveMuteProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *notificationServiceServer) serveMuteJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Mute")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(NotificationMuteRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.NotificationService.Mute(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling Mute. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *notificationServiceServer) serveMuteProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Mute")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(NotificationMuteRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.NotificationService.Mute(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling Mute. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *notificationServiceServer) serveUnmute(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveUnmuteJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveUnmuteProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *notificationServiceServer) serveUnmuteJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Unmute")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(NotificationUnmuteRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.NotificationService.Unmute(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling Unmute. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *notificationServiceServer) serveUnmuteProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Unmute")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(NotificationUnmuteRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.NotificationService.Unmute(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling Unmute. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
 func (s *notificationServiceServer) ServiceDescriptor() ([]byte, int) {
 	return twirpFileDescriptor6, 0
 }
@@ -861,24 +1565,42 @@ func (s *notificationServiceServer) PathPrefix() string {
 }
 
 var twirpFileDescriptor6 = []byte{
-	// 298 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xc1, 0x4b, 0xf4, 0x30,
-	0x10, 0xc5, 0xe9, 0xb7, 0xb4, 0xec, 0x37, 0xa5, 0x07, 0xa3, 0x48, 0xad, 0x28, 0xb5, 0x07, 0xd9,
-	0x53, 0x85, 0xee, 0x41, 0x4f, 0x82, 0x2e, 0x1e, 0x16, 0xd1, 0x43, 0xbc, 0x89, 0xb0, 0xc4, 0x36,
-	0x42, 0xd9, 0x6e, 0x12, 0x93, 0x54, 0xf1, 0x8f, 0x17, 0x64, 0xb3, 0x2a, 0x13, 0xeb, 0xc5, 0x5b,
-	0xe7, 0x31, 0xef, 0x75, 0xde, 0x8f, 0x00, 0x11, 0xd2, 0xb6, 0x4f, 0x6d, 0xcd, 0x6c, 0x2b, 0x45,
-	0xa9, 0xb4, 0xb4, 0x92, 0x8c, 0xb4, 0xaa, 0xb3, 0x78, 0x25, 0x1b, 0xde, 0x6d, 0x94, 0x62, 0x0e,
-	0x7b, 0xb7, 0x68, 0x6f, 0x26, 0x7b, 0x61, 0x29, 0x37, 0x4a, 0x0a, 0xc3, 0xc9, 0x0e, 0x84, 0x56,
-	0x5a, 0xd6, 0xa5, 0x41, 0x1e, 0x4c, 0x42, 0xba, 0x19, 0xc8, 0x2e, 0x44, 0xbd, 0xd0, 0x9c, 0x35,
-	0xe9, 0x3f, 0x27, 0x7f, 0x4e, 0xc5, 0x03, 0x1c, 0xe1, 0x28, 0xfc, 0x6d, 0xbe, 0x23, 0x4f, 0x21,
-	0xc1, 0x77, 0x99, 0x34, 0xc8, 0x47, 0x93, 0xb8, 0xda, 0x2a, 0xb5, 0xaa, 0x4b, 0x6c, 0xa1, 0xfe,
-	0x5e, 0x71, 0x0e, 0xa9, 0x77, 0x68, 0xc7, 0x99, 0xa0, 0xfc, 0xb9, 0xe7, 0xc6, 0x92, 0x02, 0x92,
-	0x8e, 0x19, 0xbb, 0x58, 0x0a, 0xf9, 0x2a, 0x16, 0x6d, 0xe3, 0xee, 0xfd, 0x4f, 0xe3, 0xb5, 0x78,
-	0xbd, 0xd6, 0xe6, 0x4d, 0x71, 0x01, 0xfb, 0xd8, 0x7f, 0xc3, 0xf4, 0x92, 0x72, 0xd6, 0xfc, 0x21,
-	0xa2, 0x7a, 0x0f, 0x60, 0x1b, 0x67, 0xdc, 0x71, 0xfd, 0xd2, 0xd6, 0x9c, 0x4c, 0x21, 0x74, 0xdc,
-	0x08, 0xb8, 0x16, 0x57, 0x2b, 0x65, 0xdf, 0xb2, 0xc3, 0x41, 0x23, 0x9f, 0xed, 0x0c, 0x12, 0x8f,
-	0x90, 0x67, 0x3e, 0x1e, 0x98, 0x7f, 0xa7, 0x59, 0x41, 0xe8, 0x40, 0x90, 0x83, 0xe1, 0xdf, 0x10,
-	0xa0, 0x0c, 0x65, 0x93, 0x33, 0x18, 0x7f, 0x95, 0x27, 0xf9, 0xc0, 0xf6, 0x83, 0x0b, 0x76, 0x5e,
-	0x8e, 0xef, 0xa3, 0xb2, 0x3c, 0xd1, 0xaa, 0x7e, 0x8c, 0xdc, 0xe3, 0x99, 0x7e, 0x04, 0x00, 0x00,
-	0xff, 0xff, 0x69, 0xfc, 0x8e, 0xda, 0x64, 0x02, 0x00, 0x00,
+	// 586 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xcf, 0x6f, 0xd3, 0x30,
+	0x14, 0x56, 0xd7, 0xa5, 0xa4, 0xaf, 0x9a, 0x18, 0x86, 0x41, 0xe8, 0x56, 0xd4, 0x59, 0x02, 0x15,
+	0x21, 0x15, 0xd4, 0x4d, 0x02, 0x24, 0x2e, 0x03, 0x71, 0x28, 0xbf, 0x0e, 0x9e, 0x76, 0x41, 0x88,
+	0xca, 0x4d, 0xbc, 0x61, 0x9a, 0x3a, 0x26, 0x76, 0x40, 0x3b, 0xf2, 0x67, 0x73, 0x43, 0x71, 0x1c,
+	0x6a, 0x2f, 0x65, 0x08, 0x21, 0x6e, 0xf6, 0xd7, 0xf7, 0xbe, 0xf7, 0x3e, 0x7f, 0xef, 0xa5, 0x80,
+	0x44, 0xa6, 0xf9, 0x29, 0x8f, 0xa9, 0xe6, 0x99, 0x18, 0xcb, 0x3c, 0xd3, 0x19, 0x6a, 0xe7, 0x32,
+	0xee, 0xf7, 0x96, 0x59, 0xc2, 0xd2, 0x0a, 0xc1, 0x53, 0xb8, 0xfd, 0xce, 0x89, 0x7b, 0x91, 0x15,
+	0x42, 0x13, 0xa6, 0x64, 0x26, 0x14, 0x43, 0x37, 0x20, 0xd0, 0x99, 0xa6, 0x69, 0xd4, 0x1a, 0xb6,
+	0x46, 0x01, 0xa9, 0x2e, 0xe8, 0x26, 0x74, 0x0a, 0x91, 0x33, 0x9a, 0x44, 0x1b, 0x06, 0xb6, 0x37,
+	0x7c, 0x02, 0x43, 0x97, 0xca, 0x3d, 0x2b, 0xc2, 0xbe, 0x14, 0x4c, 0x69, 0xb4, 0x0b, 0xdd, 0x39,
+	0x3b, 0xcd, 0x72, 0x36, 0xe3, 0x89, 0x61, 0xed, 0x92, 0xb0, 0x02, 0xa6, 0x49, 0x59, 0x2e, 0x2e,
+	0xeb, 0x5b, 0xde, 0xea, 0x82, 0x3f, 0xc0, 0xfe, 0x25, 0xb4, 0xb6, 0xd3, 0xc7, 0xb0, 0xe5, 0xca,
+	0x55, 0x51, 0x6b, 0xd8, 0x1e, 0xf5, 0x26, 0xd7, 0xc6, 0xb9, 0x8c, 0xc7, 0x6e, 0x0a, 0xf1, 0xe3,
+	0x30, 0x87, 0xc8, 0xd3, 0x9f, 0x32, 0x2a, 0xea, 0x66, 0x31, 0x6c, 0xa5, 0x54, 0xe9, 0xd9, 0x42,
+	0x64, 0xdf, 0xc4, 0xaa, 0xe1, 0x5e, 0x09, 0xbe, 0x2e, 0xb1, 0x69, 0x82, 0xee, 0xc3, 0xb6, 0x4b,
+	0x38, 0xe3, 0x89, 0x8a, 0x36, 0x86, 0xed, 0x51, 0x97, 0x5c, 0x75, 0xf1, 0x69, 0xa2, 0x70, 0x0a,
+	0xbb, 0x6e, 0xa9, 0xb7, 0x34, 0x5f, 0x10, 0x46, 0x93, 0xff, 0x54, 0xed, 0x33, 0x5c, 0x77, 0xab,
+	0x1d, 0x33, 0xad, 0xb9, 0x38, 0x43, 0x08, 0x36, 0xf5, 0xb9, 0x64, 0x96, 0xdc, 0x9c, 0xd1, 0x0e,
+	0x74, 0xb8, 0x98, 0x51, 0x29, 0xcd, 0xc3, 0x87, 0x24, 0xe0, 0xe2, 0x48, 0xca, 0x32, 0x54, 0x16,
+	0xea, 0x53, 0xd4, 0x36, 0xa0, 0x39, 0x97, 0x16, 0xb1, 0x25, 0xe5, 0x69, 0xb4, 0x59, 0x45, 0x9a,
+	0x0b, 0x9e, 0xc3, 0xb6, 0xa7, 0xac, 0xd0, 0xac, 0xcc, 0x5e, 0x70, 0x51, 0xab, 0x30, 0xe7, 0xd2,
+	0x7d, 0x4d, 0xf3, 0x33, 0xa6, 0x4b, 0x79, 0x1b, 0x95, 0xfb, 0x15, 0x30, 0x4d, 0xd0, 0x00, 0x20,
+	0xce, 0x19, 0xd5, 0x2c, 0x99, 0x51, 0x6d, 0x8a, 0x76, 0x49, 0xd7, 0x22, 0x47, 0x1a, 0x7f, 0x6f,
+	0xc1, 0xde, 0x1a, 0x41, 0xab, 0x11, 0x38, 0x84, 0x50, 0x59, 0xcc, 0xba, 0x1f, 0x35, 0xdc, 0xb7,
+	0x49, 0xe4, 0x57, 0x24, 0x7a, 0x00, 0xc1, 0xb2, 0xd0, 0xac, 0x7a, 0xc6, 0xde, 0x64, 0xa7, 0x91,
+	0x52, 0x8a, 0x21, 0x55, 0x0c, 0x3e, 0x86, 0xc1, 0x05, 0xb6, 0x9a, 0xd0, 0x7a, 0x38, 0x81, 0x2b,
+	0x96, 0xd9, 0xe8, 0xbe, 0xac, 0x85, 0x3a, 0x10, 0xbf, 0x82, 0x5b, 0x8d, 0x7a, 0x96, 0xee, 0x6f,
+	0xdf, 0x10, 0xbf, 0xf1, 0xb7, 0xf9, 0x44, 0x2c, 0xff, 0x81, 0x6d, 0xf2, 0xa3, 0x7d, 0x71, 0x86,
+	0xf2, 0xaf, 0x3c, 0x66, 0xe8, 0x00, 0x02, 0xf3, 0x9d, 0x40, 0x60, 0xd4, 0xbd, 0x5c, 0x4a, 0x7d,
+	0xde, 0xbf, 0xd3, 0x50, 0xea, 0x7f, 0x4b, 0x3e, 0xc2, 0x96, 0xfb, 0xa3, 0x42, 0x77, 0x1b, 0x09,
+	0xeb, 0xbe, 0x18, 0xfd, 0x7b, 0x7f, 0x0a, 0xb3, 0xfc, 0x13, 0x08, 0xcc, 0xf2, 0xa2, 0x41, 0xb3,
+	0x11, 0x67, 0xa9, 0xfb, 0x4e, 0xcf, 0xe8, 0x09, 0x84, 0xf5, 0x16, 0xa2, 0x61, 0xd3, 0x79, 0x7f,
+	0x41, 0xbd, 0xcc, 0xa7, 0x10, 0xd6, 0x03, 0xe8, 0xbd, 0xc2, 0xfe, 0xef, 0xfc, 0x5e, 0x35, 0xfa,
+	0x0c, 0x60, 0x35, 0x38, 0x08, 0xaf, 0x4b, 0xf0, 0xa7, 0xca, 0x2b, 0xfc, 0x08, 0x36, 0xcd, 0x7a,
+	0xed, 0xad, 0x1f, 0xd4, 0x35, 0x19, 0x87, 0xd0, 0xa9, 0xe6, 0x00, 0x35, 0x2d, 0xf2, 0x06, 0xc4,
+	0xcd, 0x7a, 0x1e, 0xbe, 0xef, 0x8c, 0xc7, 0x0f, 0x73, 0x19, 0xcf, 0x3b, 0xe6, 0x8f, 0xe2, 0xe0,
+	0xe7, 0x00, 0x9a, 0x29, 0x1d, 0x12, 0x50, 0x06, 0x00, 0x00,
 }
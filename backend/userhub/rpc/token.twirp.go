@@ -26,6 +26,8 @@ type TokenService interface {
 	PostMessage(context.Context, *Empty) (*TokenPostMessageResponse, error)
 
 	GetMessages(context.Context, *Empty) (*TokenGetMessagesResponse, error)
+
+	Conversation(context.Context, *TokenConversationRequest) (*TokenConversationResponse, error)
 }
 
 // ============================
@@ -34,7 +36,7 @@ type TokenService interface {
 
 type tokenServiceProtobufClient struct {
 	client HTTPClient
-	urls   [3]string
+	urls   [4]string
 	opts   twirp.ClientOptions
 }
 
@@ -51,10 +53,11 @@ func NewTokenServiceProtobufClient(addr string, client HTTPClient, opts ...twirp
 	}
 
 	prefix := urlBase(addr) + TokenServicePathPrefix
-	urls := [3]string{
+	urls := [4]string{
 		prefix + "Auth",
 		prefix + "PostMessage",
 		prefix + "GetMessages",
+		prefix + "Conversation",
 	}
 
 	return &tokenServiceProtobufClient{
@@ -124,13 +127,33 @@ func (c *tokenServiceProtobufClient) GetMessages(ctx context.Context, in *Empty)
 	return out, nil
 }
 
+func (c *tokenServiceProtobufClient) Conversation(ctx context.Context, in *TokenConversationRequest) (*TokenConversationResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "TokenService")
+	ctx = ctxsetters.WithMethodName(ctx, "Conversation")
+	out := new(TokenConversationResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ========================
 // TokenService JSON Client
 // ========================
 
 type tokenServiceJSONClient struct {
 	client HTTPClient
-	urls   [3]string
+	urls   [4]string
 	opts   twirp.ClientOptions
 }
 
@@ -147,10 +170,11 @@ func NewTokenServiceJSONClient(addr string, client HTTPClient, opts ...twirp.Cli
 	}
 
 	prefix := urlBase(addr) + TokenServicePathPrefix
-	urls := [3]string{
+	urls := [4]string{
 		prefix + "Auth",
 		prefix + "PostMessage",
 		prefix + "GetMessages",
+		prefix + "Conversation",
 	}
 
 	return &tokenServiceJSONClient{
@@ -220,6 +244,26 @@ func (c *tokenServiceJSONClient) GetMessages(ctx context.Context, in *Empty) (*T
 	return out, nil
 }
 
+func (c *tokenServiceJSONClient) Conversation(ctx context.Context, in *TokenConversationRequest) (*TokenConversationResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "TokenService")
+	ctx = ctxsetters.WithMethodName(ctx, "Conversation")
+	out := new(TokenConversationResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ===========================
 // TokenService Server Handler
 // ===========================
@@ -277,6 +321,9 @@ func (s *tokenServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Reque
 	case "/rpc.TokenService/GetMessages":
 		s.serveGetMessages(ctx, resp, req)
 		return
+	case "/rpc.TokenService/Conversation":
+		s.serveConversation(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		err = badRouteError(msg, req.Method, req.URL.Path)
@@ -672,6 +719,135 @@ func (s *tokenServiceServer) serveGetMessagesProtobuf(ctx context.Context, resp
 	callResponseSent(ctx, s.hooks)
 }
 
+func (s *tokenServiceServer) serveConversation(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveConversationJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveConversationProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *tokenServiceServer) serveConversationJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Conversation")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(TokenConversationRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *TokenConversationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.TokenService.Conversation(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *TokenConversationResponse and nil error while calling Conversation. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *tokenServiceServer) serveConversationProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Conversation")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(TokenConversationRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *TokenConversationResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.TokenService.Conversation(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *TokenConversationResponse and nil error while calling Conversation. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
 func (s *tokenServiceServer) ServiceDescriptor() ([]byte, int) {
 	return twirpFileDescriptor7, 0
 }
@@ -685,22 +861,27 @@ func (s *tokenServiceServer) PathPrefix() string {
 }
 
 var twirpFileDescriptor7 = []byte{
-	// 259 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2e, 0xc9, 0xcf, 0x4e,
-	0xcd, 0xd3, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2e, 0x2a, 0x48, 0x96, 0xe2, 0xce, 0xcd,
-	0x4f, 0x49, 0xcd, 0x81, 0x88, 0x28, 0x69, 0x72, 0x09, 0x86, 0x80, 0x14, 0x38, 0x96, 0x96, 0x64,
-	0x04, 0xa5, 0x16, 0x17, 0xe4, 0xe7, 0x15, 0xa7, 0x0a, 0x89, 0x70, 0xb1, 0x82, 0x75, 0x49, 0x30,
-	0x2a, 0x30, 0x6a, 0x70, 0x06, 0x41, 0x38, 0x4a, 0x33, 0x18, 0xb9, 0x24, 0xc0, 0x6a, 0x03, 0xf2,
-	0x8b, 0x4b, 0x7c, 0x53, 0x8b, 0x8b, 0x13, 0xd3, 0x53, 0xe1, 0x5a, 0x1c, 0xb9, 0xd8, 0xc0, 0xaa,
-	0x8a, 0x25, 0x18, 0x15, 0x98, 0x35, 0xb8, 0x8d, 0x34, 0xf5, 0x8a, 0x0a, 0x92, 0xf5, 0x70, 0x29,
-	0x87, 0x48, 0x14, 0xbb, 0xe6, 0x95, 0x14, 0x55, 0x06, 0x41, 0x35, 0x4a, 0x59, 0x72, 0x71, 0x23,
-	0x09, 0x0b, 0x09, 0x70, 0x31, 0x67, 0xa7, 0x56, 0x42, 0x9d, 0x00, 0x62, 0x82, 0x9c, 0x55, 0x96,
-	0x98, 0x53, 0x9a, 0x2a, 0xc1, 0x04, 0x71, 0x16, 0x98, 0x63, 0xc5, 0x64, 0xc1, 0x88, 0x70, 0x9a,
-	0x7b, 0x2a, 0xcc, 0xaa, 0x62, 0x62, 0x9c, 0x86, 0x45, 0x39, 0x95, 0x9d, 0x66, 0xb4, 0x8e, 0x91,
-	0x8b, 0x07, 0xac, 0x37, 0x38, 0xb5, 0xa8, 0x2c, 0x33, 0x39, 0x55, 0x48, 0x8b, 0x8b, 0x05, 0x14,
-	0xd8, 0x42, 0x5c, 0x60, 0x67, 0xb8, 0xe6, 0x16, 0x94, 0x54, 0x4a, 0x89, 0x21, 0x9c, 0x84, 0x12,
-	0x11, 0x16, 0x5c, 0xdc, 0x48, 0xa1, 0x87, 0xa2, 0x45, 0x16, 0x6f, 0x00, 0x83, 0x74, 0x22, 0x79,
-	0x0e, 0x97, 0x4e, 0x2c, 0xfe, 0x77, 0xe2, 0x88, 0x62, 0xd3, 0xd3, 0xd3, 0x2f, 0x2a, 0x48, 0x4e,
-	0x62, 0x03, 0x27, 0x11, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff, 0xff, 0xac, 0xc8, 0x5a, 0xee, 0x43,
-	0x02, 0x00, 0x00,
+	// 337 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x92, 0xc1, 0x4b, 0x3a, 0x41,
+	0x14, 0xc7, 0x59, 0xf7, 0xf7, 0x33, 0x7d, 0xeb, 0xc1, 0x86, 0x88, 0x75, 0xa1, 0x10, 0x4f, 0xda,
+	0x61, 0x03, 0x23, 0xb0, 0x6e, 0x26, 0x12, 0x1d, 0x84, 0xd8, 0x3a, 0x75, 0x09, 0x5d, 0x1f, 0x29,
+	0xea, 0xce, 0x36, 0x6f, 0x56, 0xf0, 0x3f, 0xe9, 0x9f, 0x0d, 0x62, 0x66, 0xd6, 0x1c, 0x61, 0x8d,
+	0x0e, 0xdd, 0xe6, 0xcd, 0x7c, 0xbf, 0x33, 0x9f, 0x79, 0xdf, 0x07, 0x9e, 0xe4, 0x0b, 0x4c, 0xc2,
+	0x54, 0x70, 0xc9, 0x99, 0x2b, 0xd2, 0x38, 0xf0, 0x56, 0x7c, 0x8a, 0x4b, 0xb3, 0xd3, 0xea, 0xc0,
+	0xf1, 0xb3, 0x12, 0xf4, 0x33, 0x39, 0x8b, 0x90, 0x52, 0x9e, 0x10, 0xb2, 0x13, 0xf8, 0xaf, 0x5d,
+	0xbe, 0xd3, 0x74, 0xda, 0xd5, 0xc8, 0x14, 0xad, 0x0f, 0x07, 0x7c, 0xad, 0x7d, 0xe4, 0x24, 0x47,
+	0x48, 0x34, 0x7e, 0xc3, 0x6f, 0x4b, 0x1f, 0xca, 0x5a, 0x45, 0xbe, 0xd3, 0x74, 0xdb, 0x5e, 0xb7,
+	0x13, 0x8a, 0x34, 0x0e, 0x0f, 0xc9, 0xcd, 0x01, 0x0d, 0x13, 0x29, 0x36, 0x51, 0x6e, 0x0c, 0x6e,
+	0xc0, 0xb3, 0xb6, 0x59, 0x1d, 0xdc, 0x05, 0x6e, 0x72, 0x04, 0xb5, 0x54, 0x58, 0xeb, 0xf1, 0x32,
+	0x43, 0xbf, 0x64, 0xb0, 0x74, 0x71, 0x5b, 0xea, 0x39, 0x3b, 0xb4, 0x7b, 0xdc, 0x3e, 0x45, 0xbf,
+	0x41, 0x2b, 0x90, 0xff, 0x35, 0xda, 0x75, 0x4e, 0x36, 0xe0, 0xc9, 0x1a, 0x05, 0x8d, 0xe5, 0x9c,
+	0x27, 0x11, 0xbe, 0x67, 0x48, 0x92, 0x35, 0xa0, 0x92, 0x11, 0x8a, 0xd7, 0xf9, 0xd4, 0xb0, 0x55,
+	0xa3, 0x23, 0x55, 0x3f, 0x4c, 0xa9, 0x35, 0x80, 0x46, 0x81, 0x2d, 0xff, 0x51, 0x1d, 0xdc, 0x59,
+	0x36, 0xd9, 0xbe, 0x3f, 0xcb, 0x26, 0xbb, 0xc4, 0x4a, 0x56, 0x62, 0xdd, 0x4f, 0x07, 0x6a, 0xfa,
+	0x96, 0x27, 0x14, 0xeb, 0x79, 0x8c, 0xec, 0x02, 0xfe, 0xa9, 0xa0, 0x19, 0xe8, 0x16, 0x0c, 0x57,
+	0xa9, 0xdc, 0x04, 0xa7, 0xbb, 0x76, 0xec, 0x0d, 0x41, 0x0f, 0x3c, 0x2b, 0xb9, 0x3d, 0xcb, 0xd9,
+	0x8f, 0xe1, 0x2a, 0xa7, 0xd5, 0xd8, 0x43, 0xce, 0xa2, 0xa8, 0x46, 0x50, 0xb3, 0x3f, 0xcc, 0x2c,
+	0x79, 0x41, 0xff, 0x82, 0xf3, 0x43, 0xc7, 0xe6, 0xba, 0xbb, 0xca, 0x4b, 0x39, 0x0c, 0x2f, 0x45,
+	0x1a, 0x4f, 0xca, 0x7a, 0xda, 0xaf, 0xbe, 0x06, 0x00, 0xa6, 0x28, 0x83, 0xe3, 0x0e, 0x03, 0x00,
+	0x00,
 }
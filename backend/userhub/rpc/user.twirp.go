@@ -34,6 +34,16 @@ type UserService interface {
 	User(context.Context, *UserUserRequest) (*UserUserResponse, error)
 
 	RegisterFCMToken(context.Context, *UserRegisterFCMTokenRequest) (*Empty, error)
+
+	RegisterWebPushSubscription(context.Context, *UserRegisterWebPushSubscriptionRequest) (*Empty, error)
+
+	RegisterDeviceKeys(context.Context, *UserRegisterDeviceKeysRequest) (*UserRegisterDeviceKeysResponse, error)
+
+	UploadPrekeys(context.Context, *UserUploadPrekeysRequest) (*UserUploadPrekeysResponse, error)
+
+	RemoveDeviceKeys(context.Context, *UserRemoveDeviceKeysRequest) (*Empty, error)
+
+	KeyBundles(context.Context, *UserKeyBundlesRequest) (*UserKeyBundlesResponse, error)
 }
 
 // ===========================
@@ -42,7 +52,7 @@ type UserService interface {
 
 type userServiceProtobufClient struct {
 	client HTTPClient
-	urls   [7]string
+	urls   [12]string
 	opts   twirp.ClientOptions
 }
 
@@ -59,7 +69,7 @@ func NewUserServiceProtobufClient(addr string, client HTTPClient, opts ...twirp.
 	}
 
 	prefix := urlBase(addr) + UserServicePathPrefix
-	urls := [7]string{
+	urls := [12]string{
 		prefix + "Friends",
 		prefix + "FriendsOfFriends",
 		prefix + "Me",
@@ -67,6 +77,11 @@ func NewUserServiceProtobufClient(addr string, client HTTPClient, opts ...twirp.
 		prefix + "Users",
 		prefix + "User",
 		prefix + "RegisterFCMToken",
+		prefix + "RegisterWebPushSubscription",
+		prefix + "RegisterDeviceKeys",
+		prefix + "UploadPrekeys",
+		prefix + "RemoveDeviceKeys",
+		prefix + "KeyBundles",
 	}
 
 	return &userServiceProtobufClient{
@@ -216,13 +231,113 @@ func (c *userServiceProtobufClient) RegisterFCMToken(ctx context.Context, in *Us
 	return out, nil
 }
 
+func (c *userServiceProtobufClient) RegisterWebPushSubscription(ctx context.Context, in *UserRegisterWebPushSubscriptionRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "UserService")
+	ctx = ctxsetters.WithMethodName(ctx, "RegisterWebPushSubscription")
+	out := new(Empty)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[7], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *userServiceProtobufClient) RegisterDeviceKeys(ctx context.Context, in *UserRegisterDeviceKeysRequest) (*UserRegisterDeviceKeysResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "UserService")
+	ctx = ctxsetters.WithMethodName(ctx, "RegisterDeviceKeys")
+	out := new(UserRegisterDeviceKeysResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[8], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *userServiceProtobufClient) UploadPrekeys(ctx context.Context, in *UserUploadPrekeysRequest) (*UserUploadPrekeysResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "UserService")
+	ctx = ctxsetters.WithMethodName(ctx, "UploadPrekeys")
+	out := new(UserUploadPrekeysResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[9], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *userServiceProtobufClient) RemoveDeviceKeys(ctx context.Context, in *UserRemoveDeviceKeysRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "UserService")
+	ctx = ctxsetters.WithMethodName(ctx, "RemoveDeviceKeys")
+	out := new(Empty)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[10], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *userServiceProtobufClient) KeyBundles(ctx context.Context, in *UserKeyBundlesRequest) (*UserKeyBundlesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "UserService")
+	ctx = ctxsetters.WithMethodName(ctx, "KeyBundles")
+	out := new(UserKeyBundlesResponse)
+	err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[11], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // =======================
 // UserService JSON Client
 // =======================
 
 type userServiceJSONClient struct {
 	client HTTPClient
-	urls   [7]string
+	urls   [12]string
 	opts   twirp.ClientOptions
 }
 
@@ -239,7 +354,7 @@ func NewUserServiceJSONClient(addr string, client HTTPClient, opts ...twirp.Clie
 	}
 
 	prefix := urlBase(addr) + UserServicePathPrefix
-	urls := [7]string{
+	urls := [12]string{
 		prefix + "Friends",
 		prefix + "FriendsOfFriends",
 		prefix + "Me",
@@ -247,6 +362,11 @@ func NewUserServiceJSONClient(addr string, client HTTPClient, opts ...twirp.Clie
 		prefix + "Users",
 		prefix + "User",
 		prefix + "RegisterFCMToken",
+		prefix + "RegisterWebPushSubscription",
+		prefix + "RegisterDeviceKeys",
+		prefix + "UploadPrekeys",
+		prefix + "RemoveDeviceKeys",
+		prefix + "KeyBundles",
 	}
 
 	return &userServiceJSONClient{
@@ -396,6 +516,106 @@ func (c *userServiceJSONClient) RegisterFCMToken(ctx context.Context, in *UserRe
 	return out, nil
 }
 
+func (c *userServiceJSONClient) RegisterWebPushSubscription(ctx context.Context, in *UserRegisterWebPushSubscriptionRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "UserService")
+	ctx = ctxsetters.WithMethodName(ctx, "RegisterWebPushSubscription")
+	out := new(Empty)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[7], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *userServiceJSONClient) RegisterDeviceKeys(ctx context.Context, in *UserRegisterDeviceKeysRequest) (*UserRegisterDeviceKeysResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "UserService")
+	ctx = ctxsetters.WithMethodName(ctx, "RegisterDeviceKeys")
+	out := new(UserRegisterDeviceKeysResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[8], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *userServiceJSONClient) UploadPrekeys(ctx context.Context, in *UserUploadPrekeysRequest) (*UserUploadPrekeysResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "UserService")
+	ctx = ctxsetters.WithMethodName(ctx, "UploadPrekeys")
+	out := new(UserUploadPrekeysResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[9], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *userServiceJSONClient) RemoveDeviceKeys(ctx context.Context, in *UserRemoveDeviceKeysRequest) (*Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "UserService")
+	ctx = ctxsetters.WithMethodName(ctx, "RemoveDeviceKeys")
+	out := new(Empty)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[10], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *userServiceJSONClient) KeyBundles(ctx context.Context, in *UserKeyBundlesRequest) (*UserKeyBundlesResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "rpc")
+	ctx = ctxsetters.WithServiceName(ctx, "UserService")
+	ctx = ctxsetters.WithMethodName(ctx, "KeyBundles")
+	out := new(UserKeyBundlesResponse)
+	err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[11], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ==========================
 // UserService Server Handler
 // ==========================
@@ -465,6 +685,21 @@ func (s *userServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Reques
 	case "/rpc.UserService/RegisterFCMToken":
 		s.serveRegisterFCMToken(ctx, resp, req)
 		return
+	case "/rpc.UserService/RegisterWebPushSubscription":
+		s.serveRegisterWebPushSubscription(ctx, resp, req)
+		return
+	case "/rpc.UserService/RegisterDeviceKeys":
+		s.serveRegisterDeviceKeys(ctx, resp, req)
+		return
+	case "/rpc.UserService/UploadPrekeys":
+		s.serveUploadPrekeys(ctx, resp, req)
+		return
+	case "/rpc.UserService/RemoveDeviceKeys":
+		s.serveRemoveDeviceKeys(ctx, resp, req)
+		return
+	case "/rpc.UserService/KeyBundles":
+		s.serveKeyBundles(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		err = badRouteError(msg, req.Method, req.URL.Path)
@@ -1376,6 +1611,651 @@ func (s *userServiceServer) serveRegisterFCMTokenProtobuf(ctx context.Context, r
 	callResponseSent(ctx, s.hooks)
 }
 
+func (s *userServiceServer) serveRegisterWebPushSubscription(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveRegisterWebPushSubscriptionJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveRegisterWebPushSubscriptionProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *userServiceServer) serveRegisterWebPushSubscriptionJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RegisterWebPushSubscription")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(UserRegisterWebPushSubscriptionRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.UserService.RegisterWebPushSubscription(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling RegisterWebPushSubscription. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *userServiceServer) serveRegisterWebPushSubscriptionProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RegisterWebPushSubscription")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(UserRegisterWebPushSubscriptionRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.UserService.RegisterWebPushSubscription(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling RegisterWebPushSubscription. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *userServiceServer) serveRegisterDeviceKeys(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveRegisterDeviceKeysJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveRegisterDeviceKeysProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *userServiceServer) serveRegisterDeviceKeysJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RegisterDeviceKeys")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(UserRegisterDeviceKeysRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *UserRegisterDeviceKeysResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.UserService.RegisterDeviceKeys(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UserRegisterDeviceKeysResponse and nil error while calling RegisterDeviceKeys. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *userServiceServer) serveRegisterDeviceKeysProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RegisterDeviceKeys")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(UserRegisterDeviceKeysRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *UserRegisterDeviceKeysResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.UserService.RegisterDeviceKeys(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UserRegisterDeviceKeysResponse and nil error while calling RegisterDeviceKeys. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *userServiceServer) serveUploadPrekeys(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveUploadPrekeysJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveUploadPrekeysProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *userServiceServer) serveUploadPrekeysJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UploadPrekeys")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(UserUploadPrekeysRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *UserUploadPrekeysResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.UserService.UploadPrekeys(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UserUploadPrekeysResponse and nil error while calling UploadPrekeys. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *userServiceServer) serveUploadPrekeysProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UploadPrekeys")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(UserUploadPrekeysRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *UserUploadPrekeysResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.UserService.UploadPrekeys(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UserUploadPrekeysResponse and nil error while calling UploadPrekeys. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *userServiceServer) serveRemoveDeviceKeys(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveRemoveDeviceKeysJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveRemoveDeviceKeysProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *userServiceServer) serveRemoveDeviceKeysJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RemoveDeviceKeys")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(UserRemoveDeviceKeysRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.UserService.RemoveDeviceKeys(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling RemoveDeviceKeys. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *userServiceServer) serveRemoveDeviceKeysProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "RemoveDeviceKeys")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(UserRemoveDeviceKeysRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.UserService.RemoveDeviceKeys(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *Empty and nil error while calling RemoveDeviceKeys. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *userServiceServer) serveKeyBundles(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveKeyBundlesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveKeyBundlesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *userServiceServer) serveKeyBundlesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "KeyBundles")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(UserKeyBundlesRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the json request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *UserKeyBundlesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.UserService.KeyBundles(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UserKeyBundlesResponse and nil error while calling KeyBundles. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	respBytes := buf.Bytes()
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *userServiceServer) serveKeyBundlesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "KeyBundles")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to read request body"))
+		return
+	}
+	reqContent := new(UserKeyBundlesRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	// Call service method
+	var respContent *UserKeyBundlesResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = s.UserService.KeyBundles(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UserKeyBundlesResponse and nil error while calling KeyBundles. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
 func (s *userServiceServer) ServiceDescriptor() ([]byte, int) {
 	return twirpFileDescriptor8, 0
 }
@@ -1389,46 +2269,82 @@ func (s *userServiceServer) PathPrefix() string {
 }
 
 var twirpFileDescriptor8 = []byte{
-	// 650 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x5b, 0x4f, 0xd4, 0x40,
-	0x14, 0xce, 0xee, 0xb2, 0x97, 0x9e, 0x72, 0x59, 0x87, 0x8b, 0x65, 0xf1, 0xb2, 0x94, 0x10, 0xe1,
-	0xc1, 0x45, 0xc1, 0x10, 0x1f, 0x45, 0x02, 0x04, 0x13, 0x94, 0x14, 0x7d, 0xf1, 0xa5, 0xd6, 0xf6,
-	0x80, 0x13, 0xb7, 0x17, 0x67, 0xba, 0x10, 0x7f, 0x84, 0xd1, 0x9f, 0x6c, 0xe6, 0xd6, 0x96, 0x82,
-	0xe2, 0x83, 0x0f, 0x9b, 0xe6, 0x7c, 0xf3, 0x9d, 0xef, 0x7c, 0x73, 0xce, 0x9c, 0x2c, 0xc0, 0x84,
-	0x23, 0x1b, 0x65, 0x2c, 0xcd, 0x53, 0xd2, 0x62, 0x59, 0x38, 0xb0, 0xe3, 0x34, 0xc2, 0xb1, 0x42,
-	0x5c, 0x1f, 0x96, 0x3f, 0x70, 0x64, 0x87, 0x8c, 0x62, 0x12, 0x71, 0xf5, 0x79, 0x77, 0xae, 0xbe,
-	0xe4, 0x21, 0x4c, 0x89, 0x64, 0xa7, 0x31, 0x6c, 0x6c, 0xd8, 0xdb, 0xd6, 0x88, 0x65, 0xe1, 0x48,
-	0xb0, 0x3d, 0x09, 0x93, 0x35, 0x98, 0xa1, 0xc9, 0x25, 0xcd, 0xd1, 0xe7, 0x79, 0x90, 0x4f, 0xb8,
-	0xd3, 0x1c, 0x36, 0x36, 0x2c, 0x6f, 0x5a, 0x81, 0x67, 0x12, 0x73, 0xc7, 0x70, 0xef, 0x46, 0x81,
-	0xbb, 0x84, 0x5f, 0x42, 0xf7, 0x5c, 0xf1, 0x9d, 0xe6, 0xb0, 0xb5, 0x61, 0x6f, 0x3f, 0x2a, 0x18,
-	0xb7, 0x1a, 0xf5, 0x0c, 0xdd, 0x3d, 0x82, 0xf9, 0x0a, 0xcb, 0x43, 0x9e, 0xa5, 0x09, 0x47, 0xf2,
-	0xac, 0x14, 0x6c, 0x48, 0xc1, 0xa5, 0xdb, 0x05, 0x4b, 0xa1, 0x1f, 0x0d, 0x70, 0x2b, 0xc7, 0xa6,
-	0x52, 0x21, 0xf9, 0xff, 0x3a, 0x44, 0xd6, 0x4a, 0x73, 0x2d, 0x69, 0xae, 0x22, 0x53, 0xf8, 0x09,
-	0xe0, 0xc1, 0xdf, 0xec, 0x90, 0xbd, 0xfa, 0x0d, 0x9f, 0xd4, 0x6f, 0xf8, 0x87, 0x2b, 0x94, 0x25,
-	0xde, 0xc0, 0xac, 0xa0, 0x9f, 0x60, 0x21, 0x7a, 0xc7, 0xed, 0x96, 0xa1, 0x47, 0xb9, 0x1f, 0x44,
-	0x31, 0x4d, 0xe4, 0xc5, 0x7a, 0x5e, 0x97, 0xf2, 0x3d, 0x11, 0xba, 0xbf, 0x9a, 0xb0, 0x24, 0x98,
-	0x07, 0x11, 0xcd, 0x4f, 0x59, 0x7a, 0x4e, 0xc7, 0xe8, 0xe1, 0xb7, 0x09, 0xf2, 0x5c, 0xf4, 0x04,
-	0xe3, 0x80, 0x8e, 0xfd, 0xf0, 0x4b, 0x90, 0x5c, 0x60, 0x24, 0xd5, 0x7b, 0xde, 0xb4, 0x04, 0xf7,
-	0x15, 0x46, 0x16, 0xa0, 0x2d, 0x63, 0xdd, 0x30, 0x15, 0x90, 0x75, 0x98, 0x0d, 0x2e, 0x83, 0x3c,
-	0x60, 0x45, 0x6e, 0x4b, 0xe6, 0xce, 0x28, 0xd4, 0x24, 0xaf, 0x80, 0xa5, 0x69, 0x34, 0x72, 0xa6,
-	0xa4, 0x40, 0x4f, 0x01, 0xc7, 0x11, 0xd9, 0x84, 0x7e, 0x16, 0x70, 0x7e, 0x95, 0xb2, 0xa8, 0x50,
-	0x69, 0x4b, 0x95, 0x39, 0x83, 0x1b, 0x9d, 0x4d, 0xe8, 0x87, 0x13, 0xc6, 0x30, 0xc9, 0x7d, 0x73,
-	0xe4, 0x74, 0xa4, 0xdc, 0x9c, 0xc6, 0x4f, 0x35, 0x4c, 0x56, 0x61, 0x3a, 0xc1, 0xab, 0x92, 0xd6,
-	0x95, 0x34, 0x3b, 0xc1, 0x2b, 0x43, 0x71, 0x9f, 0x42, 0x5f, 0x74, 0x44, 0xfc, 0xb8, 0xe9, 0xc5,
-	0x32, 0xf4, 0x44, 0x27, 0x7d, 0xaa, 0xc7, 0x66, 0x79, 0x5d, 0x11, 0x1f, 0x47, 0xdc, 0x7d, 0xa1,
-	0xf6, 0x46, 0xd3, 0xf5, 0x40, 0x1e, 0x43, 0x5b, 0x9c, 0x9b, 0x19, 0x57, 0x26, 0xa2, 0x70, 0xf7,
-	0x08, 0xe6, 0x4c, 0x96, 0xa9, 0x71, 0x1f, 0xba, 0xba, 0x86, 0xec, 0xb4, 0xe5, 0x75, 0x54, 0x09,
-	0xd1, 0x26, 0x79, 0x90, 0x04, 0x31, 0xea, 0x3e, 0x4b, 0x37, 0x6f, 0x83, 0x18, 0xdd, 0xe7, 0xa5,
-	0xdb, 0x7f, 0x7c, 0x0e, 0xee, 0x27, 0x58, 0x51, 0xf4, 0x0b, 0xca, 0x73, 0x64, 0x87, 0xfb, 0x27,
-	0xef, 0xd3, 0xaf, 0x98, 0x18, 0x1f, 0x0b, 0xd0, 0xce, 0x45, 0xac, 0x5d, 0xa8, 0x40, 0x98, 0x88,
-	0xf0, 0x92, 0x86, 0x28, 0xfc, 0x69, 0x13, 0x0a, 0x38, 0x8e, 0xc8, 0x2c, 0x34, 0x53, 0x2e, 0x67,
-	0x6c, 0x79, 0xcd, 0x94, 0x6f, 0xff, 0x6c, 0x81, 0x2d, 0x4a, 0x9c, 0x21, 0x13, 0x0c, 0xb2, 0x05,
-	0x5d, 0xfd, 0xa6, 0x09, 0x48, 0x37, 0x07, 0x71, 0x96, 0x7f, 0x1f, 0x38, 0xf5, 0xa7, 0x5f, 0xd9,
-	0x92, 0x7e, 0x7d, 0x1b, 0xae, 0x65, 0xae, 0xde, 0xb9, 0x34, 0x64, 0x1d, 0x9a, 0x27, 0x78, 0x2d,
-	0x69, 0xbe, 0x48, 0xaa, 0xac, 0xce, 0x2e, 0xd8, 0x95, 0xb7, 0x4f, 0x56, 0x0a, 0xce, 0xcd, 0x8d,
-	0x18, 0x54, 0xc4, 0xc8, 0x2e, 0xb4, 0xe5, 0xc8, 0xc9, 0x62, 0x91, 0x51, 0x7d, 0x31, 0x83, 0xa5,
-	0x3a, 0xac, 0xeb, 0xed, 0xc0, 0x94, 0x00, 0xc8, 0xc2, 0xb5, 0x73, 0x93, 0xb5, 0x58, 0x43, 0x75,
-	0xd2, 0x2b, 0xe8, 0xd7, 0xa7, 0x45, 0x86, 0xe5, 0x58, 0x6f, 0x1f, 0x64, 0xd5, 0xee, 0xeb, 0xde,
-	0xc7, 0xce, 0x68, 0xb4, 0xc5, 0xb2, 0xf0, 0x73, 0x47, 0xfe, 0x9f, 0xec, 0xfc, 0x0e, 0x00, 0x00,
-	0xff, 0xff, 0xb5, 0x9d, 0x29, 0xf4, 0x6f, 0x06, 0x00, 0x00,
+	// 1219 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0x5b, 0x4f, 0x1b, 0x57,
+	0x10, 0x96, 0xef, 0xf6, 0xd8, 0x80, 0x39, 0x01, 0x67, 0x31, 0x81, 0x9a, 0x45, 0x69, 0xa0, 0x4d,
+	0x49, 0x4b, 0x5a, 0x14, 0xf5, 0xa1, 0x6a, 0xa0, 0x10, 0x51, 0x44, 0x82, 0x96, 0x5e, 0xd4, 0x48,
+	0x95, 0xb5, 0xf6, 0x0e, 0xf8, 0x08, 0x7b, 0x77, 0xbb, 0xbb, 0x06, 0x6d, 0xff, 0x43, 0x1f, 0xfa,
+	0x0b, 0xfa, 0xda, 0xf7, 0x4a, 0xfd, 0x7d, 0xd5, 0xb9, 0xed, 0xcd, 0x36, 0x38, 0x52, 0x1f, 0x22,
+	0xe7, 0xcc, 0x7c, 0xf3, 0xcd, 0x37, 0x73, 0xe6, 0x9c, 0x3d, 0x00, 0x8c, 0x7d, 0xf4, 0xf6, 0x5c,
+	0xcf, 0x09, 0x1c, 0x52, 0xf0, 0xdc, 0x7e, 0xbb, 0x3e, 0x72, 0x2c, 0x1c, 0x0a, 0x8b, 0xde, 0x85,
+	0xb5, 0x1f, 0x7d, 0xf4, 0x4e, 0x3c, 0x8a, 0xb6, 0xe5, 0x8b, 0x9f, 0x77, 0x57, 0xe2, 0x97, 0x6c,
+	0x40, 0x91, 0x05, 0x6b, 0xb9, 0x4e, 0x6e, 0xa7, 0xbe, 0x5f, 0xdb, 0xf3, 0xdc, 0xfe, 0x1e, 0x43,
+	0x1b, 0xdc, 0x4c, 0xb6, 0x61, 0x81, 0xda, 0xb7, 0x34, 0xc0, 0xae, 0x1f, 0x98, 0xc1, 0xd8, 0xd7,
+	0xf2, 0x9d, 0xdc, 0x4e, 0xcd, 0x68, 0x08, 0xe3, 0x25, 0xb7, 0xe9, 0x43, 0x58, 0x9e, 0x48, 0xf0,
+	0x10, 0xf1, 0x2b, 0xa8, 0x5c, 0x09, 0xbc, 0x96, 0xef, 0x14, 0x76, 0xea, 0xfb, 0x9b, 0x11, 0x62,
+	0xaa, 0x50, 0x43, 0xc1, 0xf5, 0x37, 0xf0, 0x28, 0x81, 0x32, 0xd0, 0x77, 0x1d, 0xdb, 0x47, 0xf2,
+	0x79, 0x4c, 0x98, 0xe3, 0x84, 0xad, 0xe9, 0x84, 0x31, 0xd1, 0x1f, 0x39, 0xd0, 0x13, 0xee, 0x77,
+	0x57, 0x19, 0xca, 0xff, 0xaf, 0x43, 0x64, 0x3b, 0x16, 0x57, 0xe8, 0x14, 0xd2, 0x34, 0x91, 0x1e,
+	0x13, 0x9e, 0xdc, 0x27, 0x87, 0xbc, 0xce, 0x56, 0xf8, 0x2c, 0x5b, 0xe1, 0x8c, 0x12, 0xe2, 0x14,
+	0xdf, 0xc3, 0x22, 0x83, 0x9f, 0x63, 0x44, 0xfa, 0x40, 0x75, 0x6b, 0x50, 0xa5, 0x7e, 0xd7, 0xb4,
+	0x46, 0xd4, 0xe6, 0x85, 0x55, 0x8d, 0x0a, 0xf5, 0x5f, 0xb3, 0xa5, 0xfe, 0x4f, 0x11, 0x5a, 0x0c,
+	0x79, 0x6c, 0xd1, 0xe0, 0xc2, 0x73, 0xae, 0xe8, 0x10, 0x0d, 0xfc, 0x6d, 0x8c, 0x7e, 0xc0, 0x7a,
+	0x82, 0x23, 0x93, 0x0e, 0xbb, 0xfd, 0x81, 0x69, 0x5f, 0xa3, 0xc5, 0xd9, 0xab, 0x46, 0x83, 0x1b,
+	0x8f, 0x84, 0x8d, 0xac, 0x40, 0x89, 0xaf, 0x65, 0xc3, 0xc4, 0x82, 0x3c, 0x85, 0x45, 0xf3, 0xd6,
+	0x0c, 0x4c, 0x2f, 0x8a, 0x2d, 0xf0, 0xd8, 0x05, 0x61, 0x55, 0xc1, 0xeb, 0x50, 0x93, 0x30, 0x6a,
+	0x69, 0x45, 0x4e, 0x50, 0x15, 0x86, 0x53, 0x8b, 0xec, 0x42, 0xd3, 0x35, 0x7d, 0xff, 0xce, 0xf1,
+	0xac, 0x88, 0xa5, 0xc4, 0x59, 0x96, 0x94, 0x5d, 0xf1, 0xec, 0x42, 0xb3, 0x3f, 0xf6, 0x3c, 0xb4,
+	0x83, 0xae, 0x72, 0x69, 0x65, 0x4e, 0xb7, 0x24, 0xed, 0x17, 0xd2, 0x4c, 0xb6, 0xa0, 0x61, 0xe3,
+	0x5d, 0x0c, 0xab, 0x70, 0x58, 0xdd, 0xc6, 0xbb, 0x08, 0xb2, 0x0b, 0xcd, 0x1e, 0xf5, 0x82, 0x81,
+	0x65, 0x86, 0x51, 0xe2, 0xaa, 0x48, 0xac, 0xec, 0x2a, 0x71, 0x1b, 0xaa, 0xca, 0xa4, 0xd5, 0x84,
+	0x7e, 0xb5, 0x26, 0xcf, 0x81, 0x0c, 0xa8, 0x85, 0xdd, 0x88, 0x2b, 0x44, 0xd3, 0xd3, 0x80, 0x13,
+	0x35, 0x99, 0xe7, 0x50, 0x3a, 0x7e, 0x41, 0xd3, 0x23, 0x9f, 0xc0, 0x72, 0x40, 0x47, 0xd8, 0xfd,
+	0xdd, 0xb1, 0x31, 0xca, 0x5a, 0x17, 0x59, 0x99, 0xe3, 0xbd, 0x63, 0x63, 0xa2, 0x6d, 0x11, 0x56,
+	0x6b, 0x88, 0xb4, 0x0a, 0x43, 0x5e, 0x81, 0x66, 0xd1, 0x6b, 0xf4, 0x83, 0xee, 0x95, 0xc7, 0x36,
+	0xd2, 0xee, 0xc7, 0x55, 0x2c, 0x70, 0xbe, 0x96, 0xf0, 0x9f, 0x28, 0x77, 0xa2, 0x8b, 0xd9, 0x48,
+	0x6d, 0x51, 0x74, 0x31, 0x13, 0xa1, 0x7f, 0x06, 0x4d, 0x36, 0x34, 0xec, 0x9f, 0xaf, 0xc6, 0x65,
+	0x0d, 0xaa, 0x6c, 0xd8, 0xba, 0x54, 0x4e, 0x76, 0xcd, 0xa8, 0xb0, 0xf5, 0xa9, 0xe5, 0xeb, 0x5f,
+	0xc2, 0x72, 0x02, 0x2e, 0x67, 0xf6, 0x23, 0x28, 0x31, 0xbf, 0x3a, 0x06, 0x89, 0xa1, 0x15, 0x76,
+	0xfd, 0x0d, 0x2c, 0xa9, 0x28, 0x95, 0xe3, 0x31, 0x54, 0x64, 0x0e, 0x3e, 0x8c, 0x35, 0xa3, 0x2c,
+	0x52, 0xb0, 0x96, 0x70, 0x87, 0x6d, 0x8e, 0x50, 0x8e, 0x22, 0x57, 0xf3, 0xd6, 0x1c, 0xa1, 0xfe,
+	0x45, 0xac, 0x76, 0xce, 0x13, 0xa3, 0xff, 0x9d, 0x83, 0x75, 0x81, 0xbf, 0xa6, 0x7e, 0x80, 0xde,
+	0xc9, 0xd1, 0xf9, 0x0f, 0xce, 0x0d, 0xda, 0x4a, 0xc8, 0x0a, 0x94, 0x02, 0xb6, 0x96, 0x32, 0xc4,
+	0x82, 0xa9, 0xb0, 0xf0, 0x96, 0xf6, 0x91, 0x09, 0x94, 0x2a, 0x84, 0xe1, 0xd4, 0x22, 0x8b, 0x90,
+	0x77, 0x7c, 0x7e, 0x0e, 0x6a, 0x46, 0xde, 0xf1, 0xd9, 0xec, 0xb8, 0x9e, 0x73, 0x4b, 0x2d, 0xf4,
+	0xd4, 0xec, 0xab, 0x35, 0x69, 0x41, 0xd9, 0xdd, 0xff, 0xea, 0xc0, 0x1a, 0xf0, 0x89, 0xaf, 0x19,
+	0x72, 0x45, 0x08, 0x14, 0xcd, 0x71, 0x30, 0x90, 0xc3, 0xcd, 0xff, 0xcf, 0x2e, 0xc0, 0x8f, 0x93,
+	0x52, 0x7f, 0xc6, 0xde, 0xc5, 0xd8, 0x1f, 0x5c, 0x8e, 0x7b, 0x7e, 0xdf, 0xa3, 0x6e, 0x40, 0x9d,
+	0x48, 0x75, 0x1b, 0xaa, 0x68, 0x5b, 0xae, 0x43, 0xed, 0x40, 0x0a, 0x8f, 0xd6, 0x89, 0x94, 0xf9,
+	0xa9, 0x29, 0x0b, 0x71, 0xca, 0x74, 0x9d, 0xc5, 0x74, 0x9d, 0xfa, 0x21, 0x00, 0x93, 0x73, 0xe1,
+	0xe1, 0x0d, 0x86, 0x64, 0x15, 0xca, 0x37, 0x18, 0xaa, 0x0d, 0x2b, 0x19, 0xa5, 0x1b, 0x0c, 0x4f,
+	0xd9, 0x75, 0x0c, 0xee, 0xb8, 0x37, 0xa4, 0xfd, 0xee, 0x0d, 0x86, 0x32, 0x63, 0x4d, 0x58, 0xce,
+	0x30, 0xd4, 0xff, 0xcc, 0xc3, 0x46, 0xb2, 0xa6, 0xef, 0x38, 0xf9, 0x19, 0x86, 0xd1, 0xb4, 0xa5,
+	0x24, 0xe4, 0x32, 0xad, 0xde, 0x82, 0x06, 0xb5, 0xd0, 0x0e, 0x68, 0x10, 0x26, 0xf8, 0xeb, 0xca,
+	0x76, 0x86, 0x21, 0xd9, 0x81, 0xa6, 0x4f, 0xaf, 0x6d, 0xb4, 0xba, 0x2e, 0x17, 0xca, 0x68, 0x0a,
+	0x5c, 0xe1, 0xa2, 0xb0, 0x0b, 0xfd, 0xa7, 0x16, 0xbb, 0x06, 0x53, 0x48, 0x59, 0x70, 0x23, 0x09,
+	0x23, 0x07, 0xf0, 0x38, 0x4d, 0xc7, 0x56, 0x66, 0x30, 0xf6, 0x50, 0xee, 0xe0, 0x6a, 0x12, 0x7e,
+	0xa9, 0x9c, 0x64, 0x17, 0x2a, 0x22, 0xc0, 0xd7, 0xca, 0xfc, 0x18, 0x2c, 0x45, 0x93, 0x28, 0xa0,
+	0x86, 0xf2, 0xeb, 0x47, 0xb0, 0x39, 0xab, 0x25, 0x72, 0xa6, 0xb7, 0xa0, 0x21, 0xb3, 0xf7, 0x9d,
+	0xb1, 0xdc, 0xe2, 0x92, 0x51, 0x17, 0xb6, 0x23, 0x66, 0xd2, 0x7b, 0xa0, 0xf1, 0xa3, 0xe0, 0x0e,
+	0x1d, 0x53, 0x8a, 0x99, 0xaf, 0xa5, 0x09, 0xa1, 0xf9, 0x07, 0x84, 0x7e, 0x03, 0x6b, 0x53, 0x72,
+	0xcc, 0xaf, 0xf1, 0x6b, 0x75, 0xf4, 0x46, 0xce, 0x2d, 0x7e, 0xd8, 0xce, 0xeb, 0xfb, 0xb0, 0xca,
+	0x62, 0xcf, 0x30, 0x3c, 0x1c, 0xdb, 0xd6, 0x10, 0xe7, 0xb9, 0x9d, 0xfe, 0xcd, 0xc3, 0x42, 0x2a,
+	0xe8, 0xde, 0x6b, 0x66, 0xf6, 0x01, 0xcf, 0x4e, 0x5d, 0x61, 0xbe, 0xa9, 0x2b, 0xce, 0x37, 0x75,
+	0xa5, 0x0f, 0x9b, 0xba, 0xf2, 0x7d, 0x53, 0xf7, 0x0c, 0xca, 0x92, 0xb5, 0xd2, 0xc9, 0x4d, 0xdb,
+	0x4b, 0xe9, 0x26, 0x4f, 0xa0, 0x16, 0x53, 0x56, 0xc5, 0x29, 0x8d, 0x0c, 0xfa, 0x09, 0xb4, 0x52,
+	0x7d, 0x8b, 0x77, 0xf9, 0x39, 0x54, 0x7a, 0xc2, 0x24, 0x6f, 0x77, 0x12, 0x65, 0x88, 0xd0, 0x86,
+	0x82, 0xec, 0xff, 0x55, 0x86, 0x3a, 0x73, 0x5d, 0xa2, 0xc7, 0x5a, 0x49, 0x5e, 0x40, 0x45, 0xbe,
+	0x80, 0x08, 0xf0, 0xb8, 0xe3, 0x91, 0x1b, 0x84, 0x6d, 0x2d, 0xfb, 0x50, 0x4a, 0xbc, 0xa9, 0x9a,
+	0xd9, 0xb7, 0x53, 0x2a, 0x72, 0xeb, 0xc1, 0x27, 0x16, 0x79, 0x0a, 0xf9, 0x73, 0x4c, 0x05, 0x3d,
+	0x8a, 0x82, 0x12, 0x0f, 0xad, 0x03, 0xa8, 0x27, 0x5e, 0x4a, 0x64, 0x3d, 0xc2, 0x4c, 0xbe, 0x9f,
+	0xda, 0x09, 0x32, 0x72, 0x00, 0x25, 0x86, 0xf2, 0xc9, 0x6a, 0x14, 0x91, 0xfc, 0x78, 0xb6, 0x5b,
+	0x59, 0xb3, 0xcc, 0xf7, 0x12, 0x8a, 0xcc, 0x40, 0x56, 0x52, 0x7e, 0x15, 0xb5, 0x9a, 0xb1, 0xca,
+	0xa0, 0x6f, 0xa1, 0x99, 0xfd, 0x6e, 0x91, 0x4e, 0x04, 0x9d, 0xf1, 0x49, 0x4b, 0xc9, 0xfd, 0x09,
+	0xd6, 0xef, 0xf9, 0x9c, 0x90, 0x4f, 0x27, 0xc8, 0x66, 0x7f, 0x74, 0x52, 0xbc, 0xbf, 0x02, 0x99,
+	0xbc, 0xbf, 0x88, 0x3e, 0x41, 0x37, 0x71, 0xea, 0xdb, 0xdb, 0xf7, 0x62, 0x64, 0xe1, 0x6f, 0x61,
+	0x21, 0x75, 0xeb, 0x90, 0x8d, 0xb8, 0x41, 0x53, 0x6e, 0xbc, 0xf6, 0xe6, 0x2c, 0x77, 0xb2, 0x91,
+	0xe9, 0x5b, 0x28, 0xd5, 0xc8, 0xa9, 0x17, 0x54, 0xaa, 0xe0, 0x63, 0x80, 0xf8, 0x78, 0x90, 0xf6,
+	0xe4, 0x29, 0x88, 0xa2, 0xd6, 0xa7, 0xfa, 0x84, 0x90, 0xc3, 0xea, 0xfb, 0xf2, 0xde, 0xde, 0x0b,
+	0xcf, 0xed, 0xf7, 0xca, 0xfc, 0xaf, 0xc1, 0x97, 0xff, 0x0d, 0x00, 0x4a, 0x12, 0xe8, 0x2e, 0x2d,
+	0x0e, 0x00, 0x00,
 }
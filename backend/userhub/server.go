@@ -2,11 +2,7 @@ package userhub
 
 import (
 	"context"
-	"errors"
-	"fmt"
-	"log"
 	"net/http"
-	"net/http/httputil"
 
 	"github.com/ansel1/merry"
 	"github.com/appleboy/go-fcm"
@@ -14,9 +10,14 @@ import (
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/cors"
 	"github.com/gorilla/sessions"
+	"github.com/jmoiron/sqlx"
 	"github.com/twitchtv/twirp"
 
 	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/health"
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/common/metrics"
+	"github.com/mreider/koto/backend/common/tracing"
 	"github.com/mreider/koto/backend/token"
 	"github.com/mreider/koto/backend/userhub/bcrypt"
 	"github.com/mreider/koto/backend/userhub/config"
@@ -24,6 +25,8 @@ import (
 	"github.com/mreider/koto/backend/userhub/routers"
 	"github.com/mreider/koto/backend/userhub/rpc"
 	"github.com/mreider/koto/backend/userhub/services"
+	"github.com/mreider/koto/backend/userhub/services/push"
+	"github.com/mreider/koto/backend/userhub/services/webpush"
 )
 
 const (
@@ -35,16 +38,17 @@ const (
 
 type Server struct {
 	cfg            config.Config
+	db             *sqlx.DB
 	pubKeyPEM      string
 	repos          repo.Repos
 	tokenGenerator token.Generator
 	tokenParser    token.Parser
-	s3Storage      *common.S3Storage
+	blobStorage    common.BlobStorage
 	sessionStore   *sessions.CookieStore
 	staticFS       http.FileSystem
 }
 
-func NewServer(cfg config.Config, pubKeyPEM string, repos repo.Repos, tokenGenerator token.Generator, tokenParser token.Parser, s3Storage *common.S3Storage,
+func NewServer(cfg config.Config, db *sqlx.DB, pubKeyPEM string, repos repo.Repos, tokenGenerator token.Generator, tokenParser token.Parser, blobStorage common.BlobStorage,
 	staticFS http.FileSystem) *Server {
 	sessionStore := sessions.NewCookieStore([]byte(cookieAuthenticationKey))
 	sessionStore.Options.HttpOnly = true
@@ -52,61 +56,77 @@ func NewServer(cfg config.Config, pubKeyPEM string, repos repo.Repos, tokenGener
 
 	return &Server{
 		cfg:            cfg,
+		db:             db,
 		pubKeyPEM:      pubKeyPEM,
 		repos:          repos,
 		tokenGenerator: tokenGenerator,
 		tokenParser:    tokenParser,
-		s3Storage:      s3Storage,
+		blobStorage:    blobStorage,
 		sessionStore:   sessionStore,
 		staticFS:       staticFS,
 	}
 }
 
-func (s *Server) Run() error {
+// Run serves requests until ctx is done, then stops accepting new requests, waits for the running ones
+// and stops the background jobs. The whole shutdown is limited by the configured timeout.
+func (s *Server) Run(ctx context.Context) error {
 	r := chi.NewRouter()
 	s.setupMiddlewares(r)
 
-	r.Mount("/image", routers.Image(s.repos.User, s.s3Storage, s.staticFS))
-
-	rpcHooks := &twirp.ServerHooks{
-		Error: func(ctx context.Context, err twirp.Error) context.Context {
-			cause := errors.Unwrap(err)
-			if cause != nil {
-				if err.Code() == twirp.Internal {
-					log.Print(merry.Details(cause))
-				} else {
-					sourceLine := merry.SourceLine(cause)
-					if sourceLine != "" {
-						log.Printf("%s: %s\n", cause, sourceLine)
-					}
-				}
-			} else {
-				log.Println(err)
-			}
-			return ctx
-		},
+	checker := health.NewChecker()
+	checker.Add("postgres", s.db.PingContext)
+	checker.Add("storage", s.blobStorage.Ping)
+	r.Handle("/healthz", checker.LiveHandler())
+	r.Handle("/readyz", checker.ReadyHandler())
+
+	// notifications are sent by the other jobs, so the sender stops last
+	workers := common.NewWorkers()
+	senders := common.NewWorkers()
+
+	r.Mount("/image", routers.Image(s.repos.User, s.blobStorage, s.staticFS))
+	if fsStorage, ok := s.blobStorage.(*common.FSStorage); ok {
+		r.Mount("/blob", fsStorage.Handler())
+	}
+	r.Mount("/digest", routers.Digest(s.repos.User, s.tokenParser))
+	if s.cfg.MetricsToken != "" {
+		r.Handle("/metrics", metrics.Handler(metrics.Default, s.cfg.MetricsToken))
 	}
+
+	rpcHooks := twirp.ChainHooks(metrics.TwirpHooks(), logging.TwirpHooks(), tracing.TwirpHooks())
 	mailSender := common.NewMailSender(s.cfg.SMTP)
-	var firebaseClient *fcm.Client
-	if s.cfg.FirebaseToken != "" {
-		var err error
-		firebaseClient, err = fcm.NewClient(s.cfg.FirebaseToken)
+	pushProviders, err := s.pushProviders()
+	if err != nil {
+		return err
+	}
+	var webPushClient *webpush.Client
+	if s.cfg.VAPIDPrivateKey != "" {
+		webPushClient, err = webpush.NewClient(s.cfg.VAPIDPrivateKey, s.cfg.VAPIDSubject)
 		if err != nil {
-			return merry.Prepend(err, "can't create Firebase client")
+			return merry.Prepend(err, "can't create Web Push client")
 		}
 	}
-	notificationSender := services.NewNotificationSender(s.repos, firebaseClient)
-	notificationSender.Start()
-	baseService := services.NewBase(s.repos, s.s3Storage, s.tokenGenerator, s.tokenParser, mailSender,
+	notificationSender := services.NewNotificationSender(s.repos, pushProviders, webPushClient)
+	senders.Go(notificationSender.Run)
+	baseService := services.NewBase(s.repos, s.blobStorage, s.tokenGenerator, s.tokenParser, mailSender,
 		s.cfg.FrontendAddress, notificationSender)
 
+	birthdayNotifier := services.NewBirthdayNotifier(s.repos, notificationSender, mailSender, s.cfg.FrontendAddress)
+	workers.Go(birthdayNotifier.Notify)
+
+	digestSender := services.NewDigestSender(s.repos, s.tokenGenerator, mailSender, s.cfg.FrontendAddress, s.cfg.ExternalAddress)
+	workers.Go(digestSender.Send)
+
 	passwordHash := bcrypt.NewPasswordHash()
 
 	authService := services.NewAuth(baseService, sessionUserKey, sessionUserPasswordHashKey, passwordHash, s.cfg.TestMode, s.cfg.AdminList(), s.cfg.AdminFriendship)
 	authServiceHandler := rpc.NewAuthServiceServer(authService, rpcHooks)
 	r.Handle(authServiceHandler.PathPrefix()+"*", s.findSessionUser(s.authSessionProvider(authServiceHandler)))
 
-	infoService := services.NewInfo(baseService, s.pubKeyPEM)
+	var webPushPublicKey string
+	if webPushClient != nil {
+		webPushPublicKey = webPushClient.PublicKey()
+	}
+	infoService := services.NewInfo(baseService, s.pubKeyPEM, webPushPublicKey)
 	infoServiceHandler := rpc.NewInfoServiceServer(infoService, rpcHooks)
 	r.Handle(infoServiceHandler.PathPrefix()+"*", infoServiceHandler)
 
@@ -138,15 +158,25 @@ func (s *Server) Run() error {
 	messageHubNotificationServiceHandler := rpc.NewMessageHubNotificationServiceServer(messageHubNotificationService, rpcHooks)
 	r.Handle(messageHubNotificationServiceHandler.PathPrefix()+"*", messageHubNotificationServiceHandler)
 
-	log.Println("started on " + s.cfg.ListenAddress)
-	return http.ListenAndServe(s.cfg.ListenAddress, r)
+	s3Cleaner := common.NewS3Cleaner(s.db, s.blobStorage, nil)
+	workers.Go(s3Cleaner.Clean)
+
+	notificationCleaner := common.NewNotificationCleaner(s.repos.Notification, s.cfg.NotificationRetention())
+	workers.Go(notificationCleaner.Clean)
+
+	server := &http.Server{
+		Addr:    s.cfg.ListenAddress,
+		Handler: r,
+	}
+	return common.Serve(ctx, server, checker, s.cfg.ShutdownDrainDelay(), s.cfg.ShutdownTimeout(), workers, senders)
 }
 
 func (s *Server) setupMiddlewares(r *chi.Mux) {
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
+	r.Use(logging.Middleware)
 	r.Use(middleware.Recoverer)
+	r.Use(tracing.Middleware)
 	r.Use(middleware.Compress(5))
 
 	corsOptions := cors.Options{
@@ -172,7 +202,7 @@ func (s *Server) findSessionUser(next http.Handler) http.Handler {
 			next.ServeHTTP(w, r)
 			return
 		}
-		user, err := s.repos.User.FindUserByID(userID)
+		user, err := s.repos.User.FindUserByID(r.Context(), userID)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -187,7 +217,8 @@ func (s *Server) findSessionUser(next http.Handler) http.Handler {
 			return
 		}
 
-		isAdmin := s.cfg.IsAdmin(user.Name)
+		isAdmin := user.IsAdmin || s.cfg.IsAdmin(user.Name)
+		logging.AddField(r.Context(), "user_id", user.ID)
 
 		ctx := context.WithValue(r.Context(), services.ContextUserKey, *user)
 		ctx = context.WithValue(ctx, services.ContextIsAdminKey, isAdmin)
@@ -197,21 +228,12 @@ func (s *Server) findSessionUser(next http.Handler) http.Handler {
 
 func (s *Server) checkAuth(next http.Handler) http.Handler {
 	return s.findSessionUser(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// TODO: remove
-		if r.URL.Path == "/rpc.UserService/RegisterFCMToken" {
-			requestDump, err := httputil.DumpRequest(r, true)
-			if err != nil {
-				fmt.Println(err)
-			}
-			fmt.Println(string(requestDump))
-		}
-
 		user, ok := r.Context().Value(services.ContextUserKey).(repo.User)
 		if !ok {
 			http.Error(w, "", http.StatusUnauthorized)
 			return
 		}
-		isAdmin := s.cfg.IsAdmin(user.Name) || s.cfg.IsAdmin(user.Email)
+		isAdmin := user.IsAdmin || s.cfg.IsAdmin(user.Name) || s.cfg.IsAdmin(user.Email)
 		if !isAdmin && !user.ConfirmedAt.Valid && r.URL.Path != "/rpc.UserService/Me" {
 			http.Error(w, "", http.StatusForbidden)
 			return
@@ -252,3 +274,34 @@ func (s *sessionWrapper) Save(options services.SessionSaveOptions) error {
 	s.session.Options.MaxAge = int(options.MaxAge.Seconds())
 	return s.session.Save(s.r, s.w)
 }
+
+func (s *Server) pushProviders() (map[string]push.Provider, error) {
+	providers := map[string]push.Provider{
+		push.ProviderUnifiedPush: push.NewUnifiedPush(),
+	}
+	if s.cfg.FirebaseToken != "" {
+		firebaseClient, err := fcm.NewClient(s.cfg.FirebaseToken)
+		if err != nil {
+			return nil, merry.Prepend(err, "can't create Firebase client")
+		}
+		providers[push.ProviderFCM] = push.NewFCM(firebaseClient)
+	}
+	if s.cfg.APNs.KeyPath != "" {
+		address := push.APNsProductionAddress
+		if s.cfg.APNs.Sandbox {
+			address = push.APNsSandboxAddress
+		}
+		apnsProvider, err := push.NewAPNs(push.APNsConfig{
+			Address: address,
+			KeyPath: s.cfg.APNs.KeyPath,
+			KeyID:   s.cfg.APNs.KeyID,
+			TeamID:  s.cfg.APNs.TeamID,
+			Topic:   s.cfg.APNs.Topic,
+		})
+		if err != nil {
+			return nil, merry.Prepend(err, "can't create APNs client")
+		}
+		providers[push.ProviderAPNs] = apnsProvider
+	}
+	return providers, nil
+}
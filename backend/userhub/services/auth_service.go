@@ -3,7 +3,6 @@ package services
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/url"
 	"regexp"
 	"strings"
@@ -13,6 +12,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/twitchtv/twirp"
 
+	"github.com/mreider/koto/backend/common/logging"
 	"github.com/mreider/koto/backend/token"
 	"github.com/mreider/koto/backend/userhub/repo"
 	"github.com/mreider/koto/backend/userhub/rpc"
@@ -36,6 +36,10 @@ var (
 	userNameRe = regexp.MustCompile(`^\w(\w|-|_|\.)+\w$`)
 )
 
+func IsValidUserName(name string) bool {
+	return userNameRe.MatchString(name)
+}
+
 type PasswordHash interface {
 	GenerateHash(password string) (string, error)
 	CompareHashAndPassword(hash, password string) bool
@@ -64,7 +68,7 @@ func NewAuth(base *BaseService, sessionUserKey, sessionUserPasswordHashKey strin
 	}
 }
 
-func (s *authService) Register(_ context.Context, r *rpc.AuthRegisterRequest) (*rpc.Empty, error) {
+func (s *authService) Register(ctx context.Context, r *rpc.AuthRegisterRequest) (*rpc.Empty, error) {
 	if r.Name == "" {
 		return nil, twirp.InvalidArgumentError("username", "shouldn't be empty")
 	}
@@ -75,11 +79,11 @@ func (s *authService) Register(_ context.Context, r *rpc.AuthRegisterRequest) (*
 		return nil, twirp.InvalidArgumentError("password", "shouldn't be empty")
 	}
 	r.Name = strings.TrimSpace(r.Name)
-	if !userNameRe.MatchString(r.Name) {
+	if !IsValidUserName(r.Name) {
 		return nil, twirp.InvalidArgumentError("username", "is invalid")
 	}
 
-	user, err := s.repos.User.FindUserByName(r.Name)
+	user, err := s.repos.User.FindUserByName(ctx, r.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -97,12 +101,12 @@ func (s *authService) Register(_ context.Context, r *rpc.AuthRegisterRequest) (*
 		return nil, merry.Wrap(err)
 	}
 
-	err = s.repos.User.AddUser(userID.String(), r.Name, r.Email, passwordHash)
+	err = s.repos.User.AddUser(ctx, userID.String(), r.Name, r.Email, passwordHash)
 	if err != nil {
 		return nil, merry.Wrap(err)
 	}
 
-	user, err = s.repos.User.FindUserByID(userID.String())
+	user, err = s.repos.User.FindUserByID(ctx, userID.String())
 	if err != nil {
 		return nil, merry.Wrap(err)
 	}
@@ -112,19 +116,19 @@ func (s *authService) Register(_ context.Context, r *rpc.AuthRegisterRequest) (*
 	if r.InviteToken == "" {
 		err := s.sendConfirmLink(*user)
 		if err != nil {
-			log.Printf("can't send email to %s: %s\n", user.Email, err)
+			logging.FromContext(ctx).WithError(err).WithField("user_id", user.ID).Error("can't send email")
 		}
 	} else {
-		err := s.confirmInviteToken(*user, r.InviteToken)
+		err := s.confirmInviteToken(ctx, *user, r.InviteToken)
 		if err != nil {
-			log.Printf("can't confirm invite token for %s: %s\n", user.Email, err)
+			logging.FromContext(ctx).WithError(err).WithField("user_id", user.ID).Error("can't confirm invite token")
 		}
 	}
 	return &rpc.Empty{}, nil
 }
 
 func (s *authService) Login(ctx context.Context, r *rpc.AuthLoginRequest) (*rpc.Empty, error) {
-	user, err := s.repos.User.FindUserByName(r.Name)
+	user, err := s.repos.User.FindUserByName(ctx, r.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -168,12 +172,12 @@ func (s *authService) getAuthSession(ctx context.Context) Session {
 func (s *authService) Confirm(ctx context.Context, r *rpc.AuthConfirmRequest) (*rpc.Empty, error) {
 	if s.testMode {
 		if s.isAdmin(ctx) {
-			user, err := s.repos.User.FindUserByIDOrName(r.Token)
+			user, err := s.repos.User.FindUserByIDOrName(ctx, r.Token)
 			if err != nil {
 				return nil, err
 			}
 			if user != nil {
-				_, err = s.repos.User.ConfirmUser(user.ID)
+				_, err = s.repos.User.ConfirmUser(ctx, user.ID)
 				if err != nil {
 					return nil, err
 				}
@@ -182,7 +186,7 @@ func (s *authService) Confirm(ctx context.Context, r *rpc.AuthConfirmRequest) (*
 		}
 	}
 
-	err := s.confirmUser(r.Token)
+	err := s.confirmUser(ctx, r.Token)
 	if err != nil {
 		return nil, err
 	}
@@ -201,8 +205,8 @@ func (s *authService) SendConfirmLink(ctx context.Context, _ *rpc.Empty) (*rpc.E
 	return &rpc.Empty{}, nil
 }
 
-func (s *authService) SendResetPasswordLink(_ context.Context, r *rpc.AuthSendResetPasswordLinkRequest) (*rpc.Empty, error) {
-	user, err := s.repos.User.FindUserByName(r.Name)
+func (s *authService) SendResetPasswordLink(ctx context.Context, r *rpc.AuthSendResetPasswordLinkRequest) (*rpc.Empty, error) {
+	user, err := s.repos.User.FindUserByName(ctx, r.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -227,7 +231,7 @@ func (s *authService) SendResetPasswordLink(_ context.Context, r *rpc.AuthSendRe
 	return &rpc.Empty{}, nil
 }
 
-func (s *authService) ResetPassword(_ context.Context, r *rpc.AuthResetPasswordRequest) (*rpc.Empty, error) {
+func (s *authService) ResetPassword(ctx context.Context, r *rpc.AuthResetPasswordRequest) (*rpc.Empty, error) {
 	if r.NewPassword == "" {
 		return nil, twirp.InvalidArgumentError("new password", "is empty")
 	}
@@ -242,7 +246,7 @@ func (s *authService) ResetPassword(_ context.Context, r *rpc.AuthResetPasswordR
 		return nil, token.ErrInvalidToken.Here()
 	}
 
-	user, err := s.repos.User.FindUserByName(userName)
+	user, err := s.repos.User.FindUserByName(ctx, userName)
 	if err != nil {
 		return nil, err
 	}
@@ -256,7 +260,7 @@ func (s *authService) ResetPassword(_ context.Context, r *rpc.AuthResetPasswordR
 		return nil, err
 	}
 
-	err = s.repos.User.SetPassword(user.ID, passwordHash)
+	err = s.repos.User.SetPassword(ctx, user.ID, passwordHash)
 	if err != nil {
 		return nil, err
 	}
@@ -285,7 +289,7 @@ func (s *authService) sendConfirmLink(user repo.User) error {
 	return s.mailSender.SendHTMLEmail([]string{user.Email}, confirmEmailSubject, fmt.Sprintf(confirmEmailBody, link))
 }
 
-func (s *authService) confirmUser(confirmToken string) error {
+func (s *authService) confirmUser(ctx context.Context, confirmToken string) error {
 	_, claims, err := s.tokenParser.Parse(confirmToken, "user-confirm")
 	if err != nil {
 		return merry.Wrap(err)
@@ -296,7 +300,7 @@ func (s *authService) confirmUser(confirmToken string) error {
 		return token.ErrInvalidToken.Here()
 	}
 
-	ok, err = s.repos.User.ConfirmUser(userID)
+	ok, err = s.repos.User.ConfirmUser(ctx, userID)
 	if err != nil {
 		return merry.Wrap(err)
 	}
@@ -308,12 +312,12 @@ func (s *authService) confirmUser(confirmToken string) error {
 		return nil
 	}
 
-	user, err := s.repos.User.FindUserByID(userID)
+	user, err := s.repos.User.FindUserByID(ctx, userID)
 	if err != nil {
 		return merry.Wrap(err)
 	}
 
-	admin, err := s.repos.User.FindUserByName(s.adminList[0])
+	admin, err := s.repos.User.FindUserByName(ctx, s.adminList[0])
 	if err != nil {
 		return merry.Wrap(err)
 	}
@@ -323,7 +327,7 @@ func (s *authService) confirmUser(confirmToken string) error {
 
 	switch s.adminFriendship {
 	case "invite":
-		err = s.repos.Invite.AddInvite(user.ID, admin.ID)
+		err = s.repos.Invite.AddInvite(ctx, user.ID, admin.ID)
 		if err != nil {
 			return merry.Wrap(err)
 		}
@@ -332,14 +336,14 @@ func (s *authService) confirmUser(confirmToken string) error {
 		})
 		err = s.sendInviteLinkToRegisteredUser(*user, admin.Email)
 		if err != nil {
-			log.Println("can't invite by email:", err)
+			logging.FromContext(ctx).WithError(err).Error("can't invite by email")
 		}
 	case "accept":
-		err = s.repos.Invite.AddInvite(userID, admin.ID)
+		err = s.repos.Invite.AddInvite(ctx, userID, admin.ID)
 		if err != nil {
 			return merry.Wrap(err)
 		}
-		err = s.repos.Invite.AcceptInvite(userID, admin.ID, true)
+		err = s.repos.Invite.AcceptInvite(ctx, userID, admin.ID, true)
 		if err != nil {
 			return merry.Wrap(err)
 		}
@@ -350,7 +354,7 @@ func (s *authService) confirmUser(confirmToken string) error {
 	return nil
 }
 
-func (s *authService) confirmInviteToken(user repo.User, confirmToken string) error {
+func (s *authService) confirmInviteToken(ctx context.Context, user repo.User, confirmToken string) error {
 	_, claims, err := s.tokenParser.Parse(confirmToken, "user-invite")
 	if err != nil {
 		return merry.Wrap(err)
@@ -361,7 +365,7 @@ func (s *authService) confirmInviteToken(user repo.User, confirmToken string) er
 		return token.ErrInvalidToken.Here()
 	}
 
-	_, err = s.repos.User.ConfirmUser(user.ID)
+	_, err = s.repos.User.ConfirmUser(ctx, user.ID)
 	return err
 }
 
@@ -373,3 +377,73 @@ func (s *authService) sendInviteLinkToRegisteredUser(inviter repo.User, userEmai
 	link := fmt.Sprintf("%s"+invitationsFrontendPath, s.frontendAddress)
 	return s.mailSender.SendHTMLEmail([]string{userEmail}, inviter.Name+" invited you to be friends on KOTO", fmt.Sprintf(inviteRegisteredUserEmailBody, link))
 }
+
+func (s *authService) ConfirmEmailChange(ctx context.Context, r *rpc.AuthConfirmRequest) (*rpc.Empty, error) {
+	_, claims, err := s.tokenParser.Parse(r.Token, "user-email-change")
+	if err != nil {
+		return nil, err
+	}
+	userID, _ := claims["id"].(string)
+	email, _ := claims["email"].(string)
+	oldEmail, _ := claims["old_email"].(string)
+	if userID == "" || email == "" {
+		return nil, token.ErrInvalidToken.Here()
+	}
+
+	user, err := s.repos.User.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, twirp.NotFoundError("user not found")
+	}
+	if user.Email == email {
+		return &rpc.Empty{}, nil
+	}
+	if user.Email != oldEmail {
+		return nil, twirp.NewError(twirp.FailedPrecondition, "email has been changed since the link was sent")
+	}
+	err = s.checkEmailIsFree(ctx, user.ID, email)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repos.User.SetEmail(ctx, user.ID, email)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.Empty{}, nil
+}
+
+func (s *authService) RevertEmailChange(ctx context.Context, r *rpc.AuthConfirmRequest) (*rpc.Empty, error) {
+	_, claims, err := s.tokenParser.Parse(r.Token, "user-email-revert")
+	if err != nil {
+		return nil, err
+	}
+	userID, _ := claims["id"].(string)
+	email, _ := claims["email"].(string)
+	if userID == "" || email == "" {
+		return nil, token.ErrInvalidToken.Here()
+	}
+
+	user, err := s.repos.User.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, twirp.NotFoundError("user not found")
+	}
+	if user.Email == email {
+		return &rpc.Empty{}, nil
+	}
+	err = s.checkEmailIsFree(ctx, user.ID, email)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repos.User.SetEmail(ctx, user.ID, email)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.Empty{}, nil
+}
@@ -17,7 +17,7 @@ func TestAuthService_Register_EmptyValues(t *testing.T) {
 	repos := repo.Repos{
 		User: nil,
 	}
-	base := services.NewBase(repos, nil, nil, nil, nil, "", services.NewNotificationSender(repos, nil))
+	base := services.NewBase(repos, nil, nil, nil, nil, "", services.NewNotificationSender(repos, nil, nil))
 	s := services.NewAuth(base, "session-user-key", "session-user-password-hash-key", &passwordHash{}, false, nil, "")
 
 	ctx := context.Background()
@@ -57,7 +57,7 @@ func TestAuthService_Register_NameWithSpaces(t *testing.T) {
 	repos := repo.Repos{
 		User: nil,
 	}
-	base := services.NewBase(repos, nil, nil, nil, nil, "", services.NewNotificationSender(repos, nil))
+	base := services.NewBase(repos, nil, nil, nil, nil, "", services.NewNotificationSender(repos, nil, nil))
 	s := services.NewAuth(base, "session-user-key", "session-user-password-hash-key", &passwordHash{}, false, nil, "")
 
 	ctx := context.Background()
@@ -74,19 +74,19 @@ func TestAuthService_Register_NameWithSpaces(t *testing.T) {
 }
 
 func TestAuthService_Register_Duplicated(t *testing.T) {
-	te := NewTestEnvironment("auth")
+	te := NewTestEnvironment()
 	defer te.Cleanup()
 
 	repos := repo.Repos{
-		User: repo.NewUsers(te.db),
+		User: repo.NewUsers(te.DB),
 	}
-	err := repos.User.AddUser("1", "user1", "user1@mail.org", "password1")
+	err := repos.User.AddUser(te.Ctx, "1", "user1", "user1@mail.org", "password1")
 	require.Nil(t, err)
 
-	base := services.NewBase(repos, nil, nil, nil, nil, "", services.NewNotificationSender(repos, nil))
+	base := services.NewBase(repos, nil, nil, nil, nil, "", services.NewNotificationSender(repos, nil, nil))
 	s := services.NewAuth(base, "session-user-key", "session-user-password-hash-key", &passwordHash{}, false, nil, "")
 
-	_, err = s.Register(te.ctx, &rpc.AuthRegisterRequest{
+	_, err = s.Register(te.Ctx, &rpc.AuthRegisterRequest{
 		Name:     "user1",
 		Email:    "user2@mail.org",
 		Password: "password1",
@@ -96,7 +96,7 @@ func TestAuthService_Register_Duplicated(t *testing.T) {
 	assert.Equal(t, twirp.AlreadyExists, twirpErr.Code())
 	assert.Equal(t, "user already exists", twirpErr.Msg())
 
-	_, err = s.Register(te.ctx, &rpc.AuthRegisterRequest{
+	_, err = s.Register(te.Ctx, &rpc.AuthRegisterRequest{
 		Name:     "user2",
 		Email:    "user1@mail.org",
 		Password: "password1",
@@ -105,28 +105,28 @@ func TestAuthService_Register_Duplicated(t *testing.T) {
 }
 
 func TestAuthService_Register(t *testing.T) {
-	te := NewTestEnvironment("auth")
+	te := NewTestEnvironment()
 	defer te.Cleanup()
 
 	repos := repo.Repos{
-		User: repo.NewUsers(te.db),
+		User: repo.NewUsers(te.DB),
 	}
-	err := repos.User.AddUser("1", "user1", "user1@mail.org", "password1")
+	err := repos.User.AddUser(te.Ctx, "1", "user1", "user1@mail.org", "password1")
 	require.Nil(t, err)
-	userCount, err := repos.User.UserCount()
+	userCount, err := repos.User.UserCount(te.Ctx)
 	require.Nil(t, err)
 
-	base := services.NewBase(repos, nil, nil, nil, nil, "", services.NewNotificationSender(repos, nil))
+	base := services.NewBase(repos, nil, nil, nil, nil, "", services.NewNotificationSender(repos, nil, nil))
 	s := services.NewAuth(base, "session-user-key", "session-user-password-hash-key", &passwordHash{}, false, nil, "")
 
-	_, err = s.Register(te.ctx, &rpc.AuthRegisterRequest{
+	_, err = s.Register(te.Ctx, &rpc.AuthRegisterRequest{
 		Name:     "user2",
 		Email:    "user2@mail.org",
 		Password: "password2",
 	})
 	assert.Nil(t, err)
 
-	user2, err := repos.User.FindUserByName("user2")
+	user2, err := repos.User.FindUserByName(te.Ctx, "user2")
 	assert.Nil(t, err)
 	assert.NotEmpty(t, user2.ID)
 	assert.Equal(t, "user2", user2.Name)
@@ -135,49 +135,49 @@ func TestAuthService_Register(t *testing.T) {
 	assert.NotEmpty(t, user2.CreatedAt)
 	assert.NotEmpty(t, user2.UpdatedAt)
 
-	users, err := repos.User.FindUsersByEmail("user2@mail.org")
+	users, err := repos.User.FindUsersByEmail(te.Ctx, "user2@mail.org")
 	assert.Nil(t, err)
 	assert.Equal(t, 1, len(users))
 
-	newUserCount, err := repos.User.UserCount()
+	newUserCount, err := repos.User.UserCount(te.Ctx)
 	assert.Nil(t, err)
 	assert.Equal(t, userCount+1, newUserCount)
 
-	_, err = s.Register(te.ctx, &rpc.AuthRegisterRequest{
+	_, err = s.Register(te.Ctx, &rpc.AuthRegisterRequest{
 		Name:     "user2-1",
 		Email:    "user2@mail.org",
 		Password: "password2-1",
 	})
 	assert.Nil(t, err)
 
-	users, err = repos.User.FindUsersByEmail("user2@mail.org")
+	users, err = repos.User.FindUsersByEmail(te.Ctx, "user2@mail.org")
 	assert.Nil(t, err)
 	assert.Equal(t, 2, len(users))
 
-	newUserCount, err = repos.User.UserCount()
+	newUserCount, err = repos.User.UserCount(te.Ctx)
 	assert.Nil(t, err)
 	assert.Equal(t, userCount+2, newUserCount)
 }
 
 func TestAuthService_Login(t *testing.T) {
-	te := NewTestEnvironment("auth")
+	te := NewTestEnvironment()
 	defer te.Cleanup()
 
 	repos := repo.Repos{
-		User: repo.NewUsers(te.db),
+		User: repo.NewUsers(te.DB),
 	}
-	err := repos.User.AddUser("1", "user1", "user1@mail.org", "password1-hash")
+	err := repos.User.AddUser(te.Ctx, "1", "user1", "user1@mail.org", "password1-hash")
 	require.Nil(t, err)
-	err = repos.User.AddUser("11", "User1", "User1@mail.org", "password11-hash")
+	err = repos.User.AddUser(te.Ctx, "11", "User1", "User1@mail.org", "password11-hash")
 	require.NotNil(t, err)
 	assert.Contains(t, err.Error(), `duplicate key value violates unique constraint`)
-	err = repos.User.AddUser("2", "User2", "User2@mail.org", "pass2-hash")
+	err = repos.User.AddUser(te.Ctx, "2", "User2", "User2@mail.org", "pass2-hash")
 	require.Nil(t, err)
 
-	base := services.NewBase(repos, nil, nil, nil, nil, "", services.NewNotificationSender(repos, nil))
+	base := services.NewBase(repos, nil, nil, nil, nil, "", services.NewNotificationSender(repos, nil, nil))
 	s := services.NewAuth(base, "session-user-key", "session-user-password-hash-key", &passwordHash{}, false, nil, "")
 
-	_, err = s.Login(te.ctx, &rpc.AuthLoginRequest{
+	_, err = s.Login(te.Ctx, &rpc.AuthLoginRequest{
 		Name:     "user1",
 		Password: "password2",
 	})
@@ -187,7 +187,7 @@ func TestAuthService_Login(t *testing.T) {
 	assert.Equal(t, "invalid username or password", twirpErr.Msg())
 
 	session := newSession()
-	ctx := context.WithValue(te.ctx, services.ContextSession, session)
+	ctx := context.WithValue(te.Ctx, services.ContextSession, session)
 
 	_, err = s.Login(ctx, &rpc.AuthLoginRequest{
 		Name:     "user1",
@@ -198,7 +198,7 @@ func TestAuthService_Login(t *testing.T) {
 	assert.Equal(t, "hash", session.values["session-user-password-hash-key"])
 
 	session = newSession()
-	ctx = context.WithValue(te.ctx, services.ContextSession, session)
+	ctx = context.WithValue(te.Ctx, services.ContextSession, session)
 
 	_, err = s.Login(ctx, &rpc.AuthLoginRequest{
 		Name:     "user1@mail.org",
@@ -210,7 +210,7 @@ func TestAuthService_Login(t *testing.T) {
 	assert.Equal(t, "invalid username or password", twirpErr.Msg())
 
 	session = newSession()
-	ctx = context.WithValue(te.ctx, services.ContextSession, session)
+	ctx = context.WithValue(te.Ctx, services.ContextSession, session)
 
 	_, err = s.Login(ctx, &rpc.AuthLoginRequest{
 		Name:     "user2",
@@ -222,7 +222,7 @@ func TestAuthService_Login(t *testing.T) {
 }
 
 func TestAuthService_Logout(t *testing.T) {
-	te := NewTestEnvironment("auth")
+	te := NewTestEnvironment()
 	defer te.Cleanup()
 
 	repos := repo.Repos{}
@@ -230,9 +230,9 @@ func TestAuthService_Logout(t *testing.T) {
 	session := newSession()
 	session.values["session-user-key"] = "123"
 	session.values["session-user-password-hash-key"] = "hash"
-	ctx := context.WithValue(te.ctx, services.ContextSession, session)
+	ctx := context.WithValue(te.Ctx, services.ContextSession, session)
 
-	base := services.NewBase(repos, nil, nil, nil, nil, "", services.NewNotificationSender(repos, nil))
+	base := services.NewBase(repos, nil, nil, nil, nil, "", services.NewNotificationSender(repos, nil, nil))
 	s := services.NewAuth(base, "session-user-key", "session-user-password-hash-key", &passwordHash{}, false, nil, "")
 
 	_, err := s.Logout(ctx, &rpc.Empty{})
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/twitchtv/twirp"
+
 	"github.com/mreider/koto/backend/common"
 	"github.com/mreider/koto/backend/token"
 	"github.com/mreider/koto/backend/userhub/repo"
@@ -11,7 +13,7 @@ import (
 
 type BaseService struct {
 	repos              repo.Repos
-	s3Storage          *common.S3Storage
+	blobStorage        common.BlobStorage
 	tokenGenerator     token.Generator
 	tokenParser        token.Parser
 	mailSender         *common.MailSender
@@ -19,11 +21,11 @@ type BaseService struct {
 	notificationSender NotificationSender
 }
 
-func NewBase(repos repo.Repos, s3Storage *common.S3Storage, tokenGenerator token.Generator, tokenParser token.Parser,
+func NewBase(repos repo.Repos, blobStorage common.BlobStorage, tokenGenerator token.Generator, tokenParser token.Parser,
 	mailSender *common.MailSender, frontendAddress string, notificationSender NotificationSender) *BaseService {
 	return &BaseService{
 		repos:              repos,
-		s3Storage:          s3Storage,
+		blobStorage:        blobStorage,
 		tokenGenerator:     tokenGenerator,
 		tokenParser:        tokenParser,
 		mailSender:         mailSender,
@@ -50,5 +52,19 @@ func (s *BaseService) createBlobLink(ctx context.Context, blobID string) (string
 	if blobID == "" {
 		return "", nil
 	}
-	return s.s3Storage.CreateLink(ctx, blobID, time.Hour*24)
+	return s.blobStorage.CreateLink(ctx, blobID, time.Hour*24)
+}
+
+// checkEmailIsFree returns AlreadyExists if the email belongs to another user.
+func (s *BaseService) checkEmailIsFree(ctx context.Context, userID, email string) error {
+	users, err := s.repos.User.FindUsersByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if user.ID != userID {
+			return twirp.NewError(twirp.AlreadyExists, "email is already used")
+		}
+	}
+	return nil
 }
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/userhub/repo"
+)
+
+const (
+	birthdayCheckInterval = time.Minute * 15
+	birthdayDigestHour    = 8
+
+	birthdayDigestSubject = "Today's birthdays on KOTO"
+	birthdayDigestBody    = `<p>Today is the birthday of:</p><ul>%s</ul>
+<p><a href="%s" target="_blank">Open KOTO</a> to congratulate them.</p>`
+)
+
+type BirthdayNotifier struct {
+	repos              repo.Repos
+	notificationSender NotificationSender
+	mailSender         *common.MailSender
+	frontendAddress    string
+}
+
+func NewBirthdayNotifier(repos repo.Repos, notificationSender NotificationSender, mailSender *common.MailSender, frontendAddress string) *BirthdayNotifier {
+	return &BirthdayNotifier{
+		repos:              repos,
+		notificationSender: notificationSender,
+		mailSender:         mailSender,
+		frontendAddress:    frontendAddress,
+	}
+}
+
+func (n *BirthdayNotifier) Notify(ctx context.Context) {
+	ticker := time.NewTicker(birthdayCheckInterval)
+	defer ticker.Stop()
+
+	n.notify(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n.notify(ctx)
+		}
+	}
+}
+
+func (n *BirthdayNotifier) notify(ctx context.Context) {
+	now := common.CurrentTimestamp()
+	birthdays, err := n.repos.Birthday.FriendBirthdays(ctx, birthdayMonthDays(now))
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("can't load birthdays")
+		return
+	}
+
+	userBirthdays := make(map[string][]repo.FriendBirthday)
+	var userIDs []string
+	for _, birthday := range birthdays {
+		if _, ok := userBirthdays[birthday.UserID]; !ok {
+			userIDs = append(userIDs, birthday.UserID)
+		}
+		userBirthdays[birthday.UserID] = append(userBirthdays[birthday.UserID], birthday)
+	}
+
+	for _, userID := range userIDs {
+		err = n.notifyUser(ctx, userBirthdays[userID], now)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("can't send birthday notifications")
+		}
+	}
+}
+
+func (n *BirthdayNotifier) notifyUser(ctx context.Context, birthdays []repo.FriendBirthday, now time.Time) error {
+	user := birthdays[0]
+	localNow, today := todayBirthdays(birthdays, now)
+	if len(today) == 0 {
+		return nil
+	}
+
+	var items []string
+	for _, birthday := range today {
+		channels, err := n.repos.NotificationSetting.Channels(ctx, []string{user.UserID}, "user/birthday", nil, birthday.FriendID)
+		if err != nil {
+			return err
+		}
+		if len(channels) == 0 || !channels[0].Email {
+			continue
+		}
+
+		item := html.EscapeString(birthday.FriendName)
+		if !birthday.HideBirthdayYear {
+			item += fmt.Sprintf(" (turns %d)", localNow.Year()-birthday.Birthday.Time.Year())
+		}
+		items = append(items, "<li>"+item+"</li>")
+	}
+
+	claimed, err := n.repos.Birthday.ClaimDigest(ctx, user.UserID, localNow, now)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	// the notifications are sent after the email, so a failed digest is retried as a whole
+	if n.mailSender.Enabled() && user.UserEmail != "" && len(items) > 0 {
+		err = n.mailSender.SendHTMLEmail([]string{user.UserEmail}, birthdayDigestSubject,
+			fmt.Sprintf(birthdayDigestBody, strings.Join(items, ""), n.frontendAddress))
+		if err != nil {
+			releaseErr := n.repos.Birthday.ReleaseDigest(ctx, user.UserID, localNow)
+			if releaseErr != nil {
+				logging.FromContext(ctx).WithError(releaseErr).Error("can't release birthday digest")
+			}
+			return err
+		}
+	}
+
+	for _, birthday := range today {
+		n.notificationSender.SendNotification([]string{user.UserID}, "Today is "+birthday.FriendName+"'s birthday", "user/birthday", map[string]interface{}{
+			"user_id": birthday.FriendID,
+		})
+	}
+	return nil
+}
+
+// birthdayMonthDays returns the birthdays ("01-02") to load, local dates differ from the UTC date by one day at most.
+func birthdayMonthDays(now time.Time) []string {
+	var monthDays []string
+	for _, day := range []time.Time{now.AddDate(0, 0, -1), now, now.AddDate(0, 0, 1)} {
+		monthDays = append(monthDays, day.Format("01-02"))
+		if isLeapDayCelebration(day) {
+			monthDays = append(monthDays, "02-29")
+		}
+	}
+	return monthDays
+}
+
+// todayBirthdays returns the local time of the user and the birthdays of the user's local date.
+// Nothing is returned before the digest hour.
+func todayBirthdays(birthdays []repo.FriendBirthday, now time.Time) (time.Time, []repo.FriendBirthday) {
+	localNow := now.In(userLocation(birthdays[0].UserTimeZone))
+	if localNow.Hour() < birthdayDigestHour {
+		return localNow, nil
+	}
+
+	var today []repo.FriendBirthday
+	for _, birthday := range birthdays {
+		if isBirthday(birthday.Birthday.Time, localNow) {
+			today = append(today, birthday)
+		}
+	}
+	return localNow, today
+}
+
+func userLocation(timeZone string) *time.Location {
+	if timeZone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// isBirthday celebrates February 29 birthdays on February 28 in non-leap years.
+func isBirthday(birthday, day time.Time) bool {
+	if birthday.Month() == day.Month() && birthday.Day() == day.Day() {
+		return true
+	}
+	return birthday.Month() == time.February && birthday.Day() == 29 && isLeapDayCelebration(day)
+}
+
+func isLeapDayCelebration(day time.Time) bool {
+	return day.Month() == time.February && day.Day() == 28 &&
+		time.Date(day.Year(), time.February, 29, 0, 0, 0, 0, time.UTC).Month() != time.February
+}
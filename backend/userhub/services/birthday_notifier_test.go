@@ -0,0 +1,107 @@
+package services
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mreider/koto/backend/userhub/repo"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestIsBirthday(t *testing.T) {
+	tests := []struct {
+		name     string
+		birthday time.Time
+		day      time.Time
+		want     bool
+	}{
+		{"same day", date(1990, time.May, 3), date(2021, time.May, 3), true},
+		{"other day", date(1990, time.May, 3), date(2021, time.May, 4), false},
+		{"other month", date(1990, time.May, 3), date(2021, time.June, 3), false},
+		{"leap day in a leap year", date(1992, time.February, 29), date(2024, time.February, 29), true},
+		{"leap day on Feb 28 of a leap year", date(1992, time.February, 29), date(2024, time.February, 28), false},
+		{"leap day on Feb 28 of a common year", date(1992, time.February, 29), date(2021, time.February, 28), true},
+		{"leap day on Mar 1 of a common year", date(1992, time.February, 29), date(2021, time.March, 1), false},
+		{"Feb 28 in a leap year", date(1990, time.February, 28), date(2024, time.February, 28), true},
+		{"Feb 28 on Feb 29", date(1990, time.February, 28), date(2024, time.February, 29), false},
+		{"century common year", date(1992, time.February, 29), date(2100, time.February, 28), true},
+		{"century leap year", date(1992, time.February, 29), date(2000, time.February, 28), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, isBirthday(test.birthday, test.day))
+		})
+	}
+}
+
+func TestBirthdayMonthDays(t *testing.T) {
+	tests := []struct {
+		name string
+		now  time.Time
+		want []string
+	}{
+		{"regular day", date(2021, time.May, 3), []string{"05-02", "05-03", "05-04"}},
+		{"new year", date(2021, time.January, 1), []string{"12-31", "01-01", "01-02"}},
+		{"Feb 28 of a common year", date(2021, time.February, 28), []string{"02-27", "02-28", "02-29", "03-01"}},
+		{"Mar 1 of a common year", date(2021, time.March, 1), []string{"02-28", "02-29", "03-01", "03-02"}},
+		{"Feb 28 of a leap year", date(2024, time.February, 28), []string{"02-27", "02-28", "02-29"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, birthdayMonthDays(test.now))
+		})
+	}
+}
+
+func TestTodayBirthdays(t *testing.T) {
+	friendBirthday := func(friendID, timeZone string, birthday time.Time) repo.FriendBirthday {
+		return repo.FriendBirthday{
+			UserID:       "1",
+			UserTimeZone: timeZone,
+			FriendID:     friendID,
+			Birthday:     sql.NullTime{Time: birthday, Valid: true},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		timeZone string
+		now      time.Time
+		want     []string
+	}{
+		{"UTC after the digest hour", "", time.Date(2021, time.May, 3, 9, 0, 0, 0, time.UTC), []string{"2"}},
+		{"UTC before the digest hour", "", time.Date(2021, time.May, 3, 7, 59, 0, 0, time.UTC), nil},
+		{"invalid time zone falls back to UTC", "Nowhere/City", time.Date(2021, time.May, 3, 9, 0, 0, 0, time.UTC), []string{"2"}},
+		{"local date is ahead of UTC", "Pacific/Auckland", time.Date(2021, time.May, 2, 22, 0, 0, 0, time.UTC), []string{"2"}},
+		{"local time is before the digest hour", "Pacific/Auckland", time.Date(2021, time.May, 2, 18, 0, 0, 0, time.UTC), nil},
+		{"local date is behind UTC", "America/Los_Angeles", time.Date(2021, time.May, 4, 2, 0, 0, 0, time.UTC), []string{"2"}},
+		{"local date catches up with UTC", "America/Los_Angeles", time.Date(2021, time.May, 4, 16, 0, 0, 0, time.UTC), []string{"3"}},
+		{"leap day birthday on Feb 28 of a common year", "", time.Date(2021, time.February, 28, 9, 0, 0, 0, time.UTC), []string{"5"}},
+		{"leap day birthday in a leap year", "", time.Date(2024, time.February, 29, 9, 0, 0, 0, time.UTC), []string{"5"}},
+		{"no leap day birthday on Feb 28 of a leap year", "", time.Date(2024, time.February, 28, 9, 0, 0, 0, time.UTC), nil},
+		{"leap day birthday on local Feb 28 when UTC is Mar 1", "America/New_York", time.Date(2021, time.March, 1, 3, 0, 0, 0, time.UTC), []string{"5"}},
+		{"no leap day birthday on local Mar 1 when UTC is Feb 28", "Asia/Tokyo", time.Date(2021, time.February, 28, 23, 30, 0, 0, time.UTC), nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			birthdays := []repo.FriendBirthday{
+				friendBirthday("2", test.timeZone, date(1990, time.May, 3)),
+				friendBirthday("3", test.timeZone, date(1985, time.May, 4)),
+				friendBirthday("5", test.timeZone, date(1992, time.February, 29)),
+			}
+
+			_, today := todayBirthdays(birthdays, test.now)
+			var friendIDs []string
+			for _, birthday := range today {
+				friendIDs = append(friendIDs, birthday.FriendID)
+			}
+			assert.Equal(t, test.want, friendIDs)
+		})
+	}
+}
@@ -38,7 +38,7 @@ func (s *blobService) UploadLink(ctx context.Context, r *rpc.BlobUploadLinkReque
 		blobID = strings.TrimSuffix(r.FileName, ext) + "-" + blobID + ext
 	}
 
-	uploadLink, formData, err := s.s3Storage.CreateUploadLink(ctx, blobID, r.ContentType,
+	uploadLink, formData, err := s.blobStorage.CreateUploadLink(ctx, blobID, r.ContentType, 0,
 		map[string]string{
 			"user-id":   user.ID,
 			"user-name": user.Name,
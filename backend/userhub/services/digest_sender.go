@@ -0,0 +1,280 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ansel1/merry"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/common/tracing"
+	"github.com/mreider/koto/backend/token"
+	"github.com/mreider/koto/backend/userhub/repo"
+)
+
+const (
+	digestCheckInterval       = time.Minute * 15
+	digestHour                = 8
+	digestMaxNotifications    = 20
+	digestMaxPosts            = 5
+	digestHubMessageCount     = 50
+	digestHubTokenDuration    = time.Minute * 10
+	digestUnsubscribeDuration = time.Hour * 24 * 90
+)
+
+var digestTemplate = template.Must(template.New("digest").Parse(`<p>Hi {{.UserName}}!</p>
+{{if .Notifications}}<p>You have unread notifications:</p>
+<ul>{{range .Notifications}}<li>{{.Text}}</li>{{end}}</ul>{{end}}
+{{if .Posts}}<p>Popular posts from your friends:</p>
+<ul>{{range .Posts}}<li><b>{{.UserName}}</b>: {{.Text}} ({{.Likes}} likes, {{.Comments}} comments)</li>{{end}}</ul>{{end}}
+<p><a href="{{.FrontendAddress}}" target="_blank">Open KOTO</a></p>
+<p style="font-size: small"><a href="{{.UnsubscribeLink}}" target="_blank">Unsubscribe</a> from these emails.</p>`))
+
+type digestPost struct {
+	UserID    string     `json:"user_id"`
+	UserName  string     `json:"user_name"`
+	Text      string     `json:"text"`
+	CreatedAt string     `json:"created_at"`
+	Likes     int        `json:"likes"`
+	Comments  []struct{} `json:"comments"`
+}
+
+type digestData struct {
+	UserName        string
+	Notifications   []common.Notification
+	Posts           []digestTemplatePost
+	FrontendAddress string
+	UnsubscribeLink string
+}
+
+type digestTemplatePost struct {
+	UserName string
+	Text     string
+	Likes    int
+	Comments int
+}
+
+type DigestSender struct {
+	repos           repo.Repos
+	tokenGenerator  token.Generator
+	mailSender      *common.MailSender
+	frontendAddress string
+	externalAddress string
+	client          *http.Client
+}
+
+func NewDigestSender(repos repo.Repos, tokenGenerator token.Generator, mailSender *common.MailSender, frontendAddress, externalAddress string) *DigestSender {
+	return &DigestSender{
+		repos:           repos,
+		tokenGenerator:  tokenGenerator,
+		mailSender:      mailSender,
+		frontendAddress: frontendAddress,
+		externalAddress: externalAddress,
+		client: &http.Client{
+			Transport: tracing.Transport(nil),
+			Timeout:   time.Second * 30,
+		},
+	}
+}
+
+func (d *DigestSender) Send(ctx context.Context) {
+	if !d.mailSender.Enabled() {
+		return
+	}
+
+	ticker := time.NewTicker(digestCheckInterval)
+	defer ticker.Stop()
+
+	d.send(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.send(ctx)
+		}
+	}
+}
+
+func (d *DigestSender) send(ctx context.Context) {
+	users, err := d.repos.Digest.DigestRecipients(ctx)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("can't load digest recipients")
+		return
+	}
+
+	now := common.CurrentTimestamp()
+	for _, user := range users {
+		err = d.sendUserDigest(ctx, user, now)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("can't send digest")
+		}
+	}
+}
+
+func (d *DigestSender) sendUserDigest(ctx context.Context, user repo.User, now time.Time) error {
+	localNow := now.In(userLocation(user.TimeZone))
+	if localNow.Hour() < digestHour {
+		return nil
+	}
+
+	periodStart := time.Date(localNow.Year(), localNow.Month(), localNow.Day(), 0, 0, 0, 0, time.UTC)
+	period := time.Hour * 24
+	if user.DigestFrequency == repo.DigestWeekly {
+		if localNow.Weekday() != time.Monday {
+			return nil
+		}
+		period *= 7
+	}
+
+	claimed, err := d.repos.Digest.ClaimDigest(ctx, user.ID, periodStart, now)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	err = d.sendClaimedDigest(ctx, user, now, period)
+	if err != nil {
+		releaseErr := d.repos.Digest.ReleaseDigest(ctx, user.ID, periodStart)
+		if releaseErr != nil {
+			logging.FromContext(ctx).WithError(releaseErr).Error("can't release digest")
+		}
+		return err
+	}
+	return d.repos.Digest.MarkDigestSent(ctx, user.ID, periodStart, now)
+}
+
+func (d *DigestSender) sendClaimedDigest(ctx context.Context, user repo.User, now time.Time, period time.Duration) error {
+	since := now.Add(-period)
+	notifications, err := d.repos.Digest.UnreadNotifications(ctx, user.ID, since, digestMaxNotifications)
+	if err != nil {
+		return err
+	}
+	posts := d.topPosts(ctx, user, since)
+	if len(notifications) == 0 && len(posts) == 0 {
+		return nil
+	}
+
+	unsubscribeToken, err := d.tokenGenerator.Generate(user.ID, user.Name, "digest-unsubscribe", now.Add(digestUnsubscribeDuration), nil)
+	if err != nil {
+		return err
+	}
+	unsubscribeLink := d.externalAddress + "/digest/unsubscribe?token=" + url.QueryEscape(unsubscribeToken)
+
+	var body bytes.Buffer
+	err = digestTemplate.Execute(&body, digestData{
+		UserName:        user.Name,
+		Notifications:   notifications,
+		Posts:           posts,
+		FrontendAddress: d.frontendAddress,
+		UnsubscribeLink: unsubscribeLink,
+	})
+	if err != nil {
+		return merry.Wrap(err)
+	}
+
+	subject := "Your daily KOTO digest"
+	if user.DigestFrequency == repo.DigestWeekly {
+		subject = "Your weekly KOTO digest"
+	}
+	return d.mailSender.SendHTMLEmailWithHeaders([]string{user.Email}, subject, body.String(), map[string]string{
+		"List-Unsubscribe":      "<" + unsubscribeLink + ">",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	})
+}
+
+// topPosts loads recent friends' posts from their message hubs. Unavailable hubs are skipped.
+func (d *DigestSender) topPosts(ctx context.Context, user repo.User, since time.Time) []digestTemplatePost {
+	exp := common.CurrentTimestamp().Add(digestHubTokenDuration)
+	authToken, err := d.tokenGenerator.Generate(user.ID, user.Name, "auth", exp, nil)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("can't generate auth token")
+		return nil
+	}
+	hubTokens, err := getMessagesTokens(ctx, d.repos, d.tokenGenerator, user, exp)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("can't generate hub tokens")
+		return nil
+	}
+
+	var posts []digestPost
+	for hubAddress, hubToken := range hubTokens {
+		hubPosts, err := d.loadHubPosts(ctx, hubAddress, authToken, hubToken)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).WithField("hub", hubAddress).Error("can't load posts")
+			continue
+		}
+		for _, post := range hubPosts {
+			createdAt, err := common.RPCStringToTime(post.CreatedAt)
+			if err != nil || createdAt.Before(since) || post.UserID == user.ID {
+				continue
+			}
+			posts = append(posts, post)
+		}
+	}
+
+	sort.SliceStable(posts, func(i, j int) bool {
+		return posts[i].Likes+len(posts[i].Comments) > posts[j].Likes+len(posts[j].Comments)
+	})
+	if len(posts) > digestMaxPosts {
+		posts = posts[:digestMaxPosts]
+	}
+
+	result := make([]digestTemplatePost, len(posts))
+	for i, post := range posts {
+		result[i] = digestTemplatePost{
+			UserName: post.UserName,
+			Text:     post.Text,
+			Likes:    post.Likes,
+			Comments: len(post.Comments),
+		}
+	}
+	return result
+}
+
+func (d *DigestSender) loadHubPosts(ctx context.Context, hubAddress, authToken, hubToken string) ([]digestPost, error) {
+	reqBody, err := json.Marshal(map[string]interface{}{
+		"token": hubToken,
+		"count": digestHubMessageCount,
+	})
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf("%s/rpc.MessageService/Messages", strings.TrimSuffix(hubAddress, "/")),
+		bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Authorization", "Bearer "+authToken)
+
+	resp, err := d.client.Do(r)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, merry.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Messages []digestPost `json:"messages"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return body.Messages, nil
+}
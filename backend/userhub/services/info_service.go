@@ -2,25 +2,28 @@ package services
 
 import (
 	"context"
-	"log"
 	"sync"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/mreider/koto/backend/common"
 	"github.com/mreider/koto/backend/userhub/rpc"
 )
 
 type infoService struct {
 	*BaseService
-	pubKeyPem string
+	pubKeyPem        string
+	webPushPublicKey string
 
 	dockerOnce    sync.Once
 	dockerCreated string
 }
 
-func NewInfo(base *BaseService, pubKeyPem string) rpc.InfoService {
+func NewInfo(base *BaseService, pubKeyPem, webPushPublicKey string) rpc.InfoService {
 	return &infoService{
-		BaseService: base,
-		pubKeyPem:   pubKeyPem,
+		BaseService:      base,
+		pubKeyPem:        pubKeyPem,
+		webPushPublicKey: webPushPublicKey,
 	}
 }
 
@@ -30,11 +33,17 @@ func (s *infoService) PublicKey(_ context.Context, _ *rpc.Empty) (*rpc.InfoPubli
 	}, nil
 }
 
+func (s *infoService) WebPushPublicKey(_ context.Context, _ *rpc.Empty) (*rpc.InfoWebPushPublicKeyResponse, error) {
+	return &rpc.InfoWebPushPublicKeyResponse{
+		PublicKey: s.webPushPublicKey,
+	}, nil
+}
+
 func (s *infoService) Version(_ context.Context, _ *rpc.Empty) (*rpc.InfoVersionResponse, error) {
 	s.dockerOnce.Do(func() {
 		container, err := common.CurrentContainer(context.Background())
 		if err != nil {
-			log.Println("can't get docker info:", err)
+			logrus.WithError(err).Error("can't get docker info")
 		}
 		if container != nil {
 			s.dockerCreated = container.ImageCreated()
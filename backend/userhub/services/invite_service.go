@@ -3,7 +3,6 @@ package services
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/url"
 	"strings"
 	"time"
@@ -12,6 +11,7 @@ import (
 	"github.com/twitchtv/twirp"
 
 	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
 	"github.com/mreider/koto/backend/userhub/repo"
 	"github.com/mreider/koto/backend/userhub/rpc"
 )
@@ -42,14 +42,14 @@ func (s *inviteService) Create(ctx context.Context, r *rpc.InviteCreateRequest)
 		return nil, twirp.NewError(twirp.InvalidArgument, "")
 	}
 
-	friend, err := s.repos.User.FindUserByIDOrName(r.Friend)
+	friend, err := s.repos.User.FindUserByIDOrName(ctx, r.Friend)
 	if err != nil {
 		return nil, err
 	}
 
 	if friend == nil {
 		if strings.Contains(r.Friend, "@") && strings.Contains(r.Friend, ".") {
-			friends, err := s.repos.User.FindUsersByEmail(r.Friend)
+			friends, err := s.repos.User.FindUsersByEmail(ctx, r.Friend)
 			if err != nil {
 				return nil, err
 			}
@@ -64,7 +64,7 @@ func (s *inviteService) Create(ctx context.Context, r *rpc.InviteCreateRequest)
 	}
 
 	if friend != nil {
-		alreadyFriends, err := s.repos.Friend.AreFriends(user, *friend)
+		alreadyFriends, err := s.repos.Friend.AreFriends(ctx, user, *friend)
 		if err != nil {
 			return nil, err
 		}
@@ -72,7 +72,7 @@ func (s *inviteService) Create(ctx context.Context, r *rpc.InviteCreateRequest)
 			return nil, twirp.NewError(twirp.AlreadyExists, "already a friend.")
 		}
 
-		err = s.repos.Invite.AddInvite(user.ID, friend.ID)
+		err = s.repos.Invite.AddInvite(ctx, user.ID, friend.ID)
 		if err != nil {
 			return nil, err
 		}
@@ -81,17 +81,17 @@ func (s *inviteService) Create(ctx context.Context, r *rpc.InviteCreateRequest)
 		})
 		err = s.sendInviteLinkToRegisteredUser(user, friend.Email)
 		if err != nil {
-			log.Println("can't invite by email:", err)
+			logging.FromContext(ctx).WithError(err).Error("can't invite by email")
 		}
 	} else {
-		err = s.repos.Invite.AddInviteByEmail(user.ID, r.Friend)
+		err = s.repos.Invite.AddInviteByEmail(ctx, user.ID, r.Friend)
 		if err != nil {
 			return nil, err
 		}
 
 		err = s.sendInviteLinkToUnregisteredUser(user, r.Friend)
 		if err != nil {
-			log.Println("can't invite by email:", err)
+			logging.FromContext(ctx).WithError(err).Error("can't invite by email")
 		}
 	}
 
@@ -100,7 +100,7 @@ func (s *inviteService) Create(ctx context.Context, r *rpc.InviteCreateRequest)
 
 func (s *inviteService) Accept(ctx context.Context, r *rpc.InviteAcceptRequest) (*rpc.Empty, error) {
 	user := s.getUser(ctx)
-	err := s.repos.Invite.AcceptInvite(r.InviterId, user.ID, false)
+	err := s.repos.Invite.AcceptInvite(ctx, r.InviterId, user.ID, false)
 	if err != nil {
 		if merry.Is(err, repo.ErrInviteNotFound) {
 			return nil, twirp.NotFoundError(err.Error())
@@ -115,7 +115,7 @@ func (s *inviteService) Accept(ctx context.Context, r *rpc.InviteAcceptRequest)
 
 func (s *inviteService) Reject(ctx context.Context, r *rpc.InviteRejectRequest) (*rpc.Empty, error) {
 	user := s.getUser(ctx)
-	err := s.repos.Invite.RejectInvite(r.InviterId, user.ID)
+	err := s.repos.Invite.RejectInvite(ctx, r.InviterId, user.ID)
 	if err != nil {
 		if merry.Is(err, repo.ErrInviteNotFound) {
 			return nil, twirp.NotFoundError(err.Error())
@@ -130,7 +130,7 @@ func (s *inviteService) Reject(ctx context.Context, r *rpc.InviteRejectRequest)
 
 func (s *inviteService) FromMe(ctx context.Context, _ *rpc.Empty) (*rpc.InviteFromMeResponse, error) {
 	user := s.getUser(ctx)
-	invites, err := s.repos.Invite.InvitesFromMe(user)
+	invites, err := s.repos.Invite.InvitesFromMe(ctx, user)
 	if err != nil {
 		return nil, err
 	}
@@ -163,7 +163,7 @@ func (s *inviteService) FromMe(ctx context.Context, _ *rpc.Empty) (*rpc.InviteFr
 
 func (s *inviteService) ForMe(ctx context.Context, _ *rpc.Empty) (*rpc.InviteForMeResponse, error) {
 	user := s.getUser(ctx)
-	invites, err := s.repos.Invite.InvitesForMe(user)
+	invites, err := s.repos.Invite.InvitesForMe(ctx, user)
 	if err != nil {
 		return nil, err
 	}
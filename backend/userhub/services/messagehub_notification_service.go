@@ -6,15 +6,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/ansel1/merry"
 	"github.com/dgrijalva/jwt-go"
+	"github.com/gofrs/uuid"
 	"github.com/twitchtv/twirp"
 
+	"github.com/mreider/koto/backend/common/tracing"
 	"github.com/mreider/koto/backend/token"
+	"github.com/mreider/koto/backend/userhub/repo"
 	"github.com/mreider/koto/backend/userhub/rpc"
 )
 
@@ -31,7 +35,10 @@ func NewMessageHubNotification(base *BaseService) rpc.MessageHubNotificationServ
 	}
 }
 
-func (s *messageHubNotificationService) PostNotifications(ctx context.Context, r *rpc.MessageHubNotificationPostNotificationsRequest) (*rpc.Empty, error) {
+// PostNotifications returns the in-app recipients of the notifications, so the hub stores only the notifications
+// allowed by the users' settings and mutes. Push notifications are sent once per notification id,
+// they are stored with the key, so they are sent even if the user hub restarts.
+func (s *messageHubNotificationService) PostNotifications(ctx context.Context, r *rpc.MessageHubNotificationPostNotificationsRequest) (*rpc.MessageHubNotificationPostNotificationsResponse, error) {
 	tokenParser, err := s.getTokenParser(ctx, r.Node)
 	if err != nil {
 		return nil, err
@@ -48,24 +55,120 @@ func (s *messageHubNotificationService) PostNotifications(ctx context.Context, r
 	if rawNotifications, ok = claims["notifications"].([]interface{}); !ok {
 		return nil, twirp.InvalidArgumentError("token", "is invalid")
 	}
-	notifications := make([]Notification, len(rawNotifications))
+
+	hubNotifications := make([]repo.HubNotification, len(rawNotifications))
+	inAppRecipients := make(map[string]*rpc.MessageHubNotificationRecipients)
 	for i, rawNotification := range rawNotifications {
 		rawNotification := rawNotification.(map[string]interface{})
 		rawUserIDs := rawNotification["users"].([]interface{})
 		userIDs := make([]string, len(rawUserIDs))
-		for i, rawUserID := range rawUserIDs {
-			userIDs[i] = rawUserID.(string)
+		for j, rawUserID := range rawUserIDs {
+			userIDs[j] = rawUserID.(string)
 		}
-		notifications[i] = Notification{
+		notification := Notification{
 			UserIDs:     userIDs,
 			Text:        rawNotification["text"].(string),
 			MessageType: rawNotification["message_type"].(string),
 			Data:        rawNotification["data"].(map[string]interface{}),
-			IsExternal:  true,
 		}
+		jsonNotification, err := json.Marshal(notification)
+		if err != nil {
+			return nil, merry.Wrap(err)
+		}
+
+		id, _ := rawNotification["id"].(string)
+		if id == "" {
+			// notifications without an idempotency key are sent every time they are posted
+			uuidID, err := uuid.NewV4()
+			if err != nil {
+				return nil, merry.Wrap(err)
+			}
+			id = uuidID.String()
+		} else {
+			inAppUserIDs, _, err := notificationRecipients(ctx, s.repos.NotificationSetting, notification)
+			if err != nil {
+				return nil, err
+			}
+			inAppRecipients[id] = &rpc.MessageHubNotificationRecipients{
+				UserIds: inAppUserIDs,
+			}
+		}
+		hubNotifications[i] = repo.HubNotification{
+			ID:           id,
+			Notification: jsonNotification,
+		}
+	}
+
+	_, err = s.repos.HubNotification.ClaimNotifications(ctx, r.Node, hubNotifications)
+	if err != nil {
+		return nil, err
+	}
+	s.notificationSender.SendHubNotifications()
+	return &rpc.MessageHubNotificationPostNotificationsResponse{
+		InAppRecipients: inAppRecipients,
+	}, nil
+}
+
+// UserFriends lets a message hub resolve the current friends of a user assigned to it,
+// e.g. when it publishes a scheduled message.
+func (s *messageHubNotificationService) UserFriends(ctx context.Context, r *rpc.MessageHubNotificationUserFriendsRequest) (*rpc.MessageHubNotificationUserFriendsResponse, error) {
+	isHubActive, err := s.repos.MessageHubs.IsHubActive(ctx, r.Node)
+	if err != nil {
+		return nil, err
+	}
+	if !isHubActive {
+		return nil, twirp.NewError(twirp.PermissionDenied, "hub isn't approved or is disabled")
+	}
+
+	tokenParser, err := s.getTokenParser(ctx, r.Node)
+	if err != nil {
+		return nil, err
+	}
+	_, claims, err := tokenParser.Parse(r.Token, "user-friends")
+	if err != nil {
+		return nil, err
+	}
+	if claimsAddress, ok := claims["id"].(string); !ok || claimsAddress != r.Node {
+		return nil, twirp.InvalidArgumentError("token", "is invalid")
+	}
+	userID, ok := claims["user_id"].(string)
+	if !ok {
+		return nil, twirp.InvalidArgumentError("token", "is invalid")
+	}
+
+	userHubs, err := s.repos.MessageHubs.UserHubs(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	var isUserHub bool
+	for hubAddress := range userHubs {
+		if strings.TrimSuffix(hubAddress, "/") == strings.TrimSuffix(r.Node, "/") {
+			isUserHub = true
+		}
+	}
+	if !isUserHub {
+		return nil, twirp.NotFoundError("user not found")
+	}
+
+	user, err := s.repos.User.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, twirp.NotFoundError("user not found")
+	}
+	friends, err := s.repos.Friend.Friends(ctx, *user)
+	if err != nil {
+		return nil, err
+	}
+	friendIDs := make([]string, len(friends))
+	for i, friend := range friends {
+		friendIDs[i] = friend.ID
 	}
-	s.notificationSender.SendExternalNotifications(notifications)
-	return &rpc.Empty{}, nil
+	sort.Strings(friendIDs)
+	return &rpc.MessageHubNotificationUserFriendsResponse{
+		FriendIds: friendIDs,
+	}, nil
 }
 
 func (s *messageHubNotificationService) getTokenParser(ctx context.Context, nodeAddress string) (token.Parser, error) {
@@ -88,7 +191,8 @@ func (s *messageHubNotificationService) getTokenParser(ctx context.Context, node
 
 func loadNodePublicKey(ctx context.Context, nodeAddress string) (*rsa.PublicKey, error) {
 	client := &http.Client{
-		Timeout: time.Second * 30,
+		Transport: tracing.Transport(nil),
+		Timeout:   time.Second * 30,
 	}
 	r, err := http.NewRequestWithContext(ctx, http.MethodPost,
 		fmt.Sprintf("%s/rpc.InfoService/PublicKey", strings.TrimSuffix(nodeAddress, "/")),
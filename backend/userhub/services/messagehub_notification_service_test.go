@@ -0,0 +1,182 @@
+package services_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/mreider/koto/backend/token"
+	"github.com/mreider/koto/backend/userhub/repo"
+	"github.com/mreider/koto/backend/userhub/rpc"
+	"github.com/mreider/koto/backend/userhub/services"
+)
+
+type notificationSender struct {
+	services.NotificationSender
+	wakeUps int
+}
+
+func (n *notificationSender) SendHubNotifications() {
+	n.wakeUps++
+}
+
+// newTestHub serves the public key of a message hub.
+func newTestHub(t *testing.T) (*httptest.Server, token.Generator) {
+	hubKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(&hubKey.PublicKey)
+	require.Nil(t, err)
+	hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"public_key": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})),
+		})
+	}))
+	return hub, token.NewGenerator(hubKey)
+}
+
+func TestMessageHubNotificationService_UserFriends(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	repos := repo.Repos{
+		User:        repo.NewUsers(te.DB),
+		Invite:      repo.NewInvites(te.DB),
+		Friend:      repo.NewFriends(te.DB),
+		MessageHubs: repo.NewMessageHubs(te.DB),
+	}
+	for i := 1; i <= 4; i++ {
+		id := strconv.Itoa(i)
+		require.Nil(t, repos.User.AddUser(te.Ctx, id, "user"+id, "user"+id+"@mail.org", "password"+id+"-hash"))
+	}
+	for _, friendID := range []string{"3", "2"} {
+		require.Nil(t, repos.Invite.AddInvite(te.Ctx, "1", friendID))
+		require.Nil(t, repos.Invite.AcceptInvite(te.Ctx, "1", friendID, false))
+	}
+
+	hub, hubTokenGenerator := newTestHub(t)
+	defer hub.Close()
+
+	hubID, err := repos.MessageHubs.AddHub(te.Ctx, hub.URL, "", repo.User{ID: "1"}, 0)
+	require.Nil(t, err)
+	require.Nil(t, repos.MessageHubs.AssignUserToHub(te.Ctx, "1", hubID))
+
+	s := services.NewMessageHubNotification(services.NewBase(repos, nil, nil, nil, nil, "", nil))
+
+	userFriends := func(node, issuer, scope, userID string) (*rpc.MessageHubNotificationUserFriendsResponse, error) {
+		tok, err := hubTokenGenerator.Generate(issuer, "", scope, time.Now().Add(time.Minute), map[string]interface{}{
+			"user_id": userID,
+		})
+		require.Nil(t, err)
+		return s.UserFriends(te.Ctx, &rpc.MessageHubNotificationUserFriendsRequest{Node: node, Token: tok})
+	}
+
+	_, err = userFriends(hub.URL, hub.URL, "user-friends", "1")
+	assert.Equal(t, twirp.PermissionDenied, err.(twirp.Error).Code(), "the hub isn't approved")
+
+	require.Nil(t, repos.MessageHubs.ApproveHub(te.Ctx, hubID))
+	resp, err := userFriends(hub.URL, hub.URL, "user-friends", "1")
+	require.Nil(t, err)
+	assert.Equal(t, []string{"2", "3"}, resp.FriendIds)
+
+	_, err = userFriends(hub.URL, hub.URL, "user-friends", "4")
+	assert.Equal(t, twirp.NotFound, err.(twirp.Error).Code(), "the user doesn't use the hub")
+
+	_, err = userFriends(hub.URL, "http://other-hub", "user-friends", "1")
+	assert.Equal(t, twirp.InvalidArgument, err.(twirp.Error).Code(), "the token is issued by another hub")
+
+	_, err = userFriends(hub.URL, hub.URL, "notifications", "1")
+	assert.NotNil(t, err)
+
+	require.Nil(t, repos.MessageHubs.DisableHub(te.Ctx, hubID))
+	_, err = userFriends(hub.URL, hub.URL, "user-friends", "1")
+	assert.Equal(t, twirp.PermissionDenied, err.(twirp.Error).Code(), "the hub is disabled")
+}
+
+func TestMessageHubNotificationService_PostNotifications(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	repos := repo.Repos{
+		User:                repo.NewUsers(te.DB),
+		HubNotification:     repo.NewHubNotifications(te.DB),
+		NotificationSetting: repo.NewNotificationSettings(te.DB),
+	}
+	for i := 1; i <= 4; i++ {
+		id := strconv.Itoa(i)
+		require.Nil(t, repos.User.AddUser(te.Ctx, id, "user"+id, "user"+id+"@mail.org", "password"+id+"-hash"))
+	}
+	require.Nil(t, repos.NotificationSetting.SetSetting(te.Ctx, "2", repo.NotificationSetting{Type: "message/like", InApp: false, Push: true, Email: true}))
+	require.Nil(t, repos.NotificationSetting.Mute(te.Ctx, "3", repo.MuteThread, "message-1"))
+	require.Nil(t, repos.NotificationSetting.Mute(te.Ctx, "4", repo.MuteUser, "1"))
+
+	hub, hubTokenGenerator := newTestHub(t)
+	defer hub.Close()
+
+	sender := &notificationSender{}
+	s := services.NewMessageHubNotification(services.NewBase(repos, nil, nil, nil, nil, "", sender))
+
+	postNotifications := func(notifications ...map[string]interface{}) *rpc.MessageHubNotificationPostNotificationsResponse {
+		tok, err := hubTokenGenerator.Generate(hub.URL, "", "notifications", time.Now().Add(time.Minute), map[string]interface{}{
+			"notifications": notifications,
+		})
+		require.Nil(t, err)
+		resp, err := s.PostNotifications(te.Ctx, &rpc.MessageHubNotificationPostNotificationsRequest{Node: hub.URL, NotificationsToken: tok})
+		require.Nil(t, err)
+		return resp
+	}
+	like := map[string]interface{}{
+		"id":           "ntf-1",
+		"users":        []string{"1", "2", "3", "4"},
+		"text":         "user1 liked your post",
+		"message_type": "message/like",
+		"data":         map[string]interface{}{"message_id": "message-1", "user_id": "1"},
+	}
+	comment := map[string]interface{}{
+		"id":           "ntf-2",
+		"users":        []string{"2", "3"},
+		"text":         "user1 commented on your post",
+		"message_type": "comment/post",
+		"data":         map[string]interface{}{"message_id": "message-2", "user_id": "1"},
+	}
+
+	resp := postNotifications(like, comment)
+	require.Len(t, resp.InAppRecipients, 2)
+	assert.Equal(t, []string{"1"}, resp.InAppRecipients["ntf-1"].UserIds,
+		"user2 disabled in-app likes, user3 muted the thread, user4 muted the actor")
+	assert.ElementsMatch(t, []string{"2", "3"}, resp.InAppRecipients["ntf-2"].UserIds)
+	assert.Equal(t, 1, sender.wakeUps)
+
+	unsent := func() []services.Notification {
+		hubNotifications, err := repos.HubNotification.UnsentNotifications(te.Ctx, 10)
+		require.Nil(t, err)
+		notifications := make([]services.Notification, len(hubNotifications))
+		for i, hubNotification := range hubNotifications {
+			require.Nil(t, json.Unmarshal(hubNotification.Notification, &notifications[i]))
+		}
+		return notifications
+	}
+	notifications := unsent()
+	require.Len(t, notifications, 2, "the claimed notifications are stored until they are pushed")
+	assert.Equal(t, []string{"1", "2", "3", "4"}, notifications[0].UserIDs)
+	assert.Equal(t, "comment/post", notifications[1].MessageType)
+
+	resp = postNotifications(like)
+	assert.Equal(t, []string{"1"}, resp.InAppRecipients["ntf-1"].UserIds, "a retried notification gets the recipients again")
+	assert.Len(t, unsent(), 2, "a retried notification isn't pushed again")
+
+	hubNotifications, err := repos.HubNotification.UnsentNotifications(te.Ctx, 10)
+	require.Nil(t, err)
+	require.Nil(t, repos.HubNotification.MarkNotificationsSent(te.Ctx, hubNotifications, time.Now()))
+	assert.Empty(t, unsent())
+}
@@ -3,12 +3,12 @@ package services
 import (
 	"context"
 	"database/sql"
-	"log"
 
 	"github.com/ansel1/merry"
 	"github.com/twitchtv/twirp"
 
 	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
 	"github.com/mreider/koto/backend/userhub/repo"
 	"github.com/mreider/koto/backend/userhub/rpc"
 )
@@ -29,22 +29,22 @@ func (s *messageHubService) Register(ctx context.Context, r *rpc.MessageHubRegis
 	user := s.getUser(ctx)
 
 	r.Address = common.CleanPublicURL(r.Address)
-	hubExists, err := s.repos.MessageHubs.HubExists(r.Address)
+	hubExists, err := s.repos.MessageHubs.HubExists(ctx, r.Address)
 	if err != nil {
 		return nil, err
 	}
 	if hubExists {
 		return nil, twirp.NewError(twirp.AlreadyExists, "hub already exists")
 	}
-	hubID, err := s.repos.MessageHubs.AddHub(r.Address, r.Details, user, int(r.PostLimit))
+	hubID, err := s.repos.MessageHubs.AddHub(ctx, r.Address, r.Details, user, int(r.PostLimit))
 	if err != nil {
 		return nil, err
 	}
 
 	for _, admin := range s.admins {
-		adminUser, err := s.repos.User.FindUserByName(admin)
+		adminUser, err := s.repos.User.FindUserByName(ctx, admin)
 		if err != nil && !merry.Is(err, sql.ErrNoRows) {
-			log.Println(err)
+			logging.FromContext(ctx).Error(err)
 		}
 		if adminUser != nil {
 			s.notificationSender.SendNotification([]string{adminUser.ID}, user.Name+" added a new message hub", "message-hub/add", map[string]interface{}{
@@ -62,9 +62,9 @@ func (s *messageHubService) Hubs(ctx context.Context, _ *rpc.Empty) (*rpc.Messag
 	var hubs []repo.MessageHub
 	var err error
 	if s.isAdmin(ctx) {
-		hubs, err = s.repos.MessageHubs.AllHubs()
+		hubs, err = s.repos.MessageHubs.AllHubs(ctx)
 	} else {
-		hubs, err = s.repos.MessageHubs.Hubs(user)
+		hubs, err = s.repos.MessageHubs.Hubs(ctx, user)
 	}
 	if err != nil {
 		return nil, err
@@ -92,12 +92,18 @@ func (s *messageHubService) Hubs(ctx context.Context, _ *rpc.Empty) (*rpc.Messag
 	}, nil
 }
 
+// VerifyHubAddress checks that a message hub answers at the address.
+func VerifyHubAddress(ctx context.Context, address string) error {
+	_, err := loadNodePublicKey(ctx, address)
+	return err
+}
+
 func (s *messageHubService) Verify(ctx context.Context, r *rpc.MessageHubVerifyRequest) (*rpc.MessageHubVerifyResponse, error) {
-	hub, err := s.repos.MessageHubs.Hub(r.HubId)
+	hub, err := s.repos.MessageHubs.Hub(ctx, r.HubId)
 	if err != nil {
 		return nil, err
 	}
-	_, err = loadNodePublicKey(ctx, hub.Address)
+	err = VerifyHubAddress(ctx, hub.Address)
 	if err != nil {
 		return &rpc.MessageHubVerifyResponse{
 			Error: err.Error(),
@@ -122,12 +128,12 @@ func (s *messageHubService) Approve(ctx context.Context, r *rpc.MessageHubApprov
 		}, nil
 	}
 
-	err = s.repos.MessageHubs.ApproveHub(r.HubId)
+	err = s.repos.MessageHubs.ApproveHub(ctx, r.HubId)
 	if err != nil {
 		return nil, err
 	}
 
-	hub, err := s.repos.MessageHubs.Hub(r.HubId)
+	hub, err := s.repos.MessageHubs.Hub(ctx, r.HubId)
 	if err != nil {
 		return nil, err
 	}
@@ -141,7 +147,7 @@ func (s *messageHubService) Approve(ctx context.Context, r *rpc.MessageHubApprov
 
 func (s *messageHubService) Remove(ctx context.Context, r *rpc.MessageHubRemoveRequest) (*rpc.Empty, error) {
 	user := s.getUser(ctx)
-	hub, err := s.repos.MessageHubs.Hub(r.HubId)
+	hub, err := s.repos.MessageHubs.Hub(ctx, r.HubId)
 	if err != nil {
 		if merry.Is(err, repo.ErrHubNotFound) {
 			return nil, twirp.NotFoundError(err.Error())
@@ -153,7 +159,7 @@ func (s *messageHubService) Remove(ctx context.Context, r *rpc.MessageHubRemoveR
 		return nil, twirp.NotFoundError(repo.ErrHubNotFound.Error())
 	}
 
-	err = s.repos.MessageHubs.RemoveHub(r.HubId)
+	err = s.repos.MessageHubs.RemoveHub(ctx, r.HubId)
 	if err != nil {
 		return nil, err
 	}
@@ -170,7 +176,7 @@ func (s *messageHubService) Remove(ctx context.Context, r *rpc.MessageHubRemoveR
 
 func (s *messageHubService) SetPostLimit(ctx context.Context, r *rpc.MessageHubSetPostLimitRequest) (*rpc.Empty, error) {
 	user := s.getUser(ctx)
-	hub, err := s.repos.MessageHubs.Hub(r.HubId)
+	hub, err := s.repos.MessageHubs.Hub(ctx, r.HubId)
 	if err != nil {
 		if merry.Is(err, repo.ErrHubNotFound) {
 			return nil, twirp.NotFoundError(err.Error())
@@ -182,7 +188,7 @@ func (s *messageHubService) SetPostLimit(ctx context.Context, r *rpc.MessageHubS
 		return nil, twirp.NotFoundError(repo.ErrHubNotFound.Error())
 	}
 
-	err = s.repos.MessageHubs.SetHubPostLimit(user.ID, r.HubId, int(r.PostLimit))
+	err = s.repos.MessageHubs.SetHubPostLimit(ctx, user.ID, r.HubId, int(r.PostLimit))
 	if err != nil {
 		return nil, err
 	}
@@ -1,39 +1,68 @@
 package services
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"encoding/json"
+	"time"
 
-	"github.com/appleboy/go-fcm"
+	"github.com/ansel1/merry"
 
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/common/logging"
+	"github.com/mreider/koto/backend/common/metrics"
 	"github.com/mreider/koto/backend/userhub/repo"
+	"github.com/mreider/koto/backend/userhub/services/push"
+	"github.com/mreider/koto/backend/userhub/services/webpush"
+)
+
+const (
+	webPushTTL              = time.Hour * 24
+	maxPushFailures         = 5
+	notificationQueueSize   = 10000
+	hubNotificationInterval = time.Second * 5
+	hubNotificationBatch    = 100
+)
+
+var (
+	// the name is specific to the user hub, so it doesn't replace a message hub metric in a binary that links both
+	droppedNotifications = metrics.NewCounter("koto_userhub_notifications_dropped_total",
+		"Notifications dropped because their recipients can't be resolved.")
+	pushResults = metrics.NewCounter("koto_push_sent_total", "Push notifications sent by provider and result.",
+		"provider", "result")
 )
 
 type NotificationSender interface {
-	Start()
+	Run(ctx context.Context)
 	SendNotification(userIDs []string, text, messageType string, data map[string]interface{})
-	SendExternalNotifications(notifications []Notification)
+	// SendHubNotifications sends the notifications claimed by HubNotificationRepo.ClaimNotifications.
+	SendHubNotifications()
 }
 
 type notificationSender struct {
-	repos          repo.Repos
-	firebaseClient *fcm.Client
-	notifications  chan []Notification
+	repos         repo.Repos
+	pushProviders map[string]push.Provider
+	webPushClient *webpush.Client
+	notifications chan []Notification
+	wakeUp        chan struct{}
 }
 
 type Notification struct {
-	UserIDs     []string
-	Text        string
-	MessageType string
-	Data        map[string]interface{}
-	IsExternal  bool
+	UserIDs     []string               `json:"user_ids"`
+	Text        string                 `json:"text"`
+	MessageType string                 `json:"message_type"`
+	Data        map[string]interface{} `json:"data"`
+	IsExternal  bool                   `json:"-"`
 }
 
-func NewNotificationSender(repos repo.Repos, firebaseClient *fcm.Client) NotificationSender {
+// NewNotificationSender creates a sender which delivers push messages with the provider registered for the token's OS.
+// The provider with the empty key is used for all other tokens.
+func NewNotificationSender(repos repo.Repos, pushProviders map[string]push.Provider, webPushClient *webpush.Client) NotificationSender {
 	return &notificationSender{
-		repos:          repos,
-		firebaseClient: firebaseClient,
-		notifications:  make(chan []Notification, 10000),
+		repos:         repos,
+		pushProviders: pushProviders,
+		webPushClient: webPushClient,
+		notifications: make(chan []Notification, notificationQueueSize),
+		wakeUp:        make(chan struct{}, 1),
 	}
 }
 
@@ -47,50 +76,213 @@ func (n *notificationSender) SendNotification(userIDs []string, text, messageTyp
 	}}
 }
 
-func (n *notificationSender) SendExternalNotifications(notifications []Notification) {
-	for i := range notifications {
-		notifications[i].IsExternal = true
+func (n *notificationSender) SendHubNotifications() {
+	select {
+	case n.wakeUp <- struct{}{}:
+	default:
+	}
+}
+
+// Run sends the queued notifications until ctx is done, then sends the rest of the queue.
+// Hub notifications are kept in the database until they are sent, so they survive restarts.
+func (n *notificationSender) Run(ctx context.Context) {
+	metrics.GaugeFunc("koto_notification_queue_depth", "Notification batches waiting to be sent.", func() (float64, error) {
+		return float64(len(n.notifications)), nil
+	})
+
+	ticker := time.NewTicker(hubNotificationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			n.drain()
+			n.deliverHubNotifications(context.Background())
+			return
+		case ntfs := <-n.notifications:
+			n.deliver(context.Background(), ntfs)
+		case <-n.wakeUp:
+			n.deliverHubNotifications(ctx)
+		case <-ticker.C:
+			n.deliverHubNotifications(ctx)
+		}
 	}
-	n.notifications <- notifications
 }
 
-func (n *notificationSender) Start() {
-	go func() {
-		for ntfs := range n.notifications {
-			for _, ntf := range ntfs {
-				if !ntf.IsExternal {
-					err := n.repos.Notification.AddNotifications(ntf.UserIDs, ntf.Text, ntf.MessageType, ntf.Data)
-					if err != nil {
-						log.Println("can't add notification to database:", err)
-					}
-				}
-
-				if n.firebaseClient == nil {
-					continue
-				}
-
-				fcmTokens, err := n.repos.FCMToken.UsersTokens(ntf.UserIDs)
-				if err != nil {
-					log.Println("can't load user fcm tokens:", err)
-				}
-				// TODO remove debug messages
-				fmt.Println("Users:", ntf.UserIDs)
-				fmt.Println("FCM Tokens:", fcmTokens)
-				for _, fcmToken := range fcmTokens {
-					resp, err := n.firebaseClient.SendWithRetry(&fcm.Message{
-						To: fcmToken,
-						Notification: &fcm.Notification{
-							Title: "KOTO",
-							Body:  ntf.Text,
-						},
-					}, 3)
-					if err != nil {
-						log.Println("can't send firebase notification:", err)
-					} else if resp.Error != nil {
-						log.Println("can't send firebase notification:", resp.Error)
-					}
-				}
+func (n *notificationSender) deliverHubNotifications(ctx context.Context) {
+	for {
+		hubNotifications, err := n.repos.HubNotification.UnsentNotifications(ctx, hubNotificationBatch)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("can't load hub notifications")
+			return
+		}
+		if len(hubNotifications) == 0 {
+			return
+		}
+
+		ntfs := make([]Notification, 0, len(hubNotifications))
+		for _, hubNotification := range hubNotifications {
+			var ntf Notification
+			err = json.Unmarshal(hubNotification.Notification, &ntf)
+			if err != nil {
+				logging.FromContext(ctx).WithError(err).Error("can't decode hub notification")
+				droppedNotifications.Inc()
+				continue
 			}
+			ntf.IsExternal = true
+			ntfs = append(ntfs, ntf)
+		}
+		n.deliver(ctx, ntfs)
+
+		err = n.repos.HubNotification.MarkNotificationsSent(ctx, hubNotifications, common.CurrentTimestamp())
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("can't update hub notifications")
+			return
+		}
+		if len(hubNotifications) < hubNotificationBatch {
+			return
 		}
-	}()
+	}
+}
+
+func (n *notificationSender) drain() {
+	for {
+		select {
+		case ntfs := <-n.notifications:
+			n.deliver(context.Background(), ntfs)
+		default:
+			return
+		}
+	}
+}
+
+func (n *notificationSender) deliver(ctx context.Context, ntfs []Notification) {
+	for _, ntf := range ntfs {
+		inAppUserIDs, pushUserIDs, err := notificationRecipients(ctx, n.repos.NotificationSetting, ntf)
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("can't load notification settings")
+			droppedNotifications.Inc()
+			continue
+		}
+
+		if !ntf.IsExternal && len(inAppUserIDs) > 0 {
+			err := n.repos.Notification.AddNotifications(ctx, inAppUserIDs, ntf.Text, ntf.MessageType, ntf.Data)
+			if err != nil {
+				logging.FromContext(ctx).WithError(err).Error("can't add notification to database")
+			}
+		}
+
+		if len(pushUserIDs) == 0 {
+			continue
+		}
+		n.sendPush(ctx, pushUserIDs, ntf)
+		n.sendWebPush(ctx, pushUserIDs, ntf)
+	}
+}
+
+func (n *notificationSender) sendPush(ctx context.Context, userIDs []string, ntf Notification) {
+	if len(n.pushProviders) == 0 {
+		return
+	}
+
+	tokens, err := n.repos.FCMToken.UsersTokens(ctx, userIDs)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("can't load user push tokens")
+		return
+	}
+	message := push.Message{
+		Title: "KOTO",
+		Body:  ntf.Text,
+		Type:  ntf.MessageType,
+		Data:  ntf.Data,
+	}
+	for _, token := range tokens {
+		providerName := token.Provider
+		provider, ok := n.pushProviders[providerName]
+		if !ok {
+			continue
+		}
+
+		err = provider.Send(ctx, push.Device{Token: token.Token, P256dh: token.P256dh, Auth: token.Auth}, message)
+		switch {
+		case err == nil:
+			pushResults.Inc(providerName, "ok")
+			err = n.repos.FCMToken.TokenSucceeded(ctx, token.Token)
+		case merry.Is(err, push.ErrInvalidToken):
+			pushResults.Inc(providerName, "invalid_token")
+			logging.FromContext(ctx).Infof("removing invalid %s push token", providerName)
+			err = n.repos.FCMToken.DeleteToken(ctx, token.Token)
+		default:
+			pushResults.Inc(providerName, "error")
+			logging.FromContext(ctx).WithError(err).Error("can't send push notification")
+			err = n.repos.FCMToken.TokenFailed(ctx, token.Token, maxPushFailures)
+		}
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("can't update push token")
+		}
+	}
+}
+
+func (n *notificationSender) sendWebPush(ctx context.Context, userIDs []string, ntf Notification) {
+	if n.webPushClient == nil {
+		return
+	}
+
+	subscriptions, err := n.repos.WebPushSubscription.UsersSubscriptions(ctx, userIDs)
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("can't load user web push subscriptions")
+		return
+	}
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"title": "KOTO",
+		"body":  ntf.Text,
+		"type":  ntf.MessageType,
+		"data":  ntf.Data,
+	})
+	if err != nil {
+		logging.FromContext(ctx).WithError(err).Error("can't encode web push notification")
+		return
+	}
+	for _, subscription := range subscriptions {
+		err = n.webPushClient.Send(ctx, webpush.Subscription{
+			Endpoint: subscription.Endpoint,
+			P256dh:   subscription.P256dh,
+			Auth:     subscription.Auth,
+		}, payload, webPushTTL)
+		if merry.Is(err, webpush.ErrSubscriptionExpired) {
+			err = n.repos.WebPushSubscription.RemoveSubscription(ctx, subscription.Endpoint)
+		}
+		if err != nil {
+			logging.FromContext(ctx).WithError(err).Error("can't send web push notification")
+		}
+	}
+}
+
+// notificationRecipients applies the users' notification settings and mutes.
+func notificationRecipients(ctx context.Context, settings repo.NotificationSettingRepo, ntf Notification) (inAppUserIDs, pushUserIDs []string, err error) {
+	var threadIDs []string
+	for _, key := range []string{"message_id", "conversation_id"} {
+		if threadID, ok := ntf.Data[key].(string); ok && threadID != "" {
+			threadIDs = append(threadIDs, threadID)
+		}
+	}
+	actorID, _ := ntf.Data["user_id"].(string)
+
+	channels, err := settings.Channels(ctx, ntf.UserIDs, ntf.MessageType, threadIDs, actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, userChannels := range channels {
+		if userChannels.InApp {
+			inAppUserIDs = append(inAppUserIDs, userChannels.UserID)
+		}
+		if userChannels.Push {
+			pushUserIDs = append(pushUserIDs, userChannels.UserID)
+		}
+	}
+	return inAppUserIDs, pushUserIDs, nil
 }
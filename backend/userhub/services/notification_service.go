@@ -3,10 +3,19 @@ package services
 import (
 	"context"
 
+	"github.com/ansel1/merry"
+	"github.com/twitchtv/twirp"
+
 	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/userhub/repo"
 	"github.com/mreider/koto/backend/userhub/rpc"
 )
 
+const (
+	defaultNotificationCount = 50
+	maxNotificationCount     = 200
+)
+
 type notificationService struct {
 	*BaseService
 }
@@ -19,7 +28,7 @@ func NewNotification(base *BaseService) rpc.NotificationService {
 
 func (s *notificationService) Count(ctx context.Context, _ *rpc.Empty) (*rpc.NotificationCountResponse, error) {
 	user := s.getUser(ctx)
-	total, unread, err := s.repos.Notification.Counts(user.ID)
+	total, unread, err := s.repos.Notification.Counts(ctx, user.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -29,10 +38,20 @@ func (s *notificationService) Count(ctx context.Context, _ *rpc.Empty) (*rpc.Not
 	}, nil
 }
 
-func (s *notificationService) Notifications(ctx context.Context, _ *rpc.Empty) (*rpc.NotificationNotificationsResponse, error) {
+func (s *notificationService) Notifications(ctx context.Context, r *rpc.NotificationNotificationsRequest) (*rpc.NotificationNotificationsResponse, error) {
+	count := int(r.Count)
+	if count <= 0 {
+		count = defaultNotificationCount
+	} else if count > maxNotificationCount {
+		count = maxNotificationCount
+	}
+
 	user := s.getUser(ctx)
-	notifications, err := s.repos.Notification.Notifications(user.ID)
+	notifications, err := s.repos.Notification.Notifications(ctx, user.ID, r.BeforeId, count)
 	if err != nil {
+		if merry.Is(err, common.ErrNotificationNotFound) {
+			return nil, twirp.NotFoundError(err.Error())
+		}
 		return nil, err
 	}
 	rpcNotifications := make([]*rpc.Notification, len(notifications))
@@ -43,6 +62,7 @@ func (s *notificationService) Notifications(ctx context.Context, _ *rpc.Empty) (
 			Type:      notification.Type,
 			Data:      notification.Data.String(),
 			CreatedAt: common.TimeToRPCString(notification.CreatedAt),
+			UpdatedAt: common.TimeToRPCString(notification.UpdatedAt),
 			ReadAt:    common.NullTimeToRPCString(notification.ReadAt),
 		}
 	}
@@ -54,7 +74,12 @@ func (s *notificationService) Notifications(ctx context.Context, _ *rpc.Empty) (
 
 func (s *notificationService) Clean(ctx context.Context, r *rpc.NotificationCleanRequest) (*rpc.Empty, error) {
 	user := s.getUser(ctx)
-	err := s.repos.Notification.Clean(user.ID, r.LastKnownId)
+	var err error
+	if len(r.NotificationIds) > 0 {
+		err = s.repos.Notification.DeleteNotifications(ctx, user.ID, r.NotificationIds)
+	} else {
+		err = s.repos.Notification.Clean(ctx, user.ID, r.LastKnownId)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -63,9 +88,112 @@ func (s *notificationService) Clean(ctx context.Context, r *rpc.NotificationClea
 
 func (s *notificationService) MarkRead(ctx context.Context, r *rpc.NotificationMarkReadRequest) (*rpc.Empty, error) {
 	user := s.getUser(ctx)
-	err := s.repos.Notification.MarkRead(user.ID, r.LastKnownId)
+	var err error
+	if len(r.NotificationIds) > 0 {
+		err = s.repos.Notification.MarkNotificationsRead(ctx, user.ID, r.NotificationIds)
+	} else {
+		err = s.repos.Notification.MarkRead(ctx, user.ID, r.LastKnownId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.Empty{}, nil
+}
+
+func (s *notificationService) Settings(ctx context.Context, _ *rpc.Empty) (*rpc.NotificationSettingsResponse, error) {
+	user := s.getUser(ctx)
+	settings, err := s.repos.NotificationSetting.Settings(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	mutes, err := s.repos.NotificationSetting.Mutes(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcSettings := make([]*rpc.NotificationSetting, len(settings))
+	for i, setting := range settings {
+		rpcSettings[i] = &rpc.NotificationSetting{
+			Type:  setting.Type,
+			InApp: setting.InApp,
+			Push:  setting.Push,
+			Email: setting.Email,
+		}
+	}
+	rpcMutes := make([]*rpc.NotificationMute, len(mutes))
+	for i, mute := range mutes {
+		rpcMutes[i] = &rpc.NotificationMute{
+			Kind:      mute.Kind,
+			TargetId:  mute.TargetID,
+			CreatedAt: common.TimeToRPCString(mute.CreatedAt),
+		}
+	}
+	return &rpc.NotificationSettingsResponse{
+		Settings: rpcSettings,
+		Mutes:    rpcMutes,
+	}, nil
+}
+
+func (s *notificationService) SetSetting(ctx context.Context, r *rpc.NotificationSetSettingRequest) (*rpc.Empty, error) {
+	if r.Setting == nil || r.Setting.Type == "" {
+		return nil, twirp.InvalidArgumentError("type", "is empty")
+	}
+
+	user := s.getUser(ctx)
+	var err error
+	if r.Setting.InApp && r.Setting.Push && r.Setting.Email {
+		err = s.repos.NotificationSetting.RemoveSetting(ctx, user.ID, r.Setting.Type)
+	} else {
+		err = s.repos.NotificationSetting.SetSetting(ctx, user.ID, repo.NotificationSetting{
+			Type:  r.Setting.Type,
+			InApp: r.Setting.InApp,
+			Push:  r.Setting.Push,
+			Email: r.Setting.Email,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.Empty{}, nil
+}
+
+func (s *notificationService) Mute(ctx context.Context, r *rpc.NotificationMuteRequest) (*rpc.Empty, error) {
+	err := validateMute(r.Kind, r.TargetId)
+	if err != nil {
+		return nil, err
+	}
+
+	user := s.getUser(ctx)
+	if r.Kind == repo.MuteUser && r.TargetId == user.ID {
+		return nil, twirp.InvalidArgumentError("target_id", "is invalid")
+	}
+	err = s.repos.NotificationSetting.Mute(ctx, user.ID, r.Kind, r.TargetId)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.Empty{}, nil
+}
+
+func (s *notificationService) Unmute(ctx context.Context, r *rpc.NotificationUnmuteRequest) (*rpc.Empty, error) {
+	err := validateMute(r.Kind, r.TargetId)
+	if err != nil {
+		return nil, err
+	}
+
+	user := s.getUser(ctx)
+	err = s.repos.NotificationSetting.Unmute(ctx, user.ID, r.Kind, r.TargetId)
 	if err != nil {
 		return nil, err
 	}
 	return &rpc.Empty{}, nil
 }
+
+func validateMute(kind, targetID string) error {
+	if kind != repo.MuteThread && kind != repo.MuteUser {
+		return twirp.InvalidArgumentError("kind", "is invalid")
+	}
+	if targetID == "" {
+		return twirp.InvalidArgumentError("target_id", "is empty")
+	}
+	return nil
+}
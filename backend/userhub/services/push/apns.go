@@ -0,0 +1,146 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	APNsProductionAddress = "https://api.push.apple.com"
+	APNsSandboxAddress    = "https://api.sandbox.push.apple.com"
+
+	apnsTokenDuration = time.Minute * 50
+)
+
+type APNsConfig struct {
+	Address string
+	KeyPath string
+	KeyID   string
+	TeamID  string
+	Topic   string
+}
+
+type apnsProvider struct {
+	cfg        APNsConfig
+	key        *ecdsa.PrivateKey
+	httpClient *http.Client
+
+	authToken          string
+	authTokenCreatedAt time.Time
+	authTokenMu        sync.Mutex
+}
+
+// NewAPNs creates a provider that talks to the APNs HTTP/2 API using a token-based (.p8) key.
+func NewAPNs(cfg APNsConfig) (Provider, error) {
+	keyPEM, err := ioutil.ReadFile(cfg.KeyPath)
+	if err != nil {
+		return nil, merry.Prepend(err, "can't read APNs key")
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, merry.New("APNs key must be PEM encoded")
+	}
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, merry.Prepend(err, "can't parse APNs key")
+	}
+	key, ok := parsedKey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, merry.New("APNs key is not an ECDSA key")
+	}
+	if cfg.Address == "" {
+		cfg.Address = APNsProductionAddress
+	}
+
+	return &apnsProvider{
+		cfg: cfg,
+		key: key,
+		httpClient: &http.Client{
+			Timeout: time.Second * 30,
+		},
+	}, nil
+}
+
+func (p *apnsProvider) Send(ctx context.Context, device Device, message Message) error {
+	authToken, err := p.getAuthToken()
+	if err != nil {
+		return err
+	}
+
+	payload := make(map[string]interface{}, len(message.Data)+2)
+	for key, value := range message.Data {
+		payload[key] = value
+	}
+	payload["type"] = message.Type
+	payload["aps"] = map[string]interface{}{
+		"alert": map[string]string{
+			"title": message.Title,
+			"body":  message.Body,
+		},
+		"sound": "default",
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.Address+"/3/device/"+device.Token, bytes.NewReader(body))
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	r.Header.Set("Authorization", "bearer "+authToken)
+	r.Header.Set("apns-topic", p.cfg.Topic)
+	r.Header.Set("apns-push-type", "alert")
+	r.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(r)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var respBody struct {
+		Reason string `json:"reason"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&respBody)
+	if resp.StatusCode == http.StatusGone || respBody.Reason == "BadDeviceToken" || respBody.Reason == "Unregistered" {
+		return ErrInvalidToken.Here()
+	}
+	return merry.Errorf("unexpected status %d: %s", resp.StatusCode, respBody.Reason)
+}
+
+func (p *apnsProvider) getAuthToken() (string, error) {
+	p.authTokenMu.Lock()
+	defer p.authTokenMu.Unlock()
+
+	now := time.Now()
+	if p.authToken != "" && now.Sub(p.authTokenCreatedAt) < apnsTokenDuration {
+		return p.authToken, nil
+	}
+
+	t := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": p.cfg.TeamID,
+		"iat": now.Unix(),
+	})
+	t.Header["kid"] = p.cfg.KeyID
+	authToken, err := t.SignedString(p.key)
+	if err != nil {
+		return "", merry.Wrap(err)
+	}
+	p.authToken, p.authTokenCreatedAt = authToken, now
+	return authToken, nil
+}
@@ -0,0 +1,43 @@
+package push
+
+import (
+	"context"
+
+	"github.com/ansel1/merry"
+	"github.com/appleboy/go-fcm"
+)
+
+type fcmProvider struct {
+	client *fcm.Client
+}
+
+func NewFCM(client *fcm.Client) Provider {
+	return &fcmProvider{
+		client: client,
+	}
+}
+
+func (p *fcmProvider) Send(_ context.Context, device Device, message Message) error {
+	resp, err := p.client.SendWithRetry(&fcm.Message{
+		To: device.Token,
+		Notification: &fcm.Notification{
+			Title: message.Title,
+			Body:  message.Body,
+		},
+	}, 3)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	if resp.Error != nil {
+		return merry.Wrap(resp.Error)
+	}
+	for _, result := range resp.Results {
+		if result.Unregistered() {
+			return ErrInvalidToken.Here()
+		}
+		if result.Error != nil {
+			return merry.Wrap(result.Error)
+		}
+	}
+	return nil
+}
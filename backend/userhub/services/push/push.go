@@ -0,0 +1,37 @@
+package push
+
+import (
+	"context"
+
+	"github.com/ansel1/merry"
+)
+
+var (
+	ErrInvalidToken = merry.New("push token is invalid")
+)
+
+type Message struct {
+	Title string
+	Body  string
+	Type  string
+	Data  map[string]interface{}
+}
+
+// Device is a push token registered by a device.
+// P256dh and Auth are the keys the payload is encrypted with, they are used by UnifiedPush.
+type Device struct {
+	Token  string
+	P256dh string
+	Auth   string
+}
+
+// Provider delivers a push message to a device. It returns ErrInvalidToken when the token is dead.
+type Provider interface {
+	Send(ctx context.Context, device Device, message Message) error
+}
+
+const (
+	ProviderFCM         = "fcm"
+	ProviderAPNs        = "apns"
+	ProviderUnifiedPush = "unifiedpush"
+)
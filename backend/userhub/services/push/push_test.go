@@ -0,0 +1,139 @@
+package push
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ansel1/merry"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+func TestUnifiedPush_Send(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		require.Equal(t, "aes128gcm", r.Header.Get("Content-Encoding"))
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		received = body
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	_, x, y, err := elliptic.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	device := Device{
+		Token:  server.URL + "/up123",
+		P256dh: base64.RawURLEncoding.EncodeToString(elliptic.Marshal(elliptic.P256(), x, y)),
+		Auth:   base64.RawURLEncoding.EncodeToString([]byte("0123456789abcdef")),
+	}
+
+	provider := NewUnifiedPush()
+	err = provider.Send(context.Background(), device, Message{Title: "KOTO", Body: "hello", Type: "message/post"})
+	require.True(t, merry.Is(err, common.ErrAddressNotPublic), "the endpoint on a private address is refused")
+
+	provider.(*unifiedPushProvider).httpClient = &http.Client{}
+	err = provider.Send(context.Background(), device, Message{Title: "KOTO", Body: "hello", Type: "message/post"})
+	require.NoError(t, err)
+	require.NotEmpty(t, received)
+	require.NotContains(t, string(received), "hello", "the payload is encrypted")
+
+	gone := device
+	gone.Token = server.URL + "/gone"
+	err = provider.Send(context.Background(), gone, Message{Body: "hello"})
+	require.True(t, merry.Is(err, ErrInvalidToken))
+
+	withoutKeys := Device{Token: server.URL + "/up123"}
+	err = provider.Send(context.Background(), withoutKeys, Message{Body: "hello"})
+	require.True(t, merry.Is(err, ErrInvalidToken), "a device registered without the keys is removed")
+}
+
+func TestValidateUnifiedPushDevice(t *testing.T) {
+	_, x, y, err := elliptic.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	device := Device{
+		Token:  "https://ntfy.example.com/up123",
+		P256dh: base64.RawURLEncoding.EncodeToString(elliptic.Marshal(elliptic.P256(), x, y)),
+		Auth:   base64.RawURLEncoding.EncodeToString([]byte("0123456789abcdef")),
+	}
+	require.NoError(t, ValidateUnifiedPushDevice(device))
+
+	for _, endpoint := range []string{"http://ntfy.example.com/up123", "https://localhost/up123", "https://192.168.1.10/up123"} {
+		invalid := device
+		invalid.Token = endpoint
+		require.Error(t, ValidateUnifiedPushDevice(invalid), endpoint)
+	}
+
+	invalid := device
+	invalid.P256dh = ""
+	require.Error(t, ValidateUnifiedPushDevice(invalid))
+}
+
+func TestAPNs_Send(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	tempDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(tempDir) }()
+	keyPath := filepath.Join(tempDir, "key.p8")
+	err = ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}), 0600)
+	require.NoError(t, err)
+
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "at.koto.app", r.Header.Get("apns-topic"))
+		authToken, err := jwt.Parse(strings.TrimPrefix(r.Header.Get("Authorization"), "bearer "), func(t *jwt.Token) (interface{}, error) {
+			return &key.PublicKey, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, "KEY1", authToken.Header["kid"])
+		require.Equal(t, "TEAM1", authToken.Claims.(jwt.MapClaims)["iss"])
+
+		if r.URL.Path == "/3/device/dead" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"reason":"BadDeviceToken"}`))
+			return
+		}
+		require.Equal(t, "/3/device/token1", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	provider, err := NewAPNs(APNsConfig{
+		Address: server.URL,
+		KeyPath: keyPath,
+		KeyID:   "KEY1",
+		TeamID:  "TEAM1",
+		Topic:   "at.koto.app",
+	})
+	require.NoError(t, err)
+
+	err = provider.Send(context.Background(), Device{Token: "token1"}, Message{Title: "KOTO", Body: "hello", Data: map[string]interface{}{"message_id": "1"}})
+	require.NoError(t, err)
+	require.Equal(t, "1", received["message_id"])
+	require.Equal(t, "hello", received["aps"].(map[string]interface{})["alert"].(map[string]interface{})["body"])
+
+	err = provider.Send(context.Background(), Device{Token: "dead"}, Message{Body: "hello"})
+	require.True(t, merry.Is(err, ErrInvalidToken))
+}
@@ -0,0 +1,86 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ansel1/merry"
+
+	"github.com/mreider/koto/backend/common"
+	"github.com/mreider/koto/backend/userhub/services/webpush"
+)
+
+const (
+	unifiedPushTTL = time.Hour * 24
+)
+
+type unifiedPushProvider struct {
+	httpClient *http.Client
+}
+
+// NewUnifiedPush creates a provider for UnifiedPush distributors (e.g. ntfy). The token is the endpoint URL.
+// The payload is encrypted for the device like a Web Push message, so the distributor can't read it.
+func NewUnifiedPush() Provider {
+	return &unifiedPushProvider{
+		httpClient: common.NewPublicHTTPClient(time.Second * 30),
+	}
+}
+
+func (p *unifiedPushProvider) Send(ctx context.Context, device Device, message Message) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"title": message.Title,
+		"body":  message.Body,
+		"type":  message.Type,
+		"data":  message.Data,
+	})
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	body, err := webpush.Encrypt(webpush.Subscription{
+		Endpoint: device.Token,
+		P256dh:   device.P256dh,
+		Auth:     device.Auth,
+	}, payload)
+	if err != nil {
+		if merry.Is(err, webpush.ErrPayloadTooLarge) {
+			return err
+		}
+		// the device was registered without the keys
+		return ErrInvalidToken.Here()
+	}
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, device.Token, bytes.NewReader(body))
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	r.Header.Set("Content-Encoding", "aes128gcm")
+	r.Header.Set("Content-Type", "application/octet-stream")
+	r.Header.Set("TTL", strconv.Itoa(int(unifiedPushTTL.Seconds())))
+
+	resp, err := p.httpClient.Do(r)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrInvalidToken.Here()
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return merry.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// ValidateUnifiedPushDevice checks that the endpoint is a public HTTPS URL and the payload can be encrypted with the keys.
+func ValidateUnifiedPushDevice(device Device) error {
+	return webpush.ValidateSubscription(webpush.Subscription{
+		Endpoint: device.Token,
+		P256dh:   device.P256dh,
+		Auth:     device.Auth,
+	})
+}
@@ -6,11 +6,17 @@ import (
 	"time"
 
 	"github.com/ansel1/merry"
+	"github.com/twitchtv/twirp"
 
 	"github.com/mreider/koto/backend/token"
+	"github.com/mreider/koto/backend/userhub/repo"
 	"github.com/mreider/koto/backend/userhub/rpc"
 )
 
+const (
+	maxConversationMembers = 10
+)
+
 type tokenService struct {
 	*BaseService
 	tokenGenerator token.Generator
@@ -41,7 +47,7 @@ func (s *tokenService) Auth(ctx context.Context, _ *rpc.Empty) (*rpc.TokenAuthRe
 func (s *tokenService) PostMessage(ctx context.Context, _ *rpc.Empty) (*rpc.TokenPostMessageResponse, error) {
 	user := s.getUser(ctx)
 
-	hubs, err := s.repos.MessageHubs.ConnectedHubs(user)
+	hubs, err := s.repos.MessageHubs.ConnectedHubs(ctx, user)
 	if err != nil {
 		return nil, err
 	}
@@ -69,12 +75,12 @@ func (s *tokenService) PostMessage(ctx context.Context, _ *rpc.Empty) (*rpc.Toke
 	})
 
 	hubs = hubs[:1]
-	err = s.repos.MessageHubs.AssignUserToHub(user.ID, hubs[0].Hub.ID)
+	err = s.repos.MessageHubs.AssignUserToHub(ctx, user.ID, hubs[0].Hub.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	friends, err := s.repos.Friend.Friends(user)
+	friends, err := s.repos.Friend.Friends(ctx, user)
 	if err != nil {
 		return nil, err
 	}
@@ -89,9 +95,12 @@ func (s *tokenService) PostMessage(ctx context.Context, _ *rpc.Empty) (*rpc.Toke
 	tokens := make(map[string]string)
 	exp := time.Now().Add(s.tokenDuration)
 	for _, hub := range hubs {
+		// the hub enforces its post limit with post_limit and distance
 		claims := map[string]interface{}{
-			"hub":     hub.Hub.Address,
-			"friends": friendIDs,
+			"hub":        hub.Hub.Address,
+			"friends":    friendIDs,
+			"post_limit": hub.Hub.PostLimit,
+			"distance":   hub.MinDistance,
 		}
 		hubToken, err := s.tokenGenerator.Generate(user.ID, user.Name, "post-message", exp, claims)
 		if err != nil {
@@ -106,10 +115,18 @@ func (s *tokenService) PostMessage(ctx context.Context, _ *rpc.Empty) (*rpc.Toke
 
 func (s *tokenService) GetMessages(ctx context.Context, _ *rpc.Empty) (*rpc.TokenGetMessagesResponse, error) {
 	user := s.getUser(ctx)
-	tokens := make(map[string]string)
-	exp := time.Now().Add(s.tokenDuration)
 
-	friends, err := s.repos.Friend.Friends(user)
+	tokens, err := getMessagesTokens(ctx, s.repos, s.tokenGenerator, user, time.Now().Add(s.tokenDuration))
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.TokenGetMessagesResponse{
+		Tokens: tokens,
+	}, nil
+}
+
+func getMessagesTokens(ctx context.Context, repos repo.Repos, tokenGenerator token.Generator, user repo.User, exp time.Time) (map[string]string, error) {
+	friends, err := repos.Friend.Friends(ctx, user)
 	if err != nil {
 		return nil, err
 	}
@@ -118,23 +135,100 @@ func (s *tokenService) GetMessages(ctx context.Context, _ *rpc.Empty) (*rpc.Toke
 	for i, u := range friends {
 		userIDs[i+1] = u.ID
 	}
-	userHubs, err := s.repos.MessageHubs.UserHubs(userIDs)
+	userHubs, err := repos.MessageHubs.UserHubs(ctx, userIDs)
 	if err != nil {
 		return nil, err
 	}
 
+	tokens := make(map[string]string)
 	for hubAddress, hubUserIDs := range userHubs {
 		claims := map[string]interface{}{
 			"hub":   hubAddress,
 			"users": hubUserIDs,
 		}
-		hubToken, err := s.tokenGenerator.Generate(user.ID, user.Name, "get-messages", exp, claims)
+		hubToken, err := tokenGenerator.Generate(user.ID, user.Name, "get-messages", exp, claims)
 		if err != nil {
 			return nil, merry.Wrap(err)
 		}
 		tokens[hubAddress] = hubToken
 	}
-	return &rpc.TokenGetMessagesResponse{
-		Tokens: tokens,
+	return tokens, nil
+}
+
+func (s *tokenService) Conversation(ctx context.Context, r *rpc.TokenConversationRequest) (*rpc.TokenConversationResponse, error) {
+	user := s.getUser(ctx)
+
+	if len(r.UserIds) == 0 {
+		return nil, twirp.InvalidArgumentError("user_ids", "is empty")
+	}
+
+	friends, err := s.repos.Friend.FriendsWithSubFriends(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	friendNames := make(map[string]string, len(friends))
+	subFriends := make(map[string]map[string]bool, len(friends))
+	for friend, friendFriends := range friends {
+		friendNames[friend.ID] = friend.Name
+		subFriends[friend.ID] = make(map[string]bool, len(friendFriends))
+		for _, friendFriend := range friendFriends {
+			subFriends[friend.ID][friendFriend.ID] = true
+		}
+	}
+
+	members := map[string]interface{}{
+		user.ID: user.Name,
+	}
+	for _, userID := range r.UserIds {
+		if userID == user.ID {
+			continue
+		}
+		friendName, ok := friendNames[userID]
+		if !ok {
+			return nil, twirp.InvalidArgumentError("user_ids", "should contain only friends")
+		}
+		members[userID] = friendName
+	}
+	if len(members) < 2 {
+		return nil, twirp.InvalidArgumentError("user_ids", "should contain at least one friend")
+	}
+	if len(members) > maxConversationMembers {
+		return nil, twirp.InvalidArgumentError("user_ids", "too many users")
+	}
+	for memberID := range members {
+		for otherMemberID := range members {
+			if memberID == user.ID || otherMemberID == user.ID || memberID == otherMemberID {
+				continue
+			}
+			if !subFriends[memberID][otherMemberID] {
+				return nil, twirp.InvalidArgumentError("user_ids", "should contain only users who are friends with each other")
+			}
+		}
+	}
+
+	userHubs, err := s.repos.MessageHubs.UserHubs(ctx, []string{user.ID})
+	if err != nil {
+		return nil, err
+	}
+	if len(userHubs) == 0 {
+		return nil, twirp.NewError(twirp.FailedPrecondition, "user doesn't have a message hub")
+	}
+
+	var hubAddress string
+	for address := range userHubs {
+		hubAddress = address
+	}
+
+	claims := map[string]interface{}{
+		"hub":     hubAddress,
+		"members": members,
+	}
+	conversationToken, err := s.tokenGenerator.Generate(user.ID, user.Name, "conversation", time.Now().Add(s.tokenDuration), claims)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return &rpc.TokenConversationResponse{
+		Hub:   hubAddress,
+		Token: conversationToken,
 	}, nil
 }
@@ -0,0 +1,82 @@
+package services_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/mreider/koto/backend/token"
+	"github.com/mreider/koto/backend/userhub/repo"
+	"github.com/mreider/koto/backend/userhub/rpc"
+	"github.com/mreider/koto/backend/userhub/services"
+)
+
+func TestTokenService_Conversation(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	repos := repo.Repos{
+		User:        repo.NewUsers(te.DB),
+		Invite:      repo.NewInvites(te.DB),
+		Friend:      repo.NewFriends(te.DB),
+		MessageHubs: repo.NewMessageHubs(te.DB),
+	}
+	for i := 1; i <= 4; i++ {
+		id := strconv.Itoa(i)
+		require.Nil(t, repos.User.AddUser(te.Ctx, id, "user"+id, "user"+id+"@mail.org", "password"+id+"-hash"))
+	}
+	require.Nil(t, repos.Invite.AddInvite(te.Ctx, "1", "2"))
+	require.Nil(t, repos.Invite.AcceptInvite(te.Ctx, "1", "2", false))
+	require.Nil(t, repos.Invite.AddInvite(te.Ctx, "1", "3"))
+	require.Nil(t, repos.Invite.AcceptInvite(te.Ctx, "1", "3", false))
+	require.Nil(t, repos.Invite.AddInvite(te.Ctx, "1", "4"))
+	require.Nil(t, repos.Invite.AcceptInvite(te.Ctx, "1", "4", false))
+	require.Nil(t, repos.Invite.AddInvite(te.Ctx, "2", "3"))
+	require.Nil(t, repos.Invite.AcceptInvite(te.Ctx, "2", "3", false))
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	tokenGenerator := token.NewGenerator(privateKey)
+	tokenParser := token.NewParser(func() *rsa.PublicKey { return &privateKey.PublicKey })
+
+	base := services.NewBase(repos, nil, tokenGenerator, tokenParser, nil, "", nil)
+	s := services.NewToken(base, tokenGenerator, time.Hour)
+
+	user1Ctx := context.WithValue(te.Ctx, services.ContextUserKey, repo.User{ID: "1", Name: "user1"})
+
+	_, err = s.Conversation(user1Ctx, &rpc.TokenConversationRequest{UserIds: []string{"2"}})
+	assert.Equal(t, twirp.FailedPrecondition, err.(twirp.Error).Code(), "the user has no message hub")
+
+	hubID, err := repos.MessageHubs.AddHub(te.Ctx, "http://hub1", "", repo.User{ID: "1"}, 0)
+	require.Nil(t, err)
+	require.Nil(t, repos.MessageHubs.ApproveHub(te.Ctx, hubID))
+	require.Nil(t, repos.MessageHubs.AssignUserToHub(te.Ctx, "1", hubID))
+
+	for _, userIDs := range [][]string{nil, {"1"}, {"5"}, {"2", "5"}, {"2", "4"}, {"2", "3", "4"}} {
+		_, err = s.Conversation(user1Ctx, &rpc.TokenConversationRequest{UserIds: userIDs})
+		assert.Equal(t, twirp.InvalidArgument, err.(twirp.Error).Code(), "%v", userIDs)
+	}
+
+	resp, err := s.Conversation(user1Ctx, &rpc.TokenConversationRequest{UserIds: []string{"2"}})
+	require.Nil(t, err)
+	assert.Equal(t, "http://hub1", resp.Hub)
+
+	_, claims, err := tokenParser.Parse(resp.Token, "conversation")
+	require.Nil(t, err)
+	assert.Equal(t, "1", claims["id"])
+	assert.Equal(t, "http://hub1", claims["hub"])
+	assert.Equal(t, map[string]interface{}{"1": "user1", "2": "user2"}, claims["members"])
+
+	resp, err = s.Conversation(user1Ctx, &rpc.TokenConversationRequest{UserIds: []string{"2", "3"}})
+	require.Nil(t, err, "the members are friends with each other")
+	_, claims, err = tokenParser.Parse(resp.Token, "conversation")
+	require.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"1": "user1", "2": "user2", "3": "user3"}, claims["members"])
+}
@@ -3,9 +3,13 @@ package services
 import (
 	"bytes"
 	"context"
+	"database/sql"
+	"fmt"
+	"html"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/ansel1/merry"
 	"github.com/disintegration/imaging"
@@ -15,11 +19,31 @@ import (
 	"github.com/mreider/koto/backend/common"
 	"github.com/mreider/koto/backend/userhub/repo"
 	"github.com/mreider/koto/backend/userhub/rpc"
+	"github.com/mreider/koto/backend/userhub/services/push"
+	"github.com/mreider/koto/backend/userhub/services/webpush"
 )
 
 const (
 	avatarThumbnailWidth  = 100
 	avatarThumbnailHeight = 100
+
+	maxDevicePrekeys       = 100
+	keyBundleTokenDuration = time.Hour * 24
+
+	birthdayLayout = "2006-01-02"
+
+	emailChangeTokenDuration = time.Hour * 24
+	emailRevertTokenDuration = time.Hour * 24 * 30
+
+	emailChangeFrontendPath = "/confirm-email?token=%s"
+	emailChangeSubject      = "Please confirm your new KOTO email"
+	emailChangeBody         = `<p>Hi %s!</p><p>Please click the link below to confirm your new email address:</p>
+<p><a href="%s" target="_blank">Click here</a>.</p><p>Thanks!</p>`
+
+	emailRevertFrontendPath = "/revert-email?token=%s"
+	emailRevertSubject      = "Your KOTO email is being changed"
+	emailRevertBody         = `<p>Hi %s!</p><p>Someone requested to change the email of your KOTO account to %s.</p>
+<p>If it wasn't you, <a href="%s" target="_blank">click here</a> to keep this address.</p>`
 )
 
 type userService struct {
@@ -36,12 +60,12 @@ func NewUser(base *BaseService, passwordHash PasswordHash) rpc.UserService {
 
 func (s *userService) Friends(ctx context.Context, _ *rpc.Empty) (*rpc.UserFriendsResponse, error) {
 	user := s.getUser(ctx)
-	friendMap, err := s.repos.Friend.FriendsWithSubFriends(user)
+	friendMap, err := s.repos.Friend.FriendsWithSubFriends(ctx, user)
 	if err != nil {
 		return nil, err
 	}
 
-	inviteStatuses, err := s.repos.Invite.InviteStatuses(user)
+	inviteStatuses, err := s.repos.Invite.InviteStatuses(ctx, user)
 	if err != nil {
 		return nil, err
 	}
@@ -87,12 +111,12 @@ func (s *userService) Friends(ctx context.Context, _ *rpc.Empty) (*rpc.UserFrien
 
 func (s *userService) FriendsOfFriends(ctx context.Context, _ *rpc.Empty) (*rpc.UserFriendsOfFriendsResponse, error) {
 	user := s.getUser(ctx)
-	friendsOfFriends, err := s.repos.Friend.FriendsOfFriends(user)
+	friendsOfFriends, err := s.repos.Friend.FriendsOfFriends(ctx, user)
 	if err != nil {
 		return nil, err
 	}
 
-	inviteStatuses, err := s.repos.Invite.InviteStatuses(user)
+	inviteStatuses, err := s.repos.Invite.InviteStatuses(ctx, user)
 	if err != nil {
 		return nil, err
 	}
@@ -135,13 +159,21 @@ func (s *userService) Me(ctx context.Context, _ *rpc.Empty) (*rpc.UserMeResponse
 	user := s.getUser(ctx)
 	isAdmin := s.isAdmin(ctx)
 
+	rpcUser := &rpc.User{
+		Id:               user.ID,
+		Name:             user.Name,
+		Email:            user.Email,
+		IsConfirmed:      user.ConfirmedAt.Valid,
+		HideBirthdayYear: user.HideBirthdayYear,
+		TimeZone:         user.TimeZone,
+		DigestFrequency:  user.DigestFrequency,
+	}
+	if user.Birthday.Valid {
+		rpcUser.Birthday = user.Birthday.Time.Format(birthdayLayout)
+	}
+
 	return &rpc.UserMeResponse{
-		User: &rpc.User{
-			Id:          user.ID,
-			Name:        user.Name,
-			Email:       user.Email,
-			IsConfirmed: user.ConfirmedAt.Valid,
-		},
+		User:    rpcUser,
 		IsAdmin: isAdmin,
 	}, nil
 }
@@ -154,7 +186,7 @@ func (s *userService) EditProfile(ctx context.Context, r *rpc.UserEditProfileReq
 			return nil, twirp.InvalidArgumentError("email", "is empty")
 		}
 		if r.Email != user.Email {
-			err := s.repos.User.SetEmail(user.ID, r.Email)
+			err := s.changeEmail(ctx, user, r.Email)
 			if err != nil {
 				return nil, err
 			}
@@ -175,7 +207,7 @@ func (s *userService) EditProfile(ctx context.Context, r *rpc.UserEditProfileReq
 			return nil, err
 		}
 
-		err = s.repos.User.SetPassword(user.ID, newPasswordHash)
+		err = s.repos.User.SetPassword(ctx, user.ID, newPasswordHash)
 		if err != nil {
 			return nil, err
 		}
@@ -188,6 +220,47 @@ func (s *userService) EditProfile(ctx context.Context, r *rpc.UserEditProfileReq
 		}
 	}
 
+	if r.BirthdayChanged {
+		var birthday sql.NullTime
+		if r.Birthday != "" {
+			date, err := time.Parse(birthdayLayout, r.Birthday)
+			if err != nil {
+				return nil, twirp.InvalidArgumentError("birthday", "should be in YYYY-MM-DD format")
+			}
+			if date.After(common.CurrentTimestamp()) {
+				return nil, twirp.InvalidArgumentError("birthday", "shouldn't be in the future")
+			}
+			birthday = sql.NullTime{Time: date, Valid: true}
+		}
+		err := s.repos.User.SetBirthday(ctx, user.ID, birthday, r.HideBirthdayYear)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.TimeZoneChanged {
+		if r.TimeZone != "" {
+			_, err := time.LoadLocation(r.TimeZone)
+			if err != nil {
+				return nil, twirp.InvalidArgumentError("time_zone", "is unknown")
+			}
+		}
+		err := s.repos.User.SetTimeZone(ctx, user.ID, r.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.DigestFrequencyChanged {
+		if r.DigestFrequency != repo.DigestOff && r.DigestFrequency != repo.DigestDaily && r.DigestFrequency != repo.DigestWeekly {
+			return nil, twirp.InvalidArgumentError("digest_frequency", "should be off, daily or weekly")
+		}
+		err := s.repos.User.SetDigestFrequency(ctx, user.ID, r.DigestFrequency)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &rpc.Empty{}, nil
 }
 
@@ -197,7 +270,7 @@ func (s *userService) setAvatar(ctx context.Context, user repo.User, avatarID st
 	}
 
 	if avatarID == "" {
-		err := s.repos.User.SetAvatar(user.ID, "", "")
+		err := s.repos.User.SetAvatar(ctx, user.ID, "", "")
 		if err != nil {
 			return merry.Wrap(err)
 		}
@@ -205,7 +278,7 @@ func (s *userService) setAvatar(ctx context.Context, user repo.User, avatarID st
 	}
 
 	var buf bytes.Buffer
-	err = s.s3Storage.Read(ctx, avatarID, &buf)
+	err = s.blobStorage.Read(ctx, avatarID, &buf)
 	if err != nil {
 		return merry.Wrap(err)
 	}
@@ -231,20 +304,20 @@ func (s *userService) setAvatar(ctx context.Context, user repo.User, avatarID st
 
 	ext := filepath.Ext(avatarID)
 	thumbnailID := strings.TrimSuffix(avatarID, ext) + "-thumbnail.jpg"
-	err = s.s3Storage.PutObject(ctx, thumbnailID, buf.Bytes(), "image/jpeg")
+	err = s.blobStorage.PutObject(ctx, thumbnailID, buf.Bytes(), "image/jpeg")
 	if err != nil {
 		return merry.Wrap(err)
 	}
 
-	err = s.repos.User.SetAvatar(user.ID, avatarID, thumbnailID)
+	err = s.repos.User.SetAvatar(ctx, user.ID, avatarID, thumbnailID)
 	if err != nil {
 		return merry.Wrap(err)
 	}
 	return nil
 }
 
-func (s *userService) Users(_ context.Context, r *rpc.UserUsersRequest) (*rpc.UserUsersResponse, error) {
-	users, err := s.repos.User.FindUsers(r.UserIds)
+func (s *userService) Users(ctx context.Context, r *rpc.UserUsersRequest) (*rpc.UserUsersResponse, error) {
+	users, err := s.repos.User.FindUsers(ctx, r.UserIds)
 	if err != nil {
 		return nil, err
 	}
@@ -261,7 +334,7 @@ func (s *userService) Users(_ context.Context, r *rpc.UserUsersRequest) (*rpc.Us
 	}, nil
 }
 
-func (s *userService) User(_ context.Context, r *rpc.UserUserRequest) (*rpc.UserUserResponse, error) {
+func (s *userService) User(ctx context.Context, r *rpc.UserUserRequest) (*rpc.UserUserResponse, error) {
 	if r.UserId == "" && r.UserName == "" {
 		return nil, twirp.NewError(twirp.InvalidArgument, "user_id or user_name should be specified")
 	}
@@ -271,13 +344,13 @@ func (s *userService) User(_ context.Context, r *rpc.UserUserRequest) (*rpc.User
 	var user *repo.User
 	if r.UserId != "" {
 		var err error
-		user, err = s.repos.User.FindUserByID(r.UserId)
+		user, err = s.repos.User.FindUserByID(ctx, r.UserId)
 		if err != nil {
 			return nil, err
 		}
 	} else {
 		var err error
-		user, err = s.repos.User.FindUserByName(r.UserName)
+		user, err = s.repos.User.FindUserByName(ctx, r.UserName)
 		if err != nil {
 			return nil, err
 		}
@@ -305,10 +378,271 @@ func (s *userService) RegisterFCMToken(ctx context.Context, r *rpc.UserRegisterF
 	if r.Os == "" {
 		return nil, twirp.InvalidArgumentError("os", "is empty")
 	}
+	switch r.Provider {
+	case push.ProviderFCM, push.ProviderAPNs:
+	case push.ProviderUnifiedPush:
+		err := push.ValidateUnifiedPushDevice(push.Device{Token: r.Token, P256dh: r.P256Dh, Auth: r.Auth})
+		if err != nil {
+			return nil, twirp.InvalidArgumentError("token", err.Error())
+		}
+	case "":
+		return nil, twirp.InvalidArgumentError("provider", "is empty")
+	default:
+		return nil, twirp.InvalidArgumentError("provider", "is unknown")
+	}
+
+	err := s.repos.FCMToken.AddToken(ctx, user.ID, repo.FCMToken{
+		Token:    r.Token,
+		DeviceID: r.DeviceId,
+		OS:       r.Os,
+		Provider: r.Provider,
+		P256dh:   r.P256Dh,
+		Auth:     r.Auth,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.Empty{}, nil
+}
+
+func (s *userService) RegisterWebPushSubscription(ctx context.Context, r *rpc.UserRegisterWebPushSubscriptionRequest) (*rpc.Empty, error) {
+	user := s.getUser(ctx)
+
+	if r.Endpoint == "" {
+		return nil, twirp.InvalidArgumentError("endpoint", "is empty")
+	}
+	err := webpush.ValidateSubscription(webpush.Subscription{
+		Endpoint: r.Endpoint,
+		P256dh:   r.P256Dh,
+		Auth:     r.Auth,
+	})
+	if err != nil {
+		return nil, twirp.InvalidArgumentError("subscription", err.Error())
+	}
+
+	err = s.repos.WebPushSubscription.AddSubscription(ctx, repo.WebPushSubscription{
+		UserID:   user.ID,
+		Endpoint: r.Endpoint,
+		P256dh:   r.P256Dh,
+		Auth:     r.Auth,
+		DeviceID: r.DeviceId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.Empty{}, nil
+}
+
+func (s *userService) RegisterDeviceKeys(ctx context.Context, r *rpc.UserRegisterDeviceKeysRequest) (*rpc.UserRegisterDeviceKeysResponse, error) {
+	user := s.getUser(ctx)
+
+	if r.DeviceId == "" {
+		return nil, twirp.InvalidArgumentError("device_id", "is empty")
+	}
+	if r.IdentityKey == "" {
+		return nil, twirp.InvalidArgumentError("identity_key", "is empty")
+	}
+	if r.SignedPrekey == "" {
+		return nil, twirp.InvalidArgumentError("signed_prekey", "is empty")
+	}
+	if r.SignedPrekeySignature == "" {
+		return nil, twirp.InvalidArgumentError("signed_prekey_signature", "is empty")
+	}
+
+	err := s.repos.DeviceKey.SetDeviceKey(ctx, repo.DeviceKey{
+		UserID:                user.ID,
+		DeviceID:              r.DeviceId,
+		IdentityKey:           r.IdentityKey,
+		SignedPrekeyID:        int(r.SignedPrekeyId),
+		SignedPrekey:          r.SignedPrekey,
+		SignedPrekeySignature: r.SignedPrekeySignature,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prekeyCount, err := s.addPrekeys(ctx, user.ID, r.DeviceId, r.Prekeys)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.UserRegisterDeviceKeysResponse{
+		PrekeyCount: int32(prekeyCount),
+	}, nil
+}
+
+func (s *userService) UploadPrekeys(ctx context.Context, r *rpc.UserUploadPrekeysRequest) (*rpc.UserUploadPrekeysResponse, error) {
+	user := s.getUser(ctx)
+
+	if r.DeviceId == "" {
+		return nil, twirp.InvalidArgumentError("device_id", "is empty")
+	}
+
+	keys, err := s.repos.DeviceKey.DeviceKeys(ctx, []string{user.ID})
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, key := range keys {
+		if key.DeviceID == r.DeviceId {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, twirp.NotFoundError("device not found")
+	}
+
+	prekeyCount, err := s.addPrekeys(ctx, user.ID, r.DeviceId, r.Prekeys)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.UserUploadPrekeysResponse{
+		PrekeyCount: int32(prekeyCount),
+	}, nil
+}
+
+func (s *userService) RemoveDeviceKeys(ctx context.Context, r *rpc.UserRemoveDeviceKeysRequest) (*rpc.Empty, error) {
+	user := s.getUser(ctx)
 
-	err := s.repos.FCMToken.AddToken(user.ID, r.Token, r.DeviceId, r.Os)
+	err := s.repos.DeviceKey.RemoveDevice(ctx, user.ID, r.DeviceId)
 	if err != nil {
 		return nil, err
 	}
 	return &rpc.Empty{}, nil
 }
+
+func (s *userService) KeyBundles(ctx context.Context, r *rpc.UserKeyBundlesRequest) (*rpc.UserKeyBundlesResponse, error) {
+	user := s.getUser(ctx)
+
+	friends, err := s.repos.Friend.Friends(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	userNames := make(map[string]string, len(friends)+1)
+	userNames[user.ID] = user.Name
+	for _, friend := range friends {
+		userNames[friend.ID] = friend.Name
+	}
+
+	for _, userID := range r.UserIds {
+		if _, ok := userNames[userID]; !ok {
+			return nil, twirp.InvalidArgumentError("user_ids", "should contain only friends")
+		}
+	}
+
+	keys, err := s.repos.DeviceKey.DeviceKeys(ctx, r.UserIds)
+	if err != nil {
+		return nil, err
+	}
+
+	exp := time.Now().Add(keyBundleTokenDuration)
+	bundles := make([]*rpc.UserKeyBundle, len(keys))
+	for i, key := range keys {
+		bundles[i] = &rpc.UserKeyBundle{
+			UserId:                key.UserID,
+			DeviceId:              key.DeviceID,
+			IdentityKey:           key.IdentityKey,
+			SignedPrekeyId:        int32(key.SignedPrekeyID),
+			SignedPrekey:          key.SignedPrekey,
+			SignedPrekeySignature: key.SignedPrekeySignature,
+		}
+		claims := map[string]interface{}{
+			"device_id":               key.DeviceID,
+			"identity_key":            key.IdentityKey,
+			"signed_prekey_id":        key.SignedPrekeyID,
+			"signed_prekey":           key.SignedPrekey,
+			"signed_prekey_signature": key.SignedPrekeySignature,
+		}
+
+		prekey, err := s.repos.DeviceKey.ClaimPrekey(ctx, key.UserID, key.DeviceID)
+		if err != nil {
+			return nil, err
+		}
+		if prekey != nil {
+			bundles[i].Prekey = &rpc.UserPrekey{
+				KeyId:     int32(prekey.KeyID),
+				PublicKey: prekey.PublicKey,
+			}
+			claims["prekey_id"] = prekey.KeyID
+			claims["prekey"] = prekey.PublicKey
+		}
+
+		bundles[i].Signature, err = s.tokenGenerator.Generate(key.UserID, userNames[key.UserID], "key-bundle", exp, claims)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &rpc.UserKeyBundlesResponse{
+		Bundles: bundles,
+	}, nil
+}
+
+func (s *userService) addPrekeys(ctx context.Context, userID, deviceID string, rpcPrekeys []*rpc.UserPrekey) (int, error) {
+	prekeyCount, err := s.repos.DeviceKey.PrekeyCount(ctx, userID, deviceID)
+	if err != nil {
+		return 0, err
+	}
+	if len(rpcPrekeys) == 0 {
+		return prekeyCount, nil
+	}
+	if prekeyCount+len(rpcPrekeys) > maxDevicePrekeys {
+		return 0, twirp.InvalidArgumentError("prekeys", "too many prekeys")
+	}
+
+	prekeys := make([]repo.DevicePrekey, len(rpcPrekeys))
+	for i, prekey := range rpcPrekeys {
+		if prekey.PublicKey == "" {
+			return 0, twirp.InvalidArgumentError("prekeys", "public key is empty")
+		}
+		prekeys[i] = repo.DevicePrekey{
+			KeyID:     int(prekey.KeyId),
+			PublicKey: prekey.PublicKey,
+		}
+	}
+	err = s.repos.DeviceKey.AddPrekeys(ctx, userID, deviceID, prekeys)
+	if err != nil {
+		return 0, err
+	}
+	return s.repos.DeviceKey.PrekeyCount(ctx, userID, deviceID)
+}
+
+// changeEmail sends a confirmation link to the new address and a revert link to the old one.
+// The email is switched only after the new address is confirmed.
+func (s *userService) changeEmail(ctx context.Context, user repo.User, email string) error {
+	err := s.checkEmailIsFree(ctx, user.ID, email)
+	if err != nil {
+		return err
+	}
+	if !s.mailSender.Enabled() {
+		return twirp.NewError(twirp.FailedPrecondition, "email can't be changed without a mail server to confirm it")
+	}
+
+	now := common.CurrentTimestamp()
+	confirmToken, err := s.tokenGenerator.Generate(user.ID, user.Name, "user-email-change", now.Add(emailChangeTokenDuration),
+		map[string]interface{}{
+			"email":     email,
+			"old_email": user.Email,
+		})
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	link := fmt.Sprintf("%s"+emailChangeFrontendPath, s.frontendAddress, confirmToken)
+	err = s.mailSender.SendHTMLEmail([]string{email}, emailChangeSubject, fmt.Sprintf(emailChangeBody, html.EscapeString(user.Name), link))
+	if err != nil {
+		return err
+	}
+
+	if user.Email == "" {
+		return nil
+	}
+	revertToken, err := s.tokenGenerator.Generate(user.ID, user.Name, "user-email-revert", now.Add(emailRevertTokenDuration),
+		map[string]interface{}{
+			"email": user.Email,
+		})
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	link = fmt.Sprintf("%s"+emailRevertFrontendPath, s.frontendAddress, revertToken)
+	return s.mailSender.SendHTMLEmail([]string{user.Email}, emailRevertSubject,
+		fmt.Sprintf(emailRevertBody, html.EscapeString(user.Name), html.EscapeString(email), link))
+}
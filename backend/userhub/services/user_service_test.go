@@ -0,0 +1,171 @@
+package services_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+
+	"github.com/mreider/koto/backend/token"
+	"github.com/mreider/koto/backend/userhub/repo"
+	"github.com/mreider/koto/backend/userhub/rpc"
+	"github.com/mreider/koto/backend/userhub/services"
+)
+
+func TestUserService_KeyBundles(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	repos := repo.Repos{
+		User:      repo.NewUsers(te.DB),
+		Invite:    repo.NewInvites(te.DB),
+		Friend:    repo.NewFriends(te.DB),
+		DeviceKey: repo.NewDeviceKeys(te.DB),
+	}
+	require.Nil(t, repos.User.AddUser(te.Ctx, "1", "user1", "user1@mail.org", "password1-hash"))
+	require.Nil(t, repos.User.AddUser(te.Ctx, "2", "user2", "user2@mail.org", "password2-hash"))
+	require.Nil(t, repos.User.AddUser(te.Ctx, "3", "user3", "user3@mail.org", "password3-hash"))
+	require.Nil(t, repos.Invite.AddInvite(te.Ctx, "1", "2"))
+	require.Nil(t, repos.Invite.AcceptInvite(te.Ctx, "1", "2", false))
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	tokenGenerator := token.NewGenerator(privateKey)
+	tokenParser := token.NewParser(func() *rsa.PublicKey { return &privateKey.PublicKey })
+
+	base := services.NewBase(repos, nil, tokenGenerator, tokenParser, nil, "", services.NewNotificationSender(repos, nil, nil))
+	s := services.NewUser(base, &passwordHash{})
+
+	user1Ctx := context.WithValue(te.Ctx, services.ContextUserKey, repo.User{ID: "1", Name: "user1"})
+	user2Ctx := context.WithValue(te.Ctx, services.ContextUserKey, repo.User{ID: "2", Name: "user2"})
+	user3Ctx := context.WithValue(te.Ctx, services.ContextUserKey, repo.User{ID: "3", Name: "user3"})
+
+	registerResp, err := s.RegisterDeviceKeys(user2Ctx, &rpc.UserRegisterDeviceKeysRequest{
+		DeviceId:              "phone",
+		IdentityKey:           "identity-2",
+		SignedPrekeyId:        1,
+		SignedPrekey:          "signed-prekey-2",
+		SignedPrekeySignature: "signature-2",
+		Prekeys: []*rpc.UserPrekey{
+			{KeyId: 1, PublicKey: "prekey-2-1"},
+		},
+	})
+	require.Nil(t, err)
+	assert.Equal(t, int32(1), registerResp.PrekeyCount)
+
+	bundlesResp, err := s.KeyBundles(user1Ctx, &rpc.UserKeyBundlesRequest{UserIds: []string{"2"}})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(bundlesResp.Bundles))
+	bundle := bundlesResp.Bundles[0]
+	assert.Equal(t, "2", bundle.UserId)
+	assert.Equal(t, "phone", bundle.DeviceId)
+	assert.Equal(t, "identity-2", bundle.IdentityKey)
+	require.NotNil(t, bundle.Prekey)
+	assert.Equal(t, "prekey-2-1", bundle.Prekey.PublicKey)
+
+	_, claims, err := tokenParser.Parse(bundle.Signature, "key-bundle")
+	require.Nil(t, err)
+	assert.Equal(t, "2", claims["id"])
+	assert.Equal(t, "identity-2", claims["identity_key"])
+	assert.Equal(t, "prekey-2-1", claims["prekey"])
+
+	// one-time prekeys are handed out only once
+	bundlesResp, err = s.KeyBundles(user1Ctx, &rpc.UserKeyBundlesRequest{UserIds: []string{"2"}})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(bundlesResp.Bundles))
+	assert.Nil(t, bundlesResp.Bundles[0].Prekey)
+
+	_, err = s.KeyBundles(user3Ctx, &rpc.UserKeyBundlesRequest{UserIds: []string{"2"}})
+	require.NotNil(t, err)
+	assert.Equal(t, twirp.InvalidArgument, err.(twirp.Error).Code())
+}
+
+func TestUserService_ChangeEmail(t *testing.T) {
+	te := NewTestEnvironment()
+	defer te.Cleanup()
+
+	repos := repo.Repos{
+		User:   repo.NewUsers(te.DB),
+		Invite: repo.NewInvites(te.DB),
+	}
+	require.Nil(t, repos.User.AddUser(te.Ctx, "1", "user1", "user1@mail.org", "password1-hash"))
+	require.Nil(t, repos.User.AddUser(te.Ctx, "2", "user2", "user2@mail.org", "password2-hash"))
+	require.Nil(t, repos.User.AddUser(te.Ctx, "3", "user3", "user3@mail.org", "password3-hash"))
+	require.Nil(t, repos.Invite.AddInviteByEmail(te.Ctx, "3", "new@mail.org"))
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	tokenGenerator := token.NewGenerator(privateKey)
+	tokenParser := token.NewParser(func() *rsa.PublicKey { return &privateKey.PublicKey })
+
+	base := services.NewBase(repos, nil, tokenGenerator, tokenParser, nil, "", services.NewNotificationSender(repos, nil, nil))
+	s := services.NewUser(base, &passwordHash{})
+	auth := services.NewAuth(base, "session-user-key", "session-user-password-hash-key", &passwordHash{}, false, nil, "")
+
+	user1Ctx := context.WithValue(te.Ctx, services.ContextUserKey, repo.User{ID: "1", Name: "user1", Email: "user1@mail.org"})
+	_, err = s.EditProfile(user1Ctx, &rpc.UserEditProfileRequest{EmailChanged: true, Email: "user2@mail.org"})
+	require.NotNil(t, err)
+	assert.Equal(t, twirp.AlreadyExists, err.(twirp.Error).Code())
+
+	_, err = s.EditProfile(user1Ctx, &rpc.UserEditProfileRequest{EmailChanged: true, Email: "free@mail.org"})
+	require.NotNil(t, err)
+	assert.Equal(t, twirp.FailedPrecondition, err.(twirp.Error).Code(), "the email can't be confirmed without a mail server")
+
+	emailChangeToken := func(scope string, claims map[string]interface{}) string {
+		tok, err := tokenGenerator.Generate("1", "user1", scope, time.Now().Add(time.Hour), claims)
+		require.Nil(t, err)
+		return tok
+	}
+	userEmail := func() string {
+		user, err := repos.User.FindUserByID(te.Ctx, "1")
+		require.Nil(t, err)
+		return user.Email
+	}
+
+	_, err = auth.ConfirmEmailChange(te.Ctx, &rpc.AuthConfirmRequest{Token: emailChangeToken("user-email-change", map[string]interface{}{
+		"email":     "new@mail.org",
+		"old_email": "other@mail.org",
+	})})
+	require.NotNil(t, err)
+	assert.Equal(t, twirp.FailedPrecondition, err.(twirp.Error).Code(), "the email has been changed since the link was sent")
+	assert.Equal(t, "user1@mail.org", userEmail())
+
+	_, err = auth.ConfirmEmailChange(te.Ctx, &rpc.AuthConfirmRequest{Token: emailChangeToken("user-email-change", map[string]interface{}{
+		"email":     "new@mail.org",
+		"old_email": "user1@mail.org",
+	})})
+	require.Nil(t, err)
+	assert.Equal(t, "new@mail.org", userEmail())
+
+	invites, err := repos.Invite.InvitesForMe(te.Ctx, repo.User{ID: "1"})
+	require.Nil(t, err)
+	require.Len(t, invites, 1, "the invite to the new email is linked")
+	assert.Equal(t, "3", invites[0].UserID)
+
+	_, err = auth.ConfirmEmailChange(te.Ctx, &rpc.AuthConfirmRequest{Token: emailChangeToken("user-email-change", map[string]interface{}{
+		"email":     "user2@mail.org",
+		"old_email": "new@mail.org",
+	})})
+	require.NotNil(t, err)
+	assert.Equal(t, twirp.AlreadyExists, err.(twirp.Error).Code(), "the email is taken after the link was sent")
+	assert.Equal(t, "new@mail.org", userEmail())
+
+	revertToken := emailChangeToken("user-email-revert", map[string]interface{}{
+		"email": "user1@mail.org",
+	})
+	require.Nil(t, repos.User.SetEmail(te.Ctx, "3", "user1@mail.org"))
+	_, err = auth.RevertEmailChange(te.Ctx, &rpc.AuthConfirmRequest{Token: revertToken})
+	require.NotNil(t, err)
+	assert.Equal(t, twirp.AlreadyExists, err.(twirp.Error).Code(), "the old email is taken after the change")
+	assert.Equal(t, "new@mail.org", userEmail())
+
+	require.Nil(t, repos.User.SetEmail(te.Ctx, "3", "user3@mail.org"))
+	_, err = auth.RevertEmailChange(te.Ctx, &rpc.AuthConfirmRequest{Token: revertToken})
+	require.Nil(t, err)
+	assert.Equal(t, "user1@mail.org", userEmail())
+}
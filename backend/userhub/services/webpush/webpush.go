@@ -0,0 +1,261 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/dgrijalva/jwt-go"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+const (
+	recordSize      = 4096
+	MaxPayloadSize  = recordSize - 16 - 4 - 1 - 65 - 16 - 1
+	vapidExpiration = time.Hour * 12
+)
+
+var (
+	ErrSubscriptionExpired = merry.New("web push subscription expired")
+	ErrPayloadTooLarge     = merry.New("web push payload is too large")
+)
+
+type Subscription struct {
+	Endpoint string
+	P256dh   string
+	Auth     string
+}
+
+type Client struct {
+	privateKey *ecdsa.PrivateKey
+	publicKey  string
+	subject    string
+	httpClient *http.Client
+}
+
+// NewClient creates a Web Push client from a base64url encoded P-256 private key (VAPID).
+func NewClient(vapidPrivateKey, subject string) (*Client, error) {
+	d, err := decodeBase64(vapidPrivateKey)
+	if err != nil {
+		return nil, merry.Prepend(err, "can't decode VAPID private key")
+	}
+	if len(d) != 32 {
+		return nil, merry.New("invalid VAPID private key")
+	}
+
+	curve := elliptic.P256()
+	privateKey := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(d)}
+	privateKey.PublicKey.Curve = curve
+	privateKey.PublicKey.X, privateKey.PublicKey.Y = curve.ScalarBaseMult(d)
+
+	return &Client{
+		privateKey: privateKey,
+		publicKey:  base64.RawURLEncoding.EncodeToString(elliptic.Marshal(curve, privateKey.X, privateKey.Y)),
+		subject:    subject,
+		httpClient: common.NewPublicHTTPClient(time.Second * 30),
+	}, nil
+}
+
+// GenerateVAPIDKeys returns a new base64url encoded VAPID key pair.
+func GenerateVAPIDKeys() (publicKey, privateKey string, err error) {
+	curve := elliptic.P256()
+	d, x, y, err := elliptic.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return "", "", merry.Wrap(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(elliptic.Marshal(curve, x, y)), base64.RawURLEncoding.EncodeToString(d), nil
+}
+
+func (c *Client) PublicKey() string {
+	return c.publicKey
+}
+
+func (c *Client) Send(ctx context.Context, subscription Subscription, payload []byte, ttl time.Duration) error {
+	body, err := Encrypt(subscription, payload)
+	if err != nil {
+		return err
+	}
+	authorization, err := c.vapidAuthorization(subscription.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	r.Header.Set("Authorization", authorization)
+	r.Header.Set("Content-Encoding", "aes128gcm")
+	r.Header.Set("Content-Type", "application/octet-stream")
+	r.Header.Set("TTL", strconv.Itoa(int(ttl.Seconds())))
+
+	resp, err := c.httpClient.Do(r)
+	if err != nil {
+		return merry.Wrap(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrSubscriptionExpired.Here()
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return merry.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *Client) vapidAuthorization(endpoint string) (string, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", merry.Wrap(err)
+	}
+	t := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": endpointURL.Scheme + "://" + endpointURL.Host,
+		"exp": time.Now().Add(vapidExpiration).Unix(),
+		"sub": c.subject,
+	})
+	signed, err := t.SignedString(c.privateKey)
+	if err != nil {
+		return "", merry.Wrap(err)
+	}
+	return "vapid t=" + signed + ", k=" + c.publicKey, nil
+}
+
+func ValidateSubscription(subscription Subscription) error {
+	endpointURL, err := url.Parse(subscription.Endpoint)
+	if err != nil || endpointURL.Scheme != "https" || endpointURL.Host == "" {
+		return merry.New("invalid endpoint")
+	}
+	err = common.ValidatePublicHost(endpointURL.Hostname())
+	if err != nil {
+		return merry.Prepend(err, "invalid endpoint")
+	}
+	_, _, err = subscriptionKeys(subscription)
+	return err
+}
+
+func subscriptionKeys(subscription Subscription) (publicKey, authSecret []byte, err error) {
+	publicKey, err = decodeBase64(subscription.P256dh)
+	if err != nil || len(publicKey) != 65 {
+		return nil, nil, merry.New("invalid p256dh key")
+	}
+	x, _ := elliptic.Unmarshal(elliptic.P256(), publicKey)
+	if x == nil {
+		return nil, nil, merry.New("invalid p256dh key")
+	}
+	authSecret, err = decodeBase64(subscription.Auth)
+	if err != nil || len(authSecret) != 16 {
+		return nil, nil, merry.New("invalid auth secret")
+	}
+	return publicKey, authSecret, nil
+}
+
+// Encrypt encrypts the payload for the subscription (RFC 8291, aes128gcm content coding).
+func Encrypt(subscription Subscription, payload []byte) ([]byte, error) {
+	if len(payload) > MaxPayloadSize {
+		return nil, ErrPayloadTooLarge.Here()
+	}
+	uaPublic, authSecret, err := subscriptionKeys(subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	asPrivate, _, _, err := elliptic.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	salt := make([]byte, 16)
+	_, err = io.ReadFull(rand.Reader, salt)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return encrypt(uaPublic, authSecret, asPrivate, salt, payload)
+}
+
+func encrypt(uaPublic, authSecret, asPrivate, salt, payload []byte) ([]byte, error) {
+	curve := elliptic.P256()
+	x, y := curve.ScalarBaseMult(asPrivate)
+	asPublic := elliptic.Marshal(curve, x, y)
+
+	cek, nonce, err := contentKeys(asPrivate, uaPublic, asPublic, uaPublic, authSecret, salt)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+
+	var header bytes.Buffer
+	header.Write(salt)
+	_ = binary.Write(&header, binary.BigEndian, uint32(recordSize))
+	header.WriteByte(byte(len(asPublic)))
+	header.Write(asPublic)
+
+	plaintext := append(append([]byte(nil), payload...), 0x02)
+	return gcm.Seal(header.Bytes(), nonce, plaintext, nil), nil
+}
+
+// contentKeys derives the content encryption key and nonce from the ECDH shared secret.
+func contentKeys(privateKey, peerPublic, asPublic, uaPublic, authSecret, salt []byte) (cek, nonce []byte, err error) {
+	curve := elliptic.P256()
+	x, y := elliptic.Unmarshal(curve, peerPublic)
+	if x == nil {
+		return nil, nil, merry.New("invalid public key")
+	}
+	sharedX, _ := curve.ScalarMult(x, y, privateKey)
+	ecdhSecret := make([]byte, 32)
+	sharedBytes := sharedX.Bytes()
+	copy(ecdhSecret[len(ecdhSecret)-len(sharedBytes):], sharedBytes)
+
+	keyInfo := append(append([]byte("WebPush: info\x00"), uaPublic...), asPublic...)
+	ikm := make([]byte, 32)
+	_, err = io.ReadFull(hkdf.New(sha256.New, ecdhSecret, authSecret, keyInfo), ikm)
+	if err != nil {
+		return nil, nil, merry.Wrap(err)
+	}
+
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+	cek = make([]byte, 16)
+	_, err = io.ReadFull(hkdf.Expand(sha256.New, prk, []byte("Content-Encoding: aes128gcm\x00")), cek)
+	if err != nil {
+		return nil, nil, merry.Wrap(err)
+	}
+	nonce = make([]byte, 12)
+	_, err = io.ReadFull(hkdf.Expand(sha256.New, prk, []byte("Content-Encoding: nonce\x00")), nonce)
+	if err != nil {
+		return nil, nil, merry.Wrap(err)
+	}
+	return cek, nonce, nil
+}
+
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	s = strings.NewReplacer("+", "-", "/", "_").Replace(s)
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, merry.Wrap(err)
+	}
+	return data, nil
+}
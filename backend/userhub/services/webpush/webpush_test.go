@@ -0,0 +1,182 @@
+package webpush
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mreider/koto/backend/common"
+)
+
+type testSubscriber struct {
+	privateKey   []byte
+	subscription Subscription
+}
+
+func newTestSubscriber(t *testing.T, endpoint string) testSubscriber {
+	privateKey, x, y, err := elliptic.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	authSecret := make([]byte, 16)
+	_, err = rand.Read(authSecret)
+	require.NoError(t, err)
+
+	return testSubscriber{
+		privateKey: privateKey,
+		subscription: Subscription{
+			Endpoint: endpoint,
+			P256dh:   base64.RawURLEncoding.EncodeToString(elliptic.Marshal(elliptic.P256(), x, y)),
+			Auth:     base64.RawURLEncoding.EncodeToString(authSecret),
+		},
+	}
+}
+
+func (s testSubscriber) decrypt(t *testing.T, body []byte) []byte {
+	require.True(t, len(body) > 86)
+	salt := body[:16]
+	require.Equal(t, uint32(recordSize), binary.BigEndian.Uint32(body[16:20]))
+	keyIDLen := int(body[20])
+	asPublic := body[21 : 21+keyIDLen]
+
+	uaPublic, authSecret, err := subscriptionKeys(s.subscription)
+	require.NoError(t, err)
+	cek, nonce, err := contentKeys(s.privateKey, asPublic, asPublic, uaPublic, authSecret, salt)
+	require.NoError(t, err)
+	block, err := aes.NewCipher(cek)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	plaintext, err := gcm.Open(nil, nonce, body[21+keyIDLen:], nil)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x02), plaintext[len(plaintext)-1])
+	return plaintext[:len(plaintext)-1]
+}
+
+func TestClient_Send(t *testing.T) {
+	publicKey, privateKey, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+	client, err := NewClient(privateKey, "mailto:admin@koto.at")
+	require.NoError(t, err)
+	require.Equal(t, publicKey, client.PublicKey())
+	client.httpClient = &http.Client{}
+
+	var received []byte
+	var authorization string
+	pushService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "aes128gcm", r.Header.Get("Content-Encoding"))
+		require.Equal(t, "60", r.Header.Get("TTL"))
+		authorization = r.Header.Get("Authorization")
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		received = body
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer pushService.Close()
+
+	subscriber := newTestSubscriber(t, pushService.URL+"/push/1")
+	err = client.Send(context.Background(), subscriber.subscription, []byte(`{"body":"hello"}`), time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, `{"body":"hello"}`, string(subscriber.decrypt(t, received)))
+
+	require.True(t, strings.HasPrefix(authorization, "vapid t="))
+	parts := strings.Split(strings.TrimPrefix(authorization, "vapid t="), ", k=")
+	require.Len(t, parts, 2)
+	require.Equal(t, publicKey, parts[1])
+	parsed, err := jwt.Parse(parts[0], func(*jwt.Token) (interface{}, error) {
+		return &client.privateKey.PublicKey, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, pushService.URL, parsed.Claims.(jwt.MapClaims)["aud"])
+}
+
+func TestClient_Send_Expired(t *testing.T) {
+	_, privateKey, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+	client, err := NewClient(privateKey, "mailto:admin@koto.at")
+	require.NoError(t, err)
+	client.httpClient = &http.Client{}
+
+	pushService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer pushService.Close()
+
+	subscriber := newTestSubscriber(t, pushService.URL)
+	err = client.Send(context.Background(), subscriber.subscription, []byte("hello"), time.Minute)
+	require.True(t, merry.Is(err, ErrSubscriptionExpired))
+}
+
+func TestValidateSubscription(t *testing.T) {
+	subscriber := newTestSubscriber(t, "https://push.example.com/send/1")
+	require.NoError(t, ValidateSubscription(subscriber.subscription))
+
+	invalid := subscriber.subscription
+	invalid.Endpoint = "http://push.example.com/send/1"
+	require.Error(t, ValidateSubscription(invalid))
+
+	for _, endpoint := range []string{"https://localhost/send/1", "https://127.0.0.1/send/1", "https://[::1]/send/1", "https://10.0.0.1/send/1", "https://169.254.169.254/latest"} {
+		invalid = subscriber.subscription
+		invalid.Endpoint = endpoint
+		require.Error(t, ValidateSubscription(invalid), endpoint)
+	}
+
+	invalid = subscriber.subscription
+	invalid.Auth = "AAAA"
+	require.Error(t, ValidateSubscription(invalid))
+}
+
+func TestClient_Send_PrivateAddress(t *testing.T) {
+	_, privateKey, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+	client, err := NewClient(privateKey, "mailto:admin@koto.at")
+	require.NoError(t, err)
+
+	pushService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer pushService.Close()
+
+	subscriber := newTestSubscriber(t, pushService.URL)
+	err = client.Send(context.Background(), subscriber.subscription, []byte("hello"), time.Minute)
+	require.True(t, merry.Is(err, common.ErrAddressNotPublic))
+}
+
+// The example of RFC 8291, section 5 and appendix A.
+func TestEncrypt_RFC8291(t *testing.T) {
+	decode := func(s string) []byte {
+		data, err := decodeBase64(s)
+		require.NoError(t, err)
+		return data
+	}
+	uaPublic := decode("BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4")
+	authSecret := decode("BTBZMqHH6r4Tts7J_aSIgg")
+	asPrivate := decode("yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw")
+	salt := decode("DGv6ra1nlYgDCS1FRnbzlw")
+
+	body, err := encrypt(uaPublic, authSecret, asPrivate, salt, []byte("When I grow up, I want to be a watermelon"))
+	require.NoError(t, err)
+	require.Equal(t, "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN",
+		base64.RawURLEncoding.EncodeToString(body))
+
+	subscriber := testSubscriber{
+		privateKey: decode("q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"),
+		subscription: Subscription{
+			P256dh: "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+			Auth:   "BTBZMqHH6r4Tts7J_aSIgg",
+		},
+	}
+	require.Equal(t, "When I grow up, I want to be a watermelon", string(subscriber.decrypt(t, body)))
+}